@@ -6,7 +6,7 @@ import (
 	"log"
 	"strconv"
 
-	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/atticplaygroup/prex/internal/payment"
 	"github.com/block-vision/sui-go-sdk/models"
 	"github.com/block-vision/sui-go-sdk/signer"
 	"github.com/block-vision/sui-go-sdk/sui"
@@ -34,8 +34,19 @@ var suiTransferCmd = &cobra.Command{
 			log.Fatalf("cannot get coinObjectId: %v", err)
 		}
 
+		rpcUrl, err := cmd1.Flags().GetString("rpc-url")
+		if err != nil {
+			log.Fatalf("cannot get rpc url: %v", err)
+		}
+		if rpcUrl == "" {
+			rpcUrl, _, err = payment.DefaultSuiEndpoints(network)
+			if err != nil {
+				log.Fatalf("cannot get default rpc url: %v", err)
+			}
+		}
+
 		var ctx = context.Background()
-		var cli = sui.NewSuiClient(utils.GetSuiFullNodeHost(network))
+		var cli = sui.NewSuiClient(rpcUrl)
 
 		signerAccount, err := signer.NewSignertWithMnemonic(conf.Account.Mnemonic)
 		if err != nil {
@@ -75,6 +86,7 @@ var suiTransferCmd = &cobra.Command{
 
 func init() {
 	suiTransferCmd.Flags().StringP("network", "n", "devnet", "which network's faucet to request")
+	suiTransferCmd.Flags().String("rpc-url", "", "custom fullnode rpc url overriding the network default")
 	suiTransferCmd.Flags().StringP("recipient", "r", "", "recipient address")
 	suiTransferCmd.Flags().String("coin", "", "coin object's Sui address")
 	suiTransferCmd.Flags().IntP("amount", "a", 1_000_000_000, "the amount of coins to transfer")
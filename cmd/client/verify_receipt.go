@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	"github.com/atticplaygroup/prex/pkg/provider"
+	"github.com/atticplaygroup/prex/pkg/receipt"
+	"github.com/spf13/cobra"
+)
+
+var verifyReceiptCmd = &cobra.Command{
+	Use:   "verify-receipt",
+	Short: "Verify a purchase receipt",
+	Long: "Verify a purchase receipt returned by BuyToken and print its claims. " +
+		"The signing key is trusted if listed with --trusted-key, which works offline, " +
+		"or else if published in /.well-known/jwks.json of --prex-url.",
+	Run: verifyReceipt,
+}
+
+func init() {
+	verifyReceiptCmd.Flags().StringP("receipt", "r", "", "Receipt to verify")
+	verifyReceiptCmd.Flags().StringSlice("trusted-key", nil, "did:key of a trusted token key")
+	verifyReceiptCmd.Flags().String("prex-url", "", "Prex server to fetch the published token keys from")
+	clientCmd.AddCommand(verifyReceiptCmd)
+}
+
+type ReceiptInfo struct {
+	Jti      string    `json:"jti"`
+	Issuer   string    `json:"issuer"`
+	Buyer    string    `json:"buyer"`
+	Seller   string    `json:"seller"`
+	Audience string    `json:"audience"`
+	Amount   int64     `json:"amount"`
+	Price    int64     `json:"price"`
+	Escrow   bool      `json:"escrow"`
+	IssuedAt time.Time `json:"issued_at"`
+}
+
+func verifyReceipt(cmd *cobra.Command, args []string) {
+	receiptStr, err := cmd.Flags().GetString("receipt")
+	if err != nil || receiptStr == "" {
+		log.Fatal("cannot parse receipt")
+	}
+	trustedKeys, err := cmd.Flags().GetStringSlice("trusted-key")
+	if err != nil {
+		log.Fatal(err)
+	}
+	prexUrl, err := cmd.Flags().GetString("prex-url")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(trustedKeys) == 0 && prexUrl == "" {
+		log.Fatal("either --trusted-key or --prex-url is required")
+	}
+	keySource := provider.NewJwksKeySource(prexUrl, time.Minute)
+	claims, err := receipt.Parse(receiptStr, func(kid string) (ed25519.PublicKey, error) {
+		if slices.Contains(trustedKeys, kid) {
+			return didkey.ParseKeyId(kid)
+		}
+		if prexUrl == "" {
+			return nil, fmt.Errorf("untrusted key %s", kid)
+		}
+		return keySource.Key(context.Background(), kid)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	output, err := json.Marshal(ReceiptInfo{
+		Jti:      claims.TokenJti,
+		Issuer:   claims.Issuer,
+		Buyer:    claims.Subject,
+		Seller:   claims.Seller,
+		Audience: claims.Audience[0],
+		Amount:   claims.Amount,
+		Price:    claims.Price,
+		Escrow:   claims.Escrow,
+		IssuedAt: claims.IssuedAt.Time,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(output))
+}
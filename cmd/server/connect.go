@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"crypto/ed25519"
 	"fmt"
 	"log"
 	"net/http"
@@ -18,6 +17,7 @@ import (
 	"github.com/atticplaygroup/prex/internal/config"
 	db "github.com/atticplaygroup/prex/internal/db/sqlc"
 	"github.com/atticplaygroup/prex/internal/store"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
 	"github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1/exchangeconnect"
 	"github.com/spf13/cobra"
 )
@@ -50,22 +50,41 @@ var connectCmd = &cobra.Command{
 			log.Fatalf("failed to init server: %v\n", err)
 		}
 
+		if conf.WalletCheckInterval > 0 {
+			for _, walletManager := range server.GetWalletManagers() {
+				go walletManager.Run(ctx, conf.WalletCheckInterval)
+			}
+		}
+		if conf.EscrowSettleInterval > 0 {
+			go server.RunEscrowSettlement(ctx, conf.EscrowSettleInterval)
+		}
+
 		validator, err := protovalidate.New()
 		if err != nil {
 			log.Fatalf("failed to initialize validator: %s", err.Error())
 		}
-		privateKey := server.GetConfig().TokenSigningPrivateKey
-		publicKey := privateKey.Public().(ed25519.PublicKey)
 
 		mux := http.NewServeMux()
 		path, handler := exchangeconnect.NewExchangeServiceHandler(
 			server,
 			connect.WithInterceptors(
-				api.NewConnectAuthInterceptor(publicKey),
+				api.NewConnectAuthInterceptor(server.GetAuthenticator()),
+				api.NewConnectRateLimitInterceptor(server.GetRateLimiter()),
 				api.NewConnectValidationInterceptor(validator),
 			),
 		)
 		mux.Handle(path, handler)
+		wellKnownHandler := api.NewWellKnownHandler(
+			func(ctx context.Context) ([]*pb.SigningKey, error) {
+				resp, err := server.ListSigningKeys(ctx, connect.NewRequest(&pb.ListSigningKeysRequest{}))
+				if err != nil {
+					return nil, err
+				}
+				return resp.Msg.GetSigningKeys(), nil
+			},
+		)
+		mux.Handle(api.PATH_JWKS, wellKnownHandler)
+		mux.Handle(api.PATH_DID_DOCUMENT, wellKnownHandler)
 		c := getCorsConfig()
 		http.ListenAndServe(
 			fmt.Sprintf("127.0.0.1:%d", conf.PrexGrpcPort),
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/atticplaygroup/prex/internal/api"
 	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
@@ -42,15 +43,34 @@ var gatewayCmd = &cobra.Command{
 		mux := runtime.NewServeMux(ropts...)
 		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
-		err = pb.RegisterExchangeServiceHandlerFromEndpoint(
-			ctx, mux, fmt.Sprintf("%s:%d", grpcHost, grpcPort), opts,
-		)
+		endpoint := fmt.Sprintf("%s:%d", grpcHost, grpcPort)
+		err = pb.RegisterExchangeServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
 		if err != nil {
 			log.Fatalf("failed to register endpoint")
 		}
 
+		conn, err := grpc.NewClient(endpoint, opts...)
+		if err != nil {
+			log.Fatalf("failed to dial endpoint: %v", err)
+		}
+		defer conn.Close()
+		client := pb.NewExchangeServiceClient(conn)
+		wellKnownHandler := api.NewWellKnownHandler(
+			func(ctx context.Context) ([]*pb.SigningKey, error) {
+				resp, err := client.ListSigningKeys(ctx, &pb.ListSigningKeysRequest{})
+				if err != nil {
+					return nil, err
+				}
+				return resp.GetSigningKeys(), nil
+			},
+		)
+		rootMux := http.NewServeMux()
+		rootMux.Handle(api.PATH_JWKS, wellKnownHandler)
+		rootMux.Handle(api.PATH_DID_DOCUMENT, wellKnownHandler)
+		rootMux.Handle("/", mux)
+
 		log.Printf("starting gateway server on port %d\n", bindPort)
-		http.ListenAndServe(fmt.Sprintf(":%d", bindPort), mux)
+		http.ListenAndServe(fmt.Sprintf(":%d", bindPort), rootMux)
 	},
 }
 
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/atticplaygroup/prex/internal/config"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+)
+
+var promoteAdminCmd = &cobra.Command{
+	Use:   "promote-admin",
+	Short: "grant the admin role to an existing account",
+	Long: "Grant the admin role to an existing account. The role is embedded " +
+		"in session tokens so it takes effect on the next login.",
+	Run: func(cmd *cobra.Command, args []string) {
+		envPath, err := cmd.Flags().GetString("environment")
+		if err != nil {
+			log.Fatalf("failed to get environment config file")
+		}
+		username, err := cmd.Flags().GetString("username")
+		if err != nil {
+			log.Fatalf("failed to get username: %v", err)
+		}
+		conf := config.LoadConfig(envPath)
+
+		ctx := context.Background()
+		pool, err := pgxpool.New(ctx, conf.TestDbUrl)
+		if err != nil {
+			log.Fatalf("failed to connect to db: %v\n", err)
+		}
+		defer pool.Close()
+		account, err := db.New(pool).SetAccountPrivilege(ctx, db.SetAccountPrivilegeParams{
+			Privilege: utils.ROLE_ADMIN,
+			Username:  username,
+		})
+		if err != nil {
+			log.Fatalf("failed to promote account %s: %v\n", username, err)
+		}
+		log.Printf("account %d (%s) is now %s\n", account.AccountID, account.Username, account.Privilege)
+	},
+}
+
+func init() {
+	promoteAdminCmd.Flags().StringP("environment", "e", ".env", "environment file to load configs")
+	promoteAdminCmd.Flags().StringP("username", "u", "", "username of the account to promote")
+	promoteAdminCmd.MarkFlagRequired("username")
+	serverCmd.AddCommand(promoteAdminCmd)
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/config"
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/spf13/cobra"
+)
+
+var stageKeyCmd = &cobra.Command{
+	Use:   "stage-key",
+	Short: "generate the next token signing key and stage it in the keyring",
+	Long: "Have the signer backend generate the next token signing key and stage " +
+		"it in TOKEN_KEYRING_PATH. It is published right away and signs new " +
+		"tokens from its activation on. " +
+		"Current keys keep verifying until the overlap after that has passed. " +
+		"Restart all servers to pick up the change.",
+	Run: func(cmd *cobra.Command, args []string) {
+		envPath, err := cmd.Flags().GetString("environment")
+		if err != nil {
+			log.Fatalf("failed to get environment config file")
+		}
+		activateIn, err := cmd.Flags().GetDuration("activate-in")
+		if err != nil {
+			log.Fatalf("failed to get activation delay: %v", err)
+		}
+		overlap, err := cmd.Flags().GetDuration("overlap")
+		if err != nil {
+			log.Fatalf("failed to get overlap: %v", err)
+		}
+		conf := config.LoadConfig(envPath)
+		if conf.TokenKeyringPath == "" {
+			log.Fatalf("TOKEN_KEYRING_PATH is not set")
+		}
+		if overlap <= 0 {
+			// Long enough for every token signed by the current keys to expire
+			overlap = max(conf.MaxTokenTtl, conf.AccessTokenTimeout)
+		}
+
+		activateTime := time.Now().Add(activateIn)
+		keyId, err := signing.StageKey(
+			context.Background(), conf.TokenKeyringPath, conf.Signer, activateTime, overlap)
+		if err != nil {
+			log.Fatalf("failed to stage key: %v\n", err)
+		}
+		log.Printf("staged key %s activating at %v, current keys retire at %v\n",
+			keyId, activateTime, activateTime.Add(overlap))
+	},
+}
+
+func init() {
+	stageKeyCmd.Flags().StringP("environment", "e", ".env", "environment file to load configs")
+	stageKeyCmd.Flags().Duration("activate-in", 24*time.Hour,
+		"delay before the key signs tokens, to let verifiers refresh their key cache")
+	stageKeyCmd.Flags().Duration("overlap", 0,
+		"how long current keys verify after activation, defaults to the longest token lifetime")
+	serverCmd.AddCommand(stageKeyCmd)
+}
@@ -47,6 +47,15 @@ var startCmd = &cobra.Command{
 			log.Fatalf("failed to init server: %v\n", err)
 		}
 
+		if conf.WalletCheckInterval > 0 {
+			for _, walletManager := range server.GetWalletManagers() {
+				go walletManager.Run(ctx, conf.WalletCheckInterval)
+			}
+		}
+		if conf.EscrowSettleInterval > 0 {
+			go server.RunEscrowSettlement(ctx, conf.EscrowSettleInterval)
+		}
+
 		s := api.NewGrpcServer(server)
 		pb.RegisterExchangeServiceServer(s, server.ExchangeServiceServer)
 		go func() {
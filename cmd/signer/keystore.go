@@ -0,0 +1,42 @@
+package signer
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	"github.com/spf13/cobra"
+)
+
+var keystoreCmd = &cobra.Command{
+	Use:   "create-keystore",
+	Short: "Encrypt WALLET_MNEMONIC and TOKEN_SIGNING_SEED from the environment into a keystore",
+	Run: func(cmd *cobra.Command, args []string) {
+		keystorePath, err := cmd.Flags().GetString("keystore")
+		if err != nil {
+			log.Fatalf("cannot get keystore path: %v", err)
+		}
+		passphrase, err := readPassphrase(cmd)
+		if err != nil {
+			log.Fatalf("cannot get passphrase: %v", err)
+		}
+		if err := signing.CreateKeystore(keystorePath, passphrase, signing.KeystoreSecrets{
+			WalletMnemonic:   os.Getenv("WALLET_MNEMONIC"),
+			TokenSigningSeed: os.Getenv("TOKEN_SIGNING_SEED"),
+		}); err != nil {
+			log.Fatalf("failed to create keystore: %v", err)
+		}
+		keystore, err := signing.OpenKeystore(keystorePath, passphrase)
+		if err != nil {
+			log.Fatalf("failed to reopen keystore: %v", err)
+		}
+		fmt.Printf("wallet address: %s\n", keystore.GetAddress())
+		fmt.Printf("token key id: %s\n", didkey.KeyId(keystore.GetPublicKey()))
+	},
+}
+
+func init() {
+	signerCmd.AddCommand(keystoreCmd)
+}
@@ -0,0 +1,42 @@
+package signer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atticplaygroup/prex/cmd"
+	"github.com/spf13/cobra"
+)
+
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Remote signer holding the Prex wallet and token keys",
+}
+
+// readPassphrase prefers the passphrase file over KEYSTORE_PASSPHRASE so that
+// the secret does not need to live in the environment.
+func readPassphrase(cmd *cobra.Command) ([]byte, error) {
+	passphraseFile, err := cmd.Flags().GetString("passphrase-file")
+	if err != nil {
+		return nil, err
+	}
+	if passphraseFile == "" {
+		passphrase := os.Getenv("KEYSTORE_PASSPHRASE")
+		if passphrase == "" {
+			return nil, fmt.Errorf("neither --passphrase-file nor KEYSTORE_PASSPHRASE is set")
+		}
+		return []byte(passphrase), nil
+	}
+	content, err := os.ReadFile(passphraseFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase file: %v", err)
+	}
+	return []byte(strings.TrimRight(string(content), "\r\n")), nil
+}
+
+func init() {
+	signerCmd.PersistentFlags().StringP("keystore", "k", "keystore.json", "encrypted keystore file")
+	signerCmd.PersistentFlags().String("passphrase-file", "", "file containing the keystore passphrase")
+	cmd.RootCmd.AddCommand(signerCmd)
+}
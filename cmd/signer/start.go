@@ -0,0 +1,107 @@
+package signer
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/api"
+	"github.com/atticplaygroup/prex/internal/payment"
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1/signerconnect"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+var startCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Serve signing requests from a Prex server over gRPC",
+	Run: func(cmd *cobra.Command, args []string) {
+		keystorePath, err := cmd.Flags().GetString("keystore")
+		if err != nil {
+			log.Fatalf("cannot get keystore path: %v", err)
+		}
+		passphrase, err := readPassphrase(cmd)
+		if err != nil {
+			log.Fatalf("cannot get passphrase: %v", err)
+		}
+		keystore, err := signing.OpenKeystore(keystorePath, passphrase)
+		if err != nil {
+			log.Fatalf("failed to unlock keystore: %v", err)
+		}
+
+		var policy signing.Policy
+		if policy.MaxWithdrawAmount, err = cmd.Flags().GetInt64("max-withdraw-amount"); err != nil {
+			log.Fatalf("cannot get max withdraw amount: %v", err)
+		}
+		if policy.MaxDailyWithdrawAmount, err = cmd.Flags().GetInt64("max-daily-withdraw-amount"); err != nil {
+			log.Fatalf("cannot get max daily withdraw amount: %v", err)
+		}
+		if policy.ExemptAddresses, err = cmd.Flags().GetStringSlice("exempt-address"); err != nil {
+			log.Fatalf("cannot get exempt addresses: %v", err)
+		}
+		if policy.MaxTokenQuantity, err = cmd.Flags().GetInt64("max-token-quantity"); err != nil {
+			log.Fatalf("cannot get max token quantity: %v", err)
+		}
+		if policy.MaxTokenTtl, err = cmd.Flags().GetDuration("max-token-ttl"); err != nil {
+			log.Fatalf("cannot get max token ttl: %v", err)
+		}
+
+		network, err := cmd.Flags().GetString("network")
+		if err != nil {
+			log.Fatalf("cannot get network: %v", err)
+		}
+		rpcUrl, err := cmd.Flags().GetString("rpc-url")
+		if err != nil {
+			log.Fatalf("cannot get rpc url: %v", err)
+		}
+		if rpcUrl == "" {
+			rpcUrl, _, err = payment.DefaultSuiEndpoints(network)
+			if err != nil {
+				log.Fatalf("cannot get default rpc url: %v", err)
+			}
+		}
+
+		server, err := signing.NewSignerServer(
+			keystore,
+			policy,
+			signing.NewSuiTransactionInspector(rpcUrl),
+			os.Getenv("SIGNER_AUTH_TOKEN"),
+		)
+		if err != nil {
+			log.Fatalf("failed to init signer: %v", err)
+		}
+		validator, err := protovalidate.New()
+		if err != nil {
+			log.Fatalf("failed to initialize validator: %s", err.Error())
+		}
+
+		bind, err := cmd.Flags().GetString("bind")
+		if err != nil {
+			log.Fatalf("cannot get bind address: %v", err)
+		}
+		mux := http.NewServeMux()
+		path, handler := signerconnect.NewSignerServiceHandler(
+			server,
+			connect.WithInterceptors(api.NewConnectValidationInterceptor(validator)),
+		)
+		mux.Handle(path, handler)
+		log.Printf("signer of wallet %s listening on %s\n", keystore.GetAddress(), bind)
+		log.Fatal(http.ListenAndServe(bind, h2c.NewHandler(mux, &http2.Server{})))
+	},
+}
+
+func init() {
+	startCmd.Flags().String("bind", "127.0.0.1:50060", "address to listen on")
+	startCmd.Flags().StringP("network", "n", "devnet", "Sui network to dry run transactions on")
+	startCmd.Flags().String("rpc-url", "", "custom fullnode rpc url overriding the network default")
+	startCmd.Flags().Int64("max-withdraw-amount", 0, "max amount a transaction may pay out, 0 for unlimited")
+	startCmd.Flags().Int64("max-daily-withdraw-amount", 0, "max amount paid out in 24 hours, 0 for unlimited")
+	startCmd.Flags().StringSlice("exempt-address", nil, "addresses excluded from withdraw limits, e.g. the cold wallet")
+	startCmd.Flags().Int64("max-token-quantity", 0, "max quantity of a signed token, 0 for unlimited")
+	startCmd.Flags().Duration("max-token-ttl", 0, "max ttl of a signed token, 0 for unlimited")
+	signerCmd.AddCommand(startCmd)
+}
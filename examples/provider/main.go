@@ -0,0 +1,38 @@
+// Command provider is an echo service sold on Prex. It lives in its own module
+// to show that services only need the public packages of Prex.
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/provider"
+)
+
+// newHandler echoes the grant of every request paid with a token for audience
+// signed by the Prex at prexUrl.
+func newHandler(prexUrl string, audience string) (http.Handler, error) {
+	verifier, err := provider.NewVerifier(provider.Options{
+		Audience: audience,
+		Keys:     provider.NewJwksKeySource(prexUrl, time.Hour),
+		Quota:    provider.NewMemoryQuotaStore(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return verifier.HttpHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		grant, _ := provider.GrantFromContext(r.Context())
+		fmt.Fprintf(w, "%s spent %d of token %s\n", grant.Endpoint, grant.Cost, grant.TokenId)
+	})), nil
+}
+
+func main() {
+	handler, err := newHandler(os.Getenv("PREX_URL"), os.Getenv("AUDIENCE"))
+	if err != nil {
+		log.Fatalf("failed to create verifier: %v", err)
+	}
+	log.Fatal(http.ListenAndServe(":8080", handler))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/provider"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestMetersTokens(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	kid := didkey.KeyId(publicKey)
+	audience := "did:key:z6MkSeller"
+
+	prex := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "OKP",
+				"crv": "Ed25519",
+				"x":   base64.RawURLEncoding.EncodeToString(publicKey),
+				"kid": kid,
+			}},
+		})
+	}))
+	defer prex.Close()
+	handler, err := newHandler(prex.URL, audience)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, provider.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "5e6f7081-92a3-4b4c-9d5e-6f708192a3b4",
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Quantity: 1,
+		Usage:    pb.JwtUsage_JWT_USAGE_CREATE_SESSION,
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []int{http.StatusOK, http.StatusPaymentRequired} {
+		req := httptest.NewRequest(http.MethodGet, "/echo", nil)
+		req.Header.Set("Authorization", "Bearer "+signed)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Fatalf("expect status %d but got %d", want, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/auth"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func formatApiKey(apiKey db.ApiKey) *pb.ApiKey {
+	ret := &pb.ApiKey{
+		Name:        fmt.Sprintf(utils.RESOURCE_PATTERN_API_KEY, apiKey.AccountID, apiKey.ApiKeyID),
+		DisplayName: apiKey.DisplayName,
+		Scopes:      apiKey.Scopes,
+		Spent:       apiKey.Spent,
+		KeyPrefix:   apiKey.KeyPrefix,
+		CreateTime:  timestamppb.New(apiKey.CreateTime.Time),
+	}
+	if apiKey.SpendLimit.Valid {
+		ret.SpendLimit = &apiKey.SpendLimit.Int64
+	}
+	if apiKey.ExpireTime.Valid {
+		ret.ExpireTime = timestamppb.New(apiKey.ExpireTime.Time)
+	}
+	if apiKey.RevokeTime.Valid {
+		ret.RevokeTime = timestamppb.New(apiKey.RevokeTime.Time)
+	}
+	return ret
+}
+
+func (s *Server) CreateApiKey(
+	ctx context.Context,
+	connectReq *connect.Request[pb.CreateApiKeyRequest],
+) (*connect.Response[pb.CreateApiKeyResponse], error) {
+	req := connectReq.Msg.GetApiKey()
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	validScopes := s.authenticator.ApiKeyScopes()
+	for _, scope := range req.GetScopes() {
+		if !slices.Contains(validScopes, scope) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"unknown scope %s, expect one of %v",
+				scope,
+				validScopes,
+			)
+		}
+	}
+	expireTime := pgtype.Timestamptz{}
+	if req.GetExpireTime() != nil {
+		if !req.GetExpireTime().AsTime().After(s.auth.Clock.Now()) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"expire time %v is in the past",
+				req.GetExpireTime().AsTime(),
+			)
+		}
+		expireTime = pgtype.Timestamptz{Time: req.GetExpireTime().AsTime(), Valid: true}
+	}
+	spendLimit := pgtype.Int8{}
+	if req.SpendLimit != nil {
+		spendLimit = pgtype.Int8{Int64: req.GetSpendLimit(), Valid: true}
+	}
+	key, prefix, secretHash, err := auth.NewApiKey()
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to generate api key: %v",
+			err,
+		)
+	}
+	apiKey, err := s.store.CreateApiKey(ctx, db.CreateApiKeyParams{
+		AccountID:   accountId,
+		DisplayName: req.GetDisplayName(),
+		KeyPrefix:   prefix,
+		KeyHash:     secretHash,
+		Scopes:      req.GetScopes(),
+		SpendLimit:  spendLimit,
+		ExpireTime:  expireTime,
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to store api key: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.CreateApiKeyResponse{
+		ApiKey: formatApiKey(apiKey),
+		Key:    key,
+	}), nil
+}
+
+func (s *Server) ListApiKeys(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ListApiKeysRequest],
+) (*connect.Response[pb.ListApiKeysResponse], error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	apiKeys, err := s.store.ListApiKeys(ctx, accountId)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to list api keys: %v",
+			err,
+		)
+	}
+	ret := make([]*pb.ApiKey, 0)
+	for _, apiKey := range apiKeys {
+		ret = append(ret, formatApiKey(apiKey))
+	}
+	return connect.NewResponse(&pb.ListApiKeysResponse{
+		ApiKeys: ret,
+	}), nil
+}
+
+func (s *Server) RevokeApiKey(
+	ctx context.Context,
+	connectReq *connect.Request[pb.RevokeApiKeyRequest],
+) (*connect.Response[pb.RevokeApiKeyResponse], error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	ids, err := utils.ParseResourceName(connectReq.Msg.GetName(), []string{"accounts", "api-keys"})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"failed to parse api key name: %v",
+			err,
+		)
+	}
+	if ids[0] != accountId {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"cannot revoke api keys of account %d",
+			ids[0],
+		)
+	}
+	apiKey, err := s.store.RevokeApiKey(ctx, db.RevokeApiKeyParams{
+		ApiKeyID:  ids[1],
+		AccountID: accountId,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"active api key %s not found",
+			connectReq.Msg.GetName(),
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to revoke api key: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.RevokeApiKeyResponse{
+		ApiKey: formatApiKey(apiKey),
+	}), nil
+}
@@ -0,0 +1,124 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"log"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/api"
+	"github.com/atticplaygroup/prex/internal/config"
+	"github.com/atticplaygroup/prex/internal/payment"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/block-vision/sui-go-sdk/signer"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/lib/pq" // PostgreSQL driver
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	migrate "github.com/rubenv/sql-migrate"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestApi(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	Conf = config.LoadConfig("../../.env")
+	// Settle payments on the in-memory ledger instead of a Sui network
+	Conf.PaymentMethods = []config.PaymentMethodConfig{
+		{Coin: "sui", Network: payment.SimulatedNetwork},
+	}
+	Conf.WithdrawRecipientCount = 10
+	Conf.WithdrawCheckStatusCount = 10
+
+	var err error
+	ApiTestDb, err = sql.Open("postgres", Conf.TestDbUrl)
+	if err != nil {
+		log.Fatalf("Failed to open db: %v\n", err)
+	}
+	defer ApiTestDb.Close()
+	if err := ApiTestDb.Ping(); err != nil {
+		log.Fatalf("Failed to ping database: %v\n", err)
+	}
+	Migrations = &migrate.FileMigrationSource{
+		Dir: Conf.TestMigrateSourceUrl,
+	}
+
+	conn, err := pgxpool.New(context.Background(), Conf.TestDbUrl)
+	if err != nil {
+		log.Fatalf("Failed to connect to db: %v\n", err)
+	}
+	defer conn.Close()
+	StoreInstance = store.NewStore(conn)
+	ServerInstance, err = api.NewServer(Conf, *StoreInstance)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v\n", err)
+	}
+	Ledger = ServerInstance.GetPaymentClient().(*payment.SimulatedPaymentClient)
+
+	RunSpecs(t, "Api Suite")
+}
+
+var (
+	Conf           config.Config
+	ApiTestDb      *sql.DB
+	Migrations     *migrate.FileMigrationSource
+	StoreInstance  *store.Store
+	ServerInstance *api.Server
+	Ledger         *payment.SimulatedPaymentClient
+)
+
+func RefreshDb(testDb *sql.DB, migrations *migrate.FileMigrationSource) {
+	if _, err := migrate.Exec(testDb, "postgres", migrations, migrate.Down); err != nil {
+		log.Fatalf("Failed to migrate down: %v\n", err)
+	}
+	if _, err := migrate.Exec(testDb, "postgres", migrations, migrate.Up); err != nil {
+		log.Fatalf("Failed to migrate up: %v\n", err)
+	}
+}
+
+// AsAccount authenticates calls in ctx as accountId, like the auth
+// middleware does.
+func AsAccount(ctx context.Context, accountId int64) context.Context {
+	return context.WithValue(ctx, utils.KEY_ACCOUNT_ID, accountId)
+}
+
+// DepositFromNewWallet pays amount into username from a new wallet on the
+// simulated ledger and proves the deposit with a signed challenge.
+func DepositFromNewWallet(ctx context.Context, username string, amount int64) (*pb.Account, *signer.Signer) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	Expect(err).To(BeNil())
+	wallet := signer.NewSigner(seed)
+	Ledger.Mint(wallet.Address, amount)
+	return DepositFromWallet(ctx, wallet, username, amount), wallet
+}
+
+// DepositFromWallet pays amount into username from a wallet holding it.
+func DepositFromWallet(ctx context.Context, wallet *signer.Signer, username string, amount int64) *pb.Account {
+	digest, err := Ledger.Transfer(ctx, wallet.Address, Ledger.GetAddress(), amount)
+	Expect(err).To(BeNil())
+	challenge, err := ServerInstance.GetChallenge(ctx, connect.NewRequest(&pb.GetChallengeRequest{
+		Address: wallet.Address,
+	}))
+	Expect(err).To(BeNil())
+	signed, err := wallet.SignPersonalMessageV1(string(challenge.Msg.GetChallenge()))
+	Expect(err).To(BeNil())
+	resp, err := ServerInstance.Deposit(ctx, connect.NewRequest(&pb.DepositRequest{
+		Username: username,
+		Ttl:      durationpb.New(30 * 24 * time.Hour),
+		Proof: &pb.SuiDepositProof{
+			ChainDigest: digest,
+			StartTime:   challenge.Msg.GetStartTime(),
+			Challenge:   challenge.Msg.GetChallenge(),
+			Signature:   signed.Signature,
+		},
+	}))
+	Expect(err).To(BeNil())
+	return resp.Msg.GetAccount()
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/auth"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/internal/utils"
+	prexpb "github.com/atticplaygroup/prex/pkg/proto/gen/go/prex/v1"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var roleNames = map[prexpb.Role]string{
+	prexpb.Role_ROLE_USER:  utils.ROLE_USER,
+	prexpb.Role_ROLE_ADMIN: utils.ROLE_ADMIN,
+}
+
+type methodPolicy struct {
+	public      bool
+	role        string
+	apiKeyScope string
+}
+
+// IApiKeyGetter looks up API keys presented as bearer tokens.
+type IApiKeyGetter interface {
+	GetApiKeyByPrefix(ctx context.Context, keyPrefix string) (db.ApiKey, error)
+}
+
+// Authenticator enforces the (prex.v1.auth) option declared on every RPC so
+// that the gRPC and Connect interceptors cannot disagree.
+type Authenticator struct {
+	keyring     *signing.Keyring
+	clock       auth.Clock
+	redisClient *redis.Client
+	apiKeys     IApiKeyGetter
+	policies    map[string]methodPolicy
+}
+
+// NewAuthenticator reads the auth policies of all methods in services. It
+// fails if any method does not declare one. Revoked sessions are looked up in
+// redisClient unless it is nil. API keys are rejected if apiKeys is nil.
+func NewAuthenticator(
+	keyring *signing.Keyring,
+	clock auth.Clock,
+	redisClient *redis.Client,
+	apiKeys IApiKeyGetter,
+	services ...protoreflect.ServiceDescriptor,
+) (*Authenticator, error) {
+	policies := make(map[string]methodPolicy)
+	for _, service := range services {
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			fullMethod := fmt.Sprintf("/%s/%s", service.FullName(), method.Name())
+			policy, err := loadMethodPolicy(method)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth policy of %s: %v", fullMethod, err)
+			}
+			policies[fullMethod] = *policy
+		}
+	}
+	return &Authenticator{
+		keyring:     keyring,
+		clock:       clock,
+		redisClient: redisClient,
+		apiKeys:     apiKeys,
+		policies:    policies,
+	}, nil
+}
+
+func loadMethodPolicy(method protoreflect.MethodDescriptor) (*methodPolicy, error) {
+	options := method.Options()
+	if options == nil || !proto.HasExtension(options, prexpb.E_Auth) {
+		return nil, fmt.Errorf("no (prex.v1.auth) option declared")
+	}
+	policy, ok := proto.GetExtension(options, prexpb.E_Auth).(*prexpb.AuthPolicy)
+	if !ok || policy == nil {
+		return nil, fmt.Errorf("unexpected (prex.v1.auth) option")
+	}
+	if policy.GetPublic() {
+		if policy.GetRole() != prexpb.Role_ROLE_UNSPECIFIED || policy.GetApiKeyScope() != "" {
+			return nil, fmt.Errorf("public method cannot require role or api key scope")
+		}
+		return &methodPolicy{public: true}, nil
+	}
+	role, ok := roleNames[policy.GetRole()]
+	if !ok {
+		return nil, fmt.Errorf("unknown role %s", policy.GetRole())
+	}
+	// API keys only ever act as users
+	if policy.GetApiKeyScope() != "" && role != utils.ROLE_USER {
+		return nil, fmt.Errorf("api key scope %s on a method requiring %s", policy.GetApiKeyScope(), role)
+	}
+	return &methodPolicy{role: role, apiKeyScope: policy.GetApiKeyScope()}, nil
+}
+
+// ApiKeyScopes returns the scopes declared by any method.
+func (a *Authenticator) ApiKeyScopes() []string {
+	ret := make([]string, 0)
+	for _, policy := range a.policies {
+		if policy.apiKeyScope != "" && !slices.Contains(ret, policy.apiKeyScope) {
+			ret = append(ret, policy.apiKeyScope)
+		}
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// RequiresAuth reports whether fullMethod needs a session token. Unknown
+// methods do so they are never accidentally public.
+func (a *Authenticator) RequiresAuth(fullMethod string) bool {
+	policy, ok := a.policies[fullMethod]
+	return !ok || !policy.public
+}
+
+// Authenticate checks the session token in authString against the policy of
+// fullMethod and returns ctx with the account id and roles of the caller.
+func (a *Authenticator) Authenticate(
+	ctx context.Context, fullMethod string, authString string,
+) (context.Context, error) {
+	policy, ok := a.policies[fullMethod]
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"no auth policy for %s",
+			fullMethod,
+		)
+	}
+	if policy.public {
+		return ctx, nil
+	}
+	if token, err := parseBearer(authString); err == nil && auth.IsApiKey(token) {
+		return a.authenticateApiKey(ctx, fullMethod, policy, token)
+	}
+	authClaims, err := ParseAuthToken(authString, a.keyring, true)
+	if err != nil || authClaims.AccountId <= 0 {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"account id is invalid: %v",
+			err,
+		)
+	}
+	if err := a.checkRevocation(ctx, authClaims); err != nil {
+		return nil, err
+	}
+	// Admins may do everything users can
+	if !slices.Contains(authClaims.Roles, policy.role) &&
+		!slices.Contains(authClaims.Roles, utils.ROLE_ADMIN) {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"%s requires role %s",
+			fullMethod,
+			policy.role,
+		)
+	}
+	return withAuthClaims(ctx, authClaims), nil
+}
+
+func (a *Authenticator) authenticateApiKey(
+	ctx context.Context, fullMethod string, policy methodPolicy, token string,
+) (context.Context, error) {
+	if policy.apiKeyScope == "" || a.apiKeys == nil {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"%s cannot be called with an api key",
+			fullMethod,
+		)
+	}
+	prefix, secret, ok := auth.ParseApiKey(token)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "malformed api key")
+	}
+	apiKey, err := a.apiKeys.GetApiKeyByPrefix(ctx, prefix)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !auth.VerifyApiKeySecret(secret, apiKey.KeyHash)) {
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get api key: %v",
+			err,
+		)
+	}
+	if apiKey.RevokeTime.Valid {
+		return nil, status.Error(codes.Unauthenticated, "api key revoked")
+	}
+	if apiKey.ExpireTime.Valid && !apiKey.ExpireTime.Time.After(a.clock.Now()) {
+		return nil, status.Error(codes.Unauthenticated, "api key expired")
+	}
+	if !slices.Contains(apiKey.Scopes, policy.apiKeyScope) {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"%s requires api key scope %s",
+			fullMethod,
+			policy.apiKeyScope,
+		)
+	}
+	ctx = withAuthClaims(ctx, &AuthClaims{
+		AccountId: apiKey.AccountID,
+		Roles:     []string{utils.ROLE_USER},
+	})
+	return context.WithValue(ctx, utils.KEY_API_KEY_ID, apiKey.ApiKeyID), nil
+}
+
+// RevokeSessions invalidates all session tokens of accountId issued until now.
+func (a *Authenticator) RevokeSessions(
+	ctx context.Context, accountId int64, now time.Time, sessionTimeout time.Duration,
+) error {
+	// Revoked tokens expire by themselves after sessionTimeout
+	return a.redisClient.Set(
+		ctx,
+		fmt.Sprintf(utils.REDIS_KEY_SESSIONS_NOT_BEFORE, accountId),
+		now.UnixMicro(),
+		sessionTimeout,
+	).Err()
+}
+
+// checkRevocation rejects tokens on the jti deny-list and tokens issued before
+// all sessions of the account were revoked.
+func (a *Authenticator) checkRevocation(ctx context.Context, authClaims *AuthClaims) error {
+	if a.redisClient == nil {
+		return nil
+	}
+	values, err := a.redisClient.MGet(
+		ctx,
+		fmt.Sprintf(utils.REDIS_KEY_REVOKED_JTI, authClaims.TokenId),
+		fmt.Sprintf(utils.REDIS_KEY_SESSIONS_NOT_BEFORE, authClaims.AccountId),
+	).Result()
+	if err != nil {
+		return status.Errorf(
+			codes.Unavailable,
+			"failed to check session revocation: %v",
+			err,
+		)
+	}
+	if values[0] != nil {
+		return status.Error(codes.Unauthenticated, "access token revoked")
+	}
+	if values[1] != nil {
+		notBefore, err := strconv.ParseInt(fmt.Sprint(values[1]), 10, 64)
+		if err != nil || authClaims.IssuedAt.UnixMicro() <= notBefore {
+			return status.Error(codes.Unauthenticated, "session revoked, please log in again")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/api"
+	"github.com/atticplaygroup/prex/internal/auth"
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+var _ = Describe("Revoking sessions", Label("redis"), func() {
+	It("should only reject tokens issued before the revocation within a second", func() {
+		ctx := context.Background()
+		redisClient := redis.NewClient(&redis.Options{
+			Addr: fmt.Sprintf("%s:%d", Conf.RedisHost, Conf.RedisPort),
+		})
+		if err := redisClient.Ping(ctx).Err(); err != nil {
+			Skip(fmt.Sprintf("redis unavailable: %v", err))
+		}
+		authentication, err := auth.NewAuth(Conf)
+		Expect(err).To(BeNil())
+		authenticator, err := api.NewAuthenticator(
+			Conf.Keyring, authentication.Clock, redisClient, nil,
+			pb.File_exchange_v1_exchange_proto.Services().ByName("ExchangeService"),
+		)
+		Expect(err).To(BeNil())
+
+		accountId := time.Now().UnixNano()
+		second := time.Now().Truncate(time.Second)
+		issue := func(at time.Time, jti string) string {
+			authentication.Clock = fixedClock(at)
+			token, err := authentication.GenerateJWT(ctx, accountId, []string{utils.ROLE_USER}, &auth.Session{
+				SessionId: "revoked-session",
+				AccessJti: jti,
+			})
+			Expect(err).To(BeNil())
+			return "Bearer " + token
+		}
+		method := "/exchange.v1.ExchangeService/ListApiKeys"
+
+		before := issue(second.Add(100*time.Millisecond), fmt.Sprintf("before-%d", accountId))
+		Expect(authenticator.RevokeSessions(
+			ctx, accountId, second.Add(200*time.Millisecond), time.Minute,
+		)).To(Succeed())
+		after := issue(second.Add(300*time.Millisecond), fmt.Sprintf("after-%d", accountId))
+
+		_, err = authenticator.Authenticate(ctx, method, before)
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+		_, err = authenticator.Authenticate(ctx, method, after)
+		Expect(err).To(BeNil())
+	})
+})
+
+var _ = Describe("Authenticating access tokens", func() {
+	It("should reject JWTs of other usages signed by the same key", func() {
+		ctx := context.Background()
+		authentication, err := auth.NewAuth(Conf)
+		Expect(err).To(BeNil())
+		authenticator, err := api.NewAuthenticator(
+			Conf.Keyring, authentication.Clock, nil, nil,
+			pb.File_exchange_v1_exchange_proto.Services().ByName("ExchangeService"),
+		)
+		Expect(err).To(BeNil())
+		method := "/exchange.v1.ExchangeService/ListApiKeys"
+
+		token, err := authentication.GenerateJWT(ctx, 1, []string{utils.ROLE_USER}, &auth.Session{
+			SessionId: "session",
+			AccessJti: "access",
+		})
+		Expect(err).To(BeNil())
+		_, err = authenticator.Authenticate(ctx, method, "Bearer "+token)
+		Expect(err).To(BeNil())
+
+		signingKey, err := Conf.Keyring.Primary(time.Now())
+		Expect(err).To(BeNil())
+		receiptToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+			"sub":   "1",
+			"iat":   jwt.NewNumericDate(time.Now()),
+			"jti":   "access",
+			"sid":   "session",
+			"roles": []string{utils.ROLE_USER},
+			"usage": pb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT,
+		})
+		receiptToken.Header["kid"] = signingKey.KeyId
+		signed, err := signing.SignJwt(ctx, signingKey.Signer, receiptToken)
+		Expect(err).To(BeNil())
+		_, err = authenticator.Authenticate(ctx, method, "Bearer "+signed)
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+	})
+})
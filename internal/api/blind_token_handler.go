@@ -0,0 +1,267 @@
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/atticplaygroup/prex/pkg/blind"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) getBlindKey(ctx context.Context, keyId string, now time.Time) (*signing.BlindKey, error) {
+	blindKey, err := s.config.BlindKeyring.Key(ctx, keyId, now)
+	if errors.Is(err, signing.ErrUnknownBlindKey) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"blind token key %s not found or expired",
+			keyId,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get blind token key: %v",
+			err,
+		)
+	}
+	return blindKey, nil
+}
+
+func (s *Server) ListBlindTokenKeys(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ListBlindTokenKeysRequest],
+) (*connect.Response[pb.ListBlindTokenKeysResponse], error) {
+	blindKeys, err := s.config.BlindKeyring.Published(ctx, s.auth.Clock.Now())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get blind token keys: %v",
+			err,
+		)
+	}
+	blindTokenKeys := make([]*pb.BlindTokenKey, 0, len(blindKeys))
+	for _, blindKey := range blindKeys {
+		publicKey, err := x509.MarshalPKIXPublicKey(blindKey.PublicKey)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"failed to encode blind token key: %v",
+				err,
+			)
+		}
+		blindTokenKeys = append(blindTokenKeys, &pb.BlindTokenKey{
+			KeyId:        blindKey.KeyId,
+			Denomination: blindKey.Denomination,
+			PublicKey:    publicKey,
+			IssueEndTime: timestamppb.New(blindKey.IssueEndTime),
+			ExpireTime:   timestamppb.New(blindKey.ExpireTime),
+		})
+	}
+	return connect.NewResponse(&pb.ListBlindTokenKeysResponse{
+		BlindTokenKeys: blindTokenKeys,
+	}), nil
+}
+
+func (s *Server) IssueBlindTokens(
+	ctx context.Context,
+	connectReq *connect.Request[pb.IssueBlindTokensRequest],
+) (*connect.Response[pb.IssueBlindTokensResponse], error) {
+	req := connectReq.Msg
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	now := s.auth.Clock.Now()
+	blindKey, err := s.getBlindKey(ctx, req.GetKeyId(), now)
+	if err != nil {
+		return nil, err
+	}
+	if !blindKey.IsIssuing(now) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"blind token key %s no longer issues tokens",
+			blindKey.KeyId,
+		)
+	}
+	for _, blinded := range req.GetBlindedMessages() {
+		if err := blind.CheckBlinded(blindKey.PublicKey, blinded); err != nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"invalid blinded message: %v",
+				err,
+			)
+		}
+	}
+	tx, err := s.store.GetConn().Begin(context.Background())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to begin transaction: %v",
+			err,
+		)
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.store.Queries.WithTx(tx)
+	// Charge before signing so that unfunded callers cannot make the signer
+	// do private key operations
+	apiKeyId, _ := ctx.Value(utils.KEY_API_KEY_ID).(int64)
+	if _, err = s.store.IssueBlindTokensTx(ctx, qtx, &store.IssueBlindTokensTxParams{
+		BuyerID:      accountId,
+		ApiKeyID:     apiKeyId,
+		KeyId:        blindKey.KeyId,
+		Denomination: blindKey.Denomination,
+		Count:        int64(len(req.GetBlindedMessages())),
+		ExpireTime:   blindKey.ExpireTime,
+	}); errors.Is(err, store.ErrSpendLimitExceeded) {
+		return nil, status.Errorf(
+			codes.ResourceExhausted,
+			"%v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to execute transaction: %v",
+			err,
+		)
+	}
+	blindSignatures, err := s.config.BlindKeyring.Sign(ctx, blindKey.KeyId, req.GetBlindedMessages())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to sign blinded messages: %v",
+			err,
+		)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to commit transaction: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.IssueBlindTokensResponse{
+		BlindSignatures: blindSignatures,
+	}), nil
+}
+
+func (s *Server) RedeemBlindToken(
+	ctx context.Context,
+	connectReq *connect.Request[pb.RedeemBlindTokenRequest],
+) (*connect.Response[pb.RedeemBlindTokenResponse], error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	token, err := blind.ParseToken(connectReq.Msg.GetToken())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid token: %v",
+			err,
+		)
+	}
+	blindKey, err := s.getBlindKey(ctx, token.KeyId, s.auth.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := token.Verify(blindKey.PublicKey); err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid token: %v",
+			err,
+		)
+	}
+	audience, err := s.callerAudience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token.Audience != audience {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"token is for audience %s but caller is %s",
+			token.Audience,
+			audience,
+		)
+	}
+	blindTokenKey, err := s.store.RedeemBlindTokenTx(ctx, store.RedeemBlindTokenTxParams{
+		KeyId:      token.KeyId,
+		Nonce:      token.Nonce,
+		RedeemerID: accountId,
+	})
+	if errors.Is(err, store.ErrBlindTokenSpent) || errors.Is(err, store.ErrBlindTokenUnknown) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"%v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to redeem blind token: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.RedeemBlindTokenResponse{
+		Denomination: blindTokenKey.Denomination,
+	}), nil
+}
+
+func (s *Server) PruneSpentBlindTokens(
+	ctx context.Context,
+	connectReq *connect.Request[pb.PruneSpentBlindTokensRequest],
+) (*connect.Response[pb.PruneSpentBlindTokensResponse], error) {
+	prunedCount, err := s.store.PruneSpentBlindTokens(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to prune spent blind tokens: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.PruneSpentBlindTokensResponse{
+		PrunedCount: prunedCount,
+	}), nil
+}
+
+func (s *Server) ListExpiredBlindTokenKeys(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ListExpiredBlindTokenKeysRequest],
+) (*connect.Response[pb.ListExpiredBlindTokenKeysResponse], error) {
+	blindTokenKeys, err := s.store.ListExpiredBlindTokenKeys(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to list expired blind token keys: %v",
+			err,
+		)
+	}
+	expiredKeys := make([]*pb.ExpiredBlindTokenKey, 0, len(blindTokenKeys))
+	for _, blindTokenKey := range blindTokenKeys {
+		expiredKeys = append(expiredKeys, &pb.ExpiredBlindTokenKey{
+			KeyId:            blindTokenKey.KeyID,
+			Denomination:     blindTokenKey.Denomination,
+			IssuedCount:      blindTokenKey.Issued,
+			RedeemedCount:    blindTokenKey.Redeemed,
+			UnredeemedAmount: (blindTokenKey.Issued - blindTokenKey.Redeemed) * blindTokenKey.Denomination,
+			ExpireTime:       timestamppb.New(blindTokenKey.ExpireTime.Time),
+		})
+	}
+	return connect.NewResponse(&pb.ListExpiredBlindTokenKeysResponse{
+		ExpiredBlindTokenKeys: expiredKeys,
+	}), nil
+}
@@ -5,9 +5,9 @@ import (
 	"context"
 	"encoding/hex"
 	"math"
+	"time"
 
 	"connectrpc.com/connect"
-	"github.com/atticplaygroup/prex/internal/auth"
 	db "github.com/atticplaygroup/prex/internal/db/sqlc"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
@@ -18,7 +18,6 @@ import (
 	"github.com/atticplaygroup/prex/internal/store"
 	"github.com/atticplaygroup/prex/internal/utils"
 	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
-	"github.com/block-vision/sui-go-sdk/models"
 )
 
 func (s *Server) GetChallenge(ctx context.Context, connectReq *connect.Request[pb.GetChallengeRequest]) (*connect.Response[pb.GetChallengeResponse], error) {
@@ -32,12 +31,34 @@ func (s *Server) GetChallenge(ctx context.Context, connectReq *connect.Request[p
 			err,
 		)
 	}
+	if err := s.challenges.Issue(ctx, challenge[:]); err != nil {
+		return nil, status.Errorf(
+			codes.Unavailable,
+			"failed to issue challenge: %v",
+			err,
+		)
+	}
 	return connect.NewResponse(&pb.GetChallengeResponse{
 		Challenge: challenge[:],
 		StartTime: timestamppb.New(startTime),
 	}), nil
 }
 
+// verifySignedChallenge checks a signed challenge and consumes it so that it
+// cannot be replayed. Returns the address of the signer.
+func (s *Server) verifySignedChallenge(
+	ctx context.Context, startTime time.Time, challenge []byte, signature string,
+) (string, error) {
+	sender, err := s.auth.VerifySuiPersonalMessage(startTime, challenge, signature)
+	if err != nil {
+		return "", err
+	}
+	if err := s.challenges.Consume(ctx, challenge); err != nil {
+		return "", err
+	}
+	return sender, nil
+}
+
 func (s *Server) Deposit(ctx context.Context, connectReq *connect.Request[pb.DepositRequest]) (*connect.Response[pb.DepositResponse], error) {
 	req := connectReq.Msg
 	if req.GetTtl() == nil || req.GetTtl().Seconds < 0 || req.GetTtl().Seconds > s.config.MaxExpirationExtension {
@@ -47,7 +68,11 @@ func (s *Server) Deposit(ctx context.Context, connectReq *connect.Request[pb.Dep
 			s.config.MaxExpirationExtension,
 		)
 	}
-	senderInfo, err := s.paymentClient.CheckDeposit(
+	paymentBackend, err := s.getPaymentBackend(req.GetPaymentMethod())
+	if err != nil {
+		return nil, err
+	}
+	senderInfo, err := paymentBackend.client.CheckDeposit(
 		ctx, req.GetProof().GetChainDigest(), int(s.config.MaxDepositEpochGap),
 	)
 	if err != nil {
@@ -89,29 +114,16 @@ func (s *Server) Deposit(ctx context.Context, connectReq *connect.Request[pb.Dep
 			"chain address parse failed",
 		)
 	}
-	sender, pass, err := models.VerifyPersonalMessage(
-		string(req.GetProof().GetChallenge()),
+	sender, err := s.verifySignedChallenge(
+		ctx,
+		req.GetProof().GetStartTime().AsTime(),
+		req.GetProof().GetChallenge(),
 		req.GetProof().GetSignature(),
 	)
-	if err != nil || !pass {
-		return nil, status.Errorf(
-			codes.PermissionDenied,
-			"personal message verification failed for bytes=%v and signature=%s",
-			req.GetProof().GetChallenge(),
-			req.GetProof().GetSignature(),
-		)
-	}
-	if err := s.auth.VerifySuiAuthMessagePayload(
-		&auth.SuiAuthMessage{
-			StartTime: req.GetProof().GetStartTime().AsTime(),
-			Challenge: req.GetProof().GetChallenge(),
-			Address:   sender,
-			Signature: req.GetProof().GetSignature(),
-		},
-	); err != nil {
+	if err != nil {
 		return nil, status.Errorf(
 			codes.PermissionDenied,
-			"invalid personal message: %v",
+			"invalid deposit proof: %v",
 			err,
 		)
 	}
@@ -131,12 +143,16 @@ func (s *Server) Deposit(ctx context.Context, connectReq *connect.Request[pb.Dep
 			chainAddressBytes,
 		)
 	}
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.GetPassword()), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			"failed to hash password",
-		)
+	// Accounts without a password can only log in with a wallet signature
+	hashedPassword := []byte{}
+	if req.GetPassword() != "" {
+		hashedPassword, err = bcrypt.GenerateFromPassword([]byte(req.GetPassword()), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"failed to hash password",
+			)
+		}
 	}
 	tx, err := s.store.GetConn().Begin(ctx)
 	if err != nil {
@@ -157,11 +173,13 @@ func (s *Server) Deposit(ctx context.Context, connectReq *connect.Request[pb.Dep
 					Microseconds: int64(req.GetTtl().Seconds) * 1000,
 					Valid:        true,
 				},
-				Privilege: "user",
+				Privilege: utils.ROLE_USER,
 				Balance:   amountDeposit,
 			},
-			Digest: req.GetProof().GetChainDigest(),
-			Epoch:  senderInfo.Epoch,
+			Digest:        req.GetProof().GetChainDigest(),
+			Epoch:         senderInfo.Epoch,
+			PaymentMethod: paymentBackend.method.GetName(),
+			SenderAddress: sender,
 		})
 	if err != nil {
 		return nil, status.Errorf(
@@ -183,7 +201,7 @@ func (s *Server) PruneAccounts(
 	ctx context.Context,
 	req *connect.Request[pb.PruneAccountsRequest],
 ) (*connect.Response[pb.PruneAccountsResponse], error) {
-	if accountIds, err := s.store.DeleteInvalidAccounts(ctx); err != nil {
+	if accountIds, err := s.store.PruneAccountsTx(ctx); err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			"failed: %v",
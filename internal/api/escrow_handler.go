@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/receipt"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const ESCROW_SETTLE_BATCH_SIZE = 100
+
+func formatEscrow(escrow *db.Escrow, audience string) *pb.Escrow {
+	ret := &pb.Escrow{
+		Jti:        escrow.Jti,
+		Audience:   audience,
+		Amount:     escrow.Amount,
+		Claimed:    escrow.Claimed,
+		ExpireTime: timestamppb.New(escrow.ExpireTime.Time),
+	}
+	if escrow.SettleTime.Valid {
+		ret.SettleTime = timestamppb.New(escrow.SettleTime.Time)
+	}
+	return ret
+}
+
+// verifyConsumptionReceipt checks a receipt is signed by the did:key of the
+// audience of its token and returns it with the audience.
+func (s *Server) verifyConsumptionReceipt(
+	ctx context.Context,
+	signed *pb.SignedConsumptionReceipt,
+) (*pb.ConsumptionReceipt, string, error) {
+	consumption := &pb.ConsumptionReceipt{}
+	if err := proto.Unmarshal(signed.GetReceipt(), consumption); err != nil {
+		return nil, "", status.Errorf(
+			codes.InvalidArgument,
+			"invalid receipt: %v",
+			err,
+		)
+	}
+	if err := protovalidate.Validate(consumption); err != nil {
+		return nil, "", status.Errorf(
+			codes.InvalidArgument,
+			"invalid receipt: %v",
+			err,
+		)
+	}
+	quotaToken, err := s.store.Queries.GetQuotaToken(ctx, consumption.GetJti())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, "", status.Errorf(
+			codes.NotFound,
+			"token %s not found",
+			consumption.GetJti(),
+		)
+	} else if err != nil {
+		return nil, "", status.Errorf(
+			codes.Internal,
+			"failed to get token: %v",
+			err,
+		)
+	}
+	publicKey, err := didkey.ParseKeyId(quotaToken.Audience)
+	if err != nil {
+		return nil, "", status.Errorf(
+			codes.FailedPrecondition,
+			"audience cannot sign receipts: %v",
+			err,
+		)
+	}
+	if !receipt.VerifyConsumption(publicKey, signed) {
+		return nil, "", status.Errorf(
+			codes.PermissionDenied,
+			"receipt for %s is not signed by %s",
+			consumption.GetJti(),
+			quotaToken.Audience,
+		)
+	}
+	return consumption, quotaToken.Audience, nil
+}
+
+func (s *Server) SubmitConsumptionReceipts(
+	ctx context.Context,
+	connectReq *connect.Request[pb.SubmitConsumptionReceiptsRequest],
+) (*connect.Response[pb.SubmitConsumptionReceiptsResponse], error) {
+	receipts := make([]*pb.ConsumptionReceipt, 0, len(connectReq.Msg.GetReceipts()))
+	audiences := make([]string, 0, len(connectReq.Msg.GetReceipts()))
+	for _, signed := range connectReq.Msg.GetReceipts() {
+		receipt, audience, err := s.verifyConsumptionReceipt(ctx, signed)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+		audiences = append(audiences, audience)
+	}
+	escrows := make([]*pb.Escrow, 0, len(receipts))
+	total := int64(0)
+	for i, receipt := range receipts {
+		escrow, paid, err := s.store.ClaimEscrowTx(ctx, store.ClaimEscrowTxParams{
+			Jti:     receipt.GetJti(),
+			Claimed: receipt.GetConsumed(),
+		})
+		if errors.Is(err, store.ErrEscrowUnavailable) {
+			return nil, status.Errorf(
+				codes.FailedPrecondition,
+				"%s: %v",
+				receipt.GetJti(),
+				err,
+			)
+		} else if errors.Is(err, store.ErrReceiptExceedsEscrow) ||
+			errors.Is(err, store.ErrReceiptExceedsConsumed) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"%s: %v",
+				receipt.GetJti(),
+				err,
+			)
+		} else if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"failed to claim escrow: %v",
+				err,
+			)
+		}
+		escrows = append(escrows, formatEscrow(escrow, audiences[i]))
+		total += paid
+	}
+	return connect.NewResponse(&pb.SubmitConsumptionReceiptsResponse{
+		Escrows: escrows,
+		Amount:  total,
+	}), nil
+}
+
+// SettleEscrows returns what is unclaimed of escrows past their claim grace
+// to the buyers and returns how many escrows it settled.
+func (s *Server) SettleEscrows(ctx context.Context) (int, error) {
+	count := 0
+	for {
+		settled, err := s.store.SettleEscrowsTx(
+			ctx,
+			time.Now().Add(-s.config.EscrowClaimGrace),
+			ESCROW_SETTLE_BATCH_SIZE,
+		)
+		if err != nil {
+			return count, err
+		}
+		count += len(settled)
+		if len(settled) < ESCROW_SETTLE_BATCH_SIZE {
+			return count, nil
+		}
+	}
+}
+
+// RunEscrowSettlement settles escrows every interval until ctx is done.
+func (s *Server) RunEscrowSettlement(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if count, err := s.SettleEscrows(ctx); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("escrow settlement failed: %v", err))
+		} else if count > 0 {
+			slog.InfoContext(ctx, fmt.Sprintf("settled %d escrows", count))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
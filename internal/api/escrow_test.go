@@ -0,0 +1,150 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/receipt"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Escrowed tokens", Label("db"), func() {
+	ctx := context.Background()
+	var sellerKey ed25519.PrivateKey
+	var buyer, seller *pb.Account
+	var token, jti string
+
+	BeforeEach(func() {
+		RefreshDb(ApiTestDb, Migrations)
+		var err error
+		_, sellerKey, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		buyer, _ = DepositFromNewWallet(ctx, "did:key:z6MkBuyer", 1_000)
+		seller, _ = DepositFromNewWallet(ctx, didkey.KeyId(sellerKey.Public().(ed25519.PublicKey)), 1_000)
+		_, err = ServerInstance.UpdateRefundPolicy(
+			AsAccount(ctx, seller.GetAccountId()),
+			connect.NewRequest(&pb.UpdateRefundPolicyRequest{
+				RefundPolicy: &pb.RefundPolicy{Mode: pb.RefundMode_REFUND_MODE_UNCONSUMED},
+			}),
+		)
+		Expect(err).To(BeNil())
+
+		bought, err := ServerInstance.BuyToken(AsAccount(ctx, buyer.GetAccountId()), connect.NewRequest(&pb.BuyTokenRequest{
+			Audience: seller.GetUsername(),
+			Amount:   100,
+			Escrow:   true,
+		}))
+		Expect(err).To(BeNil())
+		token = bought.Msg.GetToken()
+		claims := jwt.RegisteredClaims{}
+		_, _, err = jwt.NewParser().ParseUnverified(token, &claims)
+		Expect(err).To(BeNil())
+		jti = claims.ID
+	})
+
+	// signReceipt signs a receipt for consumed quota of the token with key
+	signReceipt := func(key ed25519.PrivateKey, consumed int64) *pb.SignedConsumptionReceipt {
+		signed, err := receipt.SignConsumption(key, &pb.ConsumptionReceipt{Jti: jti, Consumed: consumed})
+		Expect(err).To(BeNil())
+		return signed
+	}
+
+	submit := func(receipt *pb.SignedConsumptionReceipt) (*pb.SubmitConsumptionReceiptsResponse, error) {
+		resp, err := ServerInstance.SubmitConsumptionReceipts(ctx, connect.NewRequest(&pb.SubmitConsumptionReceiptsRequest{
+			Receipts: []*pb.SignedConsumptionReceipt{receipt},
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	// redeem consumes quantity of the token as its audience
+	redeem := func(quantity int64) {
+		_, err := ServerInstance.RedeemToken(
+			AsAccount(ctx, seller.GetAccountId()),
+			connect.NewRequest(&pb.RedeemTokenRequest{Token: token, Quantity: &quantity}),
+		)
+		Expect(err).To(BeNil())
+	}
+
+	balance := func(account *pb.Account) int64 {
+		account1, err := StoreInstance.QueryBalance(ctx, account.GetAccountId())
+		Expect(err).To(BeNil())
+		return account1.Balance
+	}
+
+	It("should only pay receipts signed by the audience", func() {
+		redeem(40)
+		unsigned := signReceipt(sellerKey, 40)
+		unsigned.Signature = make([]byte, ed25519.SignatureSize)
+		_, err := submit(unsigned)
+		Expect(err).To(MatchError(ContainSubstring("is not signed by")))
+
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		_, err = submit(signReceipt(otherKey, 40))
+		Expect(err).To(MatchError(ContainSubstring("is not signed by")))
+
+		By("refusing signatures without the receipt prefix")
+		unprefixed := signReceipt(sellerKey, 40)
+		unprefixed.Signature = ed25519.Sign(sellerKey, unprefixed.GetReceipt())
+		_, err = submit(unprefixed)
+		Expect(err).To(MatchError(ContainSubstring("is not signed by")))
+		Expect(balance(seller)).To(Equal(int64(1_000)))
+
+		resp, err := submit(signReceipt(sellerKey, 40))
+		Expect(err).To(BeNil())
+		Expect(resp.GetAmount()).To(Equal(int64(40)))
+		Expect(balance(seller)).To(Equal(int64(1_040)))
+	})
+
+	It("should only escrow for audiences that can sign receipts", func() {
+		_, err := ServerInstance.BuyToken(AsAccount(ctx, buyer.GetAccountId()), connect.NewRequest(&pb.BuyTokenRequest{
+			Audience: "did:web:example.com",
+			Amount:   100,
+			Escrow:   true,
+		}))
+		Expect(err).To(MatchError(ContainSubstring("cannot escrow for audience did:web:example.com")))
+		Expect(balance(buyer)).To(Equal(int64(900)))
+	})
+
+	It("should not pay more than the audience redeemed", func() {
+		_, err := submit(signReceipt(sellerKey, 100))
+		Expect(err).To(MatchError(ContainSubstring("more than the token consumed")))
+
+		redeem(30)
+		_, err = submit(signReceipt(sellerKey, 40))
+		Expect(err).To(MatchError(ContainSubstring("more than the token consumed")))
+		resp, err := submit(signReceipt(sellerKey, 30))
+		Expect(err).To(BeNil())
+		Expect(resp.GetAmount()).To(Equal(int64(30)))
+		Expect(balance(seller)).To(Equal(int64(1_030)))
+	})
+
+	It("should refund what the audience has not claimed", func() {
+		redeem(40)
+		_, err := submit(signReceipt(sellerKey, 40))
+		Expect(err).To(BeNil())
+
+		refunded, err := ServerInstance.RefundToken(
+			AsAccount(ctx, buyer.GetAccountId()),
+			connect.NewRequest(&pb.RefundTokenRequest{Token: token}),
+		)
+		Expect(err).To(BeNil())
+		Expect(refunded.Msg.GetAmount()).To(Equal(int64(60)))
+		Expect(balance(buyer)).To(Equal(int64(960)))
+		Expect(balance(seller)).To(Equal(int64(1_040)))
+
+		By("refusing receipts for the refunded quota")
+		_, err = submit(signReceipt(sellerKey, 50))
+		Expect(err).NotTo(BeNil())
+		Expect(balance(seller)).To(Equal(int64(1_040)))
+	})
+})
@@ -5,11 +5,14 @@ import (
 	"database/sql"
 
 	"connectrpc.com/connect"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
 	"github.com/atticplaygroup/prex/internal/utils"
 	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) Login(ctx context.Context, connectReq *connect.Request[pb.LoginRequest]) (*connect.Response[pb.LoginResponse], error) {
@@ -29,6 +32,12 @@ func (s *Server) Login(ctx context.Context, connectReq *connect.Request[pb.Login
 			err,
 		)
 	}
+	if account.Password == "" {
+		return nil, status.Error(
+			codes.PermissionDenied,
+			"account has no password, log in with signature instead",
+		)
+	}
 	err = bcrypt.CompareHashAndPassword([]byte(account.Password), []byte(req.GetPassword()))
 	if err != nil {
 		return nil, status.Error(
@@ -36,17 +45,135 @@ func (s *Server) Login(ctx context.Context, connectReq *connect.Request[pb.Login
 			"username exists but password incorrect",
 		)
 	}
-	jwt, err := s.auth.GenerateJWT(account.AccountID)
+	accessToken, refreshToken, err := s.startSession(ctx, &account)
+	if err != nil {
+		return nil, err
+	}
+	accountResponse := utils.FormatAccount(account)
+	return connect.NewResponse(&pb.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Account:      &accountResponse,
+	}), nil
+}
+
+func (s *Server) LoginWithSignature(
+	ctx context.Context,
+	connectReq *connect.Request[pb.LoginWithSignatureRequest],
+) (*connect.Response[pb.LoginWithSignatureResponse], error) {
+	req := connectReq.Msg
+	sender, err := s.verifySignedChallenge(
+		ctx,
+		req.GetProof().GetStartTime().AsTime(),
+		req.GetProof().GetChallenge(),
+		req.GetProof().GetSignature(),
+	)
 	if err != nil {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"invalid login proof: %v",
+			err,
+		)
+	}
+	account, err := s.store.GetAccountByOwnerAddress(ctx, db.GetAccountByOwnerAddressParams{
+		Username:      req.GetUsername(),
+		SenderAddress: sender,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows || err.Error() == "no rows in result set" {
+			return nil, status.Errorf(
+				codes.PermissionDenied,
+				"address %s does not own username %v",
+				sender,
+				req.GetUsername(),
+			)
+		}
 		return nil, status.Errorf(
 			codes.Internal,
-			"failed to generate jwt: %v",
+			"database access failed: %v",
 			err,
 		)
 	}
+	accessToken, refreshToken, err := s.startSession(ctx, &account)
+	if err != nil {
+		return nil, err
+	}
 	accountResponse := utils.FormatAccount(account)
-	return connect.NewResponse(&pb.LoginResponse{
-		AccessToken: jwt,
-		Account:     &accountResponse,
+	return connect.NewResponse(&pb.LoginWithSignatureResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Account:      &accountResponse,
+	}), nil
+}
+
+func (s *Server) ResetPassword(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ResetPasswordRequest],
+) (*connect.Response[pb.ResetPasswordResponse], error) {
+	req := connectReq.Msg
+	sender, err := s.verifySignedChallenge(
+		ctx,
+		req.GetProof().GetStartTime().AsTime(),
+		req.GetProof().GetChallenge(),
+		req.GetProof().GetSignature(),
+	)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"invalid reset proof: %v",
+			err,
+		)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.GetNewPassword()), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to hash password",
+		)
+	}
+	account, err := s.store.ResetPasswordTx(ctx, db.ResetAccountPasswordParams{
+		Password: string(hashedPassword),
+		Cooldown: pgtype.Interval{
+			Microseconds: s.config.ResetWithdrawCooldown.Microseconds(),
+			Valid:        true,
+		},
+		Username:      req.GetUsername(),
+		SenderAddress: sender,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows || err.Error() == "no rows in result set" {
+			return nil, status.Errorf(
+				codes.PermissionDenied,
+				"address %s does not own username %v",
+				sender,
+				req.GetUsername(),
+			)
+		}
+		return nil, status.Errorf(
+			codes.Internal,
+			"database access failed: %v",
+			err,
+		)
+	}
+	if err := s.sessions.RevokeAll(ctx, account.AccountID); err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"password reset but failed to revoke sessions: %v",
+			err,
+		)
+	}
+	if err := s.authenticator.RevokeSessions(
+		ctx, account.AccountID, s.auth.Clock.Now(), s.config.SessionTimeout,
+	); err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"password reset but failed to revoke sessions: %v",
+			err,
+		)
+	}
+	accountResponse := utils.FormatAccount(*account)
+	return connect.NewResponse(&pb.ResetPasswordResponse{
+		Account:               &accountResponse,
+		WithdrawCooldownUntil: timestamppb.New(account.WithdrawCooldownUntil.Time),
 	}), nil
 }
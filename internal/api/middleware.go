@@ -2,12 +2,14 @@ package api
 
 import (
 	"context"
-	"crypto/ed25519"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"buf.build/go/protovalidate"
 	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/signing"
 	"github.com/atticplaygroup/prex/internal/utils"
 	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
 	"github.com/golang-jwt/jwt/v5"
@@ -35,21 +37,13 @@ func parseBearer(authString string) (string, error) {
 	return token, nil
 }
 
-func ParseHeaderJwt(
-	authString string,
+// ParseJwt verifies tokenString with the key of its kid in keyring.
+func ParseJwt(
+	tokenString string,
 	claims jwt.Claims,
-	jwtSecret ed25519.PublicKey,
-	headerField string,
+	keyring *signing.Keyring,
 	withValdidation bool,
-) (interface{}, error) {
-	tokenString, err := parseBearer(authString)
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Unauthenticated,
-			"invalid bearer token: %v",
-			err,
-		)
-	}
+) (jwt.Claims, error) {
 	options := []jwt.ParserOption{}
 	if !withValdidation {
 		// TODO: add jwt.WithAudience and WithIssuer to check them
@@ -59,17 +53,45 @@ func ParseHeaderJwt(
 		tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 			// Validate the algorithm
 			if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
-				return nil, status.Errorf(
-					codes.Unauthenticated,
-					"invalid signing method %s",
-					token.Method.Alg(),
-				)
+				return nil, fmt.Errorf("invalid signing method %s", token.Method.Alg())
+			}
+			kid, _ := token.Header["kid"].(string)
+			publicKey, err := keyring.VerificationKey(kid, time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("cannot verify kid %q: %v", kid, err)
 			}
-			return jwtSecret, nil
+			return publicKey, nil
 		},
 		options...,
 	)
-	if err != nil || !token.Valid {
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return token.Claims, nil
+}
+
+// ParseHeaderJwt verifies the bearer token in authString with the key of
+// its kid in keyring.
+func ParseHeaderJwt(
+	authString string,
+	claims jwt.Claims,
+	keyring *signing.Keyring,
+	headerField string,
+	withValdidation bool,
+) (interface{}, error) {
+	tokenString, err := parseBearer(authString)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"invalid bearer token: %v",
+			err,
+		)
+	}
+	parsedClaims, err := ParseJwt(tokenString, claims, keyring, withValdidation)
+	if err != nil {
 		return nil, status.Errorf(
 			codes.Unauthenticated,
 			"invalid token of %s: %v",
@@ -77,19 +99,33 @@ func ParseHeaderJwt(
 			err,
 		)
 	}
-	return token.Claims, nil
+	return parsedClaims, nil
 }
 
 type AuthClaims struct {
 	AccountId int64
+	Roles     []string
+	IssuedAt  time.Time
+	SessionId string
+	TokenId   string
+}
+
+type sessionClaims struct {
+	jwt.RegisteredClaims
+	SessionId string   `json:"sid"`
+	Roles     []string `json:"roles"`
+	// Usage tells access tokens apart from the other JWTs of Prex
+	Usage pb.JwtUsage `json:"usage"`
+	// IssuedAtMicros is the issue time in unix microseconds
+	IssuedAtMicros int64 `json:"iat_us,omitempty"`
 }
 
-func ParseAuthToken(authString string, jwtSecret ed25519.PublicKey, withValidation bool) (*AuthClaims, error) {
-	rawAuthclaims, err := ParseHeaderJwt(authString, &jwt.RegisteredClaims{}, jwtSecret, headerAuthorize, withValidation)
+func ParseAuthToken(authString string, keyring *signing.Keyring, withValidation bool) (*AuthClaims, error) {
+	rawAuthclaims, err := ParseHeaderJwt(authString, &sessionClaims{}, keyring, headerAuthorize, withValidation)
 	if err != nil {
 		return nil, err
 	}
-	authClaims, ok := rawAuthclaims.(*jwt.RegisteredClaims)
+	authClaims, ok := rawAuthclaims.(*sessionClaims)
 	if !ok {
 		return nil, status.Errorf(
 			codes.Unauthenticated,
@@ -97,6 +133,13 @@ func ParseAuthToken(authString string, jwtSecret ed25519.PublicKey, withValidati
 			rawAuthclaims,
 		)
 	}
+	if authClaims.Usage != pb.JwtUsage_JWT_USAGE_ACCESS {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"jwt of usage %v is not an access token",
+			authClaims.Usage,
+		)
+	}
 	subject, err := authClaims.GetSubject()
 	if err != nil {
 		return nil, status.Errorf(
@@ -111,7 +154,24 @@ func ParseAuthToken(authString string, jwtSecret ed25519.PublicKey, withValidati
 			"subject is not parsable as account id int64",
 		)
 	}
-	return &AuthClaims{AccountId: int64(accountId)}, nil
+	ret := &AuthClaims{
+		AccountId: int64(accountId),
+		Roles:     authClaims.Roles,
+		SessionId: authClaims.SessionId,
+		TokenId:   authClaims.ID,
+	}
+	if authClaims.IssuedAtMicros != 0 {
+		ret.IssuedAt = time.UnixMicro(authClaims.IssuedAtMicros)
+	} else if authClaims.IssuedAt != nil {
+		ret.IssuedAt = authClaims.IssuedAt.Time
+	}
+	return ret, nil
+}
+
+func withAuthClaims(ctx context.Context, authClaims *AuthClaims) context.Context {
+	ctx = context.WithValue(ctx, utils.KEY_ACCOUNT_ID, authClaims.AccountId)
+	ctx = context.WithValue(ctx, utils.KEY_SESSION_ID, authClaims.SessionId)
+	return context.WithValue(ctx, utils.KEY_ROLES, authClaims.Roles)
 }
 
 func protoValidation(req any, v protovalidate.Validator) error {
@@ -157,41 +217,26 @@ func NewConnectValidationInterceptor(v protovalidate.Validator) connect.UnaryInt
 }
 
 func NewConnectAuthInterceptor(
-	publicKey ed25519.PublicKey,
+	authenticator *Authenticator,
 ) connect.UnaryInterceptorFunc {
 	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
 		return connect.UnaryFunc(func(
 			ctx context.Context,
 			req connect.AnyRequest,
 		) (connect.AnyResponse, error) {
-			for _, authNotRequired := range []string{
-				pb.ExchangeService_Deposit_FullMethodName,
-				pb.ExchangeService_Login_FullMethodName,
-				pb.ExchangeService_Ping_FullMethodName,
-				pb.ExchangeService_ListPaymentMethods_FullMethodName,
-				pb.ExchangeService_GetChallenge_FullMethodName,
-			} {
-				if req.Spec().Procedure == authNotRequired {
-					return next(ctx, req)
-				}
-			}
-
-			authClaims, err := ParseAuthToken(req.Header().Get(headerAuthorize), publicKey, true)
-			if err != nil || authClaims.AccountId <= 0 {
-				return nil, status.Errorf(
-					codes.Unauthenticated,
-					"account id is invalid: %v",
-					err,
-				)
+			ctx, err := authenticator.Authenticate(
+				ctx, req.Spec().Procedure, req.Header().Get(headerAuthorize))
+			if err != nil {
+				return nil, err
 			}
-			return next(context.WithValue(ctx, utils.KEY_ACCOUNT_ID, authClaims.AccountId), req)
+			return next(ctx, req)
 		})
 	}
 	return connect.UnaryInterceptorFunc(interceptor)
 }
 
 func NewGrpcAuthInterceptor(
-	jwtSecret ed25519.PublicKey,
+	authenticator *Authenticator,
 ) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		headerField := headerAuthorize
@@ -199,29 +244,18 @@ func NewGrpcAuthInterceptor(
 		if len(vals) == 0 {
 			return nil, status.Error(codes.Unauthenticated, "Request unauthenticated with "+headerField)
 		}
-		authClaims, err := ParseAuthToken(vals[0], jwtSecret, true)
-		if err != nil || authClaims.AccountId <= 0 {
-			return nil, status.Errorf(
-				codes.Unauthenticated,
-				"account id is invalid: %v",
-				err,
-			)
+		ctx, err := authenticator.Authenticate(ctx, info.FullMethod, vals[0])
+		if err != nil {
+			return nil, err
 		}
-		return handler(context.WithValue(ctx, utils.KEY_ACCOUNT_ID, authClaims.AccountId), req)
+		return handler(ctx, req)
 	}
 }
 
-func AuthMiddlewareSelector(ctx context.Context, callMeta interceptors.CallMeta) bool {
-	for _, authNotRequired := range []string{
-		pb.ExchangeService_Deposit_FullMethodName,
-		pb.ExchangeService_Login_FullMethodName,
-		pb.ExchangeService_Ping_FullMethodName,
-		pb.ExchangeService_ListPaymentMethods_FullMethodName,
-		pb.ExchangeService_GetChallenge_FullMethodName,
-	} {
-		if callMeta.FullMethod() == authNotRequired {
-			return false
-		}
+func NewAuthMiddlewareSelector(
+	authenticator *Authenticator,
+) func(ctx context.Context, callMeta interceptors.CallMeta) bool {
+	return func(ctx context.Context, callMeta interceptors.CallMeta) bool {
+		return authenticator.RequiresAuth(callMeta.FullMethod())
 	}
-	return true
 }
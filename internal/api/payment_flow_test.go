@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/api"
+	"github.com/atticplaygroup/prex/internal/config"
+	"github.com/atticplaygroup/prex/internal/payment"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Paying through Prex", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(ApiTestDb, Migrations)
+	})
+
+	It("should deposit, buy a token and withdraw over the simulated ledger", func() {
+		ctx := context.Background()
+		buyer, wallet := DepositFromNewWallet(ctx, "did:key:z6MkBuyer", 1_000_000)
+		Expect(buyer.GetBalance()).To(BeEquivalentTo(1_000_000))
+		Expect(Ledger.GetBalanceOf(wallet.Address)).To(BeZero())
+		seller, _ := DepositFromNewWallet(ctx, "did:key:z6MkSeller", 1_000)
+
+		bought, err := ServerInstance.BuyToken(AsAccount(ctx, buyer.GetAccountId()), connect.NewRequest(&pb.BuyTokenRequest{
+			Audience: seller.GetUsername(),
+			Amount:   300_000,
+		}))
+		Expect(err).To(BeNil())
+		Expect(bought.Msg.GetToken()).NotTo(BeEmpty())
+
+		withdrawal, err := ServerInstance.CreateWithdraw(AsAccount(ctx, buyer.GetAccountId()), connect.NewRequest(&pb.CreateWithdrawRequest{
+			Withdrawal: &pb.Withdrawal{
+				AddressTo:   wallet.Address,
+				Amount:      500_000,
+				PriorityFee: 2_000,
+			},
+		}))
+		Expect(err).To(BeNil())
+		Expect(withdrawal.Msg.GetWithdrawal().GetAmount()).To(BeEquivalentTo(500_000))
+
+		processed, err := ServerInstance.BatchProcessWithdraws(ctx, connect.NewRequest(&pb.BatchProcessWithdrawsRequest{
+			Limit: 10,
+		}))
+		Expect(err).To(BeNil())
+		Expect(processed.Msg.GetDigest()).NotTo(BeEmpty())
+		marked, err := ServerInstance.BatchMarkWithdraws(ctx, connect.NewRequest(&pb.BatchMarkWithdrawsRequest{
+			Limit: 10,
+		}))
+		Expect(err).To(BeNil())
+		Expect(marked.Msg.GetSuccessWithdrawIds()).To(HaveLen(1))
+
+		Expect(Ledger.GetBalanceOf(wallet.Address)).To(BeEquivalentTo(500_000))
+		// The batch pays the reference gas price out of the priority fees
+		Expect(Ledger.GetBalanceOf(Ledger.GetAddress())).To(BeEquivalentTo(
+			1_001_000 - 500_000 - payment.SIMULATED_REFERENCE_GAS_PRICE))
+	})
+
+	It("should refuse mixing coins of different value in one balance", func() {
+		conf := Conf
+		conf.PaymentMethods = []config.PaymentMethodConfig{
+			{Coin: "sui", Network: "mainnet"},
+			{Coin: "sui", Network: "devnet"},
+		}
+		_, err := api.NewServer(conf, *StoreInstance)
+		Expect(err).To(MatchError(ContainSubstring("cannot share balances")))
+
+		conf.PaymentMethods = []config.PaymentMethodConfig{
+			{Coin: "sui", Network: "devnet"},
+			{Coin: "sui", Network: "localnet"},
+		}
+		_, err = api.NewServer(conf, *StoreInstance)
+		Expect(err).To(BeNil())
+	})
+
+	It("should report cached wallet statuses without raising refill alerts", func() {
+		ctx := context.Background()
+		manager := ServerInstance.GetWalletManagers()[0]
+		onRefillNeeded := manager.OnRefillNeeded
+		DeferCleanup(func() { manager.OnRefillNeeded = onRefillNeeded })
+		alerted := false
+		manager.OnRefillNeeded = func(ctx context.Context, status payment.WalletStatus) {
+			alerted = true
+		}
+
+		resp, err := ServerInstance.GetWalletStatus(ctx, connect.NewRequest(&pb.GetWalletStatusRequest{}))
+		Expect(err).To(BeNil())
+		Expect(alerted).To(BeFalse())
+		Expect(resp.Msg.GetWalletStatuses()).To(HaveLen(len(Conf.PaymentMethods)))
+		Expect(resp.Msg.GetWalletStatus().GetPaymentMethod()).To(Equal(
+			resp.Msg.GetWalletStatuses()[0].GetPaymentMethod()))
+		Expect(resp.Msg.GetWalletStatus().GetHotAddress()).To(Equal(manager.GetStatus().HotAddress))
+	})
+})
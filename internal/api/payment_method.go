@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/config"
+	"github.com/atticplaygroup/prex/internal/payment"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type paymentBackend struct {
+	method *pb.PaymentMethod
+	client payment.IPaymentClient
+	wallet *payment.HotWalletManager
+}
+
+// noPendingWithdrawals is the withdrawal queue of hot wallets only collecting
+// deposits.
+type noPendingWithdrawals struct{}
+
+func (noPendingWithdrawals) SumPendingWithdrawals(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+var paymentEnvironments = map[string]pb.PaymentEnvironment{
+	"mainnet":                pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_MAINNET,
+	"devnet":                 pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_DEVNET,
+	"testnet":                pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_TESTNET,
+	"localnet":               pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_LOCALNET,
+	payment.SimulatedNetwork: pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_SIMULATED,
+}
+
+// checkSharedBalance refuses payment methods whose coins may differ in value.
+// Deposits of all methods go to one balance and withdrawals are paid by the
+// first, so only coins of test networks, which are worth nothing, may mix.
+func checkSharedBalance(backends []*paymentBackend) error {
+	if len(backends) < 2 {
+		return nil
+	}
+	for _, backend := range backends {
+		switch backend.method.GetEnvironment() {
+		case pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_DEVNET,
+			pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_TESTNET,
+			pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_LOCALNET,
+			pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_SIMULATED:
+		default:
+			return fmt.Errorf(
+				"payment method %s cannot share balances with other payment methods",
+				backend.method.GetName(),
+			)
+		}
+	}
+	return nil
+}
+
+func newPaymentBackends(conf config.Config) ([]*paymentBackend, error) {
+	backends := make([]*paymentBackend, 0)
+	for _, methodConf := range conf.PaymentMethods {
+		if methodConf.Coin != "sui" {
+			return nil, fmt.Errorf("unsupported payment coin %s", methodConf.Coin)
+		}
+		client, err := payment.NewPaymentClient(payment.NetworkConfig{
+			Network:        methodConf.Network,
+			RpcUrl:         methodConf.RpcUrl,
+			GraphqlUrl:     methodConf.GraphqlUrl,
+			DepositAddress: methodConf.DepositAddress,
+		}, conf.Signer)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, &paymentBackend{
+			method: &pb.PaymentMethod{
+				Name: fmt.Sprintf(
+					utils.RESOURCE_PATTERN_PAYMENT_METHOD, methodConf.Coin, methodConf.Network),
+				Coin: pb.PaymentCoin_PAYMENT_COIN_SUI,
+				// Custom networks are reported as unspecified environments
+				Environment: paymentEnvironments[methodConf.Network],
+				Address:     client.GetAddress(),
+			},
+			client: client,
+		})
+	}
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no payment method configured")
+	}
+	if err := checkSharedBalance(backends); err != nil {
+		return nil, err
+	}
+	return backends, nil
+}
+
+// initHotWallets creates the hot wallet manager of every backend. Only the
+// first backend pays out withdrawals, so the others are swept but never need
+// a refill.
+func initHotWallets(conf config.Config, store *store.Store, backends []*paymentBackend) error {
+	for i, backend := range backends {
+		walletConfig := payment.WalletConfig{
+			ColdAddress:    conf.ColdWalletAddress,
+			Float:          conf.HotWalletFloat,
+			HighWaterMark:  conf.HotWalletHighWaterMark,
+			LowWaterMark:   conf.HotWalletLowWaterMark,
+			SweepGasBudget: conf.WalletSweepGasBudget,
+		}
+		var pending payment.IPendingWithdrawalGetter = store.Queries
+		if i > 0 {
+			walletConfig.LowWaterMark = 0
+			pending = noPendingWithdrawals{}
+		}
+		wallet, err := payment.NewHotWalletManager(backend.client, pending, store, walletConfig)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to init hot wallet manager of %s: %v", backend.method.GetName(), err)
+		}
+		backend.wallet = wallet
+	}
+	return nil
+}
+
+// getPaymentBackend returns the backend of the named payment method or the
+// default one if name is empty.
+func (s *Server) getPaymentBackend(name string) (*paymentBackend, error) {
+	if name == "" {
+		return s.paymentBackends[0], nil
+	}
+	for _, backend := range s.paymentBackends {
+		if backend.method.GetName() == name {
+			return backend, nil
+		}
+	}
+	return nil, status.Errorf(
+		codes.InvalidArgument,
+		"payment method %s is not enabled",
+		name,
+	)
+}
+
+func (s *Server) ListPaymentMethods(
+	ctx context.Context,
+	req *connect.Request[pb.ListPaymentMethodsRequest],
+) (*connect.Response[pb.ListPaymentMethodsResponse], error) {
+	paymentMethods := make([]*pb.PaymentMethod, 0)
+	for _, backend := range s.paymentBackends {
+		paymentMethods = append(paymentMethods, backend.method)
+	}
+	return connect.NewResponse(&pb.ListPaymentMethodsResponse{
+		PaymentMethods: paymentMethods,
+	}), nil
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/netip"
+	"path"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/ratelimit"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	headerRetryAfter   = "retry-after"
+	headerForwardedFor = "x-forwarded-for"
+)
+
+// RateLimiter limits calls per client IP on public methods and per account
+// on the others, and locks out usernames after repeated Login failures. It
+// must run after the Authenticator so the account is known. Client IPs are
+// taken from x-forwarded-for only if the peer is one of trustedProxies.
+type RateLimiter struct {
+	limiter        *ratelimit.Limiter
+	limits         map[string]ratelimit.Limit
+	fallback       ratelimit.Limit
+	lockout        ratelimit.Lockout
+	trustedProxies []netip.Prefix
+}
+
+// NewRateLimiter takes limits by method name. It fails on names not found in
+// services to catch typos in the config.
+func NewRateLimiter(
+	redisClient *redis.Client,
+	limits map[string]ratelimit.Limit,
+	lockout ratelimit.Lockout,
+	trustedProxies []netip.Prefix,
+	services ...protoreflect.ServiceDescriptor,
+) (*RateLimiter, error) {
+	known := map[string]bool{ratelimit.DEFAULT_LIMIT_NAME: true}
+	for _, service := range services {
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			known[string(methods.Get(i).Name())] = true
+		}
+	}
+	for name := range limits {
+		if !known[name] {
+			return nil, fmt.Errorf("rate limit of unknown method %s", name)
+		}
+	}
+	return &RateLimiter{
+		limiter:        ratelimit.NewLimiter(redisClient),
+		limits:         limits,
+		fallback:       limits[ratelimit.DEFAULT_LIMIT_NAME],
+		lockout:        lockout,
+		trustedProxies: trustedProxies,
+	}, nil
+}
+
+func (r *RateLimiter) limitOf(method string) ratelimit.Limit {
+	if limit, ok := r.limits[method]; ok {
+		return limit
+	}
+	return r.fallback
+}
+
+func loginLockoutSubject(req any) (string, bool) {
+	loginReq, ok := req.(*pb.LoginRequest)
+	if !ok {
+		return "", false
+	}
+	return "login:" + loginReq.GetUsername(), true
+}
+
+// check returns how long the caller has to wait before calling fullMethod.
+func (r *RateLimiter) check(
+	ctx context.Context, fullMethod string, peerAddr string, forwardedFor []string, req any,
+) (time.Duration, error) {
+	if subject, ok := loginLockoutSubject(req); ok && r.lockout.Enabled() {
+		lockedFor, err := r.limiter.LockedFor(ctx, subject)
+		if err != nil || lockedFor > 0 {
+			return lockedFor, err
+		}
+	}
+	method := path.Base(fullMethod)
+	var subject string
+	if accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64); ok {
+		subject = fmt.Sprintf("account:%d", accountId)
+	} else {
+		subject = "ip:" + ratelimit.ClientAddr(peerAddr, forwardedFor, r.trustedProxies)
+	}
+	return r.limiter.Allow(ctx, method, subject, r.limitOf(method))
+}
+
+// observe tracks Login outcomes for the lockout. Errors are only logged as
+// the call itself already finished.
+func (r *RateLimiter) observe(ctx context.Context, req any, err error) {
+	subject, ok := loginLockoutSubject(req)
+	if !ok || !r.lockout.Enabled() {
+		return
+	}
+	switch status.Code(err) {
+	case codes.OK:
+		err = r.limiter.Succeed(ctx, subject)
+	case codes.Unauthenticated, codes.PermissionDenied:
+		_, err = r.limiter.Fail(ctx, subject, r.lockout)
+	default:
+		return
+	}
+	if err != nil {
+		log.Printf("failed to track login attempt: %v", err)
+	}
+}
+
+func formatRetryAfter(retryAfter time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10)
+}
+
+func NewConnectRateLimitInterceptor(rateLimiter *RateLimiter) connect.UnaryInterceptorFunc {
+	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
+		return connect.UnaryFunc(func(
+			ctx context.Context,
+			req connect.AnyRequest,
+		) (connect.AnyResponse, error) {
+			retryAfter, err := rateLimiter.check(
+				ctx, req.Spec().Procedure, req.Peer().Addr, req.Header().Values(headerForwardedFor), req.Any(),
+			)
+			if err != nil {
+				return nil, status.Errorf(
+					codes.Unavailable,
+					"failed to check rate limit: %v",
+					err,
+				)
+			}
+			if retryAfter > 0 {
+				connectErr := connect.NewError(
+					connect.CodeResourceExhausted,
+					fmt.Errorf("rate limit exceeded, retry after %v", retryAfter),
+				)
+				connectErr.Meta().Set(headerRetryAfter, formatRetryAfter(retryAfter))
+				return nil, connectErr
+			}
+			resp, err := next(ctx, req)
+			rateLimiter.observe(ctx, req.Any(), err)
+			return resp, err
+		})
+	}
+	return connect.UnaryInterceptorFunc(interceptor)
+}
+
+func NewGrpcRateLimitInterceptor(rateLimiter *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		peerAddr := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			peerAddr = p.Addr.String()
+		}
+		// The grpc-gateway appends the address of its client here
+		md, _ := metadata.FromIncomingContext(ctx)
+		retryAfter, err := rateLimiter.check(ctx, info.FullMethod, peerAddr, md.Get(headerForwardedFor), req)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Unavailable,
+				"failed to check rate limit: %v",
+				err,
+			)
+		}
+		if retryAfter > 0 {
+			grpc.SetHeader(ctx, metadata.Pairs(headerRetryAfter, formatRetryAfter(retryAfter)))
+			return nil, status.Errorf(
+				codes.ResourceExhausted,
+				"rate limit exceeded, retry after %v",
+				retryAfter,
+			)
+		}
+		resp, err := handler(ctx, req)
+		rateLimiter.observe(ctx, req, err)
+		return resp, err
+	}
+}
@@ -0,0 +1,91 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/api"
+	"github.com/atticplaygroup/prex/internal/ratelimit"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type pingServer struct {
+	pb.UnimplementedExchangeServiceServer
+}
+
+func (pingServer) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
+	return &pb.PingResponse{Pong: "pong"}, nil
+}
+
+var _ = Describe("Rate limiting through the gateway", Label("redis"), func() {
+	var gateway *runtime.ServeMux
+
+	BeforeEach(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		redisClient := redis.NewClient(&redis.Options{
+			Addr: fmt.Sprintf("%s:%d", Conf.RedisHost, Conf.RedisPort),
+		})
+		if err := redisClient.Ping(ctx).Err(); err != nil {
+			Skip(fmt.Sprintf("redis unavailable: %v", err))
+		}
+		trustedProxies, err := ratelimit.ParseTrustedProxies("127.0.0.1")
+		Expect(err).To(BeNil())
+		rateLimiter, err := api.NewRateLimiter(
+			redisClient,
+			map[string]ratelimit.Limit{"Ping": {Count: 1, Window: time.Minute}},
+			ratelimit.Lockout{},
+			trustedProxies,
+			pb.File_exchange_v1_exchange_proto.Services().ByName("ExchangeService"),
+		)
+		Expect(err).To(BeNil())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		grpcServer := grpc.NewServer(grpc.UnaryInterceptor(api.NewGrpcRateLimitInterceptor(rateLimiter)))
+		pb.RegisterExchangeServiceServer(grpcServer, pingServer{})
+		go grpcServer.Serve(listener)
+		DeferCleanup(grpcServer.Stop)
+
+		gateway = runtime.NewServeMux()
+		Expect(pb.RegisterExchangeServiceHandlerFromEndpoint(
+			ctx, gateway, listener.Addr().String(),
+			[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		)).To(Succeed())
+	})
+
+	ping := func(remoteAddr string, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/ping", nil)
+		req.RemoteAddr = remoteAddr + ":4242"
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		recorder := httptest.NewRecorder()
+		gateway.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	It("should limit each client of the gateway on its own", func() {
+		// Fresh addresses so that earlier runs do not count
+		subnet := fmt.Sprintf("198.18.%d", rand.IntN(256))
+		first, second := subnet+".1", subnet+".2"
+
+		Expect(ping(first, "")).To(Equal(http.StatusOK))
+		Expect(ping(first, "")).To(Equal(http.StatusTooManyRequests))
+		Expect(ping(second, "")).To(Equal(http.StatusOK))
+
+		By("ignoring addresses forged by the client")
+		Expect(ping(second, subnet+".3")).To(Equal(http.StatusTooManyRequests))
+	})
+})
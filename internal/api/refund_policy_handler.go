@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var refundModes = map[string]pb.RefundMode{
+	store.REFUND_MODE_NONE:       pb.RefundMode_REFUND_MODE_NONE,
+	store.REFUND_MODE_UNREDEEMED: pb.RefundMode_REFUND_MODE_UNREDEEMED,
+	store.REFUND_MODE_UNCONSUMED: pb.RefundMode_REFUND_MODE_UNCONSUMED,
+}
+
+func formatRefundPolicy(audience string, policy *db.RefundPolicy) *pb.RefundPolicy {
+	ret := &pb.RefundPolicy{
+		Audience: audience,
+		Mode:     refundModes[policy.RefundMode],
+	}
+	if policy.RefundWindow.Valid && policy.RefundWindow.Microseconds > 0 {
+		ret.RefundWindow = durationpb.New(time.Duration(policy.RefundWindow.Microseconds) * time.Microsecond)
+	}
+	if policy.UpdateTime.Valid {
+		ret.UpdateTime = timestamppb.New(policy.UpdateTime.Time)
+	}
+	return ret
+}
+
+func (s *Server) GetRefundPolicy(
+	ctx context.Context,
+	connectReq *connect.Request[pb.GetRefundPolicyRequest],
+) (*connect.Response[pb.RefundPolicy], error) {
+	audience := connectReq.Msg.GetAudience()
+	policy, err := store.GetRefundPolicy(ctx, s.store.Queries, audience)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get refund policy: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(formatRefundPolicy(audience, policy)), nil
+}
+
+func (s *Server) UpdateRefundPolicy(
+	ctx context.Context,
+	connectReq *connect.Request[pb.UpdateRefundPolicyRequest],
+) (*connect.Response[pb.RefundPolicy], error) {
+	req := connectReq.Msg.GetRefundPolicy()
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	audience, err := s.callerAudience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var mode string
+	for name, value := range refundModes {
+		if value == req.GetMode() {
+			mode = name
+		}
+	}
+	if mode == "" {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"unknown refund mode %s",
+			req.GetMode(),
+		)
+	}
+	policy, err := s.store.UpsertRefundPolicy(ctx, db.UpsertRefundPolicyParams{
+		AccountID:  accountId,
+		RefundMode: mode,
+		RefundWindow: pgtype.Interval{
+			Microseconds: req.GetRefundWindow().AsDuration().Microseconds(),
+			Valid:        true,
+		},
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to update refund policy: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(formatRefundPolicy(audience, &policy)), nil
+}
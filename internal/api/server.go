@@ -2,15 +2,16 @@ package api
 
 import (
 	"context"
-	"crypto/ed25519"
 	"fmt"
 	"log"
+	"sync"
 
 	"buf.build/go/protovalidate"
 	"connectrpc.com/connect"
 	"github.com/atticplaygroup/prex/internal/auth"
 	"github.com/atticplaygroup/prex/internal/config"
 	"github.com/atticplaygroup/prex/internal/payment"
+	"github.com/atticplaygroup/prex/internal/ratelimit"
 	"github.com/atticplaygroup/prex/internal/store"
 	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
@@ -21,11 +22,22 @@ import (
 type Server struct {
 	pb.ExchangeServiceServer
 	// exchange.UnimplementedExchangeServer
-	config        config.Config
-	store         store.Store
-	redisClient   *redis.Client
-	auth          auth.Auth
-	paymentClient *payment.SuiPaymentClient
+	config      config.Config
+	store       store.Store
+	redisClient *redis.Client
+	auth        auth.Auth
+	sessions    *auth.SessionStore
+	challenges  *auth.ChallengeStore
+	// paymentClient pays out withdrawals. It is the backend of the first
+	// configured payment method.
+	paymentClient   payment.IPaymentClient
+	paymentBackends []*paymentBackend
+	authenticator   *Authenticator
+	rateLimiter     *RateLimiter
+	// treeHead is the latest signed log head, reused until the log grows or
+	// the signing key rotates.
+	treeHeadMu sync.Mutex
+	treeHead   *pb.SignedTreeHead
 }
 
 func (s *Server) Ping(ctx context.Context, _ *connect.Request[pb.PingRequest]) (*connect.Response[pb.PingResponse], error) {
@@ -34,44 +46,61 @@ func (s *Server) Ping(ctx context.Context, _ *connect.Request[pb.PingRequest]) (
 	}), nil
 }
 
-func (s *Server) ListPaymentMethods(
-	ctx context.Context,
-	req *connect.Request[pb.ListPaymentMethodsRequest],
-) (*connect.Response[pb.ListPaymentMethodsResponse], error) {
-	return connect.NewResponse(&pb.ListPaymentMethodsResponse{
-		// TODO: Support other options
-		PaymentMethods: []*pb.PaymentMethod{
-			{
-				Coin:        *pb.PaymentCoin_PAYMENT_COIN_SUI.Enum(),
-				Environment: *pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_DEVNET.Enum(),
-				Address:     s.config.WalletSigner.Address,
-			},
-			{
-				Coin:        *pb.PaymentCoin_PAYMENT_COIN_SUI.Enum(),
-				Environment: *pb.PaymentEnvironment_PAYMENT_ENVIRONMENT_LOCALNET.Enum(),
-				Address:     s.config.WalletSigner.Address,
-			},
-		},
-	}), nil
-}
-
 func NewServer(config config.Config, store store.Store) (*Server, error) {
-	paymentClient, err := payment.NewSuiPaymentClient(config.SuiNetwork, &config.WalletSigner)
+	paymentBackends, err := newPaymentBackends(config)
 	if err != nil {
-		log.Fatalf("failed to initialize sui client: %v", err)
+		return nil, err
+	}
+	if err := initHotWallets(config, &store, paymentBackends); err != nil {
+		return nil, err
 	}
 	authentication, err := auth.NewAuth(config)
 	if err != nil {
 		log.Fatalf("Cannot initialize auth: %v", err)
 	}
+	// Access tokens issued before kids were added expire within the timeout
+	config.Keyring.AllowMissingKeyId(
+		config.TokenSigningKeyId,
+		authentication.Clock.Now().Add(authentication.AccessTokenTimeout),
+	)
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("%s:%d", config.RedisHost, config.RedisPort),
+	})
+	authenticator, err := NewAuthenticator(
+		config.Keyring,
+		authentication.Clock,
+		redisClient,
+		store.Queries,
+		pb.File_exchange_v1_exchange_proto.Services().ByName("ExchangeService"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth policies: %v", err)
+	}
+	rateLimiter, err := NewRateLimiter(
+		redisClient,
+		config.RateLimits,
+		ratelimit.Lockout{
+			Threshold: config.LoginLockoutThreshold,
+			Base:      config.LoginLockoutBase,
+			Max:       config.LoginLockoutMax,
+		},
+		config.TrustedProxies,
+		pb.File_exchange_v1_exchange_proto.Services().ByName("ExchangeService"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rate limits: %v", err)
+	}
 	server := &Server{
-		config:        config,
-		store:         store,
-		auth:          *authentication,
-		paymentClient: paymentClient,
-		redisClient: redis.NewClient(&redis.Options{
-			Addr: fmt.Sprintf("%s:%d", config.RedisHost, config.RedisPort),
-		}),
+		config:          config,
+		store:           store,
+		auth:            *authentication,
+		sessions:        auth.NewSessionStore(redisClient, authentication),
+		challenges:      auth.NewChallengeStore(redisClient, authentication),
+		paymentClient:   paymentBackends[0].client,
+		paymentBackends: paymentBackends,
+		authenticator:   authenticator,
+		rateLimiter:     rateLimiter,
+		redisClient:     redisClient,
 	}
 	ctx := context.Background()
 	if err := server.redisClient.Ping(ctx).Err(); err != nil {
@@ -81,18 +110,16 @@ func NewServer(config config.Config, store store.Store) (*Server, error) {
 }
 
 func NewGrpcServer(server *Server) *grpc.Server {
-	privateKey := server.GetConfig().TokenSigningPrivateKey
-	publicKey := privateKey.Public().(ed25519.PublicKey)
 	validator, err := protovalidate.New()
 	if err != nil {
 		log.Fatalf("failed to initialize validator: %s", err.Error())
 	}
-	// var freeQuotaRedisRateLimiter func(context.Context, interceptors.CallMeta) bool
 	selectors := []grpc.UnaryServerInterceptor{
 		selector.UnaryServerInterceptor(
-			NewGrpcAuthInterceptor(publicKey),
-			selector.MatchFunc(AuthMiddlewareSelector),
+			NewGrpcAuthInterceptor(server.GetAuthenticator()),
+			selector.MatchFunc(NewAuthMiddlewareSelector(server.GetAuthenticator())),
 		),
+		NewGrpcRateLimitInterceptor(server.GetRateLimiter()),
 		NewGrpcValidationInterceptor(validator),
 	}
 	return grpc.NewServer(
@@ -104,6 +131,28 @@ func (s *Server) GetRedisClient() *redis.Client {
 	return s.redisClient
 }
 
+func (s *Server) GetPaymentClient() payment.IPaymentClient {
+	return s.paymentClient
+}
+
+// GetWalletManagers returns the hot wallet managers of all payment methods,
+// starting with the one paying out withdrawals.
+func (s *Server) GetWalletManagers() []*payment.HotWalletManager {
+	managers := make([]*payment.HotWalletManager, 0, len(s.paymentBackends))
+	for _, backend := range s.paymentBackends {
+		managers = append(managers, backend.wallet)
+	}
+	return managers
+}
+
+func (s *Server) GetAuthenticator() *Authenticator {
+	return s.authenticator
+}
+
+func (s *Server) GetRateLimiter() *RateLimiter {
+	return s.rateLimiter
+}
+
 func (s *Server) GetConfig() *config.Config {
 	return &s.config
 }
@@ -0,0 +1,378 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	serviceSessionActive = "active"
+	serviceSessionPaused = "paused"
+	serviceSessionClosed = "closed"
+)
+
+var serviceSessionStates = map[string]pb.ServiceSessionState{
+	serviceSessionActive: pb.ServiceSessionState_SERVICE_SESSION_STATE_ACTIVE,
+	serviceSessionPaused: pb.ServiceSessionState_SERVICE_SESSION_STATE_PAUSED,
+	serviceSessionClosed: pb.ServiceSessionState_SERVICE_SESSION_STATE_CLOSED,
+}
+
+func formatServiceSession(serviceSession *db.ServiceSession) *pb.ServiceSession {
+	return &pb.ServiceSession{
+		Name:       fmt.Sprintf(utils.RESOURCE_PATTERN_SERVICE_SESSION, serviceSession.ServiceSessionID),
+		Audience:   serviceSession.Audience,
+		Quantity:   serviceSession.Quantity,
+		Consumed:   serviceSession.Consumed,
+		Remaining:  serviceSession.Quantity - serviceSession.Consumed,
+		State:      serviceSessionStates[serviceSession.SessionState],
+		CreateTime: timestamppb.New(serviceSession.CreateTime.Time),
+		UpdateTime: timestamppb.New(serviceSession.UpdateTime.Time),
+		ExpireTime: timestamppb.New(serviceSession.ExpireTime.Time),
+	}
+}
+
+// callerAudience returns the audience tokens for the calling account carry.
+func (s *Server) callerAudience(ctx context.Context) (string, error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return "", status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	account, err := s.store.QueryBalance(ctx, accountId)
+	if err != nil {
+		return "", status.Errorf(
+			codes.Internal,
+			"failed to get account: %v",
+			err,
+		)
+	}
+	// The audience of a token is the username of the seller account
+	return account.Username, nil
+}
+
+func redeemTokenParams(token *Token) store.RedeemTokenParams {
+	ret := store.RedeemTokenParams{
+		Jti:        token.ID,
+		Audience:   token.Audience[0],
+		Quantity:   token.Quantity,
+		ExpireTime: pgtype.Timestamptz{Time: token.ExpiresAt.Time, Valid: true},
+	}
+	if token.Caveats != nil {
+		for _, limit := range token.Caveats.Limits {
+			ret.Limits = append(ret.Limits, store.QuotaLimit{Id: limit.Id, Quantity: limit.Quantity})
+		}
+	}
+	return ret
+}
+
+// generateManageJwt lets the holder manage serviceSession until it expires.
+func (s *Server) generateManageJwt(ctx context.Context, serviceSession *db.ServiceSession) (string, error) {
+	claims := &Token{
+		RegisteredClaims: &jwt.RegisteredClaims{
+			Subject:   fmt.Sprintf(utils.RESOURCE_PATTERN_SERVICE_SESSION, serviceSession.ServiceSessionID),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Audience:  jwt.ClaimStrings{serviceSession.Audience},
+			ExpiresAt: jwt.NewNumericDate(serviceSession.ExpireTime.Time),
+			ID:        uuid.NewString(),
+		},
+		Usage: pb.JwtUsage_JWT_USAGE_MANAGE_SESSION,
+	}
+	return s.signToken(ctx, claims)
+}
+
+// getManagedSession returns the session a manage-session token is for.
+func (s *Server) getManagedSession(ctx context.Context, tokenString string) (*db.ServiceSession, error) {
+	claims, err := ParseJwt(
+		tokenString,
+		&Token{RegisteredClaims: &jwt.RegisteredClaims{}},
+		s.config.Keyring,
+		true,
+	)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"invalid manage token: %v",
+			err,
+		)
+	}
+	token, ok := claims.(*Token)
+	if !ok || token.Usage != pb.JwtUsage_JWT_USAGE_MANAGE_SESSION || len(token.Audience) != 1 {
+		return nil, status.Error(
+			codes.Unauthenticated,
+			"not a manage token",
+		)
+	}
+	ids, err := utils.ParseResourceName(token.Subject, []string{"service-sessions"})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"invalid subject of manage token: %v",
+			err,
+		)
+	}
+	serviceSession, err := s.store.GetServiceSession(ctx, ids[0])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"service session %s not found",
+			token.Subject,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get service session: %v",
+			err,
+		)
+	}
+	if serviceSession.Audience != token.Audience[0] {
+		return nil, status.Error(
+			codes.Unauthenticated,
+			"manage token audience mismatch",
+		)
+	}
+	return &serviceSession, nil
+}
+
+func (s *Server) CreateServiceSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.CreateServiceSessionRequest],
+) (*connect.Response[pb.CreateServiceSessionResponse], error) {
+	audience, err := s.callerAudience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.parseQuotaToken(connectReq.Msg.GetToken(), true)
+	if err != nil {
+		return nil, err
+	}
+	// Sessions are not bound to an endpoint
+	if err := authorizeQuotaToken(token, "", 0); err != nil {
+		return nil, err
+	}
+	if token.Audience[0] != audience {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"token is for audience %s",
+			token.Audience[0],
+		)
+	}
+	serviceSession, err := s.store.CreateServiceSessionTx(ctx, redeemTokenParams(token))
+	if errors.Is(err, store.ErrQuotaExhausted) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"%v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to create service session: %v",
+			err,
+		)
+	}
+	manageToken, err := s.generateManageJwt(ctx, serviceSession)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&pb.CreateServiceSessionResponse{
+		ServiceSession: formatServiceSession(serviceSession),
+		ManageToken:    manageToken,
+	}), nil
+}
+
+func (s *Server) ConsumeServiceSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ConsumeServiceSessionRequest],
+) (*connect.Response[pb.ConsumeServiceSessionResponse], error) {
+	req := connectReq.Msg
+	audience, err := s.callerAudience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := utils.ParseResourceName(req.GetName(), []string{"service-sessions"})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"failed to parse service session name: %v",
+			err,
+		)
+	}
+	serviceSession, err := s.store.ConsumeServiceSession(ctx, db.ConsumeServiceSessionParams{
+		Quantity:         req.GetQuantity(),
+		ServiceSessionID: ids[0],
+		Audience:         audience,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"service session %s of %s is not active or has less than %d left",
+			req.GetName(),
+			audience,
+			req.GetQuantity(),
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to consume service session: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.ConsumeServiceSessionResponse{
+		ServiceSession: formatServiceSession(&serviceSession),
+	}), nil
+}
+
+func (s *Server) GetServiceSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.GetServiceSessionRequest],
+) (*connect.Response[pb.GetServiceSessionResponse], error) {
+	serviceSession, err := s.getManagedSession(ctx, connectReq.Msg.GetManageToken())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&pb.GetServiceSessionResponse{
+		ServiceSession: formatServiceSession(serviceSession),
+	}), nil
+}
+
+func (s *Server) TopUpServiceSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.TopUpServiceSessionRequest],
+) (*connect.Response[pb.TopUpServiceSessionResponse], error) {
+	req := connectReq.Msg
+	serviceSession, err := s.getManagedSession(ctx, req.GetManageToken())
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.parseQuotaToken(req.GetToken(), true)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeQuotaToken(token, "", 0); err != nil {
+		return nil, err
+	}
+	if token.Audience[0] != serviceSession.Audience {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"token is for audience %s but session for %s",
+			token.Audience[0],
+			serviceSession.Audience,
+		)
+	}
+	serviceSession, err = s.store.TopUpServiceSessionTx(ctx, store.TopUpServiceSessionTxParams{
+		ServiceSessionID: serviceSession.ServiceSessionID,
+		Token:            redeemTokenParams(token),
+	})
+	if errors.Is(err, store.ErrQuotaExhausted) || errors.Is(err, store.ErrServiceSessionUnavailable) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"%v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to top up service session: %v",
+			err,
+		)
+	}
+	manageToken, err := s.generateManageJwt(ctx, serviceSession)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&pb.TopUpServiceSessionResponse{
+		ServiceSession: formatServiceSession(serviceSession),
+		ManageToken:    manageToken,
+	}), nil
+}
+
+// setServiceSessionState moves the session of a manage token to state if it
+// is in one of fromStates.
+func (s *Server) setServiceSessionState(
+	ctx context.Context, manageToken string, state string, fromStates ...string,
+) (*pb.ServiceSession, error) {
+	serviceSession, err := s.getManagedSession(ctx, manageToken)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.store.SetServiceSessionState(ctx, db.SetServiceSessionStateParams{
+		SessionState:     state,
+		ServiceSessionID: serviceSession.ServiceSessionID,
+		FromStates:       fromStates,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"cannot change service session from %s to %s",
+			serviceSession.SessionState,
+			state,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to set service session state: %v",
+			err,
+		)
+	}
+	return formatServiceSession(&updated), nil
+}
+
+func (s *Server) PauseServiceSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.PauseServiceSessionRequest],
+) (*connect.Response[pb.PauseServiceSessionResponse], error) {
+	serviceSession, err := s.setServiceSessionState(
+		ctx, connectReq.Msg.GetManageToken(), serviceSessionPaused, serviceSessionActive)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&pb.PauseServiceSessionResponse{
+		ServiceSession: serviceSession,
+	}), nil
+}
+
+func (s *Server) ResumeServiceSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ResumeServiceSessionRequest],
+) (*connect.Response[pb.ResumeServiceSessionResponse], error) {
+	serviceSession, err := s.setServiceSessionState(
+		ctx, connectReq.Msg.GetManageToken(), serviceSessionActive, serviceSessionPaused)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&pb.ResumeServiceSessionResponse{
+		ServiceSession: serviceSession,
+	}), nil
+}
+
+func (s *Server) CloseServiceSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.CloseServiceSessionRequest],
+) (*connect.Response[pb.CloseServiceSessionResponse], error) {
+	serviceSession, err := s.setServiceSessionState(
+		ctx, connectReq.Msg.GetManageToken(), serviceSessionClosed, serviceSessionActive, serviceSessionPaused)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&pb.CloseServiceSessionResponse{
+		ServiceSession: serviceSession,
+	}), nil
+}
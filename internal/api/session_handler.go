@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/auth"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// startSession creates a session of account and returns its first access
+// and refresh tokens.
+func (s *Server) startSession(ctx context.Context, account *db.Account) (string, string, error) {
+	session, refreshToken, err := s.sessions.Create(ctx, account.AccountID)
+	if err != nil {
+		return "", "", status.Errorf(
+			codes.Internal,
+			"failed to create session: %v",
+			err,
+		)
+	}
+	accessToken, err := s.auth.GenerateJWT(ctx, account.AccountID, []string{account.Privilege}, session)
+	if err != nil {
+		return "", "", status.Errorf(
+			codes.Internal,
+			"failed to generate jwt: %v",
+			err,
+		)
+	}
+	return accessToken, refreshToken, nil
+}
+
+func (s *Server) RefreshSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.RefreshSessionRequest],
+) (*connect.Response[pb.RefreshSessionResponse], error) {
+	session, refreshToken, err := s.sessions.Rotate(ctx, connectReq.Msg.GetRefreshToken())
+	if errors.Is(err, auth.ErrSessionNotFound) ||
+		errors.Is(err, auth.ErrRefreshTokenReused) ||
+		errors.Is(err, auth.ErrMalformedRefreshToken) {
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"invalid refresh token: %v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to refresh session: %v",
+			err,
+		)
+	}
+	// Pick up privilege changes and stop refreshing expired accounts
+	account, err := s.store.QueryBalance(ctx, session.AccountId)
+	if err != nil {
+		s.sessions.Revoke(ctx, session.AccountId, session.SessionId)
+		return nil, status.Errorf(
+			codes.Unauthenticated,
+			"account %d is no longer valid: %v",
+			session.AccountId,
+			err,
+		)
+	}
+	accessToken, err := s.auth.GenerateJWT(ctx, account.AccountID, []string{account.Privilege}, session)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to generate jwt: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.RefreshSessionResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}), nil
+}
+
+func (s *Server) Logout(
+	ctx context.Context,
+	connectReq *connect.Request[pb.LogoutRequest],
+) (*connect.Response[pb.LogoutResponse], error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	sessionId, ok := ctx.Value(utils.KEY_SESSION_ID).(string)
+	if !ok || sessionId == "" {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"access token is not bound to a session",
+		)
+	}
+	if err := s.sessions.Revoke(ctx, accountId, sessionId); err != nil &&
+		!errors.Is(err, auth.ErrSessionNotFound) {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to revoke session: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.LogoutResponse{}), nil
+}
+
+func (s *Server) ListSessions(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ListSessionsRequest],
+) (*connect.Response[pb.ListSessionsResponse], error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	currentSessionId, _ := ctx.Value(utils.KEY_SESSION_ID).(string)
+	sessions, err := s.sessions.List(ctx, accountId)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to list sessions: %v",
+			err,
+		)
+	}
+	ret := make([]*pb.Session, 0)
+	for _, session := range sessions {
+		ret = append(ret, &pb.Session{
+			Name:        fmt.Sprintf(utils.RESOURCE_PATTERN_SESSION, accountId, session.SessionId),
+			CreateTime:  timestamppb.New(session.CreateTime),
+			RefreshTime: timestamppb.New(session.RefreshTime),
+			ExpireTime:  timestamppb.New(session.ExpireTime),
+			Current:     session.SessionId == currentSessionId,
+		})
+	}
+	return connect.NewResponse(&pb.ListSessionsResponse{
+		Sessions: ret,
+	}), nil
+}
+
+func (s *Server) RevokeSession(
+	ctx context.Context,
+	connectReq *connect.Request[pb.RevokeSessionRequest],
+) (*connect.Response[pb.RevokeSessionResponse], error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	nameAccountId, sessionId, err := parseSessionName(connectReq.Msg.GetName())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"failed to parse session name: %v",
+			err,
+		)
+	}
+	if nameAccountId != accountId {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"cannot revoke sessions of account %d",
+			nameAccountId,
+		)
+	}
+	if err := s.sessions.Revoke(ctx, accountId, sessionId); errors.Is(err, auth.ErrSessionNotFound) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"session %s not found",
+			connectReq.Msg.GetName(),
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to revoke session: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.RevokeSessionResponse{}), nil
+}
+
+func parseSessionName(name string) (int64, string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 4 || parts[0] != "accounts" || parts[2] != "sessions" {
+		return 0, "", fmt.Errorf("expect accounts/{account}/sessions/{session} but got %s", name)
+	}
+	accountId, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return accountId, parts[3], nil
+}
@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http/httptest"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/internal/api"
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1/signerconnect"
+	"github.com/atticplaygroup/prex/pkg/receipt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// NewServerWithSignerPolicy serves a server signing with a remote signer that
+// enforces policy.
+func NewServerWithSignerPolicy(ctx context.Context, policy signing.Policy) *api.Server {
+	memorySigner, err := signing.NewMemorySigner(Conf.WalletMnemonic, make([]byte, ed25519.SeedSize))
+	Expect(err).To(BeNil())
+	signerServer, err := signing.NewSignerServer(memorySigner, policy, nil, "")
+	Expect(err).To(BeNil())
+	_, handler := signerconnect.NewSignerServiceHandler(signerServer)
+	httpServer := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	DeferCleanup(httpServer.Close)
+
+	remoteSigner, err := signing.NewRemoteSigner(ctx, httpServer.URL, "")
+	Expect(err).To(BeNil())
+	conf := Conf
+	conf.Signer = remoteSigner
+	conf.TokenSigningKeyId = didkey.KeyId(remoteSigner.GetPublicKey())
+	conf.Keyring, err = signing.LoadKeyring("", remoteSigner, nil)
+	Expect(err).To(BeNil())
+	server, err := api.NewServer(conf, *StoreInstance)
+	Expect(err).To(BeNil())
+	return server
+}
+
+var _ = Describe("Signing tokens with a remote signer", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(ApiTestDb, Migrations)
+	})
+
+	It("should issue caveat tokens within the signer policy", func() {
+		ctx := context.Background()
+		server := NewServerWithSignerPolicy(ctx, signing.Policy{
+			MaxTokenQuantity: 1_000,
+			MaxTokenTtl:      Conf.TokenTtl,
+		})
+		buyer, _ := DepositFromNewWallet(ctx, "did:key:z6MkBuyer", 1_000_000)
+		seller, _ := DepositFromNewWallet(ctx, "did:key:z6MkSeller", 1_000)
+
+		bought, err := server.BuyToken(AsAccount(ctx, buyer.GetAccountId()), connect.NewRequest(&pb.BuyTokenRequest{
+			Audience: seller.GetUsername(),
+			Amount:   1_000,
+			Format:   pb.TokenFormat_TOKEN_FORMAT_CAVEAT,
+		}))
+		Expect(err).To(BeNil())
+		issuer := didkey.KeyId(server.GetConfig().Signer.GetPublicKey())
+		token, err := caveat.Parse(bought.Msg.GetToken(), caveat.TrustedKeys(issuer))
+		Expect(err).To(BeNil())
+		Expect(token.Claims().Quantity).To(BeEquivalentTo(1_000))
+		Expect(token.Claims().Authorize("", 1_000, time.Now())).To(Succeed())
+
+		By("signing the purchase receipt, which does not expire")
+		receiptClaims, err := receipt.Parse(bought.Msg.GetReceipt(), receipt.KeyResolver(caveat.TrustedKeys(issuer)))
+		Expect(err).To(BeNil())
+		Expect(receiptClaims.ExpiresAt).To(BeNil())
+		Expect(receiptClaims.Price).To(BeEquivalentTo(1_000))
+
+		_, err = server.BuyToken(AsAccount(ctx, buyer.GetAccountId()), connect.NewRequest(&pb.BuyTokenRequest{
+			Audience: seller.GetUsername(),
+			Amount:   1_001,
+			Format:   pb.TokenFormat_TOKEN_FORMAT_CAVEAT,
+		}))
+		Expect(err).To(MatchError(ContainSubstring("token quantity 1001 exceeds limit")))
+		account, err := StoreInstance.QueryBalance(ctx, buyer.GetAccountId())
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(BeEquivalentTo(1_000_000 - 1_000))
+	})
+})
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	PATH_JWKS         = "/.well-known/jwks.json"
+	PATH_DID_DOCUMENT = "/.well-known/did.json"
+)
+
+func (s *Server) ListSigningKeys(
+	ctx context.Context,
+	req *connect.Request[pb.ListSigningKeysRequest],
+) (*connect.Response[pb.ListSigningKeysResponse], error) {
+	now := time.Now()
+	primaryKeyId := ""
+	if primary, err := s.config.Keyring.Primary(now); err == nil {
+		primaryKeyId = primary.KeyId
+	}
+	signingKeys := make([]*pb.SigningKey, 0)
+	for _, entry := range s.config.Keyring.Published(now) {
+		signingKey := &pb.SigningKey{
+			Kid:       entry.KeyId,
+			PublicKey: entry.PublicKey,
+			Primary:   entry.KeyId == primaryKeyId,
+		}
+		if !entry.ActivateTime.IsZero() {
+			signingKey.ActivateTime = timestamppb.New(entry.ActivateTime)
+		}
+		if !entry.RetireTime.IsZero() {
+			signingKey.RetireTime = timestamppb.New(entry.RetireTime)
+		}
+		signingKeys = append(signingKeys, signingKey)
+	}
+	return connect.NewResponse(&pb.ListSigningKeysResponse{
+		SigningKeys: signingKeys,
+	}), nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+type verificationMethod struct {
+	Id           string     `json:"id"`
+	Type         string     `json:"type"`
+	Controller   string     `json:"controller"`
+	PublicKeyJwk jsonWebKey `json:"publicKeyJwk"`
+}
+
+type didDocument struct {
+	Context            []string             `json:"@context"`
+	Id                 string               `json:"id"`
+	VerificationMethod []verificationMethod `json:"verificationMethod"`
+	AssertionMethod    []string             `json:"assertionMethod"`
+}
+
+func formatJwk(signingKey *pb.SigningKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(signingKey.GetPublicKey()),
+		Kid: signingKey.GetKid(),
+		Alg: "EdDSA",
+		Use: "sig",
+	}
+}
+
+// formatDidDocument describes the keys as did:web of host. Methods are named
+// by the multibase part of their did:key.
+func formatDidDocument(host string, signingKeys []*pb.SigningKey) didDocument {
+	did := "did:web:" + strings.ReplaceAll(host, ":", "%3A")
+	ret := didDocument{
+		Context: []string{
+			"https://www.w3.org/ns/did/v1",
+			"https://w3id.org/security/suites/jws-2020/v1",
+		},
+		Id:                 did,
+		VerificationMethod: make([]verificationMethod, 0),
+		AssertionMethod:    make([]string, 0),
+	}
+	for _, signingKey := range signingKeys {
+		if len(signingKey.GetPublicKey()) != ed25519.PublicKeySize {
+			continue
+		}
+		methodId := did + "#" + strings.TrimPrefix(signingKey.GetKid(), "did:key:")
+		ret.VerificationMethod = append(ret.VerificationMethod, verificationMethod{
+			Id:           methodId,
+			Type:         "JsonWebKey2020",
+			Controller:   did,
+			PublicKeyJwk: formatJwk(signingKey),
+		})
+		ret.AssertionMethod = append(ret.AssertionMethod, methodId)
+	}
+	return ret
+}
+
+// NewWellKnownHandler serves the JWKS and did:web document of the keys
+// returned by listSigningKeys so verifiers can fetch them by kid.
+func NewWellKnownHandler(
+	listSigningKeys func(ctx context.Context) ([]*pb.SigningKey, error),
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		signingKeys, err := listSigningKeys(r.Context())
+		if err != nil {
+			log.Printf("failed to list signing keys: %v", err)
+			http.Error(w, "failed to list signing keys", http.StatusServiceUnavailable)
+			return
+		}
+		var body any
+		switch r.URL.Path {
+		case PATH_JWKS:
+			keys := make([]jsonWebKey, 0)
+			for _, signingKey := range signingKeys {
+				keys = append(keys, formatJwk(signingKey))
+			}
+			body = map[string][]jsonWebKey{"keys": keys}
+		case PATH_DID_DOCUMENT:
+			body = formatDidDocument(r.Host, signingKeys)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// Keys change rarely but verifiers should notice a rotation soon
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			log.Printf("failed to write %s: %v", r.URL.Path, err)
+		}
+	})
+}
@@ -2,50 +2,151 @@ package api
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"connectrpc.com/connect"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/signing"
 	"github.com/atticplaygroup/prex/internal/store"
 	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	"github.com/atticplaygroup/prex/pkg/didkey"
 	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/receipt"
+	"github.com/atticplaygroup/prex/pkg/transparency"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Token struct {
 	*jwt.RegisteredClaims
 	Quantity int64       `json:"quantity"`
 	Usage    pb.JwtUsage `json:"usage"`
+	// Caveats of a token in the caveat format
+	Caveats *caveat.Claims `json:"-"`
 }
 
-func (s *Server) generateJwt(audience string, quantity int64) (string, error) {
+// signToken signs claims with the primary key, naming it as the issuer.
+func (s *Server) signToken(ctx context.Context, claims *Token) (string, error) {
+	return s.signClaims(ctx, claims, &claims.Issuer)
+}
+
+// signClaims signs claims with the primary token key after setting issuer to
+// its did:key.
+func (s *Server) signClaims(ctx context.Context, claims jwt.Claims, issuer *string) (string, error) {
+	signingKey, err := s.config.Keyring.Primary(time.Now())
+	if err != nil {
+		return "", status.Errorf(
+			codes.Unavailable,
+			"failed to get signing key: %v",
+			err,
+		)
+	}
+	*issuer = signingKey.KeyId
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = signingKey.KeyId
+	jwt, err := signing.SignJwt(ctx, signingKey.Signer, token)
+	if err != nil {
+		return "", status.Errorf(
+			codes.InvalidArgument,
+			"failed to sign jwt: %v",
+			err,
+		)
+	}
+	return jwt, nil
+}
+
+func (s *Server) generateJwt(ctx context.Context, audience string, quantity int64, ttl time.Duration) (string, *Token, error) {
 	claims := &Token{
 		// No "sub" encoded inside token needed
 		RegisteredClaims: &jwt.RegisteredClaims{
-			Issuer:    s.config.TokenSigningKeyId,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Audience:  jwt.ClaimStrings{audience},
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.config.TokenTtl)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			ID:        uuid.NewString(),
 		},
 		Quantity: quantity,
 		Usage:    pb.JwtUsage_JWT_USAGE_CREATE_SESSION,
 	}
+	jwt, err := s.signToken(ctx, claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return jwt, claims, nil
+}
 
-	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims)
-	token.Header["kid"] = s.config.TokenSigningKeyId
-	jwt, err := token.SignedString(s.config.TokenSigningPrivateKey)
+// generateCaveatToken issues a quota token that holders can attenuate.
+func (s *Server) generateCaveatToken(ctx context.Context, audience string, quantity int64, ttl time.Duration) (string, *Token, error) {
+	signingKey, err := s.config.Keyring.Primary(time.Now())
 	if err != nil {
-		return "", status.Errorf(
+		return "", nil, status.Errorf(
+			codes.Unavailable,
+			"failed to get signing key: %v",
+			err,
+		)
+	}
+	now := time.Now()
+	token, err := caveat.Issue(ctx, signingKey.Signer, caveat.Authority{
+		Jti:        uuid.NewString(),
+		Audience:   audience,
+		Quantity:   quantity,
+		IssuedAt:   now.Unix(),
+		ExpireTime: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", nil, status.Errorf(
 			codes.InvalidArgument,
-			"failed to sign jwt: %v",
+			"failed to sign caveat token: %v",
 			err,
 		)
 	}
-	return jwt, err
+	return token.String(), caveatClaimsToken(token.Claims()), nil
+}
+
+func caveatClaimsToken(claims *caveat.Claims) *Token {
+	return &Token{
+		RegisteredClaims: &jwt.RegisteredClaims{
+			Issuer:    claims.Issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Unix(claims.IssuedAt, 0)),
+			Audience:  jwt.ClaimStrings{claims.Audience},
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+			ID:        claims.Jti,
+		},
+		Quantity: claims.Quantity,
+		Usage:    pb.JwtUsage_JWT_USAGE_CREATE_SESSION,
+		Caveats:  claims,
+	}
+}
+
+// signReceipt signs the purchase receipt of a token bought by buyer for
+// price, its share of the amount charged.
+func (s *Server) signReceipt(
+	ctx context.Context, buyer *db.Account, req *pb.BuyTokenRequest, claims *Token, price int64,
+) (string, error) {
+	receiptClaims := &receipt.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  buyer.Username,
+			Audience: jwt.ClaimStrings{req.GetAudience()},
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+		TokenJti: claims.ID,
+		Seller:   req.GetAudience(),
+		Amount:   claims.Quantity,
+		Price:    price,
+		Escrow:   req.GetEscrow(),
+		Usage:    pb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT,
+	}
+	return s.signClaims(ctx, receiptClaims, &receiptClaims.Issuer)
 }
 
 func (s *Server) BuyToken(
@@ -60,6 +161,26 @@ func (s *Server) BuyToken(
 			"failed to get account id",
 		)
 	}
+	if req.GetEscrow() {
+		// Only a did:key audience can sign the receipts to claim the escrow
+		if _, err := didkey.ParseKeyId(req.GetAudience()); err != nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"cannot escrow for audience %s: %v",
+				req.GetAudience(),
+				err,
+			)
+		}
+	}
+	splitCount := max(int64(req.GetSplitCount()), 1)
+	if req.GetAmount() < splitCount {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"cannot split amount %d into %d tokens",
+			req.GetAmount(),
+			splitCount,
+		)
+	}
 	tx, err := s.store.GetConn().Begin(context.Background())
 	if err != nil {
 		return nil, status.Errorf(
@@ -70,22 +191,124 @@ func (s *Server) BuyToken(
 	}
 	defer tx.Rollback(context.Background())
 	qtx := s.store.Queries.WithTx(tx)
-	_, err = s.store.BuyTokenTx(ctx, qtx, &store.BuyTokenTxParams{
-		Req:     req,
-		BuyerID: accountId,
-	})
+	maxTtl, _, err := s.maxTokenTtl(ctx, qtx, req.GetAudience())
 	if err != nil {
+		return nil, err
+	}
+	ttl := min(s.config.TokenTtl, maxTtl)
+	if req.GetTtl() != nil {
+		ttl = req.GetTtl().AsDuration()
+	}
+	if ttl > maxTtl {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"audience %s allows tokens lasting at most %v",
+			req.GetAudience(),
+			maxTtl,
+		)
+	}
+	apiKeyId, _ := ctx.Value(utils.KEY_API_KEY_ID).(int64)
+	buyer, err := s.store.BuyTokenTx(ctx, qtx, &store.BuyTokenTxParams{
+		Req:      req,
+		BuyerID:  accountId,
+		ApiKeyID: apiKeyId,
+	})
+	if errors.Is(err, store.ErrSpendLimitExceeded) {
+		return nil, status.Errorf(
+			codes.ResourceExhausted,
+			"%v",
+			err,
+		)
+	} else if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			"failed to execute transaction: %v",
 			err,
 		)
 	}
-	jwt, err := s.generateJwt(req.GetAudience(), req.GetAmount())
+	// Tokens keep the refund policy they were sold under
+	refundPolicy, err := store.GetRefundPolicy(ctx, qtx, req.GetAudience())
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
-			"failed to generate jwt: %v",
+			"failed to get refund policy: %v",
+			err,
+		)
+	}
+	generate := s.generateJwt
+	if req.GetFormat() == pb.TokenFormat_TOKEN_FORMAT_CAVEAT {
+		generate = s.generateCaveatToken
+	}
+	tokens := make([]string, 0, splitCount)
+	tokenClaims := make([]*Token, 0, splitCount)
+	prices := make([]int64, 0, splitCount)
+	entries := make([]transparency.Entry, 0, splitCount)
+	for i := range splitCount {
+		// BuyTokenTx charged the amount at a unit price of 1, so each token
+		// costs its quantity
+		quantity := req.GetAmount() / splitCount
+		if i < req.GetAmount()%splitCount {
+			quantity++
+		}
+		prices = append(prices, quantity)
+		token, claims, err := generate(ctx, req.GetAudience(), quantity, ttl)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"failed to generate jwt: %v",
+				err,
+			)
+		}
+		if _, err = qtx.CreateQuotaToken(ctx, db.CreateQuotaTokenParams{
+			Jti:          claims.ID,
+			BuyerID:      pgtype.Int8{Int64: accountId, Valid: true},
+			Audience:     req.GetAudience(),
+			Quantity:     claims.Quantity,
+			ExpireTime:   pgtype.Timestamptz{Time: claims.ExpiresAt.Time, Valid: true},
+			RefundMode:   refundPolicy.RefundMode,
+			RefundWindow: refundPolicy.RefundWindow,
+		}); err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"failed to record token: %v",
+				err,
+			)
+		}
+		if req.GetEscrow() {
+			if _, err = qtx.CreateEscrow(ctx, db.CreateEscrowParams{
+				Jti:        claims.ID,
+				BuyerID:    accountId,
+				Amount:     claims.Quantity,
+				ExpireTime: pgtype.Timestamptz{Time: claims.ExpiresAt.Time, Valid: true},
+				Audience:   req.GetAudience(),
+			}); errors.Is(err, pgx.ErrNoRows) {
+				return nil, status.Errorf(
+					codes.NotFound,
+					"audience %s has no account",
+					req.GetAudience(),
+				)
+			} else if err != nil {
+				return nil, status.Errorf(
+					codes.Internal,
+					"failed to create escrow: %v",
+					err,
+				)
+			}
+		}
+		tokens = append(tokens, token)
+		tokenClaims = append(tokenClaims, claims)
+		entries = append(entries, transparency.Entry{
+			TokenHash: transparency.HashToken(token),
+			Audience:  req.GetAudience(),
+			Quantity:  claims.Quantity,
+			IssueTime: claims.IssuedAt.Time,
+		})
+	}
+	inclusions, err := s.store.AppendLogEntries(ctx, qtx, entries)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to log token: %v",
 			err,
 		)
 	}
@@ -96,7 +319,232 @@ func (s *Server) BuyToken(
 			err,
 		)
 	}
+	// The tokens are paid for at this point so they are returned even if the
+	// tree head or receipts cannot be signed. GetTreeHead and GetInclusionProof
+	// can fill in the former.
+	treeHead, err := s.signTreeHead(ctx, inclusions[0].TreeSize, inclusions[0].RootHash)
+	if err != nil {
+		slog.ErrorContext(ctx, fmt.Sprintf("failed to sign tree head: %v", err))
+	}
+	purchased := make([]*pb.PurchasedToken, 0, len(tokens))
+	for i, token := range tokens {
+		purchaseReceipt, err := s.signReceipt(ctx, buyer, req, tokenClaims[i], prices[i])
+		if err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("failed to sign receipt of %s: %v", tokenClaims[i].ID, err))
+		}
+		purchased = append(purchased, &pb.PurchasedToken{
+			Token: token,
+			LogInclusion: &pb.LogInclusion{
+				Entry:     formatLogEntry(&inclusions[i].Entry),
+				TreeHead:  treeHead,
+				AuditPath: inclusions[i].AuditPath,
+			},
+			Receipt: purchaseReceipt,
+		})
+	}
 	return connect.NewResponse(&pb.BuyTokenResponse{
-		Token: jwt,
+		Token:        purchased[0].GetToken(),
+		LogInclusion: purchased[0].GetLogInclusion(),
+		Receipt:      purchased[0].GetReceipt(),
+		Tokens:       purchased,
+	}), nil
+}
+
+// parseQuotaToken verifies a token issued by BuyToken. Expired tokens are
+// only accepted without validation.
+func (s *Server) parseQuotaToken(tokenString string, withValidation bool) (*Token, error) {
+	if caveat.IsToken(tokenString) {
+		return s.parseCaveatToken(tokenString, withValidation)
+	}
+	claims, err := ParseJwt(
+		tokenString,
+		&Token{RegisteredClaims: &jwt.RegisteredClaims{}},
+		s.config.Keyring,
+		withValidation,
+	)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid token: %v",
+			err,
+		)
+	}
+	token, ok := claims.(*Token)
+	if !ok || token.Usage != pb.JwtUsage_JWT_USAGE_CREATE_SESSION ||
+		token.ID == "" || len(token.Audience) != 1 || token.ExpiresAt == nil {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			"not a quota token",
+		)
+	}
+	return token, nil
+}
+
+func (s *Server) parseCaveatToken(tokenString string, withValidation bool) (*Token, error) {
+	token, err := caveat.Parse(tokenString, func(kid string) (ed25519.PublicKey, error) {
+		return s.config.Keyring.VerificationKey(kid, time.Now())
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid token: %v",
+			err,
+		)
+	}
+	claims := token.Claims()
+	if withValidation && !time.Now().Before(claims.ExpiresAt) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid token: expired at %v",
+			claims.ExpiresAt,
+		)
+	}
+	return caveatClaimsToken(claims), nil
+}
+
+// authorizeQuotaToken checks the caveats of token, if any, allow spending
+// quantity on endpoint.
+func authorizeQuotaToken(token *Token, endpoint string, quantity int64) error {
+	if token.Caveats == nil {
+		return nil
+	}
+	if err := token.Caveats.Authorize(endpoint, quantity, time.Now()); err != nil {
+		return status.Errorf(
+			codes.PermissionDenied,
+			"%v",
+			err,
+		)
+	}
+	return nil
+}
+
+func formatTokenStatus(quotaToken db.QuotaToken, now time.Time) *pb.TokenStatus {
+	remaining := quotaToken.Quantity - quotaToken.Consumed
+	ret := &pb.TokenStatus{
+		Jti:        quotaToken.Jti,
+		Audience:   quotaToken.Audience,
+		Quantity:   quotaToken.Quantity,
+		Consumed:   quotaToken.Consumed,
+		Remaining:  remaining,
+		ExpireTime: timestamppb.New(quotaToken.ExpireTime.Time),
+		Active: remaining > 0 && quotaToken.ExpireTime.Time.After(now) &&
+			!quotaToken.RevokeTime.Valid,
+	}
+	if quotaToken.RevokeTime.Valid {
+		ret.RevokeTime = timestamppb.New(quotaToken.RevokeTime.Time)
+	}
+	return ret
+}
+
+func (s *Server) RedeemToken(
+	ctx context.Context,
+	connectReq *connect.Request[pb.RedeemTokenRequest],
+) (*connect.Response[pb.RedeemTokenResponse], error) {
+	req := connectReq.Msg
+	audience, err := s.callerAudience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.parseQuotaToken(req.GetToken(), true)
+	if err != nil {
+		return nil, err
+	}
+	if audience != token.Audience[0] {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"token is for audience %s",
+			token.Audience[0],
+		)
+	}
+	if err := authorizeQuotaToken(token, req.GetEndpoint(), req.GetQuantity()); err != nil {
+		return nil, err
+	}
+	params := redeemTokenParams(token)
+	params.Consume = req.GetQuantity()
+	quotaToken, err := s.store.RedeemToken(ctx, params)
+	if errors.Is(err, store.ErrQuotaExhausted) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"%v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to redeem token: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.RedeemTokenResponse{
+		TokenStatus: formatTokenStatus(quotaToken, time.Now()),
+	}), nil
+}
+
+func (s *Server) IntrospectToken(
+	ctx context.Context,
+	connectReq *connect.Request[pb.IntrospectTokenRequest],
+) (*connect.Response[pb.IntrospectTokenResponse], error) {
+	// Expired tokens are reported as inactive rather than rejected
+	token, err := s.parseQuotaToken(connectReq.Msg.GetToken(), false)
+	if err != nil {
+		return nil, err
+	}
+	quotaToken, err := s.store.GetQuotaToken(ctx, token.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Issued before tracking and never redeemed
+		quotaToken = db.QuotaToken{
+			Jti:        token.ID,
+			Audience:   token.Audience[0],
+			Quantity:   token.Quantity,
+			ExpireTime: pgtype.Timestamptz{Time: token.ExpiresAt.Time, Valid: true},
+		}
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get token: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.IntrospectTokenResponse{
+		TokenStatus: formatTokenStatus(quotaToken, time.Now()),
+	}), nil
+}
+
+func (s *Server) RefundToken(
+	ctx context.Context,
+	connectReq *connect.Request[pb.RefundTokenRequest],
+) (*connect.Response[pb.RefundTokenResponse], error) {
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	token, err := s.parseQuotaToken(connectReq.Msg.GetToken(), true)
+	if err != nil {
+		return nil, err
+	}
+	quotaToken, amount, err := s.store.RefundTokenTx(ctx, store.RefundTokenTxParams{
+		Jti:     token.ID,
+		BuyerID: accountId,
+		Now:     time.Now(),
+	})
+	if errors.Is(err, store.ErrTokenNotRefundable) || errors.Is(err, store.ErrRefundDenied) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"%v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to refund token: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.RefundTokenResponse{
+		TokenStatus: formatTokenStatus(*quotaToken, time.Now()),
+		Amount:      amount,
 	}), nil
 }
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func formatTokenPolicy(audience string, maxTokenTtl time.Duration, policy *db.TokenPolicy) *pb.TokenPolicy {
+	ret := &pb.TokenPolicy{
+		Audience:    audience,
+		MaxTokenTtl: durationpb.New(maxTokenTtl),
+	}
+	if policy != nil && policy.UpdateTime.Valid {
+		ret.UpdateTime = timestamppb.New(policy.UpdateTime.Time)
+	}
+	return ret
+}
+
+// maxTokenTtl is the longest ttl buyers may request for tokens of audience.
+// Policies are capped by MAX_TOKEN_TTL in case it was lowered after they were
+// set.
+func (s *Server) maxTokenTtl(ctx context.Context, qtx *db.Queries, audience string) (time.Duration, *db.TokenPolicy, error) {
+	policy, err := qtx.GetTokenPolicyByUsername(ctx, audience)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.config.TokenTtl, nil, nil
+	} else if err != nil {
+		return 0, nil, status.Errorf(
+			codes.Internal,
+			"failed to get token policy: %v",
+			err,
+		)
+	}
+	maxTtl := time.Duration(policy.MaxTokenTtl.Microseconds) * time.Microsecond
+	return min(maxTtl, s.config.MaxTokenTtl), &policy, nil
+}
+
+func (s *Server) GetTokenPolicy(
+	ctx context.Context,
+	connectReq *connect.Request[pb.GetTokenPolicyRequest],
+) (*connect.Response[pb.TokenPolicy], error) {
+	audience := connectReq.Msg.GetAudience()
+	maxTtl, policy, err := s.maxTokenTtl(ctx, s.store.Queries, audience)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(formatTokenPolicy(audience, maxTtl, policy)), nil
+}
+
+func (s *Server) UpdateTokenPolicy(
+	ctx context.Context,
+	connectReq *connect.Request[pb.UpdateTokenPolicyRequest],
+) (*connect.Response[pb.TokenPolicy], error) {
+	req := connectReq.Msg.GetTokenPolicy()
+	accountId, ok := ctx.Value(utils.KEY_ACCOUNT_ID).(int64)
+	if !ok {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"failed to get account id",
+		)
+	}
+	audience, err := s.callerAudience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maxTtl := req.GetMaxTokenTtl().AsDuration()
+	if maxTtl > s.config.MaxTokenTtl {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"max token ttl cannot exceed %v",
+			s.config.MaxTokenTtl,
+		)
+	}
+	policy, err := s.store.UpsertTokenPolicy(ctx, db.UpsertTokenPolicyParams{
+		AccountID: accountId,
+		MaxTokenTtl: pgtype.Interval{
+			Microseconds: maxTtl.Microseconds(),
+			Valid:        true,
+		},
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to update token policy: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(formatTokenPolicy(audience, maxTtl, &policy)), nil
+}
@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var _ = Describe("Token ttl and splitting", Label("db"), func() {
+	ctx := context.Background()
+	var buyer, seller *pb.Account
+
+	BeforeEach(func() {
+		RefreshDb(ApiTestDb, Migrations)
+		buyer, _ = DepositFromNewWallet(ctx, "did:key:z6MkBuyer", 1_000)
+		seller, _ = DepositFromNewWallet(ctx, "did:key:z6MkSeller", 1_000)
+	})
+
+	buy := func(req *pb.BuyTokenRequest) (*pb.BuyTokenResponse, error) {
+		req.Audience = seller.GetUsername()
+		resp, err := ServerInstance.BuyToken(AsAccount(ctx, buyer.GetAccountId()), connect.NewRequest(req))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	// claims parses a token without verifying it
+	claims := func(token string) jwt.MapClaims {
+		ret := jwt.MapClaims{}
+		_, _, err := jwt.NewParser().ParseUnverified(token, ret)
+		Expect(err).To(BeNil())
+		return ret
+	}
+
+	expireTime := func(token string) time.Time {
+		exp, err := claims(token).GetExpirationTime()
+		Expect(err).To(BeNil())
+		return exp.Time
+	}
+
+	It("should default to the ttl allowed by the audience if shorter", func() {
+		maxTtl := Conf.TokenTtl / 2
+		_, err := ServerInstance.UpdateTokenPolicy(
+			AsAccount(ctx, seller.GetAccountId()),
+			connect.NewRequest(&pb.UpdateTokenPolicyRequest{
+				TokenPolicy: &pb.TokenPolicy{MaxTokenTtl: durationpb.New(maxTtl)},
+			}),
+		)
+		Expect(err).To(BeNil())
+
+		bought, err := buy(&pb.BuyTokenRequest{Amount: 10})
+		Expect(err).To(BeNil())
+		Expect(expireTime(bought.GetToken())).To(BeTemporally("~", time.Now().Add(maxTtl), time.Minute))
+
+		bought, err = buy(&pb.BuyTokenRequest{Amount: 10, Ttl: durationpb.New(time.Minute)})
+		Expect(err).To(BeNil())
+		Expect(expireTime(bought.GetToken())).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+
+		_, err = buy(&pb.BuyTokenRequest{Amount: 10, Ttl: durationpb.New(Conf.TokenTtl)})
+		Expect(err).To(MatchError(ContainSubstring("allows tokens lasting at most")))
+	})
+
+	It("should default to TOKEN_TTL without a policy", func() {
+		bought, err := buy(&pb.BuyTokenRequest{Amount: 10})
+		Expect(err).To(BeNil())
+		Expect(expireTime(bought.GetToken())).To(BeTemporally("~", time.Now().Add(Conf.TokenTtl), time.Minute))
+	})
+
+	It("should split the amount over split_count tokens", func() {
+		bought, err := buy(&pb.BuyTokenRequest{Amount: 10, SplitCount: 3})
+		Expect(err).To(BeNil())
+		Expect(bought.GetTokens()).To(HaveLen(3))
+		Expect(bought.GetToken()).To(Equal(bought.GetTokens()[0].GetToken()))
+
+		jtis := map[string]bool{}
+		for i, purchased := range bought.GetTokens() {
+			tokenClaims := claims(purchased.GetToken())
+			Expect(tokenClaims["quantity"]).To(BeEquivalentTo([]int{4, 3, 3}[i]))
+			jti, _ := tokenClaims["jti"].(string)
+			jtis[jti] = true
+
+			receiptClaims := jwt.MapClaims{}
+			_, _, err := jwt.NewParser().ParseUnverified(purchased.GetReceipt(), receiptClaims)
+			Expect(err).To(BeNil())
+			Expect(receiptClaims["token_jti"]).To(Equal(jti))
+			Expect(receiptClaims["price"]).To(Equal(tokenClaims["quantity"]))
+			Expect(receiptClaims["usage"]).To(BeEquivalentTo(pb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT))
+		}
+		Expect(jtis).To(HaveLen(3))
+
+		account, err := StoreInstance.QueryBalance(ctx, buyer.GetAccountId())
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(BeEquivalentTo(1_000 - 10))
+
+		_, err = buy(&pb.BuyTokenRequest{Amount: 2, SplitCount: 3})
+		Expect(err).To(MatchError(ContainSubstring("cannot split amount 2 into 3 tokens")))
+	})
+})
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/transparency"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const LOG_ENTRIES_DEFAULT_LIMIT = 100
+
+func formatLogEntry(entry *db.TransparencyLogEntry) *pb.LogEntry {
+	return &pb.LogEntry{
+		LeafIndex: entry.LeafIndex,
+		TokenHash: entry.TokenHash,
+		Audience:  entry.Audience,
+		Quantity:  entry.Quantity,
+		IssueTime: timestamppb.New(entry.IssueTime.Time),
+	}
+}
+
+// signTreeHead signs the head of the log of treeSize entries. It must only
+// be called once they are committed, or Prex could be caught signing two
+// different trees of the same size. A head already signed for treeSize with
+// the primary key is returned as is.
+func (s *Server) signTreeHead(ctx context.Context, treeSize int64, rootHash []byte) (*pb.SignedTreeHead, error) {
+	signingKey, err := s.config.Keyring.Primary(time.Now())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unavailable,
+			"failed to get signing key: %v",
+			err,
+		)
+	}
+	s.treeHeadMu.Lock()
+	defer s.treeHeadMu.Unlock()
+	if s.treeHead != nil && s.treeHead.GetTreeSize() == treeSize && s.treeHead.GetKid() == signingKey.KeyId {
+		return s.treeHead, nil
+	}
+	head := transparency.TreeHead{
+		Size:      treeSize,
+		RootHash:  rootHash,
+		Timestamp: time.Now(),
+	}
+	signature, err := head.Sign(ctx, signingKey.Signer)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to sign tree head: %v",
+			err,
+		)
+	}
+	signedHead := &pb.SignedTreeHead{
+		TreeSize:  head.Size,
+		RootHash:  head.RootHash,
+		Timestamp: timestamppb.New(head.Timestamp),
+		Kid:       signingKey.KeyId,
+		Signature: signature,
+	}
+	// Heads of concurrent purchases may be signed out of order
+	if s.treeHead == nil || treeSize >= s.treeHead.GetTreeSize() {
+		s.treeHead = signedHead
+	}
+	return signedHead, nil
+}
+
+// checkTreeSize fails unless the log has at least treeSize entries.
+func (s *Server) checkTreeSize(ctx context.Context, treeSize int64) error {
+	logSize, err := s.store.Queries.GetLogSize(ctx)
+	if err != nil {
+		return status.Errorf(
+			codes.Internal,
+			"failed to get log size: %v",
+			err,
+		)
+	}
+	if treeSize > logSize {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"log has only %d entries",
+			logSize,
+		)
+	}
+	return nil
+}
+
+func (s *Server) GetTreeHead(
+	ctx context.Context,
+	connectReq *connect.Request[pb.GetTreeHeadRequest],
+) (*connect.Response[pb.SignedTreeHead], error) {
+	treeSize, rootHash, err := s.store.GetLogTreeHead(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get tree head: %v",
+			err,
+		)
+	}
+	treeHead, err := s.signTreeHead(ctx, treeSize, rootHash)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(treeHead), nil
+}
+
+func (s *Server) ListLogEntries(
+	ctx context.Context,
+	connectReq *connect.Request[pb.ListLogEntriesRequest],
+) (*connect.Response[pb.ListLogEntriesResponse], error) {
+	limit := connectReq.Msg.GetLimit()
+	if limit == 0 {
+		limit = LOG_ENTRIES_DEFAULT_LIMIT
+	}
+	entries, err := s.store.Queries.ListLogEntries(ctx, db.ListLogEntriesParams{
+		StartIndex: connectReq.Msg.GetStartIndex(),
+		MaxCount:   limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to list log entries: %v",
+			err,
+		)
+	}
+	logEntries := make([]*pb.LogEntry, 0, len(entries))
+	for i := range entries {
+		logEntries = append(logEntries, formatLogEntry(&entries[i]))
+	}
+	return connect.NewResponse(&pb.ListLogEntriesResponse{
+		Entries: logEntries,
+	}), nil
+}
+
+func (s *Server) GetInclusionProof(
+	ctx context.Context,
+	connectReq *connect.Request[pb.GetInclusionProofRequest],
+) (*connect.Response[pb.GetInclusionProofResponse], error) {
+	req := connectReq.Msg
+	if req.GetLeafIndex() >= req.GetTreeSize() {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"leaf %d is not in a tree of size %d",
+			req.GetLeafIndex(),
+			req.GetTreeSize(),
+		)
+	}
+	if err := s.checkTreeSize(ctx, req.GetTreeSize()); err != nil {
+		return nil, err
+	}
+	auditPath, err := s.store.GetLogInclusionProof(ctx, req.GetLeafIndex(), req.GetTreeSize())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"log nodes not found: %v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get inclusion proof: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.GetInclusionProofResponse{
+		AuditPath: auditPath,
+	}), nil
+}
+
+func (s *Server) GetConsistencyProof(
+	ctx context.Context,
+	connectReq *connect.Request[pb.GetConsistencyProofRequest],
+) (*connect.Response[pb.GetConsistencyProofResponse], error) {
+	req := connectReq.Msg
+	if req.GetOldSize() > req.GetNewSize() {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"old size %d is larger than new size %d",
+			req.GetOldSize(),
+			req.GetNewSize(),
+		)
+	}
+	if err := s.checkTreeSize(ctx, req.GetNewSize()); err != nil {
+		return nil, err
+	}
+	proof, err := s.store.GetLogConsistencyProof(ctx, req.GetOldSize(), req.GetNewSize())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"log nodes not found: %v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to get consistency proof: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.GetConsistencyProofResponse{
+		Proof: proof,
+	}), nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func walletStatusOf(backend *paymentBackend) *pb.WalletStatus {
+	walletStatus := backend.wallet.GetStatus()
+	walletConfig := backend.wallet.GetConfig()
+	ret := &pb.WalletStatus{
+		HotAddress:            walletStatus.HotAddress,
+		ColdAddress:           walletStatus.ColdAddress,
+		HotBalance:            walletStatus.HotBalance,
+		PendingWithdrawAmount: walletStatus.PendingWithdraws,
+		Float:                 walletConfig.Float,
+		HighWaterMark:         walletConfig.HighWaterMark,
+		LowWaterMark:          walletConfig.LowWaterMark,
+		RefillNeeded:          walletStatus.RefillNeeded,
+		RefillAmount:          walletStatus.RefillAmount,
+		LastSweepDigest:       walletStatus.LastSweepDigest,
+		PaymentMethod:         backend.method.GetName(),
+	}
+	if !walletStatus.LastCheckTime.IsZero() {
+		ret.LastCheckTime = timestamppb.New(walletStatus.LastCheckTime)
+	}
+	if !walletStatus.LastSweepTime.IsZero() {
+		ret.LastSweepTime = timestamppb.New(walletStatus.LastSweepTime)
+	}
+	return ret
+}
+
+// GetWalletStatus reports the cached status of every hot wallet. Checking the
+// chain here would fire refill alerts on every poll of the status page.
+func (s *Server) GetWalletStatus(
+	ctx context.Context,
+	req *connect.Request[pb.GetWalletStatusRequest],
+) (*connect.Response[pb.GetWalletStatusResponse], error) {
+	walletStatuses := make([]*pb.WalletStatus, 0, len(s.paymentBackends))
+	for _, backend := range s.paymentBackends {
+		walletStatuses = append(walletStatuses, walletStatusOf(backend))
+	}
+	return connect.NewResponse(&pb.GetWalletStatusResponse{
+		WalletStatus:   walletStatuses[0],
+		WalletStatuses: walletStatuses,
+	}), nil
+}
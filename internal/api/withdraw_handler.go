@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -13,7 +14,6 @@ import (
 	"github.com/atticplaygroup/prex/internal/store"
 	"github.com/atticplaygroup/prex/internal/utils"
 	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
-	"github.com/block-vision/sui-go-sdk/models"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -39,8 +39,10 @@ func (s *Server) CreateWithdraw(
 			"failed to get account id",
 		)
 	}
+	apiKeyId, _ := ctx.Value(utils.KEY_API_KEY_ID).(int64)
 	withdrawal, err := s.store.WithdrawTx(ctx, store.WithdrawTxParams{
 		WithdrawAll: req.GetWithdrawAll(),
+		ApiKeyID:    apiKeyId,
 		StartWithdrawalParams: db.StartWithdrawalParams{
 			AccountID:       accountId,
 			WithdrawAddress: chainAddressBytes,
@@ -48,7 +50,19 @@ func (s *Server) CreateWithdraw(
 			PriorityFee:     req.GetWithdrawal().GetPriorityFee(),
 		},
 	})
-	if err != nil {
+	if errors.Is(err, store.ErrWithdrawCooldown) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"%v",
+			err,
+		)
+	} else if errors.Is(err, store.ErrSpendLimitExceeded) {
+		return nil, status.Errorf(
+			codes.ResourceExhausted,
+			"%v",
+			err,
+		)
+	} else if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			"failed to execute withdrawTx",
@@ -64,6 +78,48 @@ func (s *Server) CreateWithdraw(
 	}), nil
 }
 
+func (s *Server) EstimateWithdrawFee(
+	ctx context.Context,
+	connectReq *connect.Request[pb.EstimateWithdrawFeeRequest],
+) (*connect.Response[pb.EstimateWithdrawFeeResponse], error) {
+	req := connectReq.Msg
+	referenceGasPrice, err := s.paymentClient.GetReferenceGasPrice(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unavailable,
+			"failed to get reference gas price: %v",
+			err,
+		)
+	}
+	pendingFees, err := s.store.ListPendingPriorityFees(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to list pending priority fees: %v",
+			err,
+		)
+	}
+	estimate := payment.EstimateWithdrawFee(
+		pendingFees, int(s.config.WithdrawRecipientCount), referenceGasPrice, req.GetPriorityFee(),
+	)
+	feePercentiles := make([]*pb.FeePercentile, 0)
+	for _, feePercentile := range estimate.FeePercentiles {
+		feePercentiles = append(feePercentiles, &pb.FeePercentile{
+			Percentile:  feePercentile.Percentile,
+			PriorityFee: feePercentile.PriorityFee,
+		})
+	}
+	return connect.NewResponse(&pb.EstimateWithdrawFeeResponse{
+		ReferenceGasPrice:    estimate.ReferenceGasPrice,
+		QueueDepth:           estimate.QueueDepth,
+		FeePercentiles:       feePercentiles,
+		BatchSize:            int64(s.config.WithdrawRecipientCount),
+		PredictedBatch:       estimate.PredictedBatch,
+		PredictedBatchFee:    estimate.PredictedBatchFee,
+		NextBatchPriorityFee: estimate.NextBatchPriorityFee,
+	}), nil
+}
+
 func (s *Server) BatchProcessWithdraws(
 	ctx context.Context,
 	connectReq *connect.Request[pb.BatchProcessWithdrawsRequest],
@@ -76,6 +132,16 @@ func (s *Server) BatchProcessWithdraws(
 			s.config.WithdrawRecipientCount,
 		)
 	}
+	// Held until the batch is paid so that the sweeper never moves its funds
+	unlock, err := s.store.AcquireWalletLock(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Unavailable,
+			"failed to lock hot wallet: %v",
+			err,
+		)
+	}
+	defer unlock()
 
 	tx, err := s.store.GetConn().Begin(ctx)
 	if err != nil {
@@ -116,17 +182,10 @@ func (s *Server) BatchProcessWithdraws(
 	if err != nil {
 		return nil, err
 	}
-	dryRunResult, err := s.paymentClient.SuiClient.SuiDryRunTransactionBlock(
-		ctx, models.SuiDryRunTransactionBlockRequest{
-			TxBytes: suiTx.TxBytes,
-		})
-	if err != nil || dryRunResult.Effects.TransactionDigest == "" {
-		return nil, fmt.Errorf("failed to calculate transaction digest: %v", err)
-	}
 
 	processingWithdrawal, err := qtx.SetWithdrawalBatch(
 		ctx, db.SetWithdrawalBatchParams{
-			TransactionDigest:      dryRunResult.Effects.TransactionDigest,
+			TransactionDigest:      suiTx.Digest,
 			TransactionBytesBase64: suiTx.TxBytes,
 			TotalPriorityFee:       totalPriorityFee,
 		})
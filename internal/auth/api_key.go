@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// API_KEY_TAG starts every API key so it can be told apart from JWTs.
+const API_KEY_TAG = "prex"
+
+// NewApiKey generates a key of the form prex_<prefix>_<secret>. The prefix
+// looks the key up and only the hash of the secret is stored.
+func NewApiKey() (key string, prefix string, secretHash []byte, err error) {
+	prefixBytes := make([]byte, 6)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", nil, err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", nil, err
+	}
+	prefix = hex.EncodeToString(prefixBytes)
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+	return API_KEY_TAG + "_" + prefix + "_" + secret, prefix, HashApiKeySecret(secret), nil
+}
+
+// ParseApiKey splits key into its prefix and secret.
+func ParseApiKey(key string) (prefix string, secret string, ok bool) {
+	tag, rest, found := strings.Cut(key, "_")
+	if !found || tag != API_KEY_TAG {
+		return "", "", false
+	}
+	prefix, secret, found = strings.Cut(rest, "_")
+	if !found || prefix == "" || secret == "" {
+		return "", "", false
+	}
+	return prefix, secret, true
+}
+
+// IsApiKey reports whether a bearer token is meant to be an API key.
+func IsApiKey(token string) bool {
+	return strings.HasPrefix(token, API_KEY_TAG+"_")
+}
+
+// HashApiKeySecret hashes the secret of an API key. Secrets are random so
+// a fast hash suffices.
+func HashApiKeySecret(secret string) []byte {
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:]
+}
+
+// VerifyApiKeySecret compares secret against a stored hash in constant time.
+func VerifyApiKeySecret(secret string, secretHash []byte) bool {
+	return subtle.ConstantTimeCompare(HashApiKeySecret(secret), secretHash) == 1
+}
@@ -0,0 +1,28 @@
+package auth_test
+
+import (
+	"github.com/atticplaygroup/prex/internal/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApiKey", Label("auth"), func() {
+	It("should parse generated keys and verify their secret", func() {
+		key, prefix, secretHash, err := auth.NewApiKey()
+		Expect(err).To(BeNil())
+		Expect(auth.IsApiKey(key)).To(BeTrue())
+
+		parsedPrefix, secret, ok := auth.ParseApiKey(key)
+		Expect(ok).To(BeTrue())
+		Expect(parsedPrefix).To(Equal(prefix))
+		Expect(auth.VerifyApiKeySecret(secret, secretHash)).To(BeTrue())
+		Expect(auth.VerifyApiKeySecret(secret+"x", secretHash)).To(BeFalse())
+	})
+
+	It("should reject malformed keys", func() {
+		for _, key := range []string{"", "prex_", "prex_abc", "prex__secret", "other_abc_secret"} {
+			_, _, ok := auth.ParseApiKey(key)
+			Expect(ok).To(BeFalse(), key)
+		}
+	})
+})
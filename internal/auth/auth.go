@@ -2,13 +2,16 @@ package auth
 
 import (
 	"bytes"
-	"crypto/ed25519"
+	"context"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/atticplaygroup/prex/internal/config"
+	"github.com/atticplaygroup/prex/internal/signing"
 	"github.com/atticplaygroup/prex/internal/utils"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/block-vision/sui-go-sdk/models"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/blake2b"
 )
@@ -21,30 +24,58 @@ type realClock struct{}
 
 func (realClock) Now() time.Time { return time.Now() }
 
+const DEFAULT_ACCESS_TOKEN_TIMEOUT = 15 * time.Minute
+
 type Auth struct {
-	Secret             ed25519.PrivateKey
+	ChallengeSecret    []byte
+	Keyring            *signing.Keyring
 	MessageAuthTimeout time.Duration
-	SessionTimeout     time.Duration
+	// SessionTimeout is how long a session lives without being refreshed
+	SessionTimeout time.Duration
+	// AccessTokenTimeout is the lifetime of session JWTs
+	AccessTokenTimeout time.Duration
 	Clock              Clock
 }
 
 func NewAuth(conf config.Config) (*Auth, error) {
+	accessTokenTimeout := conf.AccessTokenTimeout
+	if accessTokenTimeout <= 0 {
+		accessTokenTimeout = DEFAULT_ACCESS_TOKEN_TIMEOUT
+	}
 	return &Auth{
-		Secret:             conf.TokenSigningPrivateKey,
+		ChallengeSecret:    conf.ChallengeSecret,
+		Keyring:            conf.Keyring,
 		MessageAuthTimeout: conf.MessageAuthTimeout,
 		SessionTimeout:     conf.SessionTimeout,
+		AccessTokenTimeout: min(accessTokenTimeout, conf.SessionTimeout),
 		Clock:              realClock{},
 	}, nil
 }
 
-func (a *Auth) GenerateJWT(accountId int64) (string, error) {
+// GenerateJWT issues a short-lived access token carrying the session id and
+// the current access token id of session.
+func (a *Auth) GenerateJWT(ctx context.Context, accountId int64, roles []string, session *Session) (string, error) {
+	now := a.Clock.Now()
+	signingKey, err := a.Keyring.Primary(now)
+	if err != nil {
+		return "", err
+	}
 	claims := jwt.MapClaims{
-		"sub": strconv.Itoa(int(accountId)),
-		"exp": jwt.NewNumericDate(a.Clock.Now().Add(a.SessionTimeout)),
+		"sub":   strconv.Itoa(int(accountId)),
+		"iat":   jwt.NewNumericDate(now),
+		"exp":   jwt.NewNumericDate(now.Add(a.AccessTokenTimeout)),
+		"jti":   session.AccessJti,
+		"sid":   session.SessionId,
+		"roles": roles,
+		"usage": pb.JwtUsage_JWT_USAGE_ACCESS,
+
+		// iat is truncated to seconds, too coarse to order against revocations
+		"iat_us": now.UnixMicro(),
 	}
 
-	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims)
-	return token.SignedString(a.Secret)
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = signingKey.KeyId
+	return signing.SignJwt(ctx, signingKey.Signer, token)
 }
 
 func (a *Auth) GetChallenge(address string, startTime time.Time) (*[32]byte, error) {
@@ -56,7 +87,7 @@ func (a *Auth) GetChallenge(address string, startTime time.Time) (*[32]byte, err
 	if err != nil {
 		return nil, err
 	}
-	concatBytes := utils.ConcatBytes(a.Secret, addressBytes, timestampBytes)
+	concatBytes := utils.ConcatBytes(a.ChallengeSecret, addressBytes, timestampBytes)
 	challenge := blake2b.Sum256(concatBytes)
 	return &challenge, nil
 }
@@ -94,6 +125,28 @@ func (a *Auth) VerifySuiAuthMessagePayload(message *SuiAuthMessage) error {
 	return nil
 }
 
+// VerifySuiPersonalMessage checks signature is a Sui personal message signing
+// a fresh challenge and returns the address of the signer.
+func (a *Auth) VerifySuiPersonalMessage(startTime time.Time, challenge []byte, signature string) (string, error) {
+	sender, pass, err := models.VerifyPersonalMessage(string(challenge), signature)
+	if err != nil || !pass {
+		return "", fmt.Errorf(
+			"personal message verification failed for bytes=%v and signature=%s",
+			challenge,
+			signature,
+		)
+	}
+	if err := a.VerifySuiAuthMessagePayload(&SuiAuthMessage{
+		StartTime: startTime,
+		Challenge: challenge,
+		Address:   sender,
+		Signature: signature,
+	}); err != nil {
+		return "", fmt.Errorf("invalid personal message: %v", err)
+	}
+	return sender, nil
+}
+
 func (a *Auth) EncodeMessage(startTime time.Time, challenge []byte, address string) (*SuiAuthMessage, error) {
 	authMessage := SuiAuthMessage{
 		StartTime: startTime,
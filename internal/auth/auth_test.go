@@ -48,6 +48,23 @@ var _ = Describe("Authentication", Label("auth"), func() {
 		Expect(address).To(Equal(walletAddress))
 	})
 
+	It("should return the signer of a fresh signed challenge", func() {
+		sender, err := auth1.VerifySuiPersonalMessage(
+			auth1.Clock.Now(),
+			(*actualChallengeBytes)[:],
+			"AH1itCc8k3ZKMI4XRyPjftCaTjxWs+sko1xe1ZQ569VyGOueIekgvfoaRNm5urIKHd9gf9rVych7xBQycV88Pw4+9VRkoxpm+CpWKk6FUF9WOnno84czkMnCUR/W1scgkw==",
+		)
+		Expect(err).To(BeNil())
+		Expect(sender).To(Equal(walletAddress))
+
+		_, err = auth1.VerifySuiPersonalMessage(
+			auth1.Clock.Now().Add(-time.Minute),
+			(*actualChallengeBytes)[:],
+			"AH1itCc8k3ZKMI4XRyPjftCaTjxWs+sko1xe1ZQ569VyGOueIekgvfoaRNm5urIKHd9gf9rVych7xBQycV88Pw4+9VRkoxpm+CpWKk6FUF9WOnno84czkMnCUR/W1scgkw==",
+		)
+		Expect(err).NotTo(BeNil())
+	})
+
 	It("should verify offline login payload and signature", func() {
 		startTime := auth1.Clock.Now()
 		walletAddress := "0xc228a949decc98affe62522cf3f56db12686d068fab82fab605c241cafe5197c"
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrChallengeNotIssued = errors.New("challenge not issued or already used")
+
+// ChallengeStore remembers issued challenges so that each signed challenge
+// is accepted once only.
+type ChallengeStore struct {
+	redisClient        *redis.Client
+	MessageAuthTimeout time.Duration
+}
+
+func NewChallengeStore(redisClient *redis.Client, auth *Auth) *ChallengeStore {
+	return &ChallengeStore{
+		redisClient:        redisClient,
+		MessageAuthTimeout: auth.MessageAuthTimeout,
+	}
+}
+
+func challengeKey(challenge []byte) string {
+	return fmt.Sprintf(utils.REDIS_KEY_CHALLENGE, hex.EncodeToString(challenge))
+}
+
+// Issue stores challenge until it would time out anyway.
+func (c *ChallengeStore) Issue(ctx context.Context, challenge []byte) error {
+	if err := c.redisClient.Set(ctx, challengeKey(challenge), 1, c.MessageAuthTimeout).Err(); err != nil {
+		return fmt.Errorf("failed to store challenge: %v", err)
+	}
+	return nil
+}
+
+// Consume atomically removes challenge. Only the first caller succeeds.
+func (c *ChallengeStore) Consume(ctx context.Context, challenge []byte) error {
+	deleted, err := c.redisClient.Del(ctx, challengeKey(challenge)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to consume challenge: %v", err)
+	}
+	if deleted == 0 {
+		return ErrChallengeNotIssued
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("Challenges", Label("redis"), func() {
+	var challenges *auth.ChallengeStore
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		redisClient := redis.NewClient(&redis.Options{Addr: getTestRedisAddr()})
+		pingCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		if err := redisClient.Ping(pingCtx).Err(); err != nil {
+			Skip(fmt.Sprintf("redis not reachable: %v", err))
+		}
+		challenges = auth.NewChallengeStore(redisClient, &auth.Auth{
+			MessageAuthTimeout: time.Minute,
+		})
+	})
+
+	It("should accept an issued challenge only once", func() {
+		challenge := []byte(fmt.Sprintf("challenge-%d", time.Now().UnixNano()))
+		Expect(challenges.Consume(ctx, challenge)).To(MatchError(auth.ErrChallengeNotIssued))
+
+		Expect(challenges.Issue(ctx, challenge)).To(Succeed())
+		Expect(challenges.Consume(ctx, challenge)).To(Succeed())
+		Expect(challenges.Consume(ctx, challenge)).To(MatchError(auth.ErrChallengeNotIssued))
+	})
+})
@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrRefreshTokenReused    = errors.New("refresh token reused, session revoked")
+	ErrMalformedRefreshToken = errors.New("malformed refresh token")
+)
+
+// Session is a login of an account. Its refresh token is rotated on every
+// refresh and each rotation issues a new access token id.
+type Session struct {
+	SessionId   string
+	AccountId   int64
+	AccessJti   string
+	CreateTime  time.Time
+	RefreshTime time.Time
+	ExpireTime  time.Time
+}
+
+// SessionStore keeps sessions in Redis. A session expires if not refreshed
+// within SessionTimeout.
+type SessionStore struct {
+	redisClient        *redis.Client
+	SessionTimeout     time.Duration
+	AccessTokenTimeout time.Duration
+	Clock              Clock
+}
+
+func NewSessionStore(redisClient *redis.Client, auth *Auth) *SessionStore {
+	return &SessionStore{
+		redisClient:        redisClient,
+		SessionTimeout:     auth.SessionTimeout,
+		AccessTokenTimeout: auth.AccessTokenTimeout,
+		Clock:              auth.Clock,
+	}
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashRefreshSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return base64.RawStdEncoding.EncodeToString(hash[:])
+}
+
+// newRefreshToken returns a token of the form <session id>.<secret> and the
+// hash of the secret to store.
+func newRefreshToken(sessionId string) (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+	return sessionId + "." + secret, hashRefreshSecret(secret), nil
+}
+
+func sessionKey(sessionId string) string {
+	return fmt.Sprintf(utils.REDIS_KEY_SESSION, sessionId)
+}
+
+func accountSessionsKey(accountId int64) string {
+	return fmt.Sprintf(utils.REDIS_KEY_ACCOUNT_SESSIONS, accountId)
+}
+
+// Create starts a session of accountId and returns it with its first
+// refresh token.
+func (s *SessionStore) Create(ctx context.Context, accountId int64) (*Session, string, error) {
+	sessionId, err := randomHex(16)
+	if err != nil {
+		return nil, "", err
+	}
+	accessJti, err := randomHex(16)
+	if err != nil {
+		return nil, "", err
+	}
+	refreshToken, refreshHash, err := newRefreshToken(sessionId)
+	if err != nil {
+		return nil, "", err
+	}
+	now := s.Clock.Now()
+	if _, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, sessionKey(sessionId),
+			"account_id", accountId,
+			"refresh_hash", refreshHash,
+			"access_jti", accessJti,
+			"create_time", now.Unix(),
+			"refresh_time", now.Unix(),
+		)
+		pipe.Expire(ctx, sessionKey(sessionId), s.SessionTimeout)
+		pipe.SAdd(ctx, accountSessionsKey(accountId), sessionId)
+		pipe.Expire(ctx, accountSessionsKey(accountId), s.SessionTimeout)
+		return nil
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to store session: %v", err)
+	}
+	return &Session{
+		SessionId:   sessionId,
+		AccountId:   accountId,
+		AccessJti:   accessJti,
+		CreateTime:  now,
+		RefreshTime: now,
+		ExpireTime:  now.Add(s.SessionTimeout),
+	}, refreshToken, nil
+}
+
+// rotateScript swaps the refresh token hash if the presented one matches.
+// A mismatch means an old refresh token was replayed so the session is
+// dropped. Returns {status, old access jti, account id, create time}.
+var rotateScript = redis.NewScript(`
+local session = redis.call('HMGET', KEYS[1], 'refresh_hash', 'access_jti', 'account_id', 'create_time')
+if not session[1] then
+  return {0}
+end
+if session[1] ~= ARGV[1] then
+  redis.call('DEL', KEYS[1])
+  return {-1, session[2], session[3], session[4]}
+end
+redis.call('HSET', KEYS[1], 'refresh_hash', ARGV[2], 'access_jti', ARGV[3], 'refresh_time', ARGV[4])
+redis.call('EXPIRE', KEYS[1], ARGV[5])
+return {1, session[2], session[3], session[4]}
+`)
+
+// Rotate exchanges refreshToken for a new one and a new access token id. The
+// access token id issued before is revoked.
+func (s *SessionStore) Rotate(ctx context.Context, refreshToken string) (*Session, string, error) {
+	sessionId, secret, found := strings.Cut(refreshToken, ".")
+	if !found || sessionId == "" || secret == "" {
+		return nil, "", ErrMalformedRefreshToken
+	}
+	accessJti, err := randomHex(16)
+	if err != nil {
+		return nil, "", err
+	}
+	newToken, newHash, err := newRefreshToken(sessionId)
+	if err != nil {
+		return nil, "", err
+	}
+	now := s.Clock.Now()
+	result, err := rotateScript.Run(
+		ctx, s.redisClient, []string{sessionKey(sessionId)},
+		hashRefreshSecret(secret), newHash, accessJti, now.Unix(), int64(s.SessionTimeout.Seconds()),
+	).Slice()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to rotate refresh token: %v", err)
+	}
+	status, _ := result[0].(int64)
+	if status == 0 {
+		return nil, "", ErrSessionNotFound
+	}
+	oldJti, _ := result[1].(string)
+	accountId, err := strconv.ParseInt(fmt.Sprint(result[2]), 10, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("corrupted session %s: %v", sessionId, err)
+	}
+	createTime, err := strconv.ParseInt(fmt.Sprint(result[3]), 10, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("corrupted session %s: %v", sessionId, err)
+	}
+	if err := s.DenyJti(ctx, oldJti); err != nil {
+		return nil, "", err
+	}
+	if status < 0 {
+		s.redisClient.SRem(ctx, accountSessionsKey(accountId), sessionId)
+		return nil, "", ErrRefreshTokenReused
+	}
+	if err := s.redisClient.Expire(ctx, accountSessionsKey(accountId), s.SessionTimeout).Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to extend sessions of account: %v", err)
+	}
+	return &Session{
+		SessionId:   sessionId,
+		AccountId:   accountId,
+		AccessJti:   accessJti,
+		CreateTime:  time.Unix(createTime, 0),
+		RefreshTime: now,
+		ExpireTime:  now.Add(s.SessionTimeout),
+	}, newToken, nil
+}
+
+// DenyJti revokes an access token id until it would have expired anyway.
+func (s *SessionStore) DenyJti(ctx context.Context, jti string) error {
+	if jti == "" {
+		return nil
+	}
+	if err := s.redisClient.Set(
+		ctx, fmt.Sprintf(utils.REDIS_KEY_REVOKED_JTI, jti), 1, s.AccessTokenTimeout,
+	).Err(); err != nil {
+		return fmt.Errorf("failed to revoke access token: %v", err)
+	}
+	return nil
+}
+
+// List returns the live sessions of accountId.
+func (s *SessionStore) List(ctx context.Context, accountId int64) ([]Session, error) {
+	sessionIds, err := s.redisClient.SMembers(ctx, accountSessionsKey(accountId)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %v", err)
+	}
+	pipe := s.redisClient.Pipeline()
+	fieldCmds := make([]*redis.SliceCmd, len(sessionIds))
+	ttlCmds := make([]*redis.DurationCmd, len(sessionIds))
+	for i, sessionId := range sessionIds {
+		fieldCmds[i] = pipe.HMGet(ctx, sessionKey(sessionId), "account_id", "access_jti", "create_time", "refresh_time")
+		ttlCmds[i] = pipe.TTL(ctx, sessionKey(sessionId))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get sessions: %v", err)
+	}
+	now := s.Clock.Now()
+	ret := make([]Session, 0)
+	for i, sessionId := range sessionIds {
+		fields := fieldCmds[i].Val()
+		if len(fields) != 4 || fields[0] == nil {
+			// Expired, forget it
+			s.redisClient.SRem(ctx, accountSessionsKey(accountId), sessionId)
+			continue
+		}
+		createTime, _ := strconv.ParseInt(fmt.Sprint(fields[2]), 10, 64)
+		refreshTime, _ := strconv.ParseInt(fmt.Sprint(fields[3]), 10, 64)
+		accessJti, _ := fields[1].(string)
+		ret = append(ret, Session{
+			SessionId:   sessionId,
+			AccountId:   accountId,
+			AccessJti:   accessJti,
+			CreateTime:  time.Unix(createTime, 0),
+			RefreshTime: time.Unix(refreshTime, 0),
+			ExpireTime:  now.Add(ttlCmds[i].Val()),
+		})
+	}
+	return ret, nil
+}
+
+// Revoke ends session sessionId of accountId and revokes its access token.
+func (s *SessionStore) Revoke(ctx context.Context, accountId int64, sessionId string) error {
+	fields, err := s.redisClient.HMGet(ctx, sessionKey(sessionId), "account_id", "access_jti").Result()
+	if err != nil {
+		return fmt.Errorf("failed to get session: %v", err)
+	}
+	if fields[0] == nil || fmt.Sprint(fields[0]) != strconv.FormatInt(accountId, 10) {
+		return ErrSessionNotFound
+	}
+	accessJti, _ := fields[1].(string)
+	if err := s.DenyJti(ctx, accessJti); err != nil {
+		return err
+	}
+	if _, err := s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(sessionId))
+		pipe.SRem(ctx, accountSessionsKey(accountId), sessionId)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to delete session: %v", err)
+	}
+	return nil
+}
+
+// RevokeAll ends every session of accountId.
+func (s *SessionStore) RevokeAll(ctx context.Context, accountId int64) error {
+	sessions, err := s.List(ctx, accountId)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := s.Revoke(ctx, accountId, session.SessionId); err != nil && err != ErrSessionNotFound {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+func getTestRedisAddr() string {
+	host, ok := os.LookupEnv("REDIS_HOST")
+	if !ok {
+		host = "localhost"
+	}
+	port, ok := os.LookupEnv("REDIS_PORT")
+	if !ok {
+		port = "6379"
+	}
+	return fmt.Sprintf("%s:%s", host, port)
+}
+
+var _ = Describe("Sessions", Label("redis"), func() {
+	var sessions *auth.SessionStore
+	ctx := context.Background()
+	accountId := time.Now().UnixNano()
+
+	BeforeEach(func() {
+		redisClient := redis.NewClient(&redis.Options{Addr: getTestRedisAddr()})
+		pingCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		if err := redisClient.Ping(pingCtx).Err(); err != nil {
+			Skip(fmt.Sprintf("redis not reachable: %v", err))
+		}
+		sessions = auth.NewSessionStore(redisClient, &auth.Auth{
+			SessionTimeout:     time.Hour,
+			AccessTokenTimeout: time.Minute,
+			Clock:              mockClock{MockNow: time.Now()},
+		})
+	})
+
+	It("should rotate refresh tokens and revoke the session on reuse", func() {
+		session, refreshToken, err := sessions.Create(ctx, accountId)
+		Expect(err).To(BeNil())
+
+		rotated, newRefreshToken, err := sessions.Rotate(ctx, refreshToken)
+		Expect(err).To(BeNil())
+		Expect(rotated.SessionId).To(Equal(session.SessionId))
+		Expect(rotated.AccountId).To(Equal(accountId))
+		Expect(rotated.AccessJti).NotTo(Equal(session.AccessJti))
+		Expect(newRefreshToken).NotTo(Equal(refreshToken))
+
+		_, _, err = sessions.Rotate(ctx, refreshToken)
+		Expect(err).To(MatchError(auth.ErrRefreshTokenReused))
+		_, _, err = sessions.Rotate(ctx, newRefreshToken)
+		Expect(err).To(MatchError(auth.ErrSessionNotFound))
+	})
+
+	It("should list and revoke sessions of an account only", func() {
+		session, _, err := sessions.Create(ctx, accountId)
+		Expect(err).To(BeNil())
+		listed, err := sessions.List(ctx, accountId)
+		Expect(err).To(BeNil())
+		Expect(listed).To(ContainElement(HaveField("SessionId", session.SessionId)))
+
+		Expect(sessions.Revoke(ctx, accountId+1, session.SessionId)).To(MatchError(auth.ErrSessionNotFound))
+		Expect(sessions.RevokeAll(ctx, accountId)).To(Succeed())
+		listed, err = sessions.List(ctx, accountId)
+		Expect(err).To(BeNil())
+		Expect(listed).To(BeEmpty())
+	})
+})
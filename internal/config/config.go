@@ -1,16 +1,24 @@
 package config
 
 import (
+	"context"
 	"crypto/ed25519"
+	"crypto/rand"
 	"fmt"
 	"log"
+	"net/netip"
+	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/mr-tron/base58"
-
+	"github.com/atticplaygroup/prex/internal/ratelimit"
+	"github.com/atticplaygroup/prex/internal/signing"
 	"github.com/atticplaygroup/prex/internal/utils"
-	"github.com/block-vision/sui-go-sdk/signer"
+	"github.com/atticplaygroup/prex/pkg/didkey"
 	"github.com/spf13/viper"
 )
 
@@ -22,11 +30,34 @@ type Config struct {
 	WithdrawRecipientCount   int32  `mapstructure:"WITHDRAW_RECIPIENT_COUNT"`
 	WithdrawCheckStatusCount int32  `mapstructure:"WITHDRAW_CHECK_STATUS_COUNT"`
 	WalletMnemonic           string `mapstructure:"WALLET_MNEMONIC"`
-	WalletSigner             signer.Signer
 	SuiNetwork               string `mapstructure:"SUI_NETWORK"`
+	PaymentMethodsSpec       string `mapstructure:"PAYMENT_METHODS"`
+	PaymentMethods           []PaymentMethodConfig
 	TokenSigningSeed         string `mapstructure:"TOKEN_SIGNING_SEED"`
-	TokenSigningPrivateKey   ed25519.PrivateKey
 	TokenSigningKeyId        string
+	// RetiredTokenKeys are published for verification of tokens signed before
+	// the current key was introduced
+	RetiredTokenKeysSpec string `mapstructure:"RETIRED_TOKEN_KEYS"`
+	RetiredTokenKeys     []ed25519.PublicKey
+	// TokenKeyringPath holds keys staged to replace the key of the signer
+	// backend. See signing.StageKey.
+	TokenKeyringPath string `mapstructure:"TOKEN_KEYRING_PATH"`
+	Keyring          *signing.Keyring
+
+	// SignerBackend is one of memory, keystore or remote
+	SignerBackend   string `mapstructure:"SIGNER_BACKEND"`
+	KeystorePath    string `mapstructure:"KEYSTORE_PATH"`
+	RemoteSignerUrl string `mapstructure:"REMOTE_SIGNER_URL"`
+	Signer          signing.ISigner
+	// ChallengeSecret keys login and deposit challenges
+	ChallengeSecret []byte
+
+	ColdWalletAddress      string        `mapstructure:"COLD_WALLET_ADDRESS"`
+	HotWalletFloat         int64         `mapstructure:"HOT_WALLET_FLOAT"`
+	HotWalletHighWaterMark int64         `mapstructure:"HOT_WALLET_HIGH_WATER_MARK"`
+	HotWalletLowWaterMark  int64         `mapstructure:"HOT_WALLET_LOW_WATER_MARK"`
+	WalletSweepGasBudget   int64         `mapstructure:"WALLET_SWEEP_GAS_BUDGET"`
+	WalletCheckInterval    time.Duration `mapstructure:"WALLET_CHECK_INTERVAL"`
 
 	TestDbUrl            string `mapstructure:"TEST_DB_URL"`
 	TestMigrateSourceUrl string `mapstructure:"TEST_MIGRATE_SOURCE_URL"`
@@ -35,8 +66,37 @@ type Config struct {
 	MessageAuthTimeout time.Duration `mapstructure:"MESSAGE_AUTH_TIMEOUT"`
 	MaxDepositEpochGap int64         `mapstructure:"MAX_DEPOSIT_EPOCH_GAP"`
 	SessionTimeout     time.Duration `mapstructure:"SESSION_TIMEOUT"`
+	AccessTokenTimeout time.Duration `mapstructure:"ACCESS_TOKEN_TIMEOUT"`
+	// ResetWithdrawCooldown blocks withdrawals for a while after a password
+	// reset in case the wallet proving ownership was compromised.
+	ResetWithdrawCooldown time.Duration `mapstructure:"RESET_WITHDRAW_COOLDOWN"`
 
 	TokenTtl time.Duration `mapstructure:"TOKEN_TTL"`
+	// Sellers may let buyers request tokens lasting up to MAX_TOKEN_TTL, which
+	// defaults to TOKEN_TTL
+	MaxTokenTtl time.Duration `mapstructure:"MAX_TOKEN_TTL"`
+	// Blind tokens are sold in fixed denominations, each signed with its own
+	// RSA key held by the signer backend. A key issues tokens for
+	// BLIND_TOKEN_KEY_TTL and they stay redeemable for as long after that.
+	BlindTokenDenominationsSpec string        `mapstructure:"BLIND_TOKEN_DENOMINATIONS"`
+	BlindTokenKeyTtl            time.Duration `mapstructure:"BLIND_TOKEN_KEY_TTL"`
+	BlindKeyring                *signing.BlindKeyring
+	// Receipts for escrowed tokens are accepted until ESCROW_CLAIM_GRACE after
+	// expiry. The rest is then returned to buyers every ESCROW_SETTLE_INTERVAL.
+	EscrowClaimGrace     time.Duration `mapstructure:"ESCROW_CLAIM_GRACE"`
+	EscrowSettleInterval time.Duration `mapstructure:"ESCROW_SETTLE_INTERVAL"`
+
+	RateLimitsSpec string `mapstructure:"RATE_LIMITS"`
+	RateLimits     map[string]ratelimit.Limit
+	// Logins of a username are locked out exponentially longer after
+	// LOGIN_LOCKOUT_THRESHOLD consecutive failures
+	LoginLockoutThreshold int64         `mapstructure:"LOGIN_LOCKOUT_THRESHOLD"`
+	LoginLockoutBase      time.Duration `mapstructure:"LOGIN_LOCKOUT_BASE"`
+	LoginLockoutMax       time.Duration `mapstructure:"LOGIN_LOCKOUT_MAX"`
+	// Public methods called through a proxy in TRUSTED_PROXIES, like the
+	// gateway, are limited by the client IP it puts in x-forwarded-for
+	TrustedProxiesSpec string `mapstructure:"TRUSTED_PROXIES"`
+	TrustedProxies     []netip.Prefix
 
 	RedisHost string `mapstructure:"redis_host"`
 	RedisPort uint16 `mapstructure:"redis_port"`
@@ -44,6 +104,143 @@ type Config struct {
 	PrexGrpcPort uint16 `mapstructure:"PREX_GRPC_PORT"`
 }
 
+// PaymentMethodConfig is one enabled coin on one network. Endpoints and the
+// deposit address are read from PAYMENT_<COIN>_<NETWORK>_RPC_URL,
+// PAYMENT_<COIN>_<NETWORK>_GRAPHQL_URL and PAYMENT_<COIN>_<NETWORK>_DEPOSIT_ADDRESS
+// and may be left empty to use the defaults of well-known networks.
+type PaymentMethodConfig struct {
+	Coin           string
+	Network        string
+	RpcUrl         string
+	GraphqlUrl     string
+	DepositAddress string
+}
+
+var paymentMethodPartPattern = regexp.MustCompile("^[a-z0-9]+$")
+
+// parsePaymentMethods parses a comma separated list of coin:network pairs,
+// e.g. "sui:devnet,sui:localnet". An empty spec enables SUI on SUI_NETWORK.
+func parsePaymentMethods(spec string, defaultNetwork string) ([]PaymentMethodConfig, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = "sui:" + defaultNetwork
+	}
+	ret := make([]PaymentMethodConfig, 0)
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		coin, network, found := strings.Cut(strings.TrimSpace(entry), ":")
+		coin = strings.ToLower(strings.TrimSpace(coin))
+		network = strings.ToLower(strings.TrimSpace(network))
+		if !found || coin == "" || network == "" {
+			return nil, fmt.Errorf("expect payment method as coin:network but got %q", entry)
+		}
+		// Both end up in the payment method resource name
+		if !paymentMethodPartPattern.MatchString(coin) || !paymentMethodPartPattern.MatchString(network) {
+			return nil, fmt.Errorf("payment method %s:%s should only contain [a-z0-9]", coin, network)
+		}
+		if seen[coin+":"+network] {
+			return nil, fmt.Errorf("duplicated payment method %s:%s", coin, network)
+		}
+		seen[coin+":"+network] = true
+		prefix := fmt.Sprintf("PAYMENT_%s_%s_", strings.ToUpper(coin), strings.ToUpper(network))
+		ret = append(ret, PaymentMethodConfig{
+			Coin:           coin,
+			Network:        network,
+			RpcUrl:         viper.GetString(prefix + "RPC_URL"),
+			GraphqlUrl:     viper.GetString(prefix + "GRAPHQL_URL"),
+			DepositAddress: viper.GetString(prefix + "DEPOSIT_ADDRESS"),
+		})
+	}
+	return ret, nil
+}
+
+// parseRetiredTokenKeys parses a comma separated list of did:key.
+func parseRetiredTokenKeys(spec string) ([]ed25519.PublicKey, error) {
+	ret := make([]ed25519.PublicKey, 0)
+	if strings.TrimSpace(spec) == "" {
+		return ret, nil
+	}
+	for _, keyId := range strings.Split(spec, ",") {
+		publicKey, err := didkey.ParseKeyId(strings.TrimSpace(keyId))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, publicKey)
+	}
+	return ret, nil
+}
+
+// parseDenominations parses a comma separated list of distinct positive
+// amounts.
+func parseDenominations(spec string) ([]int64, error) {
+	ret := make([]int64, 0)
+	if strings.TrimSpace(spec) == "" {
+		return ret, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		denomination, err := strconv.ParseInt(strings.TrimSpace(entry), 10, 64)
+		if err != nil || denomination <= 0 {
+			return nil, fmt.Errorf("expect positive denomination but got %q", entry)
+		}
+		if slices.Contains(ret, denomination) {
+			return nil, fmt.Errorf("duplicated denomination %d", denomination)
+		}
+		ret = append(ret, denomination)
+	}
+	return ret, nil
+}
+
+// loadSigner builds the signer of SIGNER_BACKEND. Secrets only needed to unlock
+// the signer are read from viper directly to keep them out of Config.
+func loadSigner(config *Config) (signing.ISigner, error) {
+	switch config.SignerBackend {
+	case "", signing.BACKEND_MEMORY:
+		config.SignerBackend = signing.BACKEND_MEMORY
+		seed, err := utils.HexToBytes32(config.TokenSigningSeed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse TOKEN_SIGNING_SEED: %v", err)
+		}
+		return signing.NewMemorySigner(config.WalletMnemonic, seed)
+	case signing.BACKEND_KEYSTORE:
+		passphrase := viper.GetString("KEYSTORE_PASSPHRASE")
+		if passphraseFile := viper.GetString("KEYSTORE_PASSPHRASE_FILE"); passphraseFile != "" {
+			content, err := os.ReadFile(passphraseFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read passphrase file: %v", err)
+			}
+			passphrase = strings.TrimRight(string(content), "\r\n")
+		}
+		return signing.OpenKeystore(config.KeystorePath, []byte(passphrase))
+	case signing.BACKEND_REMOTE:
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return signing.NewRemoteSigner(
+			ctx, config.RemoteSignerUrl, viper.GetString("REMOTE_SIGNER_TOKEN"))
+	default:
+		return nil, fmt.Errorf("unknown signer backend %s", config.SignerBackend)
+	}
+}
+
+// loadChallengeSecret falls back to the token private key if it is available
+// in memory, which keeps challenges stable across restarts. A remote signer
+// without CHALLENGE_SECRET gets a random secret valid until restart.
+func loadChallengeSecret(config *Config) ([]byte, error) {
+	if secret := viper.GetString("CHALLENGE_SECRET"); secret != "" {
+		return utils.HexToBytes32(secret)
+	}
+	// Both the memory and keystore backends hold the token key in process
+	if memorySigner, ok := config.Signer.(interface {
+		GetPrivateKey() ed25519.PrivateKey
+	}); ok {
+		return memorySigner.GetPrivateKey(), nil
+	}
+	log.Printf("CHALLENGE_SECRET not set, challenges will not survive restarts")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
 func LoadConfig(path string) (config Config) {
 	viper.AddConfigPath(filepath.Dir(path))
 	viper.SetConfigName(filepath.Base(path))
@@ -57,25 +254,64 @@ func LoadConfig(path string) (config Config) {
 	if err := viper.Unmarshal(&config); err != nil {
 		log.Fatalf("config: %v", err)
 	}
+	if config.MaxTokenTtl < config.TokenTtl {
+		config.MaxTokenTtl = config.TokenTtl
+	}
 
-	seed, err := utils.HexToBytes32(config.TokenSigningSeed)
+	var err error
+	config.Signer, err = loadSigner(&config)
 	if err != nil {
-		log.Fatalf("failed to parse TokenSigningSeed %s: %v", config.TokenSigningSeed, err)
-	}
-	if len(seed) != ed25519.SeedSize {
-		log.Fatalf("expect seed to have len %d but got %d: %v", ed25519.SeedSize, len(seed), seed)
+		log.Fatalf("failed to load %s signer: %v", config.SignerBackend, err)
 	}
-	config.TokenSigningPrivateKey = ed25519.NewKeyFromSeed(seed)
-	buf := []byte{0xed, 0x01}
-	buf = append(buf, []byte(config.TokenSigningPrivateKey.Public().(ed25519.PublicKey))...)
-	config.TokenSigningKeyId = fmt.Sprintf("did:key:z%s", base58.Encode(buf))
+	config.TokenSigningKeyId = didkey.KeyId(config.Signer.GetPublicKey())
 	fmt.Printf("did: %s\n", config.TokenSigningKeyId)
 
-	signer, err := signer.NewSignertWithMnemonic(config.WalletMnemonic)
+	config.RetiredTokenKeys, err = parseRetiredTokenKeys(config.RetiredTokenKeysSpec)
+	if err != nil {
+		log.Fatalf("failed to parse RETIRED_TOKEN_KEYS: %v", err)
+	}
+	config.Keyring, err = signing.LoadKeyring(config.TokenKeyringPath, config.Signer, config.RetiredTokenKeys)
+	if err != nil {
+		log.Fatalf("failed to load TOKEN_KEYRING_PATH: %v", err)
+	}
+
+	denominations, err := parseDenominations(config.BlindTokenDenominationsSpec)
+	if err != nil {
+		log.Fatalf("failed to parse BLIND_TOKEN_DENOMINATIONS: %v", err)
+	}
+	if config.BlindTokenKeyTtl == 0 {
+		config.BlindTokenKeyTtl = 30 * 24 * time.Hour
+	}
+	config.BlindKeyring, err = signing.NewBlindKeyring(
+		context.Background(),
+		config.Signer,
+		denominations,
+		config.BlindTokenKeyTtl,
+		config.BlindTokenKeyTtl,
+		time.Now(),
+	)
+	if err != nil {
+		log.Fatalf("failed to load blind token keys: %v", err)
+	}
+
+	config.ChallengeSecret, err = loadChallengeSecret(&config)
+	if err != nil {
+		log.Fatalf("failed to load CHALLENGE_SECRET: %v", err)
+	}
+
+	config.PaymentMethods, err = parsePaymentMethods(config.PaymentMethodsSpec, config.SuiNetwork)
+	if err != nil {
+		log.Fatalf("failed to parse PAYMENT_METHODS: %v", err)
+	}
+
+	config.RateLimits, err = ratelimit.ParseLimits(config.RateLimitsSpec)
+	if err != nil {
+		log.Fatalf("failed to parse RATE_LIMITS: %v", err)
+	}
+	config.TrustedProxies, err = ratelimit.ParseTrustedProxies(config.TrustedProxiesSpec)
 	if err != nil {
-		log.Fatalf("failed to load mnemonic: %v", err)
+		log.Fatalf("failed to parse TRUSTED_PROXIES: %v", err)
 	}
-	config.WalletSigner = *signer
 
 	return
 }
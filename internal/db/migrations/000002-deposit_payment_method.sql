@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE deposits ADD COLUMN payment_method VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE deposits DROP COLUMN payment_method;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE deposits ADD COLUMN sender_address VARCHAR(66) NOT NULL DEFAULT '';
+CREATE INDEX ON deposits (account_id, deposit_id);
+
+-- +migrate Down
+DROP INDEX deposits_account_id_deposit_id_idx;
+ALTER TABLE deposits DROP COLUMN sender_address;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE accounts ADD COLUMN withdraw_cooldown_until TIMESTAMPTZ;
+CREATE INDEX ON deposits (sender_address);
+
+-- +migrate Down
+DROP INDEX deposits_sender_address_idx;
+ALTER TABLE accounts DROP COLUMN withdraw_cooldown_until;
@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE api_keys (
+  api_key_id BIGSERIAL PRIMARY KEY,
+  account_id BIGINT NOT NULL,
+  display_name VARCHAR(64) NOT NULL,
+  key_prefix VARCHAR(16) NOT NULL UNIQUE,
+  key_hash BYTEA NOT NULL,
+  scopes TEXT[] NOT NULL,
+  -- NULL for unlimited
+  spend_limit BIGINT CHECK (spend_limit >= 0),
+  spent BIGINT NOT NULL DEFAULT 0 CHECK (spent >= 0),
+  create_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expire_time TIMESTAMPTZ,
+  revoke_time TIMESTAMPTZ,
+  FOREIGN KEY (account_id) REFERENCES accounts (account_id) ON DELETE CASCADE
+);
+
+CREATE INDEX ON api_keys (account_id);
+
+-- +migrate Down
+DROP TABLE api_keys;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE quota_tokens (
+  jti VARCHAR(64) PRIMARY KEY,
+  -- NULL for tokens issued before tracking or of pruned accounts
+  buyer_id BIGINT,
+  audience TEXT NOT NULL,
+  quantity BIGINT NOT NULL CHECK (quantity > 0),
+  consumed BIGINT NOT NULL DEFAULT 0 CHECK (consumed >= 0 AND consumed <= quantity),
+  issue_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expire_time TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (buyer_id) REFERENCES accounts (account_id) ON DELETE SET NULL
+);
+
+CREATE INDEX ON quota_tokens (expire_time);
+
+-- +migrate Down
+DROP TABLE quota_tokens;
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE service_sessions (
+  service_session_id BIGSERIAL PRIMARY KEY,
+  audience TEXT NOT NULL,
+  quantity BIGINT NOT NULL CHECK (quantity >= 0),
+  consumed BIGINT NOT NULL DEFAULT 0 CHECK (consumed >= 0 AND consumed <= quantity),
+  session_state VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (session_state IN ('active', 'paused', 'closed')),
+  create_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expire_time TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX ON service_sessions (audience);
+
+-- +migrate Down
+DROP TABLE service_sessions;
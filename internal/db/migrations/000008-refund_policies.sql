@@ -0,0 +1,17 @@
+-- +migrate Up
+ALTER TABLE quota_tokens ADD COLUMN revoke_time TIMESTAMPTZ;
+
+-- Accounts without a policy do not refund tokens bought for them
+CREATE TABLE refund_policies (
+  account_id BIGINT PRIMARY KEY,
+  refund_mode VARCHAR(10) NOT NULL,
+  -- How long after issuance tokens are refundable, or until expiry if zero
+  refund_window INTERVAL NOT NULL DEFAULT '0',
+  update_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CHECK (refund_mode IN ('none', 'unredeemed', 'unconsumed')),
+  FOREIGN KEY (account_id) REFERENCES accounts (account_id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE refund_policies;
+ALTER TABLE quota_tokens DROP COLUMN revoke_time;
@@ -0,0 +1,21 @@
+-- +migrate Up
+-- Tokens issued minus redeemed per key is what Prex owes to their holders
+CREATE TABLE blind_token_keys (
+  key_id VARCHAR(64) PRIMARY KEY,
+  denomination BIGINT NOT NULL CHECK (denomination > 0),
+  issued BIGINT NOT NULL DEFAULT 0,
+  redeemed BIGINT NOT NULL DEFAULT 0 CHECK (redeemed >= 0 AND redeemed <= issued)
+);
+
+CREATE TABLE spent_blind_tokens (
+  nonce BYTEA PRIMARY KEY,
+  key_id VARCHAR(64) NOT NULL,
+  redeemer_id BIGINT,
+  redeem_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (key_id) REFERENCES blind_token_keys (key_id),
+  FOREIGN KEY (redeemer_id) REFERENCES accounts (account_id) ON DELETE SET NULL
+);
+
+-- +migrate Down
+DROP TABLE spent_blind_tokens;
+DROP TABLE blind_token_keys;
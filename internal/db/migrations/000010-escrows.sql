@@ -0,0 +1,22 @@
+-- +migrate Up
+-- Amounts paid for escrowed tokens are held here until the audience claims
+-- them with consumption receipts. The unclaimed rest goes back to the buyer
+-- once the token expires.
+CREATE TABLE escrows (
+  jti VARCHAR(64) PRIMARY KEY,
+  -- NULL once the buyer is pruned, whose unclaimed rest is then dropped
+  buyer_id BIGINT,
+  seller_id BIGINT NOT NULL,
+  amount BIGINT NOT NULL CHECK (amount >= 0),
+  claimed BIGINT NOT NULL DEFAULT 0 CHECK (claimed >= 0 AND claimed <= amount),
+  expire_time TIMESTAMPTZ NOT NULL,
+  settle_time TIMESTAMPTZ,
+  FOREIGN KEY (jti) REFERENCES quota_tokens (jti) ON DELETE CASCADE,
+  FOREIGN KEY (buyer_id) REFERENCES accounts (account_id) ON DELETE SET NULL,
+  FOREIGN KEY (seller_id) REFERENCES accounts (account_id) ON DELETE CASCADE
+);
+
+CREATE INDEX ON escrows (expire_time) WHERE settle_time IS NULL;
+
+-- +migrate Down
+DROP TABLE escrows;
@@ -0,0 +1,31 @@
+-- +migrate Up
+-- Single row counting the entries of the transparency log. Appending locks it
+-- so entries are numbered without gaps in commit order.
+CREATE TABLE transparency_log_size (
+  log_id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (log_id = 1),
+  tree_size BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO transparency_log_size DEFAULT VALUES;
+
+CREATE TABLE transparency_log_entries (
+  leaf_index BIGINT PRIMARY KEY,
+  token_hash BYTEA NOT NULL,
+  audience TEXT NOT NULL,
+  quantity BIGINT NOT NULL,
+  issue_time TIMESTAMPTZ NOT NULL
+);
+
+-- Hashes of the perfect subtrees of the log, enough to compute any root and
+-- proof without reading all entries
+CREATE TABLE transparency_log_nodes (
+  level INT NOT NULL,
+  node_index BIGINT NOT NULL,
+  hash BYTEA NOT NULL,
+  PRIMARY KEY (level, node_index)
+);
+
+-- +migrate Down
+DROP TABLE transparency_log_nodes;
+DROP TABLE transparency_log_entries;
+DROP TABLE transparency_log_size;
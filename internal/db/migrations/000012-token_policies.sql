@@ -0,0 +1,13 @@
+-- +migrate Up
+-- Buyers may request tokens lasting up to max_token_ttl for the account.
+-- Accounts without a policy allow TOKEN_TTL.
+CREATE TABLE token_policies (
+  account_id BIGINT PRIMARY KEY,
+  max_token_ttl INTERVAL NOT NULL,
+  update_time TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CHECK (max_token_ttl > '0'),
+  FOREIGN KEY (account_id) REFERENCES accounts (account_id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE token_policies;
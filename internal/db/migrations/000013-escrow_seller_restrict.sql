@@ -0,0 +1,13 @@
+-- +migrate Up
+-- Pruning a seller must not drop the escrows still owed to its buyers. They
+-- are settled before the account is deleted.
+ALTER TABLE escrows
+  DROP CONSTRAINT escrows_seller_id_fkey,
+  ADD CONSTRAINT escrows_seller_id_fkey
+    FOREIGN KEY (seller_id) REFERENCES accounts (account_id) ON DELETE RESTRICT;
+
+-- +migrate Down
+ALTER TABLE escrows
+  DROP CONSTRAINT escrows_seller_id_fkey,
+  ADD CONSTRAINT escrows_seller_id_fkey
+    FOREIGN KEY (seller_id) REFERENCES accounts (account_id) ON DELETE CASCADE;
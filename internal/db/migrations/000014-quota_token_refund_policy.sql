@@ -0,0 +1,18 @@
+-- +migrate Up
+-- The refund policy of the audience at purchase. Later changes to the policy
+-- do not affect tokens already sold.
+ALTER TABLE quota_tokens ADD COLUMN refund_mode VARCHAR(10) NOT NULL DEFAULT 'none'
+  CHECK (refund_mode IN ('none', 'unredeemed', 'unconsumed'));
+ALTER TABLE quota_tokens ADD COLUMN refund_window INTERVAL NOT NULL DEFAULT '0';
+
+-- Tokens sold before the snapshot keep the policy their audience has now
+UPDATE quota_tokens
+SET refund_mode = refund_policies.refund_mode,
+  refund_window = refund_policies.refund_window
+FROM refund_policies
+JOIN accounts ON accounts.account_id = refund_policies.account_id
+WHERE accounts.username = quota_tokens.audience;
+
+-- +migrate Down
+ALTER TABLE quota_tokens DROP COLUMN refund_window;
+ALTER TABLE quota_tokens DROP COLUMN refund_mode;
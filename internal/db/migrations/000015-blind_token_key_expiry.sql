@@ -0,0 +1,13 @@
+-- +migrate Up
+-- Prex owes unredeemed tokens to their holders only until the key expires.
+-- Keys configured before expiry was tracked are no longer loaded and cannot
+-- verify tokens, so they expire right away.
+ALTER TABLE blind_token_keys ADD COLUMN expire_time TIMESTAMPTZ NOT NULL
+  DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE blind_token_keys ALTER COLUMN expire_time DROP DEFAULT;
+
+CREATE INDEX ON spent_blind_tokens (key_id);
+
+-- +migrate Down
+DROP INDEX spent_blind_tokens_key_id_idx;
+ALTER TABLE blind_token_keys DROP COLUMN expire_time;
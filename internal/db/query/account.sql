@@ -37,6 +37,15 @@ WHERE username = @username
 RETURNING *
 ;
 
+-- name: DeductBalanceByUsername :one
+-- Fails with no rows if the balance is less than amount
+UPDATE accounts
+SET balance = balance - @amount
+WHERE username = @username
+AND balance >= @amount
+RETURNING *
+;
+
 -- name: GetAccount :one
 SELECT
   *
@@ -44,6 +53,29 @@ FROM accounts
 WHERE username = @username
 ;
 
+-- name: GetAccountByOwnerAddress :one
+-- The owner of an account is the sender of its first deposit. Later top-ups
+-- may come from anyone and do not grant access.
+SELECT
+  accounts.*
+FROM accounts
+WHERE username = @username
+AND @sender_address::text = (
+  SELECT sender_address
+  FROM deposits
+  WHERE deposits.account_id = accounts.account_id
+  ORDER BY deposit_id
+  LIMIT 1
+)
+;
+
+-- name: GetWithdrawCooldownForShare :one
+SELECT withdraw_cooldown_until
+FROM accounts
+WHERE account_id = @account_id
+FOR SHARE
+;
+
 -- name: QueryBalanceForShare :one
 SELECT *
 FROM accounts
@@ -63,9 +95,35 @@ AND expire_time > CURRENT_TIMESTAMP
 INSERT INTO deposits (
   transaction_digest,
   epoch,
-  account_id
+  account_id,
+  payment_method,
+  sender_address
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 )
 RETURNING *
 ;
+
+-- name: ResetAccountPassword :one
+-- Only the owner, i.e. the sender of the first deposit, may reset.
+UPDATE accounts
+SET
+  password = @password,
+  withdraw_cooldown_until = CURRENT_TIMESTAMP + @cooldown::interval
+WHERE username = @username
+AND @sender_address::text = (
+  SELECT sender_address
+  FROM deposits
+  WHERE deposits.account_id = accounts.account_id
+  ORDER BY deposit_id
+  LIMIT 1
+)
+RETURNING *
+;
+
+-- name: SetAccountPrivilege :one
+UPDATE accounts
+SET privilege = @privilege
+WHERE username = @username
+RETURNING *
+;
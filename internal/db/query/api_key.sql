@@ -0,0 +1,53 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+  account_id,
+  display_name,
+  key_prefix,
+  key_hash,
+  scopes,
+  spend_limit,
+  expire_time
+) VALUES (
+  @account_id, @display_name, @key_prefix, @key_hash, @scopes, @spend_limit, @expire_time
+)
+RETURNING *
+;
+
+-- name: GetApiKeyByPrefix :one
+SELECT *
+FROM api_keys
+WHERE key_prefix = @key_prefix
+;
+
+-- name: ListApiKeys :many
+SELECT *
+FROM api_keys
+WHERE account_id = @account_id
+ORDER BY api_key_id
+;
+
+-- name: RevokeApiKey :one
+UPDATE api_keys
+SET revoke_time = CURRENT_TIMESTAMP
+WHERE api_key_id = @api_key_id
+AND account_id = @account_id
+AND revoke_time IS NULL
+RETURNING *
+;
+
+-- name: ChargeApiKey :one
+-- Fails with no rows if the spend limit would be exceeded
+UPDATE api_keys
+SET spent = spent + @amount
+WHERE api_key_id = @api_key_id
+AND (spend_limit IS NULL OR spent + @amount <= spend_limit)
+RETURNING *
+;
+
+-- name: RevokeAccountApiKeys :many
+UPDATE api_keys
+SET revoke_time = CURRENT_TIMESTAMP
+WHERE account_id = @account_id
+AND revoke_time IS NULL
+RETURNING *
+;
@@ -0,0 +1,53 @@
+-- name: RecordBlindTokensIssued :one
+INSERT INTO blind_token_keys (
+  key_id,
+  denomination,
+  issued,
+  expire_time
+) VALUES (
+  @key_id, @denomination, @count, @expire_time
+)
+ON CONFLICT (key_id) DO UPDATE
+SET issued = blind_token_keys.issued + @count
+RETURNING *
+;
+
+-- name: ListExpiredBlindTokenKeys :many
+-- Tokens not redeemed before their key expired are owed to nobody any more
+SELECT * FROM blind_token_keys
+WHERE expire_time <= CURRENT_TIMESTAMP
+AND redeemed < issued
+ORDER BY expire_time
+;
+
+-- name: RecordBlindTokenRedeemed :one
+-- Fails with no rows if no token of the key is outstanding or the key expired
+UPDATE blind_token_keys
+SET redeemed = redeemed + 1
+WHERE key_id = @key_id
+AND redeemed < issued
+AND expire_time > CURRENT_TIMESTAMP
+RETURNING *
+;
+
+-- name: SpendBlindToken :one
+-- Fails with no rows if the token was spent before
+INSERT INTO spent_blind_tokens (
+  key_id,
+  nonce,
+  redeemer_id
+) VALUES (
+  @key_id, @nonce, @redeemer_id
+)
+ON CONFLICT (nonce) DO NOTHING
+RETURNING *
+;
+
+-- name: PruneSpentBlindTokens :execrows
+-- Tokens of expired keys are refused anyway so their nonces can be forgotten
+DELETE FROM spent_blind_tokens
+WHERE key_id IN (
+  SELECT key_id FROM blind_token_keys
+  WHERE expire_time <= CURRENT_TIMESTAMP
+)
+;
@@ -0,0 +1,74 @@
+-- name: CreateEscrow :one
+-- Fails with no rows if the audience has no account
+INSERT INTO escrows (
+  jti,
+  buyer_id,
+  seller_id,
+  amount,
+  expire_time
+)
+SELECT
+  @jti::text, @buyer_id::bigint, account_id, @amount::bigint, @expire_time::timestamptz
+FROM accounts
+WHERE username = @audience
+RETURNING *
+;
+
+-- name: GetEscrowForUpdate :one
+SELECT *
+FROM escrows
+WHERE jti = @jti
+FOR UPDATE
+;
+
+-- name: UpdateEscrowClaimed :one
+UPDATE escrows
+SET claimed = @claimed
+WHERE jti = @jti
+RETURNING *
+;
+
+-- name: ReduceEscrow :one
+-- Fails with no rows if less than amount is unclaimed
+UPDATE escrows
+SET amount = amount - @amount
+WHERE jti = @jti
+AND settle_time IS NULL
+AND amount - @amount >= claimed
+RETURNING *
+;
+
+-- name: ListSettleableEscrows :many
+SELECT *
+FROM escrows
+WHERE settle_time IS NULL
+AND expire_time < @settle_before
+ORDER BY expire_time
+LIMIT @max_count
+FOR UPDATE SKIP LOCKED
+;
+
+-- name: SettleEscrow :one
+UPDATE escrows
+SET settle_time = CURRENT_TIMESTAMP
+WHERE jti = @jti
+AND settle_time IS NULL
+RETURNING *
+;
+
+-- name: ListUnsettledEscrowsOfInvalidSellers :many
+SELECT escrows.*
+FROM escrows
+JOIN accounts ON accounts.account_id = escrows.seller_id
+WHERE escrows.settle_time IS NULL
+AND accounts.expire_time < CURRENT_TIMESTAMP
+FOR UPDATE OF escrows
+;
+
+-- name: DeleteSettledEscrowsOfInvalidSellers :execrows
+DELETE FROM escrows
+USING accounts
+WHERE accounts.account_id = escrows.seller_id
+AND escrows.settle_time IS NOT NULL
+AND accounts.expire_time < CURRENT_TIMESTAMP
+;
@@ -0,0 +1,50 @@
+-- name: CreateQuotaToken :one
+INSERT INTO quota_tokens (
+  jti,
+  buyer_id,
+  audience,
+  quantity,
+  expire_time,
+  refund_mode,
+  refund_window
+) VALUES (
+  @jti, @buyer_id, @audience, @quantity, @expire_time, @refund_mode, @refund_window
+)
+RETURNING *
+;
+
+-- name: GetQuotaToken :one
+SELECT *
+FROM quota_tokens
+WHERE jti = @jti
+;
+
+-- name: RedeemQuotaToken :one
+-- Tokens issued before tracking are recorded on their first redemption.
+-- Fails with no rows if less than consume is left.
+INSERT INTO quota_tokens (
+  jti,
+  audience,
+  quantity,
+  consumed,
+  expire_time
+) VALUES (
+  @jti, @audience, @quantity, @consume, @expire_time
+)
+ON CONFLICT (jti) DO UPDATE
+SET consumed = quota_tokens.consumed + @consume
+WHERE quota_tokens.consumed + @consume <= quota_tokens.quantity
+AND quota_tokens.revoke_time IS NULL
+RETURNING *
+;
+
+-- name: RevokeQuotaToken :one
+-- Fails with no rows unless the token of the buyer is unexpired and unrevoked
+UPDATE quota_tokens
+SET revoke_time = CURRENT_TIMESTAMP
+WHERE jti = @jti
+AND buyer_id = @buyer_id
+AND revoke_time IS NULL
+AND expire_time > CURRENT_TIMESTAMP
+RETURNING *
+;
@@ -0,0 +1,21 @@
+-- name: GetRefundPolicyByUsername :one
+SELECT refund_policies.*
+FROM refund_policies
+JOIN accounts ON accounts.account_id = refund_policies.account_id
+WHERE accounts.username = @username
+;
+
+-- name: UpsertRefundPolicy :one
+INSERT INTO refund_policies (
+  account_id,
+  refund_mode,
+  refund_window
+) VALUES (
+  @account_id, @refund_mode, @refund_window
+)
+ON CONFLICT (account_id) DO UPDATE
+SET refund_mode = EXCLUDED.refund_mode,
+  refund_window = EXCLUDED.refund_window,
+  update_time = CURRENT_TIMESTAMP
+RETURNING *
+;
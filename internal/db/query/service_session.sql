@@ -0,0 +1,50 @@
+-- name: CreateServiceSession :one
+INSERT INTO service_sessions (
+  audience,
+  quantity,
+  expire_time
+) VALUES (
+  @audience, @quantity, @expire_time
+)
+RETURNING *
+;
+
+-- name: GetServiceSession :one
+SELECT *
+FROM service_sessions
+WHERE service_session_id = @service_session_id
+;
+
+-- name: TopUpServiceSession :one
+UPDATE service_sessions
+SET quantity = quantity + @quantity,
+  expire_time = GREATEST(expire_time, @expire_time),
+  update_time = CURRENT_TIMESTAMP
+WHERE service_session_id = @service_session_id
+AND audience = @audience
+AND session_state <> 'closed'
+RETURNING *
+;
+
+-- name: SetServiceSessionState :one
+-- Fails with no rows unless the session is in one of from_states
+UPDATE service_sessions
+SET session_state = @session_state,
+  update_time = CURRENT_TIMESTAMP
+WHERE service_session_id = @service_session_id
+AND session_state = ANY(@from_states::TEXT[])
+RETURNING *
+;
+
+-- name: ConsumeServiceSession :one
+-- Fails with no rows if the session is not active or has too little left
+UPDATE service_sessions
+SET consumed = consumed + @quantity,
+  update_time = CURRENT_TIMESTAMP
+WHERE service_session_id = @service_session_id
+AND audience = @audience
+AND session_state = 'active'
+AND expire_time > CURRENT_TIMESTAMP
+AND consumed + @quantity <= quantity
+RETURNING *
+;
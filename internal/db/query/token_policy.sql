@@ -0,0 +1,19 @@
+-- name: GetTokenPolicyByUsername :one
+SELECT token_policies.*
+FROM token_policies
+JOIN accounts ON accounts.account_id = token_policies.account_id
+WHERE accounts.username = @username
+;
+
+-- name: UpsertTokenPolicy :one
+INSERT INTO token_policies (
+  account_id,
+  max_token_ttl
+) VALUES (
+  @account_id, @max_token_ttl
+)
+ON CONFLICT (account_id) DO UPDATE
+SET max_token_ttl = EXCLUDED.max_token_ttl,
+  update_time = CURRENT_TIMESTAMP
+RETURNING *
+;
@@ -0,0 +1,49 @@
+-- name: ReserveLogIndex :one
+-- Returns the new size. The row stays locked until the transaction ends.
+UPDATE transparency_log_size
+SET tree_size = tree_size + 1
+RETURNING tree_size
+;
+
+-- name: GetLogSize :one
+SELECT tree_size
+FROM transparency_log_size
+;
+
+-- name: AppendLogEntry :one
+INSERT INTO transparency_log_entries (
+  leaf_index,
+  token_hash,
+  audience,
+  quantity,
+  issue_time
+) VALUES (
+  @leaf_index, @token_hash, @audience, @quantity, @issue_time
+)
+RETURNING *
+;
+
+-- name: ListLogEntries :many
+SELECT *
+FROM transparency_log_entries
+WHERE leaf_index >= @start_index
+ORDER BY leaf_index
+LIMIT @max_count
+;
+
+-- name: PutLogNode :exec
+INSERT INTO transparency_log_nodes (
+  level,
+  node_index,
+  hash
+) VALUES (
+  @level, @node_index, @hash
+)
+;
+
+-- name: GetLogNode :one
+SELECT hash
+FROM transparency_log_nodes
+WHERE level = @level
+AND node_index = @node_index
+;
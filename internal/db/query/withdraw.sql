@@ -83,3 +83,26 @@ ORDER BY total_priority_fee DESC, create_time
 LIMIT $1
 OFFSET $2
 ;
+
+-- name: ListPendingPriorityFees :many
+SELECT
+  priority_fee
+FROM withdrawals
+WHERE processing_withdrawal_id IS NULL
+ORDER BY priority_fee DESC, create_time
+;
+
+-- name: SumPendingWithdrawals :one
+SELECT
+  COALESCE(SUM(amount), 0)::bigint AS total_amount
+FROM withdrawals
+WHERE processing_withdrawal_id IS NULL
+;
+
+-- name: LockWallet :exec
+SELECT pg_advisory_lock(@lock_id::bigint)
+;
+
+-- name: UnlockWallet :exec
+SELECT pg_advisory_unlock(@lock_id::bigint)
+;
@@ -15,27 +15,39 @@ const addDepositRecord = `-- name: AddDepositRecord :one
 INSERT INTO deposits (
   transaction_digest,
   epoch,
-  account_id
+  account_id,
+  payment_method,
+  sender_address
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 )
-RETURNING deposit_id, transaction_digest, epoch, account_id
+RETURNING deposit_id, transaction_digest, epoch, account_id, payment_method, sender_address
 `
 
 type AddDepositRecordParams struct {
 	TransactionDigest string `json:"transaction_digest"`
 	Epoch             int64  `json:"epoch"`
 	AccountID         int64  `json:"account_id"`
+	PaymentMethod     string `json:"payment_method"`
+	SenderAddress     string `json:"sender_address"`
 }
 
 func (q *Queries) AddDepositRecord(ctx context.Context, arg AddDepositRecordParams) (Deposit, error) {
-	row := q.db.QueryRow(ctx, addDepositRecord, arg.TransactionDigest, arg.Epoch, arg.AccountID)
+	row := q.db.QueryRow(ctx, addDepositRecord,
+		arg.TransactionDigest,
+		arg.Epoch,
+		arg.AccountID,
+		arg.PaymentMethod,
+		arg.SenderAddress,
+	)
 	var i Deposit
 	err := row.Scan(
 		&i.DepositID,
 		&i.TransactionDigest,
 		&i.Epoch,
 		&i.AccountID,
+		&i.PaymentMethod,
+		&i.SenderAddress,
 	)
 	return i, err
 }
@@ -44,7 +56,7 @@ const changeBalance = `-- name: ChangeBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE account_id = $2
-RETURNING account_id, username, password, balance, create_time, expire_time, privilege
+RETURNING account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
 `
 
 type ChangeBalanceParams struct {
@@ -63,6 +75,7 @@ func (q *Queries) ChangeBalance(ctx context.Context, arg ChangeBalanceParams) (A
 		&i.CreateTime,
 		&i.ExpireTime,
 		&i.Privilege,
+		&i.WithdrawCooldownUntil,
 	)
 	return i, err
 }
@@ -71,7 +84,7 @@ const changeBalanceByUsername = `-- name: ChangeBalanceByUsername :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE username = $2
-RETURNING account_id, username, password, balance, create_time, expire_time, privilege
+RETURNING account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
 `
 
 type ChangeBalanceByUsernameParams struct {
@@ -90,6 +103,37 @@ func (q *Queries) ChangeBalanceByUsername(ctx context.Context, arg ChangeBalance
 		&i.CreateTime,
 		&i.ExpireTime,
 		&i.Privilege,
+		&i.WithdrawCooldownUntil,
+	)
+	return i, err
+}
+
+const deductBalanceByUsername = `-- name: DeductBalanceByUsername :one
+UPDATE accounts
+SET balance = balance - $1
+WHERE username = $2
+AND balance >= $1
+RETURNING account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
+`
+
+type DeductBalanceByUsernameParams struct {
+	Amount   int64  `json:"amount"`
+	Username string `json:"username"`
+}
+
+// Fails with no rows if the balance is less than amount
+func (q *Queries) DeductBalanceByUsername(ctx context.Context, arg DeductBalanceByUsernameParams) (Account, error) {
+	row := q.db.QueryRow(ctx, deductBalanceByUsername, arg.Amount, arg.Username)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Password,
+		&i.Balance,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.Privilege,
+		&i.WithdrawCooldownUntil,
 	)
 	return i, err
 }
@@ -123,7 +167,7 @@ func (q *Queries) DeleteInvalidAccounts(ctx context.Context) ([]int64, error) {
 
 const getAccount = `-- name: GetAccount :one
 SELECT
-  account_id, username, password, balance, create_time, expire_time, privilege
+  account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
 FROM accounts
 WHERE username = $1
 `
@@ -139,12 +183,64 @@ func (q *Queries) GetAccount(ctx context.Context, username string) (Account, err
 		&i.CreateTime,
 		&i.ExpireTime,
 		&i.Privilege,
+		&i.WithdrawCooldownUntil,
 	)
 	return i, err
 }
 
+const getAccountByOwnerAddress = `-- name: GetAccountByOwnerAddress :one
+SELECT
+  accounts.account_id, accounts.username, accounts.password, accounts.balance, accounts.create_time, accounts.expire_time, accounts.privilege, accounts.withdraw_cooldown_until
+FROM accounts
+WHERE username = $1
+AND $2::text = (
+  SELECT sender_address
+  FROM deposits
+  WHERE deposits.account_id = accounts.account_id
+  ORDER BY deposit_id
+  LIMIT 1
+)
+`
+
+type GetAccountByOwnerAddressParams struct {
+	Username      string `json:"username"`
+	SenderAddress string `json:"sender_address"`
+}
+
+// The owner of an account is the sender of its first deposit. Later top-ups
+// may come from anyone and do not grant access.
+func (q *Queries) GetAccountByOwnerAddress(ctx context.Context, arg GetAccountByOwnerAddressParams) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByOwnerAddress, arg.Username, arg.SenderAddress)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Password,
+		&i.Balance,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.Privilege,
+		&i.WithdrawCooldownUntil,
+	)
+	return i, err
+}
+
+const getWithdrawCooldownForShare = `-- name: GetWithdrawCooldownForShare :one
+SELECT withdraw_cooldown_until
+FROM accounts
+WHERE account_id = $1
+FOR SHARE
+`
+
+func (q *Queries) GetWithdrawCooldownForShare(ctx context.Context, accountID int64) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getWithdrawCooldownForShare, accountID)
+	var withdraw_cooldown_until pgtype.Timestamptz
+	err := row.Scan(&withdraw_cooldown_until)
+	return withdraw_cooldown_until, err
+}
+
 const queryBalance = `-- name: QueryBalance :one
-SELECT account_id, username, password, balance, create_time, expire_time, privilege
+SELECT account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
 FROM accounts
 WHERE account_id = $1
 AND expire_time > CURRENT_TIMESTAMP
@@ -161,12 +257,13 @@ func (q *Queries) QueryBalance(ctx context.Context, accountID int64) (Account, e
 		&i.CreateTime,
 		&i.ExpireTime,
 		&i.Privilege,
+		&i.WithdrawCooldownUntil,
 	)
 	return i, err
 }
 
 const queryBalanceForShare = `-- name: QueryBalanceForShare :one
-SELECT account_id, username, password, balance, create_time, expire_time, privilege
+SELECT account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
 FROM accounts
 WHERE account_id = $1
 AND expire_time > CURRENT_TIMESTAMP
@@ -184,6 +281,80 @@ func (q *Queries) QueryBalanceForShare(ctx context.Context, accountID int64) (Ac
 		&i.CreateTime,
 		&i.ExpireTime,
 		&i.Privilege,
+		&i.WithdrawCooldownUntil,
+	)
+	return i, err
+}
+
+const resetAccountPassword = `-- name: ResetAccountPassword :one
+UPDATE accounts
+SET
+  password = $1,
+  withdraw_cooldown_until = CURRENT_TIMESTAMP + $2::interval
+WHERE username = $3
+AND $4::text = (
+  SELECT sender_address
+  FROM deposits
+  WHERE deposits.account_id = accounts.account_id
+  ORDER BY deposit_id
+  LIMIT 1
+)
+RETURNING account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
+`
+
+type ResetAccountPasswordParams struct {
+	Password      string          `json:"password"`
+	Cooldown      pgtype.Interval `json:"cooldown"`
+	Username      string          `json:"username"`
+	SenderAddress string          `json:"sender_address"`
+}
+
+// Only the owner, i.e. the sender of the first deposit, may reset.
+func (q *Queries) ResetAccountPassword(ctx context.Context, arg ResetAccountPasswordParams) (Account, error) {
+	row := q.db.QueryRow(ctx, resetAccountPassword,
+		arg.Password,
+		arg.Cooldown,
+		arg.Username,
+		arg.SenderAddress,
+	)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Password,
+		&i.Balance,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.Privilege,
+		&i.WithdrawCooldownUntil,
+	)
+	return i, err
+}
+
+const setAccountPrivilege = `-- name: SetAccountPrivilege :one
+UPDATE accounts
+SET privilege = $1
+WHERE username = $2
+RETURNING account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
+`
+
+type SetAccountPrivilegeParams struct {
+	Privilege string `json:"privilege"`
+	Username  string `json:"username"`
+}
+
+func (q *Queries) SetAccountPrivilege(ctx context.Context, arg SetAccountPrivilegeParams) (Account, error) {
+	row := q.db.QueryRow(ctx, setAccountPrivilege, arg.Privilege, arg.Username)
+	var i Account
+	err := row.Scan(
+		&i.AccountID,
+		&i.Username,
+		&i.Password,
+		&i.Balance,
+		&i.CreateTime,
+		&i.ExpireTime,
+		&i.Privilege,
+		&i.WithdrawCooldownUntil,
 	)
 	return i, err
 }
@@ -202,7 +373,7 @@ INSERT INTO accounts (
 ON CONFLICT (username) DO UPDATE SET
   balance = accounts.balance + EXCLUDED.balance,
   expire_time = accounts.expire_time + $5::interval
-RETURNING account_id, username, password, balance, create_time, expire_time, privilege
+RETURNING account_id, username, password, balance, create_time, expire_time, privilege, withdraw_cooldown_until
 `
 
 type UpsertAccountParams struct {
@@ -230,6 +401,7 @@ func (q *Queries) UpsertAccount(ctx context.Context, arg UpsertAccountParams) (A
 		&i.CreateTime,
 		&i.ExpireTime,
 		&i.Privilege,
+		&i.WithdrawCooldownUntil,
 	)
 	return i, err
 }
@@ -9,13 +9,36 @@ import (
 )
 
 type Account struct {
-	AccountID  int64              `json:"account_id"`
-	Username   string             `json:"username"`
-	Password   string             `json:"password"`
-	Balance    int64              `json:"balance"`
-	CreateTime pgtype.Timestamptz `json:"create_time"`
-	ExpireTime pgtype.Timestamptz `json:"expire_time"`
-	Privilege  string             `json:"privilege"`
+	AccountID             int64              `json:"account_id"`
+	Username              string             `json:"username"`
+	Password              string             `json:"password"`
+	Balance               int64              `json:"balance"`
+	CreateTime            pgtype.Timestamptz `json:"create_time"`
+	ExpireTime            pgtype.Timestamptz `json:"expire_time"`
+	Privilege             string             `json:"privilege"`
+	WithdrawCooldownUntil pgtype.Timestamptz `json:"withdraw_cooldown_until"`
+}
+
+type ApiKey struct {
+	ApiKeyID    int64              `json:"api_key_id"`
+	AccountID   int64              `json:"account_id"`
+	DisplayName string             `json:"display_name"`
+	KeyPrefix   string             `json:"key_prefix"`
+	KeyHash     []byte             `json:"key_hash"`
+	Scopes      []string           `json:"scopes"`
+	SpendLimit  pgtype.Int8        `json:"spend_limit"`
+	Spent       int64              `json:"spent"`
+	CreateTime  pgtype.Timestamptz `json:"create_time"`
+	ExpireTime  pgtype.Timestamptz `json:"expire_time"`
+	RevokeTime  pgtype.Timestamptz `json:"revoke_time"`
+}
+
+type BlindTokenKey struct {
+	KeyID        string             `json:"key_id"`
+	Denomination int64              `json:"denomination"`
+	Issued       int64              `json:"issued"`
+	Redeemed     int64              `json:"redeemed"`
+	ExpireTime   pgtype.Timestamptz `json:"expire_time"`
 }
 
 type Deposit struct {
@@ -23,6 +46,18 @@ type Deposit struct {
 	TransactionDigest string `json:"transaction_digest"`
 	Epoch             int64  `json:"epoch"`
 	AccountID         int64  `json:"account_id"`
+	PaymentMethod     string `json:"payment_method"`
+	SenderAddress     string `json:"sender_address"`
+}
+
+type Escrow struct {
+	Jti        string             `json:"jti"`
+	BuyerID    pgtype.Int8        `json:"buyer_id"`
+	SellerID   int64              `json:"seller_id"`
+	Amount     int64              `json:"amount"`
+	Claimed    int64              `json:"claimed"`
+	ExpireTime pgtype.Timestamptz `json:"expire_time"`
+	SettleTime pgtype.Timestamptz `json:"settle_time"`
 }
 
 type ProcessingWithdrawal struct {
@@ -34,6 +69,69 @@ type ProcessingWithdrawal struct {
 	CreateTime             pgtype.Timestamptz `json:"create_time"`
 }
 
+type QuotaToken struct {
+	Jti          string             `json:"jti"`
+	BuyerID      pgtype.Int8        `json:"buyer_id"`
+	Audience     string             `json:"audience"`
+	Quantity     int64              `json:"quantity"`
+	Consumed     int64              `json:"consumed"`
+	IssueTime    pgtype.Timestamptz `json:"issue_time"`
+	ExpireTime   pgtype.Timestamptz `json:"expire_time"`
+	RevokeTime   pgtype.Timestamptz `json:"revoke_time"`
+	RefundMode   string             `json:"refund_mode"`
+	RefundWindow pgtype.Interval    `json:"refund_window"`
+}
+
+type RefundPolicy struct {
+	AccountID    int64              `json:"account_id"`
+	RefundMode   string             `json:"refund_mode"`
+	RefundWindow pgtype.Interval    `json:"refund_window"`
+	UpdateTime   pgtype.Timestamptz `json:"update_time"`
+}
+
+type ServiceSession struct {
+	ServiceSessionID int64              `json:"service_session_id"`
+	Audience         string             `json:"audience"`
+	Quantity         int64              `json:"quantity"`
+	Consumed         int64              `json:"consumed"`
+	SessionState     string             `json:"session_state"`
+	CreateTime       pgtype.Timestamptz `json:"create_time"`
+	UpdateTime       pgtype.Timestamptz `json:"update_time"`
+	ExpireTime       pgtype.Timestamptz `json:"expire_time"`
+}
+
+type SpentBlindToken struct {
+	Nonce      []byte             `json:"nonce"`
+	KeyID      string             `json:"key_id"`
+	RedeemerID pgtype.Int8        `json:"redeemer_id"`
+	RedeemTime pgtype.Timestamptz `json:"redeem_time"`
+}
+
+type TokenPolicy struct {
+	AccountID   int64              `json:"account_id"`
+	MaxTokenTtl pgtype.Interval    `json:"max_token_ttl"`
+	UpdateTime  pgtype.Timestamptz `json:"update_time"`
+}
+
+type TransparencyLogEntry struct {
+	LeafIndex int64              `json:"leaf_index"`
+	TokenHash []byte             `json:"token_hash"`
+	Audience  string             `json:"audience"`
+	Quantity  int64              `json:"quantity"`
+	IssueTime pgtype.Timestamptz `json:"issue_time"`
+}
+
+type TransparencyLogNode struct {
+	Level     int32  `json:"level"`
+	NodeIndex int64  `json:"node_index"`
+	Hash      []byte `json:"hash"`
+}
+
+type TransparencyLogSize struct {
+	LogID    int16 `json:"log_id"`
+	TreeSize int64 `json:"tree_size"`
+}
+
 type Withdrawal struct {
 	WithdrawalID           int64              `json:"withdrawal_id"`
 	AccountID              int64              `json:"account_id"`
@@ -12,23 +12,87 @@ import (
 
 type Querier interface {
 	AddDepositRecord(ctx context.Context, arg AddDepositRecordParams) (Deposit, error)
+	AppendLogEntry(ctx context.Context, arg AppendLogEntryParams) (TransparencyLogEntry, error)
 	CancelWithdrawalById(ctx context.Context, withdrawalID int64) (CancelWithdrawalByIdRow, error)
 	ChangeBalance(ctx context.Context, arg ChangeBalanceParams) (Account, error)
 	ChangeBalanceByUsername(ctx context.Context, arg ChangeBalanceByUsernameParams) (Account, error)
+	// Fails with no rows if the spend limit would be exceeded
+	ChargeApiKey(ctx context.Context, arg ChargeApiKeyParams) (ApiKey, error)
 	// 'processing' withdrawals must wait being marked to avoid losing money
 	CleanOldWithdrawals(ctx context.Context, cleanTime pgtype.Timestamptz) ([]ProcessingWithdrawal, error)
+	// Fails with no rows if the session is not active or has too little left
+	ConsumeServiceSession(ctx context.Context, arg ConsumeServiceSessionParams) (ServiceSession, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	// Fails with no rows if the audience has no account
+	CreateEscrow(ctx context.Context, arg CreateEscrowParams) (Escrow, error)
+	CreateQuotaToken(ctx context.Context, arg CreateQuotaTokenParams) (QuotaToken, error)
+	CreateServiceSession(ctx context.Context, arg CreateServiceSessionParams) (ServiceSession, error)
+	// Fails with no rows if the balance is less than amount
+	DeductBalanceByUsername(ctx context.Context, arg DeductBalanceByUsernameParams) (Account, error)
 	DeleteInvalidAccounts(ctx context.Context) ([]int64, error)
+	DeleteSettledEscrowsOfInvalidSellers(ctx context.Context) (int64, error)
 	GetAccount(ctx context.Context, username string) (Account, error)
+	// The owner of an account is the sender of its first deposit. Later top-ups
+	// may come from anyone and do not grant access.
+	GetAccountByOwnerAddress(ctx context.Context, arg GetAccountByOwnerAddressParams) (Account, error)
+	GetApiKeyByPrefix(ctx context.Context, keyPrefix string) (ApiKey, error)
+	GetEscrowForUpdate(ctx context.Context, jti string) (Escrow, error)
+	GetLogNode(ctx context.Context, arg GetLogNodeParams) ([]byte, error)
+	GetLogSize(ctx context.Context) (int64, error)
+	GetQuotaToken(ctx context.Context, jti string) (QuotaToken, error)
+	GetRefundPolicyByUsername(ctx context.Context, username string) (RefundPolicy, error)
+	GetServiceSession(ctx context.Context, serviceSessionID int64) (ServiceSession, error)
+	GetTokenPolicyByUsername(ctx context.Context, username string) (TokenPolicy, error)
+	GetWithdrawCooldownForShare(ctx context.Context, accountID int64) (pgtype.Timestamptz, error)
+	ListApiKeys(ctx context.Context, accountID int64) ([]ApiKey, error)
+	// Tokens not redeemed before their key expired are owed to nobody any more
+	ListExpiredBlindTokenKeys(ctx context.Context) ([]BlindTokenKey, error)
+	ListLogEntries(ctx context.Context, arg ListLogEntriesParams) ([]TransparencyLogEntry, error)
+	ListPendingPriorityFees(ctx context.Context) ([]int64, error)
 	ListProcessingWithdrawals(ctx context.Context, arg ListProcessingWithdrawalsParams) ([]ProcessingWithdrawal, error)
+	ListSettleableEscrows(ctx context.Context, arg ListSettleableEscrowsParams) ([]Escrow, error)
+	ListUnsettledEscrowsOfInvalidSellers(ctx context.Context) ([]Escrow, error)
 	ListWithdrawals(ctx context.Context, arg ListWithdrawalsParams) ([]Withdrawal, error)
+	LockWallet(ctx context.Context, lockID int64) error
 	ProcessWithdrawals(ctx context.Context, arg ProcessWithdrawalsParams) ([]Withdrawal, error)
+	// Tokens of expired keys are refused anyway so their nonces can be forgotten
+	PruneSpentBlindTokens(ctx context.Context) (int64, error)
+	PutLogNode(ctx context.Context, arg PutLogNodeParams) error
 	QueryBalance(ctx context.Context, accountID int64) (Account, error)
 	QueryBalanceForShare(ctx context.Context, accountID int64) (Account, error)
+	// Fails with no rows if no token of the key is outstanding or the key expired
+	RecordBlindTokenRedeemed(ctx context.Context, keyID string) (BlindTokenKey, error)
+	RecordBlindTokensIssued(ctx context.Context, arg RecordBlindTokensIssuedParams) (BlindTokenKey, error)
+	// Tokens issued before tracking are recorded on their first redemption.
+	// Fails with no rows if less than consume is left.
+	RedeemQuotaToken(ctx context.Context, arg RedeemQuotaTokenParams) (QuotaToken, error)
+	// Fails with no rows if less than amount is unclaimed
+	ReduceEscrow(ctx context.Context, arg ReduceEscrowParams) (Escrow, error)
+	// Returns the new size. The row stays locked until the transaction ends.
+	ReserveLogIndex(ctx context.Context) (int64, error)
+	// Only the owner, i.e. the sender of the first deposit, may reset.
+	ResetAccountPassword(ctx context.Context, arg ResetAccountPasswordParams) (Account, error)
+	RevokeAccountApiKeys(ctx context.Context, accountID int64) ([]ApiKey, error)
+	RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (ApiKey, error)
+	// Fails with no rows unless the token of the buyer is unexpired and unrevoked
+	RevokeQuotaToken(ctx context.Context, arg RevokeQuotaTokenParams) (QuotaToken, error)
 	SelectCandidateWithdrawals(ctx context.Context, retrieveCount int32) ([]Withdrawal, error)
+	SetAccountPrivilege(ctx context.Context, arg SetAccountPrivilegeParams) (Account, error)
+	// Fails with no rows unless the session is in one of from_states
+	SetServiceSessionState(ctx context.Context, arg SetServiceSessionStateParams) (ServiceSession, error)
 	SetWithdrawalBatch(ctx context.Context, arg SetWithdrawalBatchParams) (ProcessingWithdrawal, error)
 	SetWithdrawalSuccess(ctx context.Context, transactionDigest string) (ProcessingWithdrawal, error)
+	SettleEscrow(ctx context.Context, jti string) (Escrow, error)
+	// Fails with no rows if the token was spent before
+	SpendBlindToken(ctx context.Context, arg SpendBlindTokenParams) (SpentBlindToken, error)
 	StartWithdrawal(ctx context.Context, arg StartWithdrawalParams) (Withdrawal, error)
+	SumPendingWithdrawals(ctx context.Context) (int64, error)
+	TopUpServiceSession(ctx context.Context, arg TopUpServiceSessionParams) (ServiceSession, error)
+	UnlockWallet(ctx context.Context, lockID int64) error
+	UpdateEscrowClaimed(ctx context.Context, arg UpdateEscrowClaimedParams) (Escrow, error)
 	UpsertAccount(ctx context.Context, arg UpsertAccountParams) (Account, error)
+	UpsertRefundPolicy(ctx context.Context, arg UpsertRefundPolicyParams) (RefundPolicy, error)
+	UpsertTokenPolicy(ctx context.Context, arg UpsertTokenPolicyParams) (TokenPolicy, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -72,6 +72,34 @@ func (q *Queries) CleanOldWithdrawals(ctx context.Context, cleanTime pgtype.Time
 	return items, nil
 }
 
+const listPendingPriorityFees = `-- name: ListPendingPriorityFees :many
+SELECT
+  priority_fee
+FROM withdrawals
+WHERE processing_withdrawal_id IS NULL
+ORDER BY priority_fee DESC, create_time
+`
+
+func (q *Queries) ListPendingPriorityFees(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listPendingPriorityFees)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var priority_fee int64
+		if err := rows.Scan(&priority_fee); err != nil {
+			return nil, err
+		}
+		items = append(items, priority_fee)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProcessingWithdrawals = `-- name: ListProcessingWithdrawals :many
 SELECT
   processing_withdrawal_id, transaction_digest, transaction_bytes_base64, total_priority_fee, withdrawal_status, create_time
@@ -158,6 +186,15 @@ func (q *Queries) ListWithdrawals(ctx context.Context, arg ListWithdrawalsParams
 	return items, nil
 }
 
+const lockWallet = `-- name: LockWallet :exec
+SELECT pg_advisory_lock($1::bigint)
+`
+
+func (q *Queries) LockWallet(ctx context.Context, lockID int64) error {
+	_, err := q.db.Exec(ctx, lockWallet, lockID)
+	return err
+}
+
 const processWithdrawals = `-- name: ProcessWithdrawals :many
 UPDATE withdrawals
   SET
@@ -330,3 +367,26 @@ func (q *Queries) StartWithdrawal(ctx context.Context, arg StartWithdrawalParams
 	)
 	return i, err
 }
+
+const sumPendingWithdrawals = `-- name: SumPendingWithdrawals :one
+SELECT
+  COALESCE(SUM(amount), 0)::bigint AS total_amount
+FROM withdrawals
+WHERE processing_withdrawal_id IS NULL
+`
+
+func (q *Queries) SumPendingWithdrawals(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, sumPendingWithdrawals)
+	var total_amount int64
+	err := row.Scan(&total_amount)
+	return total_amount, err
+}
+
+const unlockWallet = `-- name: UnlockWallet :exec
+SELECT pg_advisory_unlock($1::bigint)
+`
+
+func (q *Queries) UnlockWallet(ctx context.Context, lockID int64) error {
+	_, err := q.db.Exec(ctx, unlockWallet, lockID)
+	return err
+}
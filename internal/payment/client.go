@@ -8,50 +8,65 @@ import (
 
 	"github.com/shurcooL/graphql"
 
+	"github.com/atticplaygroup/prex/internal/signing"
 	"github.com/block-vision/sui-go-sdk/models"
-	"github.com/block-vision/sui-go-sdk/signer"
 	"github.com/block-vision/sui-go-sdk/sui"
 )
 
-type IEpochGetter interface {
-	GetCurrentEpoch(context.Context) (int, error)
-}
-
 type EpochGetter struct {
 	suiClient sui.ISuiAPI
 }
 
 type SuiPaymentClient struct {
-	SuiClient   sui.ISuiAPI
-	GqlClient   *graphql.Client
-	Signer      signer.Signer
-	epochGetter IEpochGetter
+	SuiClient      sui.ISuiAPI
+	GqlClient      *graphql.Client
+	Signer         signing.ITransactionSigner
+	DepositAddress string
+	epochGetter    IEpochGetter
 }
 
-func NewSuiPaymentClient(network string, walletSigner *signer.Signer) (*SuiPaymentClient, error) {
-	var clientUrl, graphqlClientUrl string
+// DefaultSuiEndpoints returns the public full node RPC and GraphQL URLs of a
+// well-known Sui network.
+func DefaultSuiEndpoints(network string) (string, string, error) {
 	switch network {
 	case "localnet":
-		clientUrl = "http://127.0.0.1:9000"
-		graphqlClientUrl = "http://127.0.0.1:9125"
+		return "http://127.0.0.1:9000", "http://127.0.0.1:9125", nil
 	case "mainnet":
-		clientUrl = "https://fullnode.mainnet.sui.io"
-		graphqlClientUrl = "https://sui-mainnet.mystenlabs.com/graphql"
+		return "https://fullnode.mainnet.sui.io", "https://sui-mainnet.mystenlabs.com/graphql", nil
 	case "testnet":
-		clientUrl = "https://fullnode.testnet.sui.io"
-		graphqlClientUrl = "https://sui-testnet.mystenlabs.com/graphql"
+		return "https://fullnode.testnet.sui.io", "https://sui-testnet.mystenlabs.com/graphql", nil
 	case "devnet":
-		clientUrl = "https://fullnode.devnet.sui.io"
-		graphqlClientUrl = "https://sui-devnet.mystenlabs.com/graphql"
+		return "https://fullnode.devnet.sui.io", "https://sui-devnet.mystenlabs.com/graphql", nil
 	default:
-		return nil, fmt.Errorf("unknown network %s", network)
+		return "", "", fmt.Errorf("unknown network %s", network)
+	}
+}
+
+func NewSuiPaymentClient(conf NetworkConfig, walletSigner signing.ITransactionSigner) (*SuiPaymentClient, error) {
+	clientUrl, graphqlClientUrl := conf.RpcUrl, conf.GraphqlUrl
+	if clientUrl == "" || graphqlClientUrl == "" {
+		defaultClientUrl, defaultGraphqlClientUrl, err := DefaultSuiEndpoints(conf.Network)
+		if err != nil {
+			return nil, fmt.Errorf("no endpoints configured: %v", err)
+		}
+		if clientUrl == "" {
+			clientUrl = defaultClientUrl
+		}
+		if graphqlClientUrl == "" {
+			graphqlClientUrl = defaultGraphqlClientUrl
+		}
+	}
+	depositAddress := conf.DepositAddress
+	if depositAddress == "" {
+		depositAddress = walletSigner.GetAddress()
 	}
 
 	suiClient := sui.NewSuiClient(clientUrl)
 	ret := SuiPaymentClient{
-		SuiClient: suiClient,
-		GqlClient: graphql.NewClient(graphqlClientUrl, nil),
-		Signer:    *walletSigner,
+		SuiClient:      suiClient,
+		GqlClient:      graphql.NewClient(graphqlClientUrl, nil),
+		Signer:         walletSigner,
+		DepositAddress: depositAddress,
 	}
 	ret.epochGetter = &EpochGetter{suiClient: suiClient}
 	return &ret, nil
@@ -77,15 +92,6 @@ func (c *EpochGetter) GetCurrentEpoch(ctx context.Context) (int, error) {
 	return epochNum, nil
 }
 
-type TransactionStatus int
-
-const (
-	UNKNOWN TransactionStatus = 0
-	SUCCESS TransactionStatus = 1
-	FAIL    TransactionStatus = 2
-	PENDING TransactionStatus = 3
-)
-
 func (c *SuiPaymentClient) CheckTransactionStatus(
 	ctx context.Context,
 	digest string,
@@ -104,16 +110,42 @@ func (c *SuiPaymentClient) CheckTransactionStatus(
 	return SUCCESS, nil
 }
 
-type TransferInfo struct {
-	Address string
-	Amount  int64
+func (c *SuiPaymentClient) GetAddress() string {
+	return c.DepositAddress
+}
+
+func (c *SuiPaymentClient) GetHotAddress() string {
+	return c.Signer.GetAddress()
+}
+
+func (c *SuiPaymentClient) GetBalance(ctx context.Context) (int64, error) {
+	balance, err := c.SuiClient.SuiXGetBalance(ctx, models.SuiXGetBalanceRequest{
+		Owner:    c.Signer.GetAddress(),
+		CoinType: "0x2::sui::SUI",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %v", err)
+	}
+	totalBalance, err := strconv.ParseInt(balance.TotalBalance, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse balance %s: %v", balance.TotalBalance, err)
+	}
+	return totalBalance, nil
+}
+
+func (c *SuiPaymentClient) GetReferenceGasPrice(ctx context.Context) (int64, error) {
+	gasPrice, err := c.SuiClient.SuiXGetReferenceGasPrice(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reference gas price: %v", err)
+	}
+	return int64(gasPrice), nil
 }
 
 func (c *SuiPaymentClient) PrepareWithdrawTransaction(
 	ctx context.Context, info []TransferInfo, gasBudget int64,
-) (*models.TxnMetaData, error) {
+) (*WithdrawTransaction, error) {
 	coins, err := c.SuiClient.SuiXGetAllCoins(ctx, models.SuiXGetAllCoinsRequest{
-		Owner: c.Signer.Address,
+		Owner: c.Signer.GetAddress(),
 	})
 	if err != nil {
 		return nil, err
@@ -136,7 +168,7 @@ func (c *SuiPaymentClient) PrepareWithdrawTransaction(
 		return nil, fmt.Errorf("gas price %d is higher than budget %d", gasPrice, gasBudget)
 	}
 	batchTx, err := c.SuiClient.PaySui(ctx, models.PaySuiRequest{
-		Signer:      c.Signer.Address,
+		Signer:      c.Signer.GetAddress(),
 		SuiObjectId: myCoins,
 		Recipient:   recipients,
 		Amount:      splitAmounts,
@@ -146,40 +178,42 @@ func (c *SuiPaymentClient) PrepareWithdrawTransaction(
 	if err != nil {
 		return nil, err
 	}
-	return &batchTx, nil
-}
-
-func (c *SuiPaymentClient) Withdraw(
-	ctx context.Context, batchTx *models.TxnMetaData,
-) (string, error) {
 	dryRunResult, err := c.SuiClient.SuiDryRunTransactionBlock(
 		ctx, models.SuiDryRunTransactionBlockRequest{
 			TxBytes: batchTx.TxBytes,
 		})
+	if err != nil || dryRunResult.Effects.TransactionDigest == "" {
+		return nil, fmt.Errorf("failed to calculate transaction digest: %v", err)
+	}
+	return &WithdrawTransaction{
+		TxBytes: batchTx.TxBytes,
+		Digest:  dryRunResult.Effects.TransactionDigest,
+	}, nil
+}
+
+func (c *SuiPaymentClient) Withdraw(
+	ctx context.Context, batchTx *WithdrawTransaction,
+) (string, error) {
+	signature, err := c.Signer.SignTransaction(ctx, batchTx.TxBytes)
 	if err != nil {
-		return "nil", fmt.Errorf("failed to calculate transaction digest: %v", err)
+		return "", fmt.Errorf("failed to sign transaction: %v", err)
 	}
-	rsp, err := c.SuiClient.SignAndExecuteTransactionBlock(
-		ctx, models.SignAndExecuteTransactionBlockRequest{
-			TxnMetaData: *batchTx,
-			PriKey:      c.Signer.PriKey,
+	rsp, err := c.SuiClient.SuiExecuteTransactionBlock(
+		ctx, models.SuiExecuteTransactionBlockRequest{
+			TxBytes:     batchTx.TxBytes,
+			Signature:   []string{signature},
 			RequestType: "WaitForLocalExecution",
 		})
 	if err != nil {
 		return "", err
 	}
 	// Sanity check
-	if dryRunResult.Effects.TransactionDigest != rsp.Digest {
-		return "", fmt.Errorf("digest mismatch with dry run: %s vs %s", rsp.Digest, dryRunResult.Effects.TransactionDigest)
+	if batchTx.Digest != rsp.Digest {
+		return "", fmt.Errorf("digest mismatch with dry run: %s vs %s", rsp.Digest, batchTx.Digest)
 	}
 	return rsp.Digest, nil
 }
 
-type DepositTransferInfo struct {
-	*TransferInfo
-	Epoch int64
-}
-
 func (c *SuiPaymentClient) CheckDeposit(ctx context.Context, digest string, maxGapEpochs int) (*DepositTransferInfo, error) {
 	currentEpoch, err := c.epochGetter.GetCurrentEpoch(ctx)
 	if err != nil {
@@ -224,7 +258,7 @@ func (c *SuiPaymentClient) CheckDeposit(ctx context.Context, digest string, maxG
 		return nil, fmt.Errorf("deposit too late")
 	}
 	for _, node := range q.TransactionBlock.Effects.BalanceChanges.Nodes {
-		if node.Amount != "" && node.Owner.Address == c.Signer.Address {
+		if node.Amount != "" && node.Owner.Address == c.DepositAddress {
 			amount, err := strconv.Atoi(node.Amount)
 			if err != nil {
 				return nil, fmt.Errorf("failed to parse amount: %s", node.Amount)
@@ -241,4 +275,3 @@ func (c *SuiPaymentClient) CheckDeposit(ctx context.Context, digest string, maxG
 	}
 	return nil, fmt.Errorf("no valid transaction found")
 }
-
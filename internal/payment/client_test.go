@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/atticplaygroup/prex/internal/payment"
+	"github.com/atticplaygroup/prex/internal/signing"
 	"github.com/atticplaygroup/prex/internal/utils"
 	"github.com/block-vision/sui-go-sdk/sui"
 
@@ -86,7 +87,8 @@ var _ = Describe("Transfer with Sui", Label("sui"), func() {
 	if suiNetwork == "" {
 		suiNetwork = "devnet"
 	}
-	client, err := payment.NewSuiPaymentClient(suiNetwork, platform)
+	client, err := payment.NewSuiPaymentClient(
+		payment.NetworkConfig{Network: suiNetwork}, signing.NewMemoryTransactionSigner(platform))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -110,7 +112,7 @@ var _ = Describe("Transfer with Sui", Label("sui"), func() {
 		Expect(len(coins.Data)).To(Equal(1))
 
 		digest, err := transferAndWait(
-			ctx, client.SuiClient, senderMnemonic, client.Signer.Address,
+			ctx, client.SuiClient, senderMnemonic, client.Signer.GetAddress(),
 			coins.Data[0].CoinObjectId, amount,
 		)
 		Expect(err).To(BeNil())
@@ -126,7 +128,8 @@ var _ = Describe("Transfer with Sui", Label("sui"), func() {
 
 	It("should return pending not found withdrawal", func() {
 		ctx := context.Background()
-		client1, err := payment.NewSuiPaymentClient(suiNetwork, platform)
+		client1, err := payment.NewSuiPaymentClient(
+			payment.NetworkConfig{Network: suiNetwork}, signing.NewMemoryTransactionSigner(platform))
 		client1.SetEpochGetter(&MockEpochGetter{})
 		Expect(err).To(BeNil())
 		status, err := client1.CheckTransactionStatus(
@@ -146,16 +149,11 @@ var _ = Describe("Transfer with Sui", Label("sui"), func() {
 			ctx, []payment.TransferInfo{transferInfo}, 8_000_000,
 		)
 		Expect(err).To(BeNil())
-		dryRunResult, err := client.SuiClient.SuiDryRunTransactionBlock(
-			ctx, models.SuiDryRunTransactionBlockRequest{
-				TxBytes: withdrawTx.TxBytes,
-			})
-		Expect(err).To(BeNil())
-		digest := dryRunResult.Effects.TransactionDigest
+		digest := withdrawTx.Digest
 		Expect(digest).To(Not(Equal("")))
 		txResult, err := client.SuiClient.SignAndExecuteTransactionBlock(
 			ctx, models.SignAndExecuteTransactionBlockRequest{
-				TxnMetaData: *withdrawTx,
+				TxnMetaData: models.TxnMetaData{TxBytes: withdrawTx.TxBytes},
 				PriKey:      platform.PriKey,
 				RequestType: "WaitForLocalExecution",
 			})
@@ -170,7 +168,7 @@ var _ = Describe("Transfer with Sui", Label("sui"), func() {
 
 	It("should withdraw multiple recipients", func() {
 		ctx := context.Background()
-		err = utils.RequestSuiFromFaucet(suiNetwork, client.Signer.Address)
+		err = utils.RequestSuiFromFaucet(suiNetwork, client.Signer.GetAddress())
 		Expect(err).To(BeNil())
 
 		transferInfo := make([]payment.TransferInfo, 0)
@@ -202,6 +200,6 @@ var _ = Describe("Transfer with Sui", Label("sui"), func() {
 		time.Sleep(5 * time.Second)
 		err = client.GqlClient.Query(ctx, &q, variables)
 		Expect(err).To(BeNil())
-		Expect(q.TransactionBlock.Sender.Address).To(Equal(client.Signer.Address))
+		Expect(q.TransactionBlock.Sender.Address).To(Equal(client.Signer.GetAddress()))
 	})
 })
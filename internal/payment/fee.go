@@ -0,0 +1,82 @@
+package payment
+
+import (
+	"cmp"
+	"slices"
+)
+
+var FEE_PERCENTILES = []int32{10, 25, 50, 75, 90}
+
+type FeePercentile struct {
+	Percentile  int32
+	PriorityFee int64
+}
+
+type FeeEstimate struct {
+	ReferenceGasPrice int64
+	QueueDepth        int64
+	FeePercentiles    []FeePercentile
+	// PredictedBatch is the 0-based index of the batch a withdrawal with the
+	// queried fee would be processed in, assuming no further withdrawals.
+	PredictedBatch int64
+	// PredictedBatchFee is the summed priority fee of that batch including
+	// the queried withdrawal. The batch fails if it is below ReferenceGasPrice.
+	PredictedBatchFee int64
+	// NextBatchPriorityFee is the lowest fee landing in the next batch while
+	// keeping its summed fee at least ReferenceGasPrice.
+	NextBatchPriorityFee int64
+}
+
+// EstimateWithdrawFee predicts how a new withdrawal paying priorityFee is
+// batched. Batches take the highest fees first and ties in order of arrival,
+// so the new withdrawal queues behind all pending ones with fees not lower.
+func EstimateWithdrawFee(
+	pendingFees []int64, batchSize int, referenceGasPrice int64, priorityFee int64,
+) *FeeEstimate {
+	fees := slices.Clone(pendingFees)
+	slices.SortFunc(fees, func(a, b int64) int {
+		return cmp.Compare(b, a)
+	})
+	ret := &FeeEstimate{
+		ReferenceGasPrice: referenceGasPrice,
+		QueueDepth:        int64(len(fees)),
+		FeePercentiles:    make([]FeePercentile, 0),
+	}
+	if len(fees) > 0 {
+		for _, percentile := range FEE_PERCENTILES {
+			// Nearest rank over ascending fees
+			rank := (int(percentile)*len(fees) + 99) / 100
+			ret.FeePercentiles = append(ret.FeePercentiles, FeePercentile{
+				Percentile:  percentile,
+				PriorityFee: fees[len(fees)-max(rank, 1)],
+			})
+		}
+	}
+	if batchSize <= 0 {
+		return ret
+	}
+
+	// Joining the next batch displaces its lowest fee if it is full
+	kept := fees[:min(batchSize-1, len(fees))]
+	nextBatchFee := int64(1)
+	if len(fees) >= batchSize {
+		nextBatchFee = fees[batchSize-1] + 1
+	}
+	keptFee := int64(0)
+	for _, fee := range kept {
+		keptFee += fee
+	}
+	ret.NextBatchPriorityFee = max(nextBatchFee, referenceGasPrice-keptFee)
+
+	position := 0
+	for position < len(fees) && fees[position] >= priorityFee {
+		position++
+	}
+	ret.PredictedBatch = int64(position / batchSize)
+	batchStart := position - position%batchSize
+	queue := slices.Insert(fees, position, priorityFee)
+	for _, fee := range queue[batchStart:min(batchStart+batchSize, len(queue))] {
+		ret.PredictedBatchFee += fee
+	}
+	return ret
+}
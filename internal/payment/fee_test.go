@@ -0,0 +1,45 @@
+package payment_test
+
+import (
+	"github.com/atticplaygroup/prex/internal/payment"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Withdraw fee estimation", Label("simulated"), func() {
+	It("should handle an empty queue", func() {
+		estimate := payment.EstimateWithdrawFee(nil, 3, 1_000, 10)
+		Expect(estimate.QueueDepth).To(BeEquivalentTo(0))
+		Expect(estimate.FeePercentiles).To(BeEmpty())
+		Expect(estimate.PredictedBatch).To(BeEquivalentTo(0))
+		Expect(estimate.PredictedBatchFee).To(BeEquivalentTo(10))
+		Expect(estimate.NextBatchPriorityFee).To(BeEquivalentTo(1_000))
+	})
+
+	It("should predict batches by fee", func() {
+		pendingFees := []int64{100, 500, 300, 200, 400, 600, 700}
+		estimate := payment.EstimateWithdrawFee(pendingFees, 3, 1_000, 350)
+		Expect(estimate.QueueDepth).To(BeEquivalentTo(7))
+		Expect(estimate.FeePercentiles).To(ContainElements(
+			payment.FeePercentile{Percentile: 10, PriorityFee: 100},
+			payment.FeePercentile{Percentile: 50, PriorityFee: 400},
+			payment.FeePercentile{Percentile: 90, PriorityFee: 700},
+		))
+		// Queue is 700 600 500 | 400 350 300 | 200 100
+		Expect(estimate.PredictedBatch).To(BeEquivalentTo(1))
+		Expect(estimate.PredictedBatchFee).To(BeEquivalentTo(1_050))
+		// Must beat 500 to displace it from the first batch
+		Expect(estimate.NextBatchPriorityFee).To(BeEquivalentTo(501))
+
+		By("tying with a queued fee")
+		estimate = payment.EstimateWithdrawFee(pendingFees, 3, 1_000, 500)
+		Expect(estimate.PredictedBatch).To(BeEquivalentTo(1))
+	})
+
+	It("should cover the reference gas price in the next batch", func() {
+		estimate := payment.EstimateWithdrawFee([]int64{100, 50}, 3, 1_000, 1)
+		Expect(estimate.PredictedBatch).To(BeEquivalentTo(0))
+		Expect(estimate.PredictedBatchFee).To(BeEquivalentTo(151))
+		Expect(estimate.NextBatchPriorityFee).To(BeEquivalentTo(850))
+	})
+})
@@ -0,0 +1,85 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/atticplaygroup/prex/internal/signing"
+)
+
+const (
+	// SimulatedNetwork selects the in-process ledger instead of a Sui node.
+	SimulatedNetwork = "simulated"
+)
+
+type IEpochGetter interface {
+	GetCurrentEpoch(context.Context) (int, error)
+}
+
+// IPaymentClient is what the exchange needs from a payment backend: verifying
+// deposits into the platform wallet and paying out batched withdrawals.
+type IPaymentClient interface {
+	IEpochGetter
+	// GetAddress returns the platform wallet address users deposit into.
+	GetAddress() string
+	// GetHotAddress returns the address of the wallet paying out withdrawals.
+	GetHotAddress() string
+	// GetBalance returns the balance of the wallet paying out withdrawals.
+	GetBalance(ctx context.Context) (int64, error)
+	GetReferenceGasPrice(ctx context.Context) (int64, error)
+	CheckDeposit(ctx context.Context, digest string, maxGapEpochs int) (*DepositTransferInfo, error)
+	// PrepareWithdrawTransaction builds an unsigned transaction paying all
+	// recipients. Its digest is known before execution so it can be recorded first.
+	PrepareWithdrawTransaction(ctx context.Context, info []TransferInfo, gasBudget int64) (*WithdrawTransaction, error)
+	Withdraw(ctx context.Context, batchTx *WithdrawTransaction) (string, error)
+	CheckTransactionStatus(ctx context.Context, digest string) (TransactionStatus, error)
+}
+
+type TransactionStatus int
+
+const (
+	UNKNOWN TransactionStatus = 0
+	SUCCESS TransactionStatus = 1
+	FAIL    TransactionStatus = 2
+	PENDING TransactionStatus = 3
+)
+
+type TransferInfo struct {
+	Address string
+	Amount  int64
+}
+
+type DepositTransferInfo struct {
+	*TransferInfo
+	Epoch int64
+}
+
+type WithdrawTransaction struct {
+	TxBytes string
+	Digest  string
+}
+
+// NetworkConfig selects a network and optionally overrides its endpoints.
+// Empty URLs fall back to the public endpoints of well-known networks and an
+// empty deposit address falls back to the wallet signer address.
+type NetworkConfig struct {
+	Network        string
+	RpcUrl         string
+	GraphqlUrl     string
+	DepositAddress string
+}
+
+func NewPaymentClient(conf NetworkConfig, walletSigner signing.ITransactionSigner) (IPaymentClient, error) {
+	if conf.Network == SimulatedNetwork {
+		depositAddress := conf.DepositAddress
+		if depositAddress == "" {
+			depositAddress = walletSigner.GetAddress()
+		}
+		return NewSimulatedPaymentClient(depositAddress), nil
+	}
+	client, err := NewSuiPaymentClient(conf, walletSigner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize sui client for %s: %v", conf.Network, err)
+	}
+	return client, nil
+}
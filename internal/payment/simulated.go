@@ -0,0 +1,248 @@
+package payment
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	SIMULATED_REFERENCE_GAS_PRICE int64 = 1_000
+)
+
+// SimulatedPaymentClient keeps an in-memory ledger that behaves like the
+// subset of Sui used by Prex. Transactions are settled instantly, so the whole
+// deposit, buy and withdraw flow can run without a Sui localnet.
+type SimulatedPaymentClient struct {
+	mu                sync.Mutex
+	address           string
+	epoch             int
+	referenceGasPrice int64
+	balances          map[string]int64
+	transactions      map[string]*simulatedTransaction
+}
+
+type simulatedTransaction struct {
+	Sender    string         `json:"sender"`
+	Transfers []TransferInfo `json:"transfers"`
+	GasFee    int64          `json:"gas_fee"`
+	Nonce     string         `json:"nonce"`
+	Epoch     int            `json:"-"`
+}
+
+func NewSimulatedPaymentClient(address string) *SimulatedPaymentClient {
+	return &SimulatedPaymentClient{
+		address:           address,
+		referenceGasPrice: SIMULATED_REFERENCE_GAS_PRICE,
+		balances:          make(map[string]int64),
+		transactions:      make(map[string]*simulatedTransaction),
+	}
+}
+
+func (c *SimulatedPaymentClient) GetAddress() string {
+	return c.address
+}
+
+// GetHotAddress returns the platform address as the simulated ledger pays
+// withdrawals from the deposit address.
+func (c *SimulatedPaymentClient) GetHotAddress() string {
+	return c.address
+}
+
+func (c *SimulatedPaymentClient) GetCurrentEpoch(ctx context.Context) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch, nil
+}
+
+// AdvanceEpoch moves the simulated chain forward by n epochs.
+func (c *SimulatedPaymentClient) AdvanceEpoch(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch += n
+}
+
+func (c *SimulatedPaymentClient) SetReferenceGasPrice(price int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.referenceGasPrice = price
+}
+
+func (c *SimulatedPaymentClient) GetReferenceGasPrice(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.referenceGasPrice, nil
+}
+
+// normalizeAddress keys balances the same whether or not an address has the
+// 0x prefix, which withdrawals omit.
+func normalizeAddress(address string) string {
+	return "0x" + strings.TrimPrefix(strings.ToLower(address), "0x")
+}
+
+// Mint credits an address out of thin air, like a faucet.
+func (c *SimulatedPaymentClient) Mint(address string, amount int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.balances[normalizeAddress(address)] += amount
+}
+
+func (c *SimulatedPaymentClient) GetBalanceOf(address string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.balances[normalizeAddress(address)]
+}
+
+func (c *SimulatedPaymentClient) GetBalance(ctx context.Context) (int64, error) {
+	return c.GetBalanceOf(c.address), nil
+}
+
+// Transfer executes a gasless transfer between two addresses and returns its
+// digest. Use it to simulate a user depositing into the platform wallet.
+func (c *SimulatedPaymentClient) Transfer(
+	ctx context.Context, sender string, recipient string, amount int64,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tx := &simulatedTransaction{
+		Sender:    sender,
+		Transfers: []TransferInfo{{Address: recipient, Amount: amount}},
+		Nonce:     uuid.NewString(),
+	}
+	txBytes, digest, err := encodeSimulatedTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+	return c.execute(txBytes, digest)
+}
+
+func (c *SimulatedPaymentClient) CheckDeposit(
+	ctx context.Context, digest string, maxGapEpochs int,
+) (*DepositTransferInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tx, ok := c.transactions[digest]
+	if !ok {
+		return nil, fmt.Errorf("no transaction found for digest")
+	}
+	if tx.Epoch+maxGapEpochs < c.epoch {
+		return nil, fmt.Errorf("deposit too late")
+	}
+	for _, transfer := range tx.Transfers {
+		if transfer.Amount != 0 && normalizeAddress(transfer.Address) == normalizeAddress(c.address) {
+			return &DepositTransferInfo{
+				TransferInfo: &TransferInfo{
+					Amount:  transfer.Amount,
+					Address: tx.Sender,
+				},
+				Epoch: int64(tx.Epoch),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("no valid transaction found")
+}
+
+func (c *SimulatedPaymentClient) PrepareWithdrawTransaction(
+	ctx context.Context, info []TransferInfo, gasBudget int64,
+) (*WithdrawTransaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.referenceGasPrice > gasBudget {
+		return nil, fmt.Errorf("gas price %d is higher than budget %d", c.referenceGasPrice, gasBudget)
+	}
+	txBytes, digest, err := encodeSimulatedTransaction(&simulatedTransaction{
+		Sender:    c.address,
+		Transfers: info,
+		GasFee:    c.referenceGasPrice,
+		Nonce:     uuid.NewString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WithdrawTransaction{
+		TxBytes: txBytes,
+		Digest:  digest,
+	}, nil
+}
+
+func (c *SimulatedPaymentClient) Withdraw(
+	ctx context.Context, batchTx *WithdrawTransaction,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	digest, err := c.execute(batchTx.TxBytes, batchTx.Digest)
+	if err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+func (c *SimulatedPaymentClient) CheckTransactionStatus(
+	ctx context.Context,
+	digest string,
+) (TransactionStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.transactions[digest]; ok {
+		return SUCCESS, nil
+	}
+	return PENDING, nil
+}
+
+// execute must be called with c.mu held. Replaying an executed transaction is
+// a no-op, mirroring the idempotency of Sui transactions.
+func (c *SimulatedPaymentClient) execute(txBytes string, digest string) (string, error) {
+	if _, ok := c.transactions[digest]; ok {
+		return digest, nil
+	}
+	rawTx, err := base64.StdEncoding.DecodeString(txBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode transaction bytes: %v", err)
+	}
+	if expected := simulatedDigest(rawTx); expected != digest {
+		return "", fmt.Errorf("digest mismatch: %s vs %s", digest, expected)
+	}
+	var tx simulatedTransaction
+	if err := json.Unmarshal(rawTx, &tx); err != nil {
+		return "", fmt.Errorf("failed to parse transaction: %v", err)
+	}
+	total := tx.GasFee
+	for _, transfer := range tx.Transfers {
+		if transfer.Amount < 0 {
+			return "", fmt.Errorf("negative transfer amount %d", transfer.Amount)
+		}
+		total += transfer.Amount
+	}
+	sender := normalizeAddress(tx.Sender)
+	if c.balances[sender] < total {
+		return "", fmt.Errorf(
+			"insufficient balance of %s: %d < %d", tx.Sender, c.balances[sender], total)
+	}
+	c.balances[sender] -= total
+	for _, transfer := range tx.Transfers {
+		c.balances[normalizeAddress(transfer.Address)] += transfer.Amount
+	}
+	tx.Epoch = c.epoch
+	c.transactions[digest] = &tx
+	return digest, nil
+}
+
+func encodeSimulatedTransaction(tx *simulatedTransaction) (string, string, error) {
+	rawTx, err := json.Marshal(tx)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(rawTx), simulatedDigest(rawTx), nil
+}
+
+func simulatedDigest(rawTx []byte) string {
+	hash := blake2b.Sum256(rawTx)
+	return base58.Encode(hash[:])
+}
@@ -0,0 +1,112 @@
+package payment_test
+
+import (
+	"context"
+
+	"github.com/atticplaygroup/prex/internal/payment"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Simulated payment client", Label("simulated"), func() {
+	platformAddress := "0xe789fb3f9e6e0736b648f3f33ff60bc0e4583583b2142cb2665bcc520635aac0"
+	userAddress := "0x4f89910d450a3654e82bc3f573e24dfcbbeed23cb3b87de4883e890bfd952473"
+	recipientAddress := "0xc228a949decc98affe62522cf3f56db12686d068fab82fab605c241cafe5197c"
+
+	var client *payment.SimulatedPaymentClient
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		client = payment.NewSimulatedPaymentClient(platformAddress)
+		client.Mint(userAddress, 10_000_000)
+	})
+
+	It("should discover successful deposit", func() {
+		digest, err := client.Transfer(ctx, userAddress, platformAddress, 1_000_000)
+		Expect(err).To(BeNil())
+		Expect(digest).To(MatchRegexp("^[A-Za-z0-9]{43,44}$"))
+
+		receipt, err := client.CheckDeposit(ctx, digest, 100)
+		Expect(err).To(BeNil())
+		Expect(receipt.Address).To(Equal(userAddress))
+		Expect(receipt.Amount).To(BeEquivalentTo(1_000_000))
+		Expect(client.GetBalanceOf(platformAddress)).To(BeEquivalentTo(1_000_000))
+	})
+
+	It("should reject unknown and late deposits", func() {
+		_, err := client.CheckDeposit(ctx, "CeVpDXKKU3Gs89efej9pKiYYQyTzifE2BDxWwquUaUht", 100)
+		Expect(err).To(MatchError(ContainSubstring("no transaction found")))
+
+		digest, err := client.Transfer(ctx, userAddress, platformAddress, 1_000_000)
+		Expect(err).To(BeNil())
+		client.AdvanceEpoch(11)
+		_, err = client.CheckDeposit(ctx, digest, 10)
+		Expect(err).To(MatchError(ContainSubstring("deposit too late")))
+	})
+
+	It("should reject deposits not paying the platform", func() {
+		digest, err := client.Transfer(ctx, userAddress, recipientAddress, 1_000_000)
+		Expect(err).To(BeNil())
+		_, err = client.CheckDeposit(ctx, digest, 100)
+		Expect(err).To(MatchError(ContainSubstring("no valid transaction found")))
+	})
+
+	It("should withdraw multiple recipients", func() {
+		_, err := client.Transfer(ctx, userAddress, platformAddress, 5_000_000)
+		Expect(err).To(BeNil())
+
+		withdrawTx, err := client.PrepareWithdrawTransaction(ctx, []payment.TransferInfo{
+			{Address: userAddress, Amount: 1_000_000},
+			{Address: recipientAddress, Amount: 2_000_000},
+		}, 100_000)
+		Expect(err).To(BeNil())
+
+		status, err := client.CheckTransactionStatus(ctx, withdrawTx.Digest)
+		Expect(err).To(BeNil())
+		Expect(status).To(Equal(payment.PENDING))
+
+		digest, err := client.Withdraw(ctx, withdrawTx)
+		Expect(err).To(BeNil())
+		Expect(digest).To(Equal(withdrawTx.Digest))
+
+		status, err = client.CheckTransactionStatus(ctx, digest)
+		Expect(err).To(BeNil())
+		Expect(status).To(Equal(payment.SUCCESS))
+		Expect(client.GetBalanceOf(recipientAddress)).To(BeEquivalentTo(2_000_000))
+		Expect(client.GetBalanceOf(platformAddress)).To(BeEquivalentTo(
+			2_000_000 - payment.SIMULATED_REFERENCE_GAS_PRICE))
+
+		By("replaying the same transaction")
+		_, err = client.Withdraw(ctx, withdrawTx)
+		Expect(err).To(BeNil())
+		Expect(client.GetBalanceOf(recipientAddress)).To(BeEquivalentTo(2_000_000))
+	})
+
+	It("should pay withdrawals to addresses without the 0x prefix", func() {
+		_, err := client.Transfer(ctx, userAddress, platformAddress, 5_000_000)
+		Expect(err).To(BeNil())
+		withdrawTx, err := client.PrepareWithdrawTransaction(ctx, []payment.TransferInfo{
+			{Address: recipientAddress[2:], Amount: 1_000_000},
+		}, 100_000)
+		Expect(err).To(BeNil())
+		_, err = client.Withdraw(ctx, withdrawTx)
+		Expect(err).To(BeNil())
+		Expect(client.GetBalanceOf(recipientAddress)).To(BeEquivalentTo(1_000_000))
+	})
+
+	It("should fail to withdraw more than the platform holds", func() {
+		withdrawTx, err := client.PrepareWithdrawTransaction(ctx, []payment.TransferInfo{
+			{Address: recipientAddress, Amount: 1_000_000},
+		}, 100_000)
+		Expect(err).To(BeNil())
+		_, err = client.Withdraw(ctx, withdrawTx)
+		Expect(err).To(MatchError(ContainSubstring("insufficient balance")))
+	})
+
+	It("should reject gas budget below reference gas price", func() {
+		_, err := client.PrepareWithdrawTransaction(ctx, []payment.TransferInfo{
+			{Address: recipientAddress, Amount: 1_000_000},
+		}, payment.SIMULATED_REFERENCE_GAS_PRICE-1)
+		Expect(err).To(MatchError(ContainSubstring("higher than budget")))
+	})
+})
@@ -0,0 +1,224 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_SWEEP_GAS_BUDGET int64 = 10_000_000
+)
+
+// IPendingWithdrawalGetter reports the total amount of withdrawals requested
+// but not yet batched into a transaction.
+type IPendingWithdrawalGetter interface {
+	SumPendingWithdrawals(ctx context.Context) (int64, error)
+}
+
+// IWalletLocker serializes the transactions spending from the hot wallet so
+// that a sweep never moves funds a withdrawal batch is paying out.
+type IWalletLocker interface {
+	// AcquireWalletLock blocks until the hot wallet is free and returns the
+	// function releasing it.
+	AcquireWalletLock(ctx context.Context) (func(), error)
+}
+
+// WalletConfig splits funds between the hot wallet signing withdrawals and a
+// watch-only cold address. All amounts are in the smallest coin unit.
+type WalletConfig struct {
+	// ColdAddress receives sweeps. Sweeping is disabled if it is empty.
+	ColdAddress string
+	// Float is the amount left in the hot wallet after a sweep on top of
+	// pending withdrawals.
+	Float int64
+	// HighWaterMark triggers a sweep when the hot balance exceeds it.
+	HighWaterMark int64
+	// LowWaterMark raises a refill alert when the hot balance minus pending
+	// withdrawals drops below it.
+	LowWaterMark   int64
+	SweepGasBudget int64
+}
+
+func (c *WalletConfig) Validate() error {
+	if c.Float < 0 || c.HighWaterMark < 0 || c.LowWaterMark < 0 || c.SweepGasBudget < 0 {
+		return fmt.Errorf("expect wallet marks to be non negative")
+	}
+	if c.ColdAddress == "" {
+		return nil
+	}
+	if c.LowWaterMark > c.Float || c.Float > c.HighWaterMark {
+		return fmt.Errorf(
+			"expect low water mark %d <= float %d <= high water mark %d",
+			c.LowWaterMark, c.Float, c.HighWaterMark,
+		)
+	}
+	return nil
+}
+
+type WalletStatus struct {
+	HotAddress       string
+	ColdAddress      string
+	HotBalance       int64
+	PendingWithdraws int64
+	RefillNeeded     bool
+	// RefillAmount brings the hot wallet back to its float after paying all
+	// pending withdrawals.
+	RefillAmount    int64
+	LastSweepDigest string
+	LastSweepTime   time.Time
+	LastCheckTime   time.Time
+}
+
+// HotWalletManager runs the sweeper and the refill monitor of a hot wallet.
+type HotWalletManager struct {
+	client  IPaymentClient
+	pending IPendingWithdrawalGetter
+	locker  IWalletLocker
+	conf    WalletConfig
+	// OnRefillNeeded is called every time a check finds the hot wallet below
+	// its low-water mark. Defaults to logging a warning.
+	OnRefillNeeded func(ctx context.Context, status WalletStatus)
+
+	mu     sync.Mutex
+	status WalletStatus
+}
+
+func NewHotWalletManager(
+	client IPaymentClient, pending IPendingWithdrawalGetter, locker IWalletLocker, conf WalletConfig,
+) (*HotWalletManager, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	if conf.SweepGasBudget == 0 {
+		conf.SweepGasBudget = DEFAULT_SWEEP_GAS_BUDGET
+	}
+	return &HotWalletManager{
+		client:  client,
+		pending: pending,
+		locker:  locker,
+		conf:    conf,
+		OnRefillNeeded: func(ctx context.Context, status WalletStatus) {
+			slog.WarnContext(ctx, fmt.Sprintf(
+				"hot wallet %s refill needed: balance %d, pending withdrawals %d, refill %d",
+				status.HotAddress, status.HotBalance, status.PendingWithdraws, status.RefillAmount,
+			))
+		},
+		status: WalletStatus{
+			HotAddress:  client.GetHotAddress(),
+			ColdAddress: conf.ColdAddress,
+		},
+	}, nil
+}
+
+func (m *HotWalletManager) GetConfig() WalletConfig {
+	return m.conf
+}
+
+func (m *HotWalletManager) GetStatus() WalletStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+func (m *HotWalletManager) refresh(ctx context.Context) (int64, int64, error) {
+	balance, err := m.client.GetBalance(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	pending, err := m.pending.SumPendingWithdrawals(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to sum pending withdrawals: %v", err)
+	}
+	return balance, pending, nil
+}
+
+// Check refreshes the wallet status and fires the refill alert if pending
+// withdrawals would drain the hot wallet below its low-water mark.
+func (m *HotWalletManager) Check(ctx context.Context) (WalletStatus, error) {
+	balance, pending, err := m.refresh(ctx)
+	if err != nil {
+		return WalletStatus{}, err
+	}
+	m.mu.Lock()
+	m.status.HotBalance = balance
+	m.status.PendingWithdraws = pending
+	m.status.LastCheckTime = time.Now()
+	available := balance - pending
+	m.status.RefillNeeded = available < m.conf.LowWaterMark
+	m.status.RefillAmount = 0
+	if m.status.RefillNeeded {
+		m.status.RefillAmount = max(m.conf.Float, m.conf.LowWaterMark) - available
+	}
+	status := m.status
+	m.mu.Unlock()
+
+	if status.RefillNeeded && m.OnRefillNeeded != nil {
+		m.OnRefillNeeded(ctx, status)
+	}
+	return status, nil
+}
+
+// Sweep moves everything above the float and pending withdrawals to the cold
+// address once the hot balance exceeds the high-water mark. It returns an
+// empty digest if nothing was swept.
+func (m *HotWalletManager) Sweep(ctx context.Context) (string, error) {
+	if m.conf.ColdAddress == "" {
+		return "", nil
+	}
+	unlock, err := m.locker.AcquireWalletLock(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to lock hot wallet: %v", err)
+	}
+	defer unlock()
+	balance, pending, err := m.refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+	if balance <= m.conf.HighWaterMark {
+		return "", nil
+	}
+	amount := balance - pending - m.conf.Float - m.conf.SweepGasBudget
+	if amount <= 0 {
+		return "", nil
+	}
+	tx, err := m.client.PrepareWithdrawTransaction(ctx, []TransferInfo{
+		{Address: m.conf.ColdAddress, Amount: amount},
+	}, m.conf.SweepGasBudget)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare sweep transaction: %v", err)
+	}
+	digest, err := m.client.Withdraw(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to sweep %d to cold address: %v", amount, err)
+	}
+	slog.InfoContext(ctx, fmt.Sprintf(
+		"swept %d from hot wallet to %s in %s", amount, m.conf.ColdAddress, digest))
+
+	m.mu.Lock()
+	m.status.LastSweepDigest = digest
+	m.status.LastSweepTime = time.Now()
+	m.mu.Unlock()
+	return digest, nil
+}
+
+// Run sweeps and checks the hot wallet every interval until ctx is done.
+func (m *HotWalletManager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.Sweep(ctx); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("hot wallet sweep failed: %v", err))
+		}
+		if _, err := m.Check(ctx); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("hot wallet check failed: %v", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
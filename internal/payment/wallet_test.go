@@ -0,0 +1,130 @@
+package payment_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/payment"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fixedPendingWithdrawals int64
+
+func (p fixedPendingWithdrawals) SumPendingWithdrawals(ctx context.Context) (int64, error) {
+	return int64(p), nil
+}
+
+type localWalletLock struct {
+	sync.Mutex
+}
+
+func (l *localWalletLock) AcquireWalletLock(ctx context.Context) (func(), error) {
+	l.Lock()
+	return l.Unlock, nil
+}
+
+var _ = Describe("Hot wallet manager", Label("simulated"), func() {
+	hotAddress := "0xe789fb3f9e6e0736b648f3f33ff60bc0e4583583b2142cb2665bcc520635aac0"
+	coldAddress := "0xc228a949decc98affe62522cf3f56db12686d068fab82fab605c241cafe5197c"
+	walletConfig := payment.WalletConfig{
+		ColdAddress:    coldAddress,
+		Float:          5_000_000,
+		HighWaterMark:  10_000_000,
+		LowWaterMark:   2_000_000,
+		SweepGasBudget: 100_000,
+	}
+
+	var client *payment.SimulatedPaymentClient
+	var walletLock *localWalletLock
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		client = payment.NewSimulatedPaymentClient(hotAddress)
+		walletLock = &localWalletLock{}
+	})
+
+	It("should reject inconsistent water marks", func() {
+		_, err := payment.NewHotWalletManager(client, fixedPendingWithdrawals(0), walletLock, payment.WalletConfig{
+			ColdAddress:   coldAddress,
+			Float:         20_000_000,
+			HighWaterMark: 10_000_000,
+		})
+		Expect(err).To(MatchError(ContainSubstring("low water mark")))
+	})
+
+	It("should sweep above the high-water mark", func() {
+		client.Mint(hotAddress, 30_000_000)
+		manager, err := payment.NewHotWalletManager(client, fixedPendingWithdrawals(1_000_000), walletLock, walletConfig)
+		Expect(err).To(BeNil())
+
+		digest, err := manager.Sweep(ctx)
+		Expect(err).To(BeNil())
+		Expect(digest).NotTo(BeEmpty())
+		Expect(manager.GetStatus().LastSweepDigest).To(Equal(digest))
+		swept := int64(30_000_000 - 1_000_000 - 5_000_000 - 100_000)
+		Expect(client.GetBalanceOf(coldAddress)).To(Equal(swept))
+		Expect(client.GetBalanceOf(hotAddress)).To(Equal(
+			30_000_000 - swept - payment.SIMULATED_REFERENCE_GAS_PRICE))
+
+		By("not sweeping again below the high-water mark")
+		digest, err = manager.Sweep(ctx)
+		Expect(err).To(BeNil())
+		Expect(digest).To(BeEmpty())
+	})
+
+	It("should wait for withdrawal batches before sweeping", func() {
+		client.Mint(hotAddress, 30_000_000)
+		manager, err := payment.NewHotWalletManager(client, fixedPendingWithdrawals(0), walletLock, walletConfig)
+		Expect(err).To(BeNil())
+		Expect(manager.GetStatus().HotAddress).To(Equal(hotAddress))
+
+		unlock, err := walletLock.AcquireWalletLock(ctx)
+		Expect(err).To(BeNil())
+		swept := make(chan string)
+		go func() {
+			defer GinkgoRecover()
+			digest, err := manager.Sweep(ctx)
+			Expect(err).To(BeNil())
+			swept <- digest
+		}()
+		Consistently(swept, 100*time.Millisecond).ShouldNot(Receive())
+		Expect(client.GetBalanceOf(coldAddress)).To(BeZero())
+
+		unlock()
+		Eventually(swept).Should(Receive(Not(BeEmpty())))
+	})
+
+	It("should not sweep without a cold address", func() {
+		client.Mint(hotAddress, 30_000_000)
+		manager, err := payment.NewHotWalletManager(client, fixedPendingWithdrawals(0), walletLock, payment.WalletConfig{})
+		Expect(err).To(BeNil())
+		digest, err := manager.Sweep(ctx)
+		Expect(err).To(BeNil())
+		Expect(digest).To(BeEmpty())
+		Expect(client.GetBalanceOf(hotAddress)).To(BeEquivalentTo(30_000_000))
+	})
+
+	It("should alert when pending withdrawals drain the hot wallet", func() {
+		client.Mint(hotAddress, 4_000_000)
+		manager, err := payment.NewHotWalletManager(client, fixedPendingWithdrawals(3_000_000), walletLock, walletConfig)
+		Expect(err).To(BeNil())
+		alerts := 0
+		manager.OnRefillNeeded = func(ctx context.Context, status payment.WalletStatus) {
+			alerts++
+		}
+
+		status, err := manager.Check(ctx)
+		Expect(err).To(BeNil())
+		Expect(status.RefillNeeded).To(BeTrue())
+		Expect(status.RefillAmount).To(BeEquivalentTo(5_000_000 - 1_000_000))
+		Expect(alerts).To(Equal(1))
+
+		client.Mint(hotAddress, 4_000_000)
+		status, err = manager.Check(ctx)
+		Expect(err).To(BeNil())
+		Expect(status.RefillNeeded).To(BeFalse())
+		Expect(alerts).To(Equal(1))
+	})
+})
@@ -0,0 +1,230 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/redis/go-redis/v9"
+)
+
+// DEFAULT_LIMIT_NAME applies to methods without their own limit.
+const DEFAULT_LIMIT_NAME = "default"
+
+// Limit allows Count calls per Window. A non-positive Count means unlimited.
+type Limit struct {
+	Count  int64
+	Window time.Duration
+}
+
+func (l Limit) Unlimited() bool {
+	return l.Count <= 0
+}
+
+// ParseLimits parses a comma separated list of name=count/window entries,
+// e.g. "default=120/1m,Login=10/1m". Names are method names or default.
+func ParseLimits(spec string) (map[string]Limit, error) {
+	ret := make(map[string]Limit)
+	if strings.TrimSpace(spec) == "" {
+		return ret, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("expect rate limit as name=count/window but got %q", entry)
+		}
+		countString, windowString, found := strings.Cut(strings.TrimSpace(value), "/")
+		if !found {
+			return nil, fmt.Errorf("expect rate limit as name=count/window but got %q", entry)
+		}
+		count, err := strconv.ParseInt(countString, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid count of rate limit %s: %v", name, err)
+		}
+		window, err := time.ParseDuration(windowString)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid window of rate limit %s: %q", name, windowString)
+		}
+		if _, ok := ret[name]; ok {
+			return nil, fmt.Errorf("duplicated rate limit %s", name)
+		}
+		ret[name] = Limit{Count: count, Window: window}
+	}
+	return ret, nil
+}
+
+// ParseTrustedProxies parses a comma separated list of addresses or prefixes,
+// e.g. "127.0.0.1,10.0.0.0/8", of proxies whose x-forwarded-for is trusted.
+func ParseTrustedProxies(spec string) ([]netip.Prefix, error) {
+	ret := make([]netip.Prefix, 0)
+	if strings.TrimSpace(spec) == "" {
+		return ret, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %v", entry, err)
+			}
+			ret = append(ret, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %v", entry, err)
+		}
+		ret = append(ret, prefix.Masked())
+	}
+	return ret, nil
+}
+
+// ClientAddr returns the host calls from peerAddr are limited by. Calls
+// relayed by a trusted proxy, e.g. the grpc-gateway, are attributed to the
+// last address in forwardedFor as only that one was appended by the proxy.
+func ClientAddr(peerAddr string, forwardedFor []string, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(peerAddr)
+	if err != nil {
+		host = peerAddr
+	}
+	if len(forwardedFor) == 0 {
+		return host
+	}
+	peerIp, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	trusted := false
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(peerIp.Unmap()) {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return host
+	}
+	hops := strings.Split(forwardedFor[len(forwardedFor)-1], ",")
+	client, err := netip.ParseAddr(strings.TrimSpace(hops[len(hops)-1]))
+	if err != nil {
+		return host
+	}
+	return client.Unmap().String()
+}
+
+// Lockout locks a subject out for Base after Threshold consecutive failures
+// and doubles the time on every further failure up to Max. Failures are
+// forgotten Max after the last one.
+type Lockout struct {
+	Threshold int64
+	Base      time.Duration
+	Max       time.Duration
+}
+
+func (l Lockout) Enabled() bool {
+	return l.Threshold > 0 && l.Base > 0
+}
+
+// Limiter counts calls in Redis so that all replicas share the same limits.
+type Limiter struct {
+	redisClient *redis.Client
+}
+
+func NewLimiter(redisClient *redis.Client) *Limiter {
+	return &Limiter{redisClient: redisClient}
+}
+
+// allowScript counts a call in a fixed window. Returns {count, pttl}.
+var allowScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+  ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
+// Allow counts a call of subject to method and returns how long to wait if
+// it exceeds limit, or zero if it may proceed.
+func (l *Limiter) Allow(
+	ctx context.Context, method string, subject string, limit Limit,
+) (time.Duration, error) {
+	if limit.Unlimited() {
+		return 0, nil
+	}
+	result, err := allowScript.Run(
+		ctx, l.redisClient,
+		[]string{fmt.Sprintf(utils.REDIS_KEY_RATE_LIMIT, method, subject)},
+		limit.Window.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count call: %v", err)
+	}
+	if result[0] <= limit.Count {
+		return 0, nil
+	}
+	return time.Duration(result[1]) * time.Millisecond, nil
+}
+
+// LockedFor returns how long subject is still locked out.
+func (l *Limiter) LockedFor(ctx context.Context, subject string) (time.Duration, error) {
+	ttl, err := l.redisClient.PTTL(ctx, fmt.Sprintf(utils.REDIS_KEY_LOCKOUT, subject)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check lockout: %v", err)
+	}
+	// Negative values mean no lockout
+	return max(ttl, 0), nil
+}
+
+// failScript records a failure and sets the lockout once the threshold is
+// reached. Returns the lockout in milliseconds or 0.
+var failScript = redis.NewScript(`
+local failures = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+local threshold = tonumber(ARGV[1])
+if failures < threshold then
+  return 0
+end
+local lockout = tonumber(ARGV[2]) * 2 ^ math.min(failures - threshold, 32)
+lockout = math.min(lockout, tonumber(ARGV[3]))
+redis.call('SET', KEYS[2], failures, 'PX', lockout)
+return lockout
+`)
+
+// Fail records a failure of subject and returns the resulting lockout.
+func (l *Limiter) Fail(ctx context.Context, subject string, lockout Lockout) (time.Duration, error) {
+	if !lockout.Enabled() {
+		return 0, nil
+	}
+	lockedFor, err := failScript.Run(
+		ctx, l.redisClient,
+		[]string{
+			fmt.Sprintf(utils.REDIS_KEY_LOCKOUT_FAILURES, subject),
+			fmt.Sprintf(utils.REDIS_KEY_LOCKOUT, subject),
+		},
+		lockout.Threshold, lockout.Base.Milliseconds(), max(lockout.Max, lockout.Base).Milliseconds(),
+	).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to record failure: %v", err)
+	}
+	return time.Duration(lockedFor) * time.Millisecond, nil
+}
+
+// Succeed forgets the failures of subject.
+func (l *Limiter) Succeed(ctx context.Context, subject string) error {
+	return l.redisClient.Del(
+		ctx,
+		fmt.Sprintf(utils.REDIS_KEY_LOCKOUT_FAILURES, subject),
+		fmt.Sprintf(utils.REDIS_KEY_LOCKOUT, subject),
+	).Err()
+}
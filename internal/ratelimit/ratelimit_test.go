@@ -0,0 +1,124 @@
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/ratelimit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ = Describe("ParseLimits", func() {
+	It("should parse limits by method name", func() {
+		limits, err := ratelimit.ParseLimits("default=120/1m, Login=10/30s,Ping=0/1s")
+		Expect(err).To(BeNil())
+		Expect(limits).To(Equal(map[string]ratelimit.Limit{
+			"default": {Count: 120, Window: time.Minute},
+			"Login":   {Count: 10, Window: 30 * time.Second},
+			"Ping":    {Count: 0, Window: time.Second},
+		}))
+		Expect(limits["Ping"].Unlimited()).To(BeTrue())
+	})
+
+	It("should reject malformed limits", func() {
+		for _, spec := range []string{"Login", "Login=10", "Login=x/1m", "Login=10/0s", "Login=1/1m,Login=2/1m"} {
+			_, err := ratelimit.ParseLimits(spec)
+			Expect(err).NotTo(BeNil(), spec)
+		}
+	})
+})
+
+var _ = Describe("ClientAddr", func() {
+	trustedProxies, err := ratelimit.ParseTrustedProxies("127.0.0.1, 10.0.0.0/8")
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to parse trusted proxies: %v", err))
+	}
+
+	It("should reject malformed proxies", func() {
+		_, err := ratelimit.ParseTrustedProxies("localhost")
+		Expect(err).NotTo(BeNil())
+		_, err = ratelimit.ParseTrustedProxies("10.0.0.0/33")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should take the address appended by a trusted proxy", func() {
+		Expect(ratelimit.ClientAddr(
+			"127.0.0.1:4242", []string{"203.0.113.1"}, trustedProxies,
+		)).To(Equal("203.0.113.1"))
+		Expect(ratelimit.ClientAddr(
+			"10.1.2.3:4242", []string{"198.51.100.7, 203.0.113.1"}, trustedProxies,
+		)).To(Equal("203.0.113.1"))
+	})
+
+	It("should ignore x-forwarded-for of other peers", func() {
+		Expect(ratelimit.ClientAddr(
+			"192.0.2.1:4242", []string{"203.0.113.1"}, trustedProxies,
+		)).To(Equal("192.0.2.1"))
+		Expect(ratelimit.ClientAddr(
+			"127.0.0.1:4242", []string{"not an ip"}, trustedProxies,
+		)).To(Equal("127.0.0.1"))
+		Expect(ratelimit.ClientAddr("127.0.0.1:4242", nil, trustedProxies)).To(Equal("127.0.0.1"))
+	})
+})
+
+var _ = Describe("Limiter", Label("redis"), func() {
+	var limiter *ratelimit.Limiter
+	ctx := context.Background()
+	subject := fmt.Sprintf("test:%d", time.Now().UnixNano())
+
+	BeforeEach(func() {
+		host, ok := os.LookupEnv("REDIS_HOST")
+		if !ok {
+			host = "localhost"
+		}
+		port, ok := os.LookupEnv("REDIS_PORT")
+		if !ok {
+			port = "6379"
+		}
+		redisClient := redis.NewClient(&redis.Options{Addr: fmt.Sprintf("%s:%s", host, port)})
+		pingCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		if err := redisClient.Ping(pingCtx).Err(); err != nil {
+			Skip(fmt.Sprintf("redis not reachable: %v", err))
+		}
+		limiter = ratelimit.NewLimiter(redisClient)
+	})
+
+	It("should reject calls over the limit until the window ends", func() {
+		limit := ratelimit.Limit{Count: 2, Window: time.Minute}
+		for i := 0; i < 2; i++ {
+			retryAfter, err := limiter.Allow(ctx, "Ping", subject, limit)
+			Expect(err).To(BeNil())
+			Expect(retryAfter).To(BeZero())
+		}
+		retryAfter, err := limiter.Allow(ctx, "Ping", subject, limit)
+		Expect(err).To(BeNil())
+		Expect(retryAfter).To(BeNumerically(">", 0))
+		Expect(retryAfter).To(BeNumerically("<=", time.Minute))
+	})
+
+	It("should lock out exponentially after repeated failures", func() {
+		lockout := ratelimit.Lockout{Threshold: 2, Base: time.Minute, Max: 3 * time.Minute}
+		lockedFor, err := limiter.Fail(ctx, subject, lockout)
+		Expect(err).To(BeNil())
+		Expect(lockedFor).To(BeZero())
+
+		for _, expected := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+			lockedFor, err = limiter.Fail(ctx, subject, lockout)
+			Expect(err).To(BeNil())
+			Expect(lockedFor).To(Equal(expected))
+		}
+		lockedFor, err = limiter.LockedFor(ctx, subject)
+		Expect(err).To(BeNil())
+		Expect(lockedFor).To(BeNumerically(">", 2*time.Minute))
+
+		Expect(limiter.Succeed(ctx, subject)).To(Succeed())
+		lockedFor, err = limiter.LockedFor(ctx, subject)
+		Expect(err).To(BeNil())
+		Expect(lockedFor).To(BeZero())
+	})
+})
@@ -0,0 +1,270 @@
+package signing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/blind"
+)
+
+const BLIND_KEY_BITS = 2048
+
+var ErrUnknownBlindKey = errors.New("unknown or expired blind token key")
+
+// BlindKey signs blind tokens of one denomination until IssueEndTime. Its
+// tokens are redeemable until ExpireTime, after which their spent nonces need
+// not be remembered.
+type BlindKey struct {
+	KeyId        string
+	Denomination int64
+	PublicKey    *rsa.PublicKey
+	IssueEndTime time.Time
+	ExpireTime   time.Time
+}
+
+func (k *BlindKey) IsIssuing(now time.Time) bool {
+	return now.Before(k.IssueEndTime)
+}
+
+func (k *BlindKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpireTime)
+}
+
+// IBlindKeyStore is a signer backend holding the RSA keys of blind tokens, so
+// that their private keys never leave it.
+type IBlindKeyStore interface {
+	// GenerateBlindKey creates a key of denomination issuing tokens until
+	// issueEndTime that expires at expireTime.
+	GenerateBlindKey(
+		ctx context.Context, denomination int64, issueEndTime time.Time, expireTime time.Time,
+	) (*BlindKey, error)
+	// ListBlindKeys returns the keys not expired at now.
+	ListBlindKeys(ctx context.Context, now time.Time) ([]BlindKey, error)
+	// SignBlinded signs blinded messages with the key of keyId if it still
+	// issues tokens.
+	SignBlinded(ctx context.Context, keyId string, blinded [][]byte) ([][]byte, error)
+}
+
+// KeystoreBlindKey is a blind key as saved in a keystore.
+type KeystoreBlindKey struct {
+	Denomination int64     `json:"denomination"`
+	IssueEndTime time.Time `json:"issue_end_time"`
+	ExpireTime   time.Time `json:"expire_time"`
+	// PKCS #8 DER of the private key
+	PrivateKey []byte `json:"private_key"`
+}
+
+type blindPrivateKey struct {
+	BlindKey
+	privateKey *rsa.PrivateKey
+}
+
+func newKeystoreBlindKey(
+	denomination int64, issueEndTime time.Time, expireTime time.Time,
+) (*KeystoreBlindKey, error) {
+	if denomination <= 0 || !issueEndTime.Before(expireTime) {
+		return nil, fmt.Errorf("invalid blind key of denomination %d", denomination)
+	}
+	privateKey, err := rsa.GenerateKey(rand.Reader, BLIND_KEY_BITS)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeystoreBlindKey{
+		Denomination: denomination,
+		IssueEndTime: issueEndTime.UTC(),
+		ExpireTime:   expireTime.UTC(),
+		PrivateKey:   der,
+	}, nil
+}
+
+func (k *KeystoreBlindKey) parse() (*blindPrivateKey, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blind key of denomination %d: %v", k.Denomination, err)
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("blind key of denomination %d is not an RSA key", k.Denomination)
+	}
+	keyId, err := blind.KeyId(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &blindPrivateKey{
+		BlindKey: BlindKey{
+			KeyId:        keyId,
+			Denomination: k.Denomination,
+			PublicKey:    &privateKey.PublicKey,
+			IssueEndTime: k.IssueEndTime,
+			ExpireTime:   k.ExpireTime,
+		},
+		privateKey: privateKey,
+	}, nil
+}
+
+func (k *blindPrivateKey) signBlinded(blinded [][]byte, now time.Time) ([][]byte, error) {
+	if !k.IsIssuing(now) {
+		return nil, fmt.Errorf("%w: %s no longer issues tokens", ErrUnknownBlindKey, k.KeyId)
+	}
+	ret := make([][]byte, 0, len(blinded))
+	for _, message := range blinded {
+		blindSignature, err := blind.Sign(k.privateKey, message)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, blindSignature)
+	}
+	return ret, nil
+}
+
+// BlindKeyring keeps a key issuing tokens for each denomination. A key issues
+// for keyTtl and its tokens stay redeemable for redeemTtl after that. Keys
+// live in the signer backend.
+type BlindKeyring struct {
+	backend       IBlindKeyStore
+	denominations []int64
+	keyTtl        time.Duration
+	redeemTtl     time.Duration
+
+	mu   sync.Mutex
+	keys []BlindKey
+}
+
+// NewBlindKeyring loads the keys of the backend. Denominations without an
+// issuing key get one on first use.
+func NewBlindKeyring(
+	ctx context.Context,
+	backend ITokenSigner,
+	denominations []int64,
+	keyTtl time.Duration,
+	redeemTtl time.Duration,
+	now time.Time,
+) (*BlindKeyring, error) {
+	ret := &BlindKeyring{
+		denominations: denominations,
+		keyTtl:        keyTtl,
+		redeemTtl:     redeemTtl,
+	}
+	if len(denominations) == 0 {
+		return ret, nil
+	}
+	keyStore, ok := backend.(IBlindKeyStore)
+	if !ok {
+		return nil, fmt.Errorf(
+			"signer backend cannot hold blind token keys, use the keystore or remote backend")
+	}
+	if keyTtl <= 0 {
+		return nil, fmt.Errorf("expect positive blind token key ttl but got %v", keyTtl)
+	}
+	ret.backend = keyStore
+	ret.mu.Lock()
+	defer ret.mu.Unlock()
+	if err := ret.refresh(ctx, now); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// refresh must be called with k.mu held.
+func (k *BlindKeyring) refresh(ctx context.Context, now time.Time) error {
+	keys, err := k.backend.ListBlindKeys(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to list blind token keys: %v", err)
+	}
+	k.keys = keys
+	return nil
+}
+
+// issuing must be called with k.mu held.
+func (k *BlindKeyring) issuing(denomination int64, now time.Time) *BlindKey {
+	for i := range k.keys {
+		if k.keys[i].Denomination == denomination && k.keys[i].IsIssuing(now) {
+			return &k.keys[i]
+		}
+	}
+	return nil
+}
+
+// Published returns the keys not expired at now, generating issuing keys of
+// denominations that lack one.
+func (k *BlindKeyring) Published(ctx context.Context, now time.Time) ([]BlindKey, error) {
+	if k.backend == nil {
+		return nil, nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, denomination := range k.denominations {
+		if k.issuing(denomination, now) != nil {
+			continue
+		}
+		// Another server may have generated it already
+		if err := k.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+		if k.issuing(denomination, now) != nil {
+			continue
+		}
+		issueEndTime := now.Add(k.keyTtl)
+		key, err := k.backend.GenerateBlindKey(ctx, denomination, issueEndTime, issueEndTime.Add(k.redeemTtl))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate blind token key: %v", err)
+		}
+		k.keys = append(k.keys, *key)
+	}
+	ret := make([]BlindKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		if !key.IsExpired(now) {
+			ret = append(ret, key)
+		}
+	}
+	slices.SortStableFunc(ret, func(a, b BlindKey) int {
+		return b.IssueEndTime.Compare(a.IssueEndTime)
+	})
+	return ret, nil
+}
+
+// Key returns the key of keyId unless it expired at now.
+func (k *BlindKeyring) Key(ctx context.Context, keyId string, now time.Time) (*BlindKey, error) {
+	if k.backend == nil {
+		return nil, ErrUnknownBlindKey
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	find := func() *BlindKey {
+		for i := range k.keys {
+			if k.keys[i].KeyId == keyId && !k.keys[i].IsExpired(now) {
+				return &k.keys[i]
+			}
+		}
+		return nil
+	}
+	if key := find(); key != nil {
+		return key, nil
+	}
+	if err := k.refresh(ctx, now); err != nil {
+		return nil, err
+	}
+	if key := find(); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownBlindKey, keyId)
+}
+
+// Sign signs blinded messages with the backend key of keyId.
+func (k *BlindKeyring) Sign(ctx context.Context, keyId string, blinded [][]byte) ([][]byte, error) {
+	if k.backend == nil {
+		return nil, ErrUnknownBlindKey
+	}
+	return k.backend.SignBlinded(ctx, keyId, blinded)
+}
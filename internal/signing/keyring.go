@@ -0,0 +1,256 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/didkey"
+)
+
+var (
+	ErrNoActiveKey = errors.New("no active token signing key")
+	ErrUnknownKey  = errors.New("unknown or retired token signing key")
+)
+
+// KeyringEntry is a token key usable between its activation and retirement.
+// Entries without a signer only verify tokens.
+type KeyringEntry struct {
+	KeyId        string
+	PublicKey    ed25519.PublicKey
+	Signer       ITokenSigner
+	ActivateTime time.Time
+	// RetireTime is zero for keys not scheduled for retirement
+	RetireTime time.Time
+}
+
+func (e *KeyringEntry) IsRetired(now time.Time) bool {
+	return !e.RetireTime.IsZero() && !now.Before(e.RetireTime)
+}
+
+// Keyring holds the token keys. New tokens are signed by the newest active
+// key while older keys keep verifying until they retire. Staged keys are
+// published ahead of activation so verifiers know them in time.
+type Keyring struct {
+	entries []KeyringEntry
+	// Tokens signed before kids were introduced carry none. They are
+	// verified with missingKeyId until missingKeyIdUntil.
+	missingKeyId      string
+	missingKeyIdUntil time.Time
+}
+
+func NewKeyring(entries ...KeyringEntry) *Keyring {
+	return &Keyring{entries: entries}
+}
+
+// Primary returns the key signing new tokens at now.
+func (k *Keyring) Primary(now time.Time) (*KeyringEntry, error) {
+	var ret *KeyringEntry
+	for i := range k.entries {
+		entry := &k.entries[i]
+		if entry.Signer == nil || entry.ActivateTime.After(now) || entry.IsRetired(now) {
+			continue
+		}
+		if ret == nil || entry.ActivateTime.After(ret.ActivateTime) {
+			ret = entry
+		}
+	}
+	if ret == nil {
+		return nil, ErrNoActiveKey
+	}
+	return ret, nil
+}
+
+// AllowMissingKeyId verifies tokens without a kid with keyId until the given
+// time, which should be when the last of them expires.
+func (k *Keyring) AllowMissingKeyId(keyId string, until time.Time) {
+	k.missingKeyId = keyId
+	k.missingKeyIdUntil = until
+}
+
+// VerificationKey returns the public key of keyId unless it is retired.
+func (k *Keyring) VerificationKey(keyId string, now time.Time) (ed25519.PublicKey, error) {
+	if keyId == "" {
+		if k.missingKeyId == "" || !now.Before(k.missingKeyIdUntil) {
+			return nil, ErrUnknownKey
+		}
+		keyId = k.missingKeyId
+	}
+	for _, entry := range k.entries {
+		if entry.KeyId == keyId && !entry.IsRetired(now) {
+			return entry.PublicKey, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// Published returns the keys not yet retired, newest first.
+func (k *Keyring) Published(now time.Time) []KeyringEntry {
+	ret := make([]KeyringEntry, 0)
+	for _, entry := range k.entries {
+		if !entry.IsRetired(now) {
+			ret = append(ret, entry)
+		}
+	}
+	slices.SortStableFunc(ret, func(a, b KeyringEntry) int {
+		return b.ActivateTime.Compare(a.ActivateTime)
+	})
+	return ret
+}
+
+// keyringFileEntry is a key in the keyring file. Keys are referred to by kid
+// only, the signer backend holds their private keys.
+type keyringFileEntry struct {
+	KeyId        string     `json:"kid"`
+	ActivateTime time.Time  `json:"activate_time"`
+	RetireTime   *time.Time `json:"retire_time,omitempty"`
+}
+
+type keyringFile struct {
+	Keys []keyringFileEntry `json:"keys"`
+}
+
+func readKeyringFile(path string) (*keyringFile, error) {
+	ret := &keyringFile{Keys: make([]keyringFileEntry, 0)}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ret, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, ret); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring %s: %v", path, err)
+	}
+	return ret, nil
+}
+
+// writeSecretFile replaces the JSON file at path atomically, readable only by
+// the owner.
+func writeSecretFile(path string, file any) error {
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadKeyring combines the key of the signer backend with the keys staged in
+// the keyring file at path and verification-only retiredKeys. Staged keys must
+// be held by the backend. An empty path yields the backend key alone.
+func LoadKeyring(
+	path string, backend ITokenSigner, retiredKeys []ed25519.PublicKey,
+) (*Keyring, error) {
+	backendEntry := KeyringEntry{
+		KeyId:     didkey.KeyId(backend.GetPublicKey()),
+		PublicKey: backend.GetPublicKey(),
+		Signer:    backend,
+	}
+	entries := make([]KeyringEntry, 0)
+	if path != "" {
+		file, err := readKeyringFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileEntry := range file.Keys {
+			entry := KeyringEntry{
+				KeyId:        fileEntry.KeyId,
+				ActivateTime: fileEntry.ActivateTime,
+			}
+			if fileEntry.RetireTime != nil {
+				entry.RetireTime = *fileEntry.RetireTime
+			}
+			if fileEntry.KeyId == backendEntry.KeyId {
+				backendEntry.ActivateTime = entry.ActivateTime
+				backendEntry.RetireTime = entry.RetireTime
+				continue
+			}
+			keyStore, ok := backend.(ITokenKeyStore)
+			if !ok {
+				return nil, fmt.Errorf(
+					"signer backend cannot hold staged key %s", fileEntry.KeyId)
+			}
+			signer, err := keyStore.TokenSigner(fileEntry.KeyId)
+			if err != nil {
+				return nil, err
+			}
+			entry.PublicKey = signer.GetPublicKey()
+			entry.Signer = signer
+			entries = append(entries, entry)
+		}
+	}
+	entries = append(entries, backendEntry)
+	for _, retiredKey := range retiredKeys {
+		entries = append(entries, KeyringEntry{
+			KeyId:     didkey.KeyId(retiredKey),
+			PublicKey: retiredKey,
+		})
+	}
+	return NewKeyring(entries...), nil
+}
+
+// StageKey has the signer backend generate a key activating at activateTime
+// and schedules all keys not yet scheduled to retire overlap after that, which
+// should outlast every token they signed. Returns the kid of the new key.
+func StageKey(
+	ctx context.Context,
+	path string,
+	backend ITokenSigner,
+	activateTime time.Time,
+	overlap time.Duration,
+) (string, error) {
+	keyStore, ok := backend.(ITokenKeyStore)
+	if !ok {
+		return "", fmt.Errorf(
+			"signer backend cannot generate token keys, use the keystore or remote backend")
+	}
+	file, err := readKeyringFile(path)
+	if err != nil {
+		return "", err
+	}
+	backendKeyId := didkey.KeyId(backend.GetPublicKey())
+	if !slices.ContainsFunc(file.Keys, func(entry keyringFileEntry) bool {
+		return entry.KeyId == backendKeyId
+	}) {
+		file.Keys = append(file.Keys, keyringFileEntry{KeyId: backendKeyId})
+	}
+	retireTime := activateTime.Add(overlap)
+	for i := range file.Keys {
+		if file.Keys[i].RetireTime == nil {
+			file.Keys[i].RetireTime = &retireTime
+		}
+	}
+	publicKey, err := keyStore.GenerateTokenKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	keyId := didkey.KeyId(publicKey)
+	file.Keys = append(file.Keys, keyringFileEntry{
+		KeyId:        keyId,
+		ActivateTime: activateTime.UTC(),
+	})
+	if err := writeSecretFile(path, file); err != nil {
+		return "", fmt.Errorf("failed to write keyring %s: %v", path, err)
+	}
+	return keyId, nil
+}
@@ -0,0 +1,349 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	KEYSTORE_VERSION = 1
+	KEYSTORE_KDF     = "argon2id"
+)
+
+// KeystoreSecrets are the plaintext contents of an encrypted keystore, in the
+// same format as WALLET_MNEMONIC and TOKEN_SIGNING_SEED.
+type KeystoreSecrets struct {
+	WalletMnemonic   string `json:"wallet_mnemonic"`
+	TokenSigningSeed string `json:"token_signing_seed"`
+	// StagedTokenSeeds are the token keys generated for rotation
+	StagedTokenSeeds []string `json:"staged_token_seeds,omitempty"`
+	// BlindKeys are the RSA keys of blind tokens
+	BlindKeys []KeystoreBlindKey `json:"blind_keys,omitempty"`
+}
+
+type keystoreKdf struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+type keystoreFile struct {
+	Version    int         `json:"version"`
+	Kdf        keystoreKdf `json:"kdf"`
+	Nonce      []byte      `json:"nonce"`
+	Ciphertext []byte      `json:"ciphertext"`
+}
+
+func (k *keystoreKdf) deriveKey(passphrase []byte) []byte {
+	return argon2.IDKey(passphrase, k.Salt, k.Time, k.Memory, k.Threads, chacha20poly1305.KeySize)
+}
+
+func encryptKeystore(passphrase []byte, secrets KeystoreSecrets) (*keystoreFile, error) {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+	kdf := keystoreKdf{
+		Name:    KEYSTORE_KDF,
+		Salt:    make([]byte, 16),
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+	if _, err := rand.Read(kdf.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(kdf.deriveKey(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &keystoreFile{
+		Version:    KEYSTORE_VERSION,
+		Kdf:        kdf,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, nil
+}
+
+// CreateKeystore encrypts secrets with a key derived from passphrase and
+// writes them to a new file only readable by the owner.
+func CreateKeystore(path string, passphrase []byte, secrets KeystoreSecrets) error {
+	if len(passphrase) == 0 {
+		return fmt.Errorf("empty keystore passphrase")
+	}
+	// Fail early on malformed secrets rather than at unlock time
+	if _, err := secrets.toSigner(); err != nil {
+		return err
+	}
+	keystore, err := encryptKeystore(passphrase, secrets)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(keystore, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create keystore: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(content); err != nil {
+		return fmt.Errorf("failed to write keystore: %v", err)
+	}
+	return nil
+}
+
+func readKeystore(path string, passphrase []byte) (*KeystoreSecrets, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %v", err)
+	}
+	var keystore keystoreFile
+	if err := json.Unmarshal(content, &keystore); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore: %v", err)
+	}
+	if keystore.Version != KEYSTORE_VERSION || keystore.Kdf.Name != KEYSTORE_KDF {
+		return nil, fmt.Errorf(
+			"unsupported keystore version %d with kdf %s", keystore.Version, keystore.Kdf.Name)
+	}
+	aead, err := chacha20poly1305.NewX(keystore.Kdf.deriveKey(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	if len(keystore.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid keystore nonce size %d", len(keystore.Nonce))
+	}
+	plaintext, err := aead.Open(nil, keystore.Nonce, keystore.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("wrong passphrase or corrupted keystore")
+	}
+	var secrets KeystoreSecrets
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore secrets: %v", err)
+	}
+	return &secrets, nil
+}
+
+// KeystoreSigner is a MemorySigner unlocked from a keystore. Token and blind
+// keys it generates are encrypted into the same keystore.
+type KeystoreSigner struct {
+	*MemorySigner
+	path       string
+	passphrase []byte
+
+	mu        sync.Mutex
+	staged    map[string]*MemoryTokenSigner
+	blindKeys map[string]*blindPrivateKey
+}
+
+// OpenKeystore decrypts the keystore at path into an in-memory signer.
+func OpenKeystore(path string, passphrase []byte) (*KeystoreSigner, error) {
+	secrets, err := readKeystore(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	memorySigner, err := secrets.toSigner()
+	if err != nil {
+		return nil, err
+	}
+	ret := &KeystoreSigner{
+		MemorySigner: memorySigner,
+		path:         path,
+		passphrase:   passphrase,
+		staged:       make(map[string]*MemoryTokenSigner),
+		blindKeys:    make(map[string]*blindPrivateKey),
+	}
+	if err := ret.addStaged(secrets.StagedTokenSeeds); err != nil {
+		return nil, err
+	}
+	if err := ret.addBlindKeys(secrets.BlindKeys); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// addStaged must be called with s.mu held or before s is shared.
+func (s *KeystoreSigner) addStaged(seeds []string) error {
+	for _, seedHex := range seeds {
+		seed, err := utils.HexToBytes32(seedHex)
+		if err != nil {
+			return fmt.Errorf("failed to parse staged token seed: %v", err)
+		}
+		signer := NewMemoryTokenSigner(ed25519.NewKeyFromSeed(seed))
+		s.staged[didkey.KeyId(signer.GetPublicKey())] = signer
+	}
+	return nil
+}
+
+// GenerateTokenKey adds a token key to the keystore file. The file is read
+// again first so that keys added by other processes are kept.
+func (s *KeystoreSigner) GenerateTokenKey(ctx context.Context) (ed25519.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secrets, err := readKeystore(s.path, s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	secrets.StagedTokenSeeds = append(secrets.StagedTokenSeeds, "0x"+hex.EncodeToString(seed))
+	keystore, err := encryptKeystore(s.passphrase, *secrets)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeSecretFile(s.path, keystore); err != nil {
+		return nil, fmt.Errorf("failed to write keystore: %v", err)
+	}
+	if err := s.addStaged(secrets.StagedTokenSeeds); err != nil {
+		return nil, err
+	}
+	return ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey), nil
+}
+
+func (s *KeystoreSigner) TokenSigner(keyId string) (ITokenSigner, error) {
+	if keyId == didkey.KeyId(s.GetPublicKey()) {
+		return s.MemoryTokenSigner, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if signer, ok := s.staged[keyId]; ok {
+		return signer, nil
+	}
+	// The key may have been staged by another process since
+	secrets, err := readKeystore(s.path, s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.addStaged(secrets.StagedTokenSeeds); err != nil {
+		return nil, err
+	}
+	if signer, ok := s.staged[keyId]; ok {
+		return signer, nil
+	}
+	return nil, fmt.Errorf("%w: %s is not in the keystore", ErrUnknownKey, keyId)
+}
+
+// addBlindKeys must be called with s.mu held or before s is shared.
+func (s *KeystoreSigner) addBlindKeys(blindKeys []KeystoreBlindKey) error {
+	for _, blindKey := range blindKeys {
+		key, err := blindKey.parse()
+		if err != nil {
+			return err
+		}
+		s.blindKeys[key.KeyId] = key
+	}
+	return nil
+}
+
+// GenerateBlindKey adds a blind key to the keystore file and drops the expired
+// ones from it.
+func (s *KeystoreSigner) GenerateBlindKey(
+	ctx context.Context, denomination int64, issueEndTime time.Time, expireTime time.Time,
+) (*BlindKey, error) {
+	blindKey, err := newKeystoreBlindKey(denomination, issueEndTime, expireTime)
+	if err != nil {
+		return nil, err
+	}
+	key, err := blindKey.parse()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secrets, err := readKeystore(s.path, s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	secrets.BlindKeys = slices.DeleteFunc(secrets.BlindKeys, func(k KeystoreBlindKey) bool {
+		return !now.Before(k.ExpireTime)
+	})
+	secrets.BlindKeys = append(secrets.BlindKeys, *blindKey)
+	keystore, err := encryptKeystore(s.passphrase, *secrets)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeSecretFile(s.path, keystore); err != nil {
+		return nil, fmt.Errorf("failed to write keystore: %v", err)
+	}
+	if err := s.addBlindKeys(secrets.BlindKeys); err != nil {
+		return nil, err
+	}
+	return &key.BlindKey, nil
+}
+
+// ListBlindKeys reads the keystore file again so that keys generated by other
+// processes are listed.
+func (s *KeystoreSigner) ListBlindKeys(ctx context.Context, now time.Time) ([]BlindKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secrets, err := readKeystore(s.path, s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.addBlindKeys(secrets.BlindKeys); err != nil {
+		return nil, err
+	}
+	ret := make([]BlindKey, 0, len(s.blindKeys))
+	for keyId, key := range s.blindKeys {
+		if key.IsExpired(now) {
+			delete(s.blindKeys, keyId)
+			continue
+		}
+		ret = append(ret, key.BlindKey)
+	}
+	return ret, nil
+}
+
+func (s *KeystoreSigner) SignBlinded(ctx context.Context, keyId string, blinded [][]byte) ([][]byte, error) {
+	s.mu.Lock()
+	key, ok := s.blindKeys[keyId]
+	if !ok {
+		// The key may have been generated by another process since
+		secrets, err := readKeystore(s.path, s.passphrase)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		if err := s.addBlindKeys(secrets.BlindKeys); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		key, ok = s.blindKeys[keyId]
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not in the keystore", ErrUnknownBlindKey, keyId)
+	}
+	return key.signBlinded(blinded, time.Now())
+}
+
+func (s *KeystoreSecrets) toSigner() (*MemorySigner, error) {
+	seed, err := utils.HexToBytes32(s.TokenSigningSeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token signing seed: %v", err)
+	}
+	return NewMemorySigner(s.WalletMnemonic, seed)
+}
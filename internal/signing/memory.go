@@ -0,0 +1,72 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/block-vision/sui-go-sdk/constant"
+	"github.com/block-vision/sui-go-sdk/signer"
+)
+
+type MemoryTransactionSigner struct {
+	wallet *signer.Signer
+}
+
+func NewMemoryTransactionSigner(wallet *signer.Signer) *MemoryTransactionSigner {
+	return &MemoryTransactionSigner{wallet: wallet}
+}
+
+func (s *MemoryTransactionSigner) GetAddress() string {
+	return s.wallet.Address
+}
+
+func (s *MemoryTransactionSigner) SignTransaction(ctx context.Context, txBytes string) (string, error) {
+	signed, err := s.wallet.SignMessage(txBytes, constant.TransactionDataIntentScope)
+	if err != nil {
+		return "", err
+	}
+	return signed.Signature, nil
+}
+
+type MemoryTokenSigner struct {
+	privateKey ed25519.PrivateKey
+}
+
+func NewMemoryTokenSigner(privateKey ed25519.PrivateKey) *MemoryTokenSigner {
+	return &MemoryTokenSigner{privateKey: privateKey}
+}
+
+func (s *MemoryTokenSigner) GetPublicKey() ed25519.PublicKey {
+	return s.privateKey.Public().(ed25519.PublicKey)
+}
+
+func (s *MemoryTokenSigner) SignToken(ctx context.Context, signingInput string) ([]byte, error) {
+	return ed25519.Sign(s.privateKey, []byte(signingInput)), nil
+}
+
+// GetPrivateKey exposes the token key for deriving other secrets from it.
+func (s *MemoryTokenSigner) GetPrivateKey() ed25519.PrivateKey {
+	return s.privateKey
+}
+
+// MemorySigner keeps both private keys in process memory.
+type MemorySigner struct {
+	*MemoryTransactionSigner
+	*MemoryTokenSigner
+}
+
+func NewMemorySigner(walletMnemonic string, tokenSigningSeed []byte) (*MemorySigner, error) {
+	if len(tokenSigningSeed) != ed25519.SeedSize {
+		return nil, fmt.Errorf(
+			"expect seed to have len %d but got %d", ed25519.SeedSize, len(tokenSigningSeed))
+	}
+	wallet, err := signer.NewSignertWithMnemonic(walletMnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mnemonic: %v", err)
+	}
+	return &MemorySigner{
+		MemoryTransactionSigner: NewMemoryTransactionSigner(wallet),
+		MemoryTokenSigner:       NewMemoryTokenSigner(ed25519.NewKeyFromSeed(tokenSigningSeed)),
+	}, nil
+}
@@ -0,0 +1,255 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/pkg/blind"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1"
+	"github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1/signerconnect"
+	"github.com/atticplaygroup/prex/pkg/transparency"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RemoteSigner delegates signing to a `prex signer` instance over gRPC. The
+// remote side holds the keys and may refuse to sign by its own policy.
+type RemoteSigner struct {
+	client         signerconnect.SignerServiceClient
+	authToken      string
+	walletAddress  string
+	tokenPublicKey ed25519.PublicKey
+}
+
+func newGrpcHttpClient(url string) *http.Client {
+	if strings.HasPrefix(url, "https://") {
+		return &http.Client{Transport: &http2.Transport{}}
+	}
+	// gRPC over cleartext HTTP/2
+	return &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}
+}
+
+// NewRemoteSigner connects to the signer at url and fetches its public keys.
+// authToken is sent as a bearer token if not empty.
+func NewRemoteSigner(ctx context.Context, url string, authToken string) (*RemoteSigner, error) {
+	s := &RemoteSigner{
+		client: signerconnect.NewSignerServiceClient(
+			newGrpcHttpClient(url), url, connect.WithGRPC(),
+		),
+		authToken: authToken,
+	}
+	resp, err := s.client.GetPublicKeys(ctx, newRequest(authToken, &pb.GetPublicKeysRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public keys from remote signer: %v", err)
+	}
+	if len(resp.Msg.GetTokenPublicKey()) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf(
+			"remote signer returned token public key of len %d", len(resp.Msg.GetTokenPublicKey()))
+	}
+	s.walletAddress = resp.Msg.GetWalletAddress()
+	s.tokenPublicKey = ed25519.PublicKey(resp.Msg.GetTokenPublicKey())
+	return s, nil
+}
+
+func newRequest[T any](authToken string, msg *T) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	if authToken != "" {
+		req.Header().Set("Authorization", "Bearer "+authToken)
+	}
+	return req
+}
+
+func (s *RemoteSigner) GetAddress() string {
+	return s.walletAddress
+}
+
+func (s *RemoteSigner) SignTransaction(ctx context.Context, txBytes string) (string, error) {
+	resp, err := s.client.SignTransaction(ctx, newRequest(s.authToken, &pb.SignTransactionRequest{
+		TxBytes: txBytes,
+	}))
+	if err != nil {
+		return "", fmt.Errorf("remote signer refused transaction: %v", err)
+	}
+	return resp.Msg.GetSignature(), nil
+}
+
+func (s *RemoteSigner) GetPublicKey() ed25519.PublicKey {
+	return s.tokenPublicKey
+}
+
+func (s *RemoteSigner) SignToken(ctx context.Context, signingInput string) ([]byte, error) {
+	return s.signToken(ctx, "", s.tokenPublicKey, signingInput)
+}
+
+// SignTreeHead signs a transparency log head through its own path, as the
+// signer refuses to sign anything but tokens with SignToken.
+func (s *RemoteSigner) SignTreeHead(ctx context.Context, head *transparency.TreeHead) ([]byte, error) {
+	return s.signTreeHead(ctx, "", s.tokenPublicKey, head)
+}
+
+func (s *RemoteSigner) signTreeHead(
+	ctx context.Context, keyId string, publicKey ed25519.PublicKey, head *transparency.TreeHead,
+) ([]byte, error) {
+	resp, err := s.client.SignTreeHead(ctx, newRequest(s.authToken, &pb.SignTreeHeadRequest{
+		TreeSize:  head.Size,
+		RootHash:  head.RootHash,
+		Timestamp: timestamppb.New(head.Timestamp),
+		KeyId:     keyId,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer refused tree head: %v", err)
+	}
+	if err := head.Verify(publicKey, resp.Msg.GetSignature()); err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid tree head signature")
+	}
+	return resp.Msg.GetSignature(), nil
+}
+
+func (s *RemoteSigner) signToken(
+	ctx context.Context, keyId string, publicKey ed25519.PublicKey, signingInput string,
+) ([]byte, error) {
+	resp, err := s.client.SignToken(ctx, newRequest(s.authToken, &pb.SignTokenRequest{
+		SigningInput: signingInput,
+		KeyId:        keyId,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer refused token: %v", err)
+	}
+	// Never hand out a token the verifiers would reject
+	if !ed25519.Verify(publicKey, []byte(signingInput), resp.Msg.GetSignature()) {
+		return nil, fmt.Errorf("remote signer returned an invalid token signature")
+	}
+	return resp.Msg.GetSignature(), nil
+}
+
+func (s *RemoteSigner) GenerateTokenKey(ctx context.Context) (ed25519.PublicKey, error) {
+	resp, err := s.client.GenerateTokenKey(ctx, newRequest(s.authToken, &pb.GenerateTokenKeyRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to generate token key: %v", err)
+	}
+	if len(resp.Msg.GetTokenPublicKey()) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf(
+			"remote signer returned token public key of len %d", len(resp.Msg.GetTokenPublicKey()))
+	}
+	return ed25519.PublicKey(resp.Msg.GetTokenPublicKey()), nil
+}
+
+// TokenSigner signs with a key the remote signer generated. Whether it still
+// holds the key is only known at signing time.
+func (s *RemoteSigner) TokenSigner(keyId string) (ITokenSigner, error) {
+	publicKey, err := didkey.ParseKeyId(keyId)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteTokenSigner{remote: s, keyId: keyId, publicKey: publicKey}, nil
+}
+
+type remoteTokenSigner struct {
+	remote    *RemoteSigner
+	keyId     string
+	publicKey ed25519.PublicKey
+}
+
+func (s *remoteTokenSigner) GetPublicKey() ed25519.PublicKey {
+	return s.publicKey
+}
+
+func (s *remoteTokenSigner) SignToken(ctx context.Context, signingInput string) ([]byte, error) {
+	return s.remote.signToken(ctx, s.keyId, s.publicKey, signingInput)
+}
+
+func (s *remoteTokenSigner) SignTreeHead(ctx context.Context, head *transparency.TreeHead) ([]byte, error) {
+	return s.remote.signTreeHead(ctx, s.keyId, s.publicKey, head)
+}
+
+func blindKeyFromPb(blindKey *pb.BlindKey) (*BlindKey, error) {
+	parsed, err := x509.ParsePKIXPublicKey(blindKey.GetPublicKey())
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid blind key: %v", err)
+	}
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("remote signer returned blind key that is not RSA")
+	}
+	keyId, err := blind.KeyId(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	// The kid must name the key it comes with
+	if keyId != blindKey.GetKeyId() {
+		return nil, fmt.Errorf("remote signer returned blind key %s as %s", keyId, blindKey.GetKeyId())
+	}
+	return &BlindKey{
+		KeyId:        keyId,
+		Denomination: blindKey.GetDenomination(),
+		PublicKey:    publicKey,
+		IssueEndTime: blindKey.GetIssueEndTime().AsTime(),
+		ExpireTime:   blindKey.GetExpireTime().AsTime(),
+	}, nil
+}
+
+func (s *RemoteSigner) GenerateBlindKey(
+	ctx context.Context, denomination int64, issueEndTime time.Time, expireTime time.Time,
+) (*BlindKey, error) {
+	resp, err := s.client.GenerateBlindKey(ctx, newRequest(s.authToken, &pb.GenerateBlindKeyRequest{
+		Denomination: denomination,
+		IssueEndTime: timestamppb.New(issueEndTime),
+		ExpireTime:   timestamppb.New(expireTime),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to generate blind key: %v", err)
+	}
+	return blindKeyFromPb(resp.Msg.GetBlindKey())
+}
+
+func (s *RemoteSigner) ListBlindKeys(ctx context.Context, now time.Time) ([]BlindKey, error) {
+	resp, err := s.client.ListBlindKeys(ctx, newRequest(s.authToken, &pb.ListBlindKeysRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to list blind keys: %v", err)
+	}
+	ret := make([]BlindKey, 0, len(resp.Msg.GetBlindKeys()))
+	for _, pbBlindKey := range resp.Msg.GetBlindKeys() {
+		blindKey, err := blindKeyFromPb(pbBlindKey)
+		if err != nil {
+			return nil, err
+		}
+		if !blindKey.IsExpired(now) {
+			ret = append(ret, *blindKey)
+		}
+	}
+	return ret, nil
+}
+
+func (s *RemoteSigner) SignBlinded(ctx context.Context, keyId string, blinded [][]byte) ([][]byte, error) {
+	resp, err := s.client.SignBlinded(ctx, newRequest(s.authToken, &pb.SignBlindedRequest{
+		KeyId:           keyId,
+		BlindedMessages: blinded,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer refused blinded messages: %v", err)
+	}
+	if len(resp.Msg.GetBlindSignatures()) != len(blinded) {
+		return nil, fmt.Errorf(
+			"remote signer returned %d blind signatures for %d messages",
+			len(resp.Msg.GetBlindSignatures()), len(blinded))
+	}
+	return resp.Msg.GetBlindSignatures(), nil
+}
@@ -0,0 +1,553 @@
+package signing
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	exchangepb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1"
+	"github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1/signerconnect"
+	"github.com/atticplaygroup/prex/pkg/transparency"
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/sui"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Policy limits what a remote signer agrees to sign. Zero values disable the
+// corresponding limit.
+type Policy struct {
+	// MaxWithdrawAmount caps what a single transaction pays to other addresses.
+	MaxWithdrawAmount int64
+	// MaxDailyWithdrawAmount caps the total paid out in any 24 hours.
+	MaxDailyWithdrawAmount int64
+	// ExemptAddresses are not counted in withdraw limits, e.g. the cold wallet.
+	ExemptAddresses []string
+	// MaxTokenQuantity caps the quantity claim of issued tokens.
+	MaxTokenQuantity int64
+	// MaxTokenTtl caps the lifetime left in issued tokens. Purchase receipts,
+	// which do not expire, are exempt.
+	MaxTokenTtl time.Duration
+}
+
+// ITransactionInspector reports how much a transaction pays from sender to
+// each other address.
+type ITransactionInspector interface {
+	GetOutflows(ctx context.Context, txBytes string, sender string) (map[string]int64, error)
+}
+
+// SuiTransactionInspector dry runs transactions on a full node.
+type SuiTransactionInspector struct {
+	suiClient sui.ISuiAPI
+}
+
+func NewSuiTransactionInspector(rpcUrl string) *SuiTransactionInspector {
+	return &SuiTransactionInspector{suiClient: sui.NewSuiClient(rpcUrl)}
+}
+
+func (i *SuiTransactionInspector) GetOutflows(
+	ctx context.Context, txBytes string, sender string,
+) (map[string]int64, error) {
+	dryRunResult, err := i.suiClient.SuiDryRunTransactionBlock(
+		ctx, models.SuiDryRunTransactionBlockRequest{TxBytes: txBytes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dry run transaction: %v", err)
+	}
+	if dryRunResult.Effects.Status.Status != "success" {
+		return nil, fmt.Errorf("transaction would fail: %s", dryRunResult.Effects.Status.Status)
+	}
+	outflows := make(map[string]int64)
+	for _, change := range dryRunResult.BalanceChanges {
+		owner := change.GetBalanceChangeOwner()
+		if owner == sender {
+			continue
+		}
+		if change.CoinType != "0x2::sui::SUI" {
+			return nil, fmt.Errorf("unexpected coin type %s", change.CoinType)
+		}
+		amount, err := strconv.ParseInt(change.Amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse balance change %s: %v", change.Amount, err)
+		}
+		if amount > 0 {
+			outflows[owner] += amount
+		}
+	}
+	return outflows, nil
+}
+
+type withdrawRecord struct {
+	time   time.Time
+	amount int64
+}
+
+// SignerServer serves the keys of signer to a Prex server after checking
+// every request against policy.
+type SignerServer struct {
+	signerconnect.UnimplementedSignerServiceHandler
+	signer    ISigner
+	policy    Policy
+	inspector ITransactionInspector
+	authToken string
+	Now       func() time.Time
+
+	mu        sync.Mutex
+	withdraws []withdrawRecord
+}
+
+func NewSignerServer(
+	signer ISigner, policy Policy, inspector ITransactionInspector, authToken string,
+) (*SignerServer, error) {
+	if inspector == nil && (policy.MaxWithdrawAmount > 0 || policy.MaxDailyWithdrawAmount > 0) {
+		return nil, fmt.Errorf("withdraw limits need a transaction inspector")
+	}
+	return &SignerServer{
+		signer:    signer,
+		policy:    policy,
+		inspector: inspector,
+		authToken: authToken,
+		Now:       time.Now,
+	}, nil
+}
+
+func (s *SignerServer) authorize(header http.Header) error {
+	if s.authToken == "" {
+		return nil
+	}
+	scheme, token, found := strings.Cut(header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "bearer") ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid signer auth token")
+	}
+	return nil
+}
+
+func (s *SignerServer) GetPublicKeys(
+	ctx context.Context,
+	req *connect.Request[pb.GetPublicKeysRequest],
+) (*connect.Response[pb.GetPublicKeysResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&pb.GetPublicKeysResponse{
+		WalletAddress:  s.signer.GetAddress(),
+		TokenPublicKey: s.signer.GetPublicKey(),
+	}), nil
+}
+
+func (s *SignerServer) SignTransaction(
+	ctx context.Context,
+	req *connect.Request[pb.SignTransactionRequest],
+) (*connect.Response[pb.SignTransactionResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	amount := int64(0)
+	if s.inspector != nil {
+		outflows, err := s.inspector.GetOutflows(ctx, req.Msg.GetTxBytes(), s.signer.GetAddress())
+		if err != nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"failed to inspect transaction: %v",
+				err,
+			)
+		}
+		for address, outflow := range outflows {
+			if !s.isExempt(address) {
+				amount += outflow
+			}
+		}
+	}
+	if err := s.checkWithdrawLimits(amount); err != nil {
+		return nil, err
+	}
+	signature, err := s.signer.SignTransaction(ctx, req.Msg.GetTxBytes())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to sign transaction: %v",
+			err,
+		)
+	}
+	s.withdraws = append(s.withdraws, withdrawRecord{time: s.Now(), amount: amount})
+	return connect.NewResponse(&pb.SignTransactionResponse{
+		Signature: signature,
+	}), nil
+}
+
+func (s *SignerServer) isExempt(address string) bool {
+	for _, exempt := range s.policy.ExemptAddresses {
+		if strings.EqualFold(address, exempt) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWithdrawLimits must be called with s.mu held.
+func (s *SignerServer) checkWithdrawLimits(amount int64) error {
+	if s.policy.MaxWithdrawAmount > 0 && amount > s.policy.MaxWithdrawAmount {
+		return status.Errorf(
+			codes.PermissionDenied,
+			"withdraw amount %d exceeds limit %d",
+			amount,
+			s.policy.MaxWithdrawAmount,
+		)
+	}
+	windowStart := s.Now().Add(-24 * time.Hour)
+	recent := make([]withdrawRecord, 0)
+	total := amount
+	for _, record := range s.withdraws {
+		if record.time.After(windowStart) {
+			recent = append(recent, record)
+			total += record.amount
+		}
+	}
+	s.withdraws = recent
+	if s.policy.MaxDailyWithdrawAmount > 0 && total > s.policy.MaxDailyWithdrawAmount {
+		return status.Errorf(
+			codes.PermissionDenied,
+			"daily withdraw amount %d exceeds limit %d",
+			total,
+			s.policy.MaxDailyWithdrawAmount,
+		)
+	}
+	return nil
+}
+
+type tokenPolicyClaims struct {
+	Quantity  int64               `json:"quantity"`
+	ExpiresAt int64               `json:"exp"`
+	Usage     exchangepb.JwtUsage `json:"usage"`
+	// names of all claims of a JWT
+	names []string
+}
+
+// receiptClaimNames are the claims a purchase receipt may carry. Tokens with
+// any other, like the jti, sid and roles of access tokens, are not receipts.
+var receiptClaimNames = []string{
+	"iss", "sub", "aud", "iat", "token_jti", "seller", "amount", "price", "escrow", "usage",
+}
+
+// isPurchaseReceipt reports whether claims are those of a purchase receipt,
+// which never expires and cannot be spent or used to log in.
+func (c *tokenPolicyClaims) isPurchaseReceipt() bool {
+	if c.Usage != exchangepb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT {
+		return false
+	}
+	for _, name := range c.names {
+		if !slices.Contains(receiptClaimNames, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTokenPolicyClaims reads the claims limited by policy from the signing
+// input of a JWT or of the first block of a caveat token.
+func parseTokenPolicyClaims(signingInput string) (*tokenPolicyClaims, error) {
+	if caveat.IsAuthoritySigningInput(signingInput) {
+		authority, err := caveat.ParseAuthoritySigningInput(signingInput)
+		if err != nil {
+			return nil, err
+		}
+		return &tokenPolicyClaims{
+			Quantity:  authority.Quantity,
+			ExpiresAt: authority.ExpireTime,
+		}, nil
+	}
+	_, rawClaims, found := strings.Cut(signingInput, ".")
+	if !found {
+		return nil, fmt.Errorf("malformed jwt signing input")
+	}
+	claimsJson, err := base64.RawURLEncoding.DecodeString(rawClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode jwt claims: %v", err)
+	}
+	var claims tokenPolicyClaims
+	if err := json.Unmarshal(claimsJson, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse jwt claims: %v", err)
+	}
+	var claimsByName map[string]json.RawMessage
+	if err := json.Unmarshal(claimsJson, &claimsByName); err != nil {
+		return nil, fmt.Errorf("failed to parse jwt claims: %v", err)
+	}
+	claims.names = slices.Collect(maps.Keys(claimsByName))
+	return &claims, nil
+}
+
+func (s *SignerServer) checkTokenPolicy(signingInput string) error {
+	claims, err := parseTokenPolicyClaims(signingInput)
+	if err != nil {
+		return err
+	}
+	if s.policy.MaxTokenQuantity > 0 && claims.Quantity > s.policy.MaxTokenQuantity {
+		return fmt.Errorf("token quantity %d exceeds limit %d", claims.Quantity, s.policy.MaxTokenQuantity)
+	}
+	if s.policy.MaxTokenTtl > 0 && !claims.isPurchaseReceipt() {
+		if claims.ExpiresAt == 0 {
+			return fmt.Errorf("token without expiration")
+		}
+		if ttl := time.Unix(claims.ExpiresAt, 0).Sub(s.Now()); ttl > s.policy.MaxTokenTtl {
+			return fmt.Errorf("token ttl %s exceeds limit %s", ttl, s.policy.MaxTokenTtl)
+		}
+	}
+	return nil
+}
+
+// tokenSigner returns the signer of the token key of keyId, or of the backend
+// key if keyId is empty.
+func (s *SignerServer) tokenSigner(keyId string) (ITokenSigner, error) {
+	if keyId == "" {
+		return s.signer, nil
+	}
+	keyStore, ok := s.signer.(ITokenKeyStore)
+	if !ok {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"unknown token key %s",
+			keyId,
+		)
+	}
+	signer, err := keyStore.TokenSigner(keyId)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"failed to find token key: %v",
+			err,
+		)
+	}
+	return signer, nil
+}
+
+func (s *SignerServer) SignToken(
+	ctx context.Context,
+	req *connect.Request[pb.SignTokenRequest],
+) (*connect.Response[pb.SignTokenResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	if err := s.checkTokenPolicy(req.Msg.GetSigningInput()); err != nil {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"token rejected by policy: %v",
+			err,
+		)
+	}
+	signer, err := s.tokenSigner(req.Msg.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.SignToken(ctx, req.Msg.GetSigningInput())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to sign token: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.SignTokenResponse{
+		Signature: signature,
+	}), nil
+}
+
+func (s *SignerServer) SignTreeHead(
+	ctx context.Context,
+	req *connect.Request[pb.SignTreeHeadRequest],
+) (*connect.Response[pb.SignTreeHeadResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	signer, err := s.tokenSigner(req.Msg.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+	head := transparency.TreeHead{
+		Size:      req.Msg.GetTreeSize(),
+		RootHash:  req.Msg.GetRootHash(),
+		Timestamp: req.Msg.GetTimestamp().AsTime(),
+	}
+	signature, err := signer.SignToken(ctx, head.SigningInput())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to sign tree head: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.SignTreeHeadResponse{
+		Signature: signature,
+	}), nil
+}
+
+func (s *SignerServer) GenerateTokenKey(
+	ctx context.Context,
+	req *connect.Request[pb.GenerateTokenKeyRequest],
+) (*connect.Response[pb.GenerateTokenKeyResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	keyStore, ok := s.signer.(ITokenKeyStore)
+	if !ok {
+		return nil, status.Error(
+			codes.FailedPrecondition,
+			"signer backend cannot generate token keys",
+		)
+	}
+	publicKey, err := keyStore.GenerateTokenKey(ctx)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to generate token key: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.GenerateTokenKeyResponse{
+		TokenPublicKey: publicKey,
+	}), nil
+}
+
+func (s *SignerServer) blindKeyStore() (IBlindKeyStore, error) {
+	keyStore, ok := s.signer.(IBlindKeyStore)
+	if !ok {
+		return nil, status.Error(
+			codes.FailedPrecondition,
+			"signer backend cannot hold blind token keys",
+		)
+	}
+	return keyStore, nil
+}
+
+func blindKeyToPb(blindKey *BlindKey) (*pb.BlindKey, error) {
+	publicKey, err := x509.MarshalPKIXPublicKey(blindKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BlindKey{
+		KeyId:        blindKey.KeyId,
+		Denomination: blindKey.Denomination,
+		PublicKey:    publicKey,
+		IssueEndTime: timestamppb.New(blindKey.IssueEndTime),
+		ExpireTime:   timestamppb.New(blindKey.ExpireTime),
+	}, nil
+}
+
+func (s *SignerServer) GenerateBlindKey(
+	ctx context.Context,
+	req *connect.Request[pb.GenerateBlindKeyRequest],
+) (*connect.Response[pb.GenerateBlindKeyResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	keyStore, err := s.blindKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	blindKey, err := keyStore.GenerateBlindKey(
+		ctx,
+		req.Msg.GetDenomination(),
+		req.Msg.GetIssueEndTime().AsTime(),
+		req.Msg.GetExpireTime().AsTime(),
+	)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to generate blind key: %v",
+			err,
+		)
+	}
+	ret, err := blindKeyToPb(blindKey)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to encode blind key: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.GenerateBlindKeyResponse{
+		BlindKey: ret,
+	}), nil
+}
+
+func (s *SignerServer) ListBlindKeys(
+	ctx context.Context,
+	req *connect.Request[pb.ListBlindKeysRequest],
+) (*connect.Response[pb.ListBlindKeysResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	keyStore, err := s.blindKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	blindKeys, err := keyStore.ListBlindKeys(ctx, time.Now())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"failed to list blind keys: %v",
+			err,
+		)
+	}
+	ret := make([]*pb.BlindKey, 0, len(blindKeys))
+	for i := range blindKeys {
+		blindKey, err := blindKeyToPb(&blindKeys[i])
+		if err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"failed to encode blind key: %v",
+				err,
+			)
+		}
+		ret = append(ret, blindKey)
+	}
+	return connect.NewResponse(&pb.ListBlindKeysResponse{
+		BlindKeys: ret,
+	}), nil
+}
+
+func (s *SignerServer) SignBlinded(
+	ctx context.Context,
+	req *connect.Request[pb.SignBlindedRequest],
+) (*connect.Response[pb.SignBlindedResponse], error) {
+	if err := s.authorize(req.Header()); err != nil {
+		return nil, err
+	}
+	keyStore, err := s.blindKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	blindSignatures, err := keyStore.SignBlinded(ctx, req.Msg.GetKeyId(), req.Msg.GetBlindedMessages())
+	if errors.Is(err, ErrUnknownBlindKey) {
+		return nil, status.Errorf(
+			codes.NotFound,
+			"failed to find blind key: %v",
+			err,
+		)
+	} else if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"failed to sign blinded messages: %v",
+			err,
+		)
+	}
+	return connect.NewResponse(&pb.SignBlindedResponse{
+		BlindSignatures: blindSignatures,
+	}), nil
+}
@@ -0,0 +1,60 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	BACKEND_MEMORY   = "memory"
+	BACKEND_KEYSTORE = "keystore"
+	BACKEND_REMOTE   = "remote"
+)
+
+// ITransactionSigner signs Sui transactions paid by the platform wallet.
+type ITransactionSigner interface {
+	GetAddress() string
+	// SignTransaction returns the serialized Sui signature of base64 encoded
+	// BCS transaction bytes.
+	SignTransaction(ctx context.Context, txBytes string) (string, error)
+}
+
+// ITokenSigner signs the JWTs issued by Prex with an ed25519 key.
+type ITokenSigner interface {
+	GetPublicKey() ed25519.PublicKey
+	SignToken(ctx context.Context, signingInput string) ([]byte, error)
+}
+
+// ITokenKeyStore is a backend holding token keys besides its own, e.g. the
+// keys staged for rotation, so that their private keys never leave it.
+type ITokenKeyStore interface {
+	// GenerateTokenKey creates a token key and returns its public key.
+	GenerateTokenKey(ctx context.Context) (ed25519.PublicKey, error)
+	// TokenSigner returns the signer of the token key of keyId.
+	TokenSigner(keyId string) (ITokenSigner, error)
+}
+
+type ISigner interface {
+	ITransactionSigner
+	ITokenSigner
+}
+
+// SignJwt signs token with tokenSigner. The token method must be EdDSA so that
+// it verifies as a plain ed25519 JWT.
+func SignJwt(ctx context.Context, tokenSigner ITokenSigner, token *jwt.Token) (string, error) {
+	if token.Method.Alg() != jwt.SigningMethodEdDSA.Alg() {
+		return "", fmt.Errorf("expect signing method EdDSA but got %s", token.Method.Alg())
+	}
+	signingInput, err := token.SigningString()
+	if err != nil {
+		return "", err
+	}
+	signature, err := tokenSigner.SignToken(ctx, signingInput)
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + token.EncodeSegment(signature), nil
+}
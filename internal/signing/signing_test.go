@@ -0,0 +1,415 @@
+package signing_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/internal/utils"
+	"github.com/atticplaygroup/prex/pkg/blind"
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	exchangepb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1/signerconnect"
+	"github.com/atticplaygroup/prex/pkg/transparency"
+	"github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/signer"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+type fixedInspector map[string]int64
+
+func (i fixedInspector) GetOutflows(
+	ctx context.Context, txBytes string, sender string,
+) (map[string]int64, error) {
+	return i, nil
+}
+
+var _ = Describe("Signing", Label("signing"), func() {
+	secrets := signing.KeystoreSecrets{
+		WalletMnemonic:   "afford list spatial try loop tunnel gift oil guess soldier happy faint",
+		TokenSigningSeed: "0x1111111111111111111111111111111111111111111111111111111111111111",
+	}
+	coldAddress := "0xc228a949decc98affe62522cf3f56db12686d068fab82fab605c241cafe5197c"
+	recipientAddress := "0x4f89910d450a3654e82bc3f573e24dfcbbeed23cb3b87de4883e890bfd952473"
+	// Any base64 payload works as signing does not parse the transaction
+	txBytes := "AAACAAgQJwAAAAAAAAAg"
+	ctx := context.Background()
+
+	It("should round trip an encrypted keystore", func() {
+		seed, err := utils.HexToBytes32(secrets.TokenSigningSeed)
+		Expect(err).To(BeNil())
+		memorySigner, err := signing.NewMemorySigner(secrets.WalletMnemonic, seed)
+		Expect(err).To(BeNil())
+
+		path := filepath.Join(GinkgoT().TempDir(), "keystore.json")
+		Expect(signing.CreateKeystore(path, []byte("hunter2"), secrets)).To(Succeed())
+		Expect(signing.CreateKeystore(path, []byte("hunter2"), secrets)).NotTo(Succeed())
+
+		keystore, err := signing.OpenKeystore(path, []byte("hunter2"))
+		Expect(err).To(BeNil())
+		Expect(keystore.GetAddress()).To(Equal(memorySigner.GetAddress()))
+		Expect(didkey.KeyId(keystore.GetPublicKey())).To(Equal(didkey.KeyId(memorySigner.GetPublicKey())))
+
+		_, err = signing.OpenKeystore(path, []byte("hunter3"))
+		Expect(err).To(MatchError(ContainSubstring("wrong passphrase")))
+	})
+
+	It("should parse the public key back from its did:key", func() {
+		seed, err := utils.HexToBytes32(secrets.TokenSigningSeed)
+		Expect(err).To(BeNil())
+		memorySigner, err := signing.NewMemorySigner(secrets.WalletMnemonic, seed)
+		Expect(err).To(BeNil())
+
+		publicKey, err := didkey.ParseKeyId(didkey.KeyId(memorySigner.GetPublicKey()))
+		Expect(err).To(BeNil())
+		Expect(publicKey).To(Equal(memorySigner.GetPublicKey()))
+
+		_, err = didkey.ParseKeyId("did:web:example.com")
+		Expect(err).NotTo(BeNil())
+		_, err = didkey.ParseKeyId("did:key:z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should sign jwt verifiable as ed25519", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "keystore.json")
+		Expect(signing.CreateKeystore(path, []byte("hunter2"), secrets)).To(Succeed())
+		keystore, err := signing.OpenKeystore(path, []byte("hunter2"))
+		Expect(err).To(BeNil())
+
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"sub": "1"})
+		signed, err := signing.SignJwt(ctx, keystore, token)
+		Expect(err).To(BeNil())
+		parsed, err := jwt.Parse(signed, func(token *jwt.Token) (interface{}, error) {
+			return keystore.GetPublicKey(), nil
+		}, jwt.WithValidMethods([]string{"EdDSA"}))
+		Expect(err).To(BeNil())
+		Expect(parsed.Valid).To(BeTrue())
+	})
+
+	It("should rotate to a staged key with overlapping validity", func() {
+		keystorePath := filepath.Join(GinkgoT().TempDir(), "keystore.json")
+		Expect(signing.CreateKeystore(keystorePath, []byte("hunter2"), secrets)).To(Succeed())
+		keystore, err := signing.OpenKeystore(keystorePath, []byte("hunter2"))
+		Expect(err).To(BeNil())
+		backendKeyId := didkey.KeyId(keystore.GetPublicKey())
+
+		path := filepath.Join(GinkgoT().TempDir(), "keyring.json")
+		now := time.Now()
+		activateTime := now.Add(time.Hour)
+		keyId, err := signing.StageKey(ctx, path, keystore, activateTime, time.Hour)
+		Expect(err).To(BeNil())
+		content, err := os.ReadFile(path)
+		Expect(err).To(BeNil())
+		Expect(string(content)).NotTo(ContainSubstring("seed"))
+
+		By("loading the staged key from the keystore in a new process")
+		keystore, err = signing.OpenKeystore(keystorePath, []byte("hunter2"))
+		Expect(err).To(BeNil())
+		keyring, err := signing.LoadKeyring(path, keystore, nil)
+		Expect(err).To(BeNil())
+
+		primary, err := keyring.Primary(now)
+		Expect(err).To(BeNil())
+		Expect(primary.KeyId).To(Equal(backendKeyId))
+		Expect(keyring.Published(now)).To(HaveLen(2))
+
+		primary, err = keyring.Primary(activateTime)
+		Expect(err).To(BeNil())
+		Expect(primary.KeyId).To(Equal(keyId))
+		_, err = keyring.VerificationKey(backendKeyId, activateTime)
+		Expect(err).To(BeNil())
+
+		retired := activateTime.Add(time.Hour)
+		_, err = keyring.VerificationKey(backendKeyId, retired)
+		Expect(err).To(MatchError(signing.ErrUnknownKey))
+		Expect(keyring.Published(retired)).To(HaveExactElements(HaveField("KeyId", keyId)))
+	})
+
+	It("should refuse to stage keys the memory backend cannot hold", func() {
+		memorySigner, err := signing.NewMemorySigner(secrets.WalletMnemonic, make([]byte, 32))
+		Expect(err).To(BeNil())
+		path := filepath.Join(GinkgoT().TempDir(), "keyring.json")
+		_, err = signing.StageKey(ctx, path, memorySigner, time.Now(), time.Hour)
+		Expect(err).To(MatchError(ContainSubstring("cannot generate token keys")))
+	})
+
+	It("should verify tokens without kid by the backend key until they expire", func() {
+		memorySigner, err := signing.NewMemorySigner(secrets.WalletMnemonic, make([]byte, 32))
+		Expect(err).To(BeNil())
+		keyring, err := signing.LoadKeyring("", memorySigner, nil)
+		Expect(err).To(BeNil())
+		now := time.Now()
+		_, err = keyring.VerificationKey("", now)
+		Expect(err).To(MatchError(signing.ErrUnknownKey))
+
+		keyring.AllowMissingKeyId(didkey.KeyId(memorySigner.GetPublicKey()), now.Add(time.Minute))
+		publicKey, err := keyring.VerificationKey("", now)
+		Expect(err).To(BeNil())
+		Expect(publicKey).To(Equal(memorySigner.GetPublicKey()))
+		_, err = keyring.VerificationKey("", now.Add(time.Minute))
+		Expect(err).To(MatchError(signing.ErrUnknownKey))
+	})
+
+	It("should hold blind token keys in the keystore until they expire", func() {
+		memorySigner, err := signing.NewMemorySigner(secrets.WalletMnemonic, make([]byte, 32))
+		Expect(err).To(BeNil())
+		now := time.Now()
+		_, err = signing.NewBlindKeyring(ctx, memorySigner, []int64{1}, time.Hour, time.Hour, now)
+		Expect(err).To(MatchError(ContainSubstring("cannot hold blind token keys")))
+
+		keystorePath := filepath.Join(GinkgoT().TempDir(), "keystore.json")
+		Expect(signing.CreateKeystore(keystorePath, []byte("hunter2"), secrets)).To(Succeed())
+		keystore, err := signing.OpenKeystore(keystorePath, []byte("hunter2"))
+		Expect(err).To(BeNil())
+		blindKeyring, err := signing.NewBlindKeyring(ctx, keystore, []int64{1, 10}, time.Hour, time.Hour, now)
+		Expect(err).To(BeNil())
+		published, err := blindKeyring.Published(ctx, now)
+		Expect(err).To(BeNil())
+		Expect(published).To(HaveLen(2))
+		keyIds := []string{published[0].KeyId, published[1].KeyId}
+
+		request, err := blind.NewRequest(published[0].PublicKey, "did:key:z6MkSeller")
+		Expect(err).To(BeNil())
+		blindSignatures, err := blindKeyring.Sign(ctx, request.KeyId, [][]byte{request.Blinded})
+		Expect(err).To(BeNil())
+		_, err = request.Finalize(blindSignatures[0])
+		Expect(err).To(BeNil())
+
+		By("loading the keys from the keystore in a new process")
+		keystore, err = signing.OpenKeystore(keystorePath, []byte("hunter2"))
+		Expect(err).To(BeNil())
+		blindKeyring, err = signing.NewBlindKeyring(ctx, keystore, []int64{1, 10}, time.Hour, time.Hour, now)
+		Expect(err).To(BeNil())
+		published, err = blindKeyring.Published(ctx, now)
+		Expect(err).To(BeNil())
+		Expect(published).To(ConsistOf(HaveField("KeyId", keyIds[0]), HaveField("KeyId", keyIds[1])))
+
+		By("rotating keys once they stop issuing")
+		issueEndTime := now.Add(time.Hour)
+		published, err = blindKeyring.Published(ctx, issueEndTime)
+		Expect(err).To(BeNil())
+		Expect(published).To(HaveLen(4))
+		Expect(published[:2]).NotTo(ContainElement(HaveField("KeyId", BeElementOf(keyIds))))
+		blindKey, err := blindKeyring.Key(ctx, keyIds[0], issueEndTime)
+		Expect(err).To(BeNil())
+		Expect(blindKey.IsIssuing(issueEndTime)).To(BeFalse())
+
+		By("forgetting keys once they expire")
+		_, err = blindKeyring.Key(ctx, keyIds[0], issueEndTime.Add(time.Hour))
+		Expect(err).To(MatchError(signing.ErrUnknownBlindKey))
+	})
+
+	Describe("remote signer", func() {
+		var memorySigner *signing.MemorySigner
+		var server *httptest.Server
+		var remoteSigner *signing.RemoteSigner
+		outflows := fixedInspector{}
+
+		BeforeEach(func() {
+			var err error
+			memorySigner, err = signing.NewMemorySigner(secrets.WalletMnemonic, make([]byte, 32))
+			Expect(err).To(BeNil())
+			signerServer, err := signing.NewSignerServer(memorySigner, signing.Policy{
+				MaxWithdrawAmount:      1_000,
+				MaxDailyWithdrawAmount: 1_500,
+				ExemptAddresses:        []string{coldAddress},
+				MaxTokenQuantity:       100,
+				MaxTokenTtl:            time.Hour,
+			}, outflows, "secret")
+			Expect(err).To(BeNil())
+			_, handler := signerconnect.NewSignerServiceHandler(signerServer)
+			server = httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+			DeferCleanup(server.Close)
+
+			remoteSigner, err = signing.NewRemoteSigner(ctx, server.URL, "secret")
+			Expect(err).To(BeNil())
+			Expect(remoteSigner.GetAddress()).To(Equal(memorySigner.GetAddress()))
+		})
+
+		It("should reject callers without the auth token", func() {
+			_, err := signing.NewRemoteSigner(ctx, server.URL, "wrong")
+			Expect(err).To(MatchError(ContainSubstring("invalid signer auth token")))
+		})
+
+		It("should enforce withdraw limits", func() {
+			outflows[recipientAddress] = 800
+			outflows[coldAddress] = 1_000_000
+			signature, err := remoteSigner.SignTransaction(ctx, txBytes)
+			Expect(err).To(BeNil())
+			wallet, err := signer.NewSignertWithMnemonic(secrets.WalletMnemonic)
+			Expect(err).To(BeNil())
+			txnMetaData := models.TxnMetaData{TxBytes: txBytes}
+			Expect(signature).To(Equal(txnMetaData.SignSerializedSigWith(wallet.PriKey).Signature))
+
+			By("exceeding the daily limit")
+			_, err = remoteSigner.SignTransaction(ctx, txBytes)
+			Expect(err).To(MatchError(ContainSubstring("daily withdraw amount")))
+
+			By("exceeding the per transaction limit")
+			outflows[recipientAddress] = 1_001
+			_, err = remoteSigner.SignTransaction(ctx, txBytes)
+			Expect(err).To(MatchError(ContainSubstring("exceeds limit 1000")))
+		})
+
+		It("should enforce token limits", func() {
+			newToken := func(quantity int64, ttl time.Duration) *jwt.Token {
+				return jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+					"quantity": quantity,
+					"exp":      time.Now().Add(ttl).Unix(),
+				})
+			}
+			_, err := signing.SignJwt(ctx, remoteSigner, newToken(100, time.Minute))
+			Expect(err).To(BeNil())
+			_, err = signing.SignJwt(ctx, remoteSigner, newToken(101, time.Minute))
+			Expect(err).To(MatchError(ContainSubstring("token quantity 101 exceeds limit")))
+			_, err = signing.SignJwt(ctx, remoteSigner, newToken(1, 2*time.Hour))
+			Expect(err).To(MatchError(ContainSubstring("ttl")))
+
+			By("signing purchase receipts, which do not expire")
+			_, err = signing.SignJwt(ctx, remoteSigner, jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+				"token_jti": "5e6f7081-92a3-4b4c-8d5e-6f708192a3b4",
+				"amount":    100,
+				"usage":     exchangepb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT,
+			}))
+			Expect(err).To(BeNil())
+
+			By("refusing access tokens passed off as receipts")
+			_, err = signing.SignJwt(ctx, remoteSigner, jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+				"sub":   "1",
+				"jti":   "5e6f7081-92a3-4b4c-8d5e-6f708192a3b4",
+				"sid":   "session",
+				"roles": []string{"admin"},
+				"usage": exchangepb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT,
+			}))
+			Expect(err).To(MatchError(ContainSubstring("token without expiration")))
+			_, err = signing.SignJwt(ctx, remoteSigner, jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+				"quantity": 1,
+				"usage":    exchangepb.JwtUsage_JWT_USAGE_CREATE_SESSION,
+			}))
+			Expect(err).To(MatchError(ContainSubstring("token without expiration")))
+		})
+
+		It("should enforce token limits on caveat tokens", func() {
+			newAuthority := func(quantity int64, ttl time.Duration) caveat.Authority {
+				return caveat.Authority{
+					Jti:        "5e6f7081-92a3-4b4c-8d5e-6f708192a3b4",
+					Audience:   "did:key:z6MkSeller",
+					Quantity:   quantity,
+					IssuedAt:   time.Now().Unix(),
+					ExpireTime: time.Now().Add(ttl).Unix(),
+				}
+			}
+			token, err := caveat.Issue(ctx, remoteSigner, newAuthority(100, time.Minute))
+			Expect(err).To(BeNil())
+			_, err = caveat.Parse(token.String(), caveat.TrustedKeys(didkey.KeyId(memorySigner.GetPublicKey())))
+			Expect(err).To(BeNil())
+			_, err = caveat.Issue(ctx, remoteSigner, newAuthority(101, time.Minute))
+			Expect(err).To(MatchError(ContainSubstring("token quantity 101 exceeds limit")))
+			_, err = caveat.Issue(ctx, remoteSigner, newAuthority(1, 2*time.Hour))
+			Expect(err).To(MatchError(ContainSubstring("ttl")))
+		})
+
+		It("should sign tree heads only through their own path", func() {
+			rootHash := sha256.Sum256([]byte("leaf"))
+			head := transparency.TreeHead{
+				Size:      1,
+				RootHash:  rootHash[:],
+				Timestamp: time.Now(),
+			}
+			signature, err := head.Sign(ctx, remoteSigner)
+			Expect(err).To(BeNil())
+			Expect(head.Verify(memorySigner.GetPublicKey(), signature)).To(Succeed())
+
+			By("refusing tree heads passed as tokens")
+			_, err = remoteSigner.SignToken(ctx, head.SigningInput())
+			Expect(err).NotTo(BeNil())
+		})
+
+		// newKeystoreSigner serves a keystore backend that can generate keys
+		newKeystoreSigner := func() *signing.RemoteSigner {
+			keystorePath := filepath.Join(GinkgoT().TempDir(), "keystore.json")
+			Expect(signing.CreateKeystore(keystorePath, []byte("hunter2"), secrets)).To(Succeed())
+			keystore, err := signing.OpenKeystore(keystorePath, []byte("hunter2"))
+			Expect(err).To(BeNil())
+			signerServer, err := signing.NewSignerServer(keystore, signing.Policy{
+				MaxTokenQuantity: 100,
+			}, nil, "secret")
+			Expect(err).To(BeNil())
+			_, handler := signerconnect.NewSignerServiceHandler(signerServer)
+			keystoreServer := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+			DeferCleanup(keystoreServer.Close)
+			keystoreSigner, err := signing.NewRemoteSigner(ctx, keystoreServer.URL, "secret")
+			Expect(err).To(BeNil())
+			return keystoreSigner
+		}
+
+		It("should stage keys held by the signer", func() {
+			keystoreSigner := newKeystoreSigner()
+
+			By("refusing to generate keys with the memory backend")
+			_, err := remoteSigner.GenerateTokenKey(ctx)
+			Expect(err).To(MatchError(ContainSubstring("cannot generate token keys")))
+
+			path := filepath.Join(GinkgoT().TempDir(), "keyring.json")
+			now := time.Now()
+			keyId, err := signing.StageKey(ctx, path, keystoreSigner, now, time.Hour)
+			Expect(err).To(BeNil())
+			keyring, err := signing.LoadKeyring(path, keystoreSigner, nil)
+			Expect(err).To(BeNil())
+			primary, err := keyring.Primary(now)
+			Expect(err).To(BeNil())
+			Expect(primary.KeyId).To(Equal(keyId))
+
+			token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"quantity": 1})
+			signed, err := signing.SignJwt(ctx, primary.Signer, token)
+			Expect(err).To(BeNil())
+			_, err = jwt.Parse(signed, func(token *jwt.Token) (any, error) {
+				return primary.PublicKey, nil
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
+			Expect(err).To(BeNil())
+
+			By("refusing keys the signer does not hold")
+			unknown, err := signing.NewMemorySigner(secrets.WalletMnemonic, make([]byte, 32))
+			Expect(err).To(BeNil())
+			unknownSigner, err := keystoreSigner.TokenSigner(didkey.KeyId(unknown.GetPublicKey()))
+			Expect(err).To(BeNil())
+			_, err = signing.SignJwt(ctx, unknownSigner, token)
+			Expect(err).To(MatchError(ContainSubstring("not in the keystore")))
+		})
+
+		It("should sign blind tokens with keys held by the signer", func() {
+			keystoreSigner := newKeystoreSigner()
+			now := time.Now()
+
+			By("refusing to generate keys with the memory backend")
+			_, err := remoteSigner.GenerateBlindKey(ctx, 1, now.Add(time.Hour), now.Add(2*time.Hour))
+			Expect(err).To(MatchError(ContainSubstring("cannot hold blind token keys")))
+
+			blindKeyring, err := signing.NewBlindKeyring(ctx, keystoreSigner, []int64{10}, time.Hour, time.Hour, now)
+			Expect(err).To(BeNil())
+			published, err := blindKeyring.Published(ctx, now)
+			Expect(err).To(BeNil())
+			Expect(published).To(HaveExactElements(HaveField("Denomination", int64(10))))
+			request, err := blind.NewRequest(published[0].PublicKey, "did:key:z6MkSeller")
+			Expect(err).To(BeNil())
+			blindSignatures, err := blindKeyring.Sign(ctx, request.KeyId, [][]byte{request.Blinded})
+			Expect(err).To(BeNil())
+			token, err := request.Finalize(blindSignatures[0])
+			Expect(err).To(BeNil())
+			Expect(token.Verify(published[0].PublicKey)).To(Succeed())
+
+			By("refusing keys the signer does not hold")
+			_, err = keystoreSigner.SignBlinded(ctx, "unknown", [][]byte{request.Blinded})
+			Expect(err).To(MatchError(ContainSubstring("not in the keystore")))
+		})
+	})
+})
@@ -10,8 +10,10 @@ import (
 
 type UpsertAccountTxParams struct {
 	db.UpsertAccountParams
-	Digest string
-	Epoch  int64
+	Digest        string
+	Epoch         int64
+	PaymentMethod string
+	SenderAddress string
 }
 
 func (s *Store) DoUpsertAccountWithTx(
@@ -30,6 +32,8 @@ func (s *Store) DoUpsertAccountWithTx(
 		AccountID:         account.AccountID,
 		TransactionDigest: arg.Digest,
 		Epoch:             arg.Epoch,
+		PaymentMethod:     arg.PaymentMethod,
+		SenderAddress:     arg.SenderAddress,
 	}); err != nil {
 		return nil, fmt.Errorf("AddDepositRecord failed: %v", err)
 	}
@@ -56,28 +60,91 @@ func (s *Store) UpsertAccountTx(ctx context.Context, arg *UpsertAccountTxParams)
 type BuyTokenTxParams struct {
 	Req     *pb.BuyTokenRequest
 	BuyerID int64
+	// ApiKeyID is charged the amount if the buyer used an API key
+	ApiKeyID int64
 }
 
+// BuyTokenTx pays the audience of the token from the buyer. Escrowed amounts
+// are only charged here and paid as the audience claims them.
 func (s *Store) BuyTokenTx(
 	ctx context.Context,
 	qtx *db.Queries,
 	arg *BuyTokenTxParams,
 ) (*db.Account, error) {
-
+	if err := chargeApiKey(ctx, qtx, arg.ApiKeyID, arg.Req.GetAmount()); err != nil {
+		return nil, err
+	}
 	account, err := qtx.ChangeBalance(ctx, db.ChangeBalanceParams{
 		AccountID:     arg.BuyerID,
-		BalanceChange: arg.Req.GetAmount(),
+		BalanceChange: -arg.Req.GetAmount(),
 	})
 	if err != nil {
 		return nil, err
 	}
+	if arg.Req.GetEscrow() {
+		return &account, nil
+	}
 
 	_, err = qtx.ChangeBalanceByUsername(ctx, db.ChangeBalanceByUsernameParams{
 		Username:      arg.Req.GetAudience(),
-		BalanceChange: -arg.Req.GetAmount(),
+		BalanceChange: arg.Req.GetAmount(),
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &account, nil
 }
+
+// PruneAccountsTx deletes expired accounts and returns their ids. Escrows
+// still held for a pruned seller are settled first, paying the unclaimed rest
+// back to the buyers.
+func (s *Store) PruneAccountsTx(ctx context.Context) ([]int64, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	escrows, err := qtx.ListUnsettledEscrowsOfInvalidSellers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, escrow := range escrows {
+		if _, err := settleEscrow(ctx, qtx, escrow.Jti); err != nil {
+			return nil, fmt.Errorf("failed to settle escrow %s: %v", escrow.Jti, err)
+		}
+	}
+	if _, err := qtx.DeleteSettledEscrowsOfInvalidSellers(ctx); err != nil {
+		return nil, err
+	}
+	accountIds, err := qtx.DeleteInvalidAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return accountIds, nil
+}
+
+// ResetPasswordTx sets a new password of the account and revokes all its API
+// keys, which could otherwise keep spending for whoever leaked the old one.
+func (s *Store) ResetPasswordTx(ctx context.Context, arg db.ResetAccountPasswordParams) (*db.Account, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	account, err := qtx.ResetAccountPassword(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := qtx.RevokeAccountApiKeys(ctx, account.AccountID); err != nil {
+		return nil, fmt.Errorf("failed to revoke api keys: %v", err)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
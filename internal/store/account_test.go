@@ -9,6 +9,8 @@ import (
 
 	db "github.com/atticplaygroup/prex/internal/db/sqlc"
 	"github.com/atticplaygroup/prex/internal/store"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -98,3 +100,143 @@ var _ = Describe("Checking deposit into database", Label("db"), func() {
 		})
 	})
 })
+
+var _ = Describe("Buying tokens", Label("db"), func() {
+	ctx := context.Background()
+	var buyer, seller *db.Account
+
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+		accounts := NewAccounts(ctx, *StoreInstance, "test_buyer", "did:key:z6MkSeller")
+		buyer, seller = accounts[0], accounts[1]
+	})
+
+	// buy returns how the balances of the buyer and the seller changed
+	buy := func(req *pb.BuyTokenRequest) (int64, int64) {
+		s := *StoreInstance
+		req.Audience = seller.Username
+		tx, err := s.GetConn().Begin(ctx)
+		Expect(err).To(BeNil())
+		account, err := s.BuyTokenTx(ctx, s.Queries.WithTx(tx), &store.BuyTokenTxParams{
+			Req:     req,
+			BuyerID: buyer.AccountID,
+		})
+		Expect(err).To(BeNil())
+		Expect(tx.Commit(ctx)).To(Succeed())
+
+		buyerAccount, err := s.QueryBalance(ctx, buyer.AccountID)
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(Equal(buyerAccount.Balance))
+		sellerAccount, err := s.QueryBalance(ctx, seller.AccountID)
+		Expect(err).To(BeNil())
+		return buyerAccount.Balance - buyer.Balance, sellerAccount.Balance - seller.Balance
+	}
+
+	It("should charge the buyer and pay the audience", func() {
+		buyerChange, sellerChange := buy(&pb.BuyTokenRequest{Amount: 300})
+		Expect(buyerChange).To(BeNumerically("<", 0))
+		Expect(sellerChange).To(BeNumerically(">", 0))
+		Expect(buyerChange).To(Equal(int64(-300)))
+		Expect(sellerChange).To(Equal(int64(300)))
+	})
+
+	It("should charge the buyer but hold the payment of escrowed tokens", func() {
+		buyerChange, sellerChange := buy(&pb.BuyTokenRequest{Amount: 300, Escrow: true})
+		Expect(buyerChange).To(Equal(int64(-300)))
+		Expect(sellerChange).To(BeZero())
+	})
+})
+
+var _ = Describe("Resetting passwords", Label("db"), func() {
+	owner := "0xe789fb3f9e6e0736b648f3f33ff60bc0e4583583b2142cb2665bcc520635aac0"
+	depositor := "0xc228a949decc98affe62522cf3f56db12686d068fab82fab605c241cafe5197c"
+
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	It("should only let the first depositor reset", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		for i, sender := range []string{owner, depositor} {
+			_, err := s.UpsertAccountTx(ctx, &store.UpsertAccountTxParams{
+				Digest: []string{
+					"DdzbG47u5MDUrSVArmVYmvnpDvspgKeAXxzgq2cNnhp6",
+					"DdzbG47u5MDUrSVArmVYmvnpDvspgKeAXxzgq2cNnhp7",
+				}[i],
+				SenderAddress: sender,
+				UpsertAccountParams: db.UpsertAccountParams{
+					Username: "test_user_1",
+					Password: "",
+					Balance:  1_000,
+					Ttl: pgtype.Interval{
+						Microseconds: 3600 * 1000 * 1000,
+						Valid:        true,
+					},
+					Privilege: "user",
+				},
+			})
+			Expect(err).To(BeNil())
+		}
+		params := db.ResetAccountPasswordParams{
+			Password: "new_hash",
+			Cooldown: pgtype.Interval{
+				Microseconds: 3600 * 1000 * 1000,
+				Valid:        true,
+			},
+			Username:      "test_user_1",
+			SenderAddress: depositor,
+		}
+
+		_, err := s.ResetAccountPassword(ctx, params)
+		Expect(err).To(MatchError(pgx.ErrNoRows))
+
+		params.SenderAddress = owner
+		account, err := s.ResetAccountPassword(ctx, params)
+		Expect(err).To(BeNil())
+		Expect(account.Password).To(Equal("new_hash"))
+		Expect(account.WithdrawCooldownUntil.Valid).To(BeTrue())
+	})
+
+	It("should revoke the api keys of the account", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		account, err := s.UpsertAccountTx(ctx, &store.UpsertAccountTxParams{
+			Digest:        "DdzbG47u5MDUrSVArmVYmvnpDvspgKeAXxzgq2cNnhp8",
+			SenderAddress: owner,
+			UpsertAccountParams: db.UpsertAccountParams{
+				Username: "test_user_1",
+				Password: "",
+				Balance:  1_000,
+				Ttl: pgtype.Interval{
+					Microseconds: 3600 * 1000 * 1000,
+					Valid:        true,
+				},
+				Privilege: "user",
+			},
+		})
+		Expect(err).To(BeNil())
+		_, err = s.CreateApiKey(ctx, db.CreateApiKeyParams{
+			AccountID:   account.AccountID,
+			DisplayName: "bot",
+			KeyPrefix:   "prex_test",
+			KeyHash:     []byte("hash"),
+			Scopes:      []string{"buy-token", "withdraw"},
+		})
+		Expect(err).To(BeNil())
+
+		_, err = s.ResetPasswordTx(ctx, db.ResetAccountPasswordParams{
+			Password:      "new_hash",
+			Username:      "test_user_1",
+			SenderAddress: owner,
+			Cooldown: pgtype.Interval{
+				Valid: true,
+			},
+		})
+		Expect(err).To(BeNil())
+		apiKeys, err := s.ListApiKeys(ctx, account.AccountID)
+		Expect(err).To(BeNil())
+		Expect(apiKeys).To(HaveLen(1))
+		Expect(apiKeys[0].RevokeTime.Valid).To(BeTrue())
+	})
+})
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrSpendLimitExceeded = errors.New("api key spend limit exceeded")
+
+// chargeApiKey adds amount to what apiKeyId has spent. An apiKeyId of 0 means
+// no API key was used.
+func chargeApiKey(ctx context.Context, qtx *db.Queries, apiKeyId int64, amount int64) error {
+	if apiKeyId == 0 {
+		return nil
+	}
+	_, err := qtx.ChargeApiKey(ctx, db.ChargeApiKeyParams{
+		ApiKeyID: apiKeyId,
+		Amount:   amount,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: cannot spend %d more", ErrSpendLimitExceeded, amount)
+	}
+	return err
+}
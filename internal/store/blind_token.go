@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrBlindTokenSpent   = errors.New("blind token already spent")
+	ErrBlindTokenUnknown = errors.New("no blind token of the key is outstanding or the key expired")
+)
+
+type IssueBlindTokensTxParams struct {
+	BuyerID int64
+	// ApiKeyID is charged the amount if the buyer used an API key
+	ApiKeyID     int64
+	KeyId        string
+	Denomination int64
+	Count        int64
+	// ExpireTime is when tokens of the key stop being redeemable
+	ExpireTime time.Time
+}
+
+// IssueBlindTokensTx charges the buyer for count tokens of a denomination. It
+// runs in the transaction of qtx so the caller can sign the tokens after the
+// charge and before committing it. Nothing about the tokens is stored so they
+// cannot be linked to the buyer.
+func (s *Store) IssueBlindTokensTx(
+	ctx context.Context,
+	qtx *db.Queries,
+	arg *IssueBlindTokensTxParams,
+) (*db.Account, error) {
+	amount := arg.Denomination * arg.Count
+	if err := chargeApiKey(ctx, qtx, arg.ApiKeyID, amount); err != nil {
+		return nil, err
+	}
+	account, err := qtx.ChangeBalance(ctx, db.ChangeBalanceParams{
+		AccountID:     arg.BuyerID,
+		BalanceChange: -amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qtx.RecordBlindTokensIssued(ctx, db.RecordBlindTokensIssuedParams{
+		KeyID:        arg.KeyId,
+		Denomination: arg.Denomination,
+		Count:        arg.Count,
+		ExpireTime:   pgtype.Timestamptz{Time: arg.ExpireTime, Valid: true},
+	}); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+type RedeemBlindTokenTxParams struct {
+	KeyId      string
+	Nonce      []byte
+	RedeemerID int64
+}
+
+// RedeemBlindTokenTx marks a verified token spent and pays its denomination
+// to the redeemer.
+func (s *Store) RedeemBlindTokenTx(ctx context.Context, arg RedeemBlindTokenTxParams) (*db.BlindTokenKey, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	blindTokenKey, err := qtx.RecordBlindTokenRedeemed(ctx, arg.KeyId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBlindTokenUnknown
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err = qtx.SpendBlindToken(ctx, db.SpendBlindTokenParams{
+		KeyID:      arg.KeyId,
+		Nonce:      arg.Nonce,
+		RedeemerID: pgtype.Int8{Int64: arg.RedeemerID, Valid: true},
+	}); errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBlindTokenSpent
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err = qtx.ChangeBalance(ctx, db.ChangeBalanceParams{
+		AccountID:     arg.RedeemerID,
+		BalanceChange: blindTokenKey.Denomination,
+	}); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return &blindTokenKey, nil
+}
@@ -0,0 +1,133 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/jackc/pgx/v5/pgtype"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Blind tokens", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	// issue charges the buyer of params and commits
+	issue := func(ctx context.Context, s store.Store, params store.IssueBlindTokensTxParams) *db.Account {
+		tx, err := s.GetConn().Begin(ctx)
+		Expect(err).To(BeNil())
+		buyer, err := s.IssueBlindTokensTx(ctx, s.Queries.WithTx(tx), &params)
+		Expect(err).To(BeNil())
+		Expect(tx.Commit(ctx)).To(Succeed())
+		return buyer
+	}
+
+	newAccount := func(ctx context.Context, s store.Store) *db.Account {
+		account, err := s.UpsertAccountTx(ctx, &store.UpsertAccountTxParams{
+			Digest: "DdzbG47u5MDUrSVArmVYmvnpDvspgKeAXxzgq2cNnhp4",
+			UpsertAccountParams: db.UpsertAccountParams{
+				Username: "test_user_1",
+				Password: "",
+				Balance:  1_000,
+				Ttl: pgtype.Interval{
+					Microseconds: 3600 * 1000 * 1000,
+					Valid:        true,
+				},
+				Privilege: "user",
+			},
+		})
+		Expect(err).To(BeNil())
+		return account
+	}
+
+	It("should pay each token out once", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		account := newAccount(ctx, s)
+		redeemParams := store.RedeemBlindTokenTxParams{
+			KeyId:      "0123456789abcdef0123456789abcdef",
+			Nonce:      make([]byte, 32),
+			RedeemerID: account.AccountID,
+		}
+		_, err := s.RedeemBlindTokenTx(ctx, redeemParams)
+		Expect(err).To(MatchError(store.ErrBlindTokenUnknown))
+
+		buyer := issue(ctx, s, store.IssueBlindTokensTxParams{
+			BuyerID:      account.AccountID,
+			KeyId:        redeemParams.KeyId,
+			Denomination: 100,
+			Count:        2,
+			ExpireTime:   time.Now().Add(time.Hour),
+		})
+		Expect(buyer.Balance).To(Equal(int64(800)))
+
+		blindTokenKey, err := s.RedeemBlindTokenTx(ctx, redeemParams)
+		Expect(err).To(BeNil())
+		Expect(blindTokenKey.Redeemed).To(Equal(int64(1)))
+		_, err = s.RedeemBlindTokenTx(ctx, redeemParams)
+		Expect(err).To(MatchError(store.ErrBlindTokenSpent))
+
+		redeemed, err := s.QueryBalance(ctx, account.AccountID)
+		Expect(err).To(BeNil())
+		Expect(redeemed.Balance).To(Equal(int64(900)))
+	})
+
+	It("should refuse and prune tokens of expired keys", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		account := newAccount(ctx, s)
+		liveParams := store.RedeemBlindTokenTxParams{
+			KeyId:      "0123456789abcdef0123456789abcdef",
+			Nonce:      make([]byte, 32),
+			RedeemerID: account.AccountID,
+		}
+		expiringParams := store.RedeemBlindTokenTxParams{
+			KeyId:      "fedcba9876543210fedcba9876543210",
+			Nonce:      []byte("expiring_nonce_0123456789abcdef_"),
+			RedeemerID: account.AccountID,
+		}
+		expireTime := time.Now().Add(time.Second)
+		issue(ctx, s, store.IssueBlindTokensTxParams{
+			BuyerID:      account.AccountID,
+			KeyId:        liveParams.KeyId,
+			Denomination: 100,
+			Count:        1,
+			ExpireTime:   time.Now().Add(time.Hour),
+		})
+		issue(ctx, s, store.IssueBlindTokensTxParams{
+			BuyerID:      account.AccountID,
+			KeyId:        expiringParams.KeyId,
+			Denomination: 10,
+			Count:        2,
+			ExpireTime:   expireTime,
+		})
+		_, err := s.RedeemBlindTokenTx(ctx, liveParams)
+		Expect(err).To(BeNil())
+		_, err = s.RedeemBlindTokenTx(ctx, expiringParams)
+		Expect(err).To(BeNil())
+		prunedCount, err := s.PruneSpentBlindTokens(ctx)
+		Expect(err).To(BeNil())
+		Expect(prunedCount).To(Equal(int64(0)))
+		expiredKeys, err := s.ListExpiredBlindTokenKeys(ctx)
+		Expect(err).To(BeNil())
+		Expect(expiredKeys).To(BeEmpty())
+
+		time.Sleep(time.Until(expireTime))
+		expiringParams.Nonce = []byte("expiring_nonce_abcdef0123456789_")
+		_, err = s.RedeemBlindTokenTx(ctx, expiringParams)
+		Expect(err).To(MatchError(store.ErrBlindTokenUnknown))
+		prunedCount, err = s.PruneSpentBlindTokens(ctx)
+		Expect(err).To(BeNil())
+		Expect(prunedCount).To(Equal(int64(1)))
+
+		expiredKeys, err = s.ListExpiredBlindTokenKeys(ctx)
+		Expect(err).To(BeNil())
+		Expect(expiredKeys).To(HaveLen(1))
+		Expect(expiredKeys[0].KeyID).To(Equal(expiringParams.KeyId))
+		Expect(expiredKeys[0].Issued - expiredKeys[0].Redeemed).To(Equal(int64(1)))
+	})
+})
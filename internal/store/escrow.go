@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrEscrowUnavailable    = errors.New("token has no unsettled escrow")
+	ErrReceiptExceedsEscrow = errors.New("receipt claims more than the escrow holds")
+	// The audience signs receipts itself, so it cannot be paid for more than
+	// it redeemed with RedeemToken
+	ErrReceiptExceedsConsumed = errors.New("receipt claims more than the token consumed")
+)
+
+type ClaimEscrowTxParams struct {
+	Jti string
+	// Claimed is the total consumption the audience claims so far
+	Claimed int64
+}
+
+// ClaimEscrowTx pays the audience of an escrowed token what a receipt claims
+// beyond earlier receipts and returns the escrow and the amount paid. Claims
+// are capped by the quota consumed, which costs a unit price of 1.
+func (s *Store) ClaimEscrowTx(ctx context.Context, arg ClaimEscrowTxParams) (*db.Escrow, int64, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	escrow, err := qtx.GetEscrowForUpdate(ctx, arg.Jti)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, ErrEscrowUnavailable
+	} else if err != nil {
+		return nil, 0, err
+	}
+	if escrow.SettleTime.Valid {
+		return nil, 0, ErrEscrowUnavailable
+	}
+	if arg.Claimed > escrow.Amount {
+		return nil, 0, fmt.Errorf("%w: %d of %d", ErrReceiptExceedsEscrow, arg.Claimed, escrow.Amount)
+	}
+	quotaToken, err := qtx.GetQuotaToken(ctx, arg.Jti)
+	if err != nil {
+		return nil, 0, err
+	}
+	if arg.Claimed > quotaToken.Consumed {
+		return nil, 0, fmt.Errorf("%w: %d of %d", ErrReceiptExceedsConsumed, arg.Claimed, quotaToken.Consumed)
+	}
+	paid := arg.Claimed - escrow.Claimed
+	if paid <= 0 {
+		// Superseded by a later receipt
+		return &escrow, 0, nil
+	}
+	if escrow, err = qtx.UpdateEscrowClaimed(ctx, db.UpdateEscrowClaimedParams{
+		Claimed: arg.Claimed,
+		Jti:     arg.Jti,
+	}); err != nil {
+		return nil, 0, err
+	}
+	if _, err = qtx.ChangeBalance(ctx, db.ChangeBalanceParams{
+		AccountID:     escrow.SellerID,
+		BalanceChange: paid,
+	}); err != nil {
+		return nil, 0, err
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, 0, err
+	}
+	return &escrow, paid, nil
+}
+
+// SettleEscrowsTx returns the unclaimed rest of up to maxCount escrows that
+// expired before settleBefore to their buyers. It returns the settled escrows.
+func (s *Store) SettleEscrowsTx(ctx context.Context, settleBefore time.Time, maxCount int32) ([]db.Escrow, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	escrows, err := qtx.ListSettleableEscrows(ctx, db.ListSettleableEscrowsParams{
+		SettleBefore: pgtype.Timestamptz{Time: settleBefore, Valid: true},
+		MaxCount:     maxCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	settled := make([]db.Escrow, 0, len(escrows))
+	for _, escrow := range escrows {
+		escrow, err := settleEscrow(ctx, qtx, escrow.Jti)
+		if err != nil {
+			return nil, err
+		}
+		settled = append(settled, *escrow)
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return settled, nil
+}
+
+// settleEscrow returns the unclaimed rest of an escrow to its buyer.
+func settleEscrow(ctx context.Context, qtx *db.Queries, jti string) (*db.Escrow, error) {
+	escrow, err := qtx.SettleEscrow(ctx, jti)
+	if err != nil {
+		return nil, err
+	}
+	if rest := escrow.Amount - escrow.Claimed; rest > 0 && escrow.BuyerID.Valid {
+		if _, err = qtx.ChangeBalance(ctx, db.ChangeBalanceParams{
+			AccountID:     escrow.BuyerID.Int64,
+			BalanceChange: rest,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &escrow, nil
+}
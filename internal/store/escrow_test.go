@@ -0,0 +1,160 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/store"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/jackc/pgx/v5/pgtype"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Escrowed quota tokens", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	It("should pay the seller as claimed up to consumption and the rest back on expiry", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		accounts := NewAccounts(ctx, s, "test_buyer", "test_seller")
+		buyer, seller := accounts[0], accounts[1]
+		_, err := s.BuyTokenTx(ctx, s.Queries, &store.BuyTokenTxParams{
+			Req: &pb.BuyTokenRequest{
+				Audience: seller.Username,
+				Amount:   100,
+				Escrow:   true,
+			},
+			BuyerID: buyer.AccountID,
+		})
+		Expect(err).To(BeNil())
+		jti := "4d5e6f70-8192-4a3b-8c4d-5e6f708192a3"
+		expireTime := pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+		_, err = s.CreateQuotaToken(ctx, db.CreateQuotaTokenParams{
+			Jti:          jti,
+			BuyerID:      pgtype.Int8{Int64: buyer.AccountID, Valid: true},
+			Audience:     seller.Username,
+			Quantity:     100,
+			ExpireTime:   expireTime,
+			RefundMode:   store.REFUND_MODE_NONE,
+			RefundWindow: pgtype.Interval{Valid: true},
+		})
+		Expect(err).To(BeNil())
+		_, err = s.CreateEscrow(ctx, db.CreateEscrowParams{
+			Jti:        jti,
+			BuyerID:    buyer.AccountID,
+			Amount:     100,
+			ExpireTime: expireTime,
+			Audience:   seller.Username,
+		})
+		Expect(err).To(BeNil())
+
+		_, err = s.RedeemToken(ctx, store.RedeemTokenParams{
+			Jti:        jti,
+			Audience:   seller.Username,
+			Quantity:   100,
+			ExpireTime: expireTime,
+			Consume:    40,
+		})
+		Expect(err).To(BeNil())
+
+		escrow, paid, err := s.ClaimEscrowTx(ctx, store.ClaimEscrowTxParams{Jti: jti, Claimed: 40})
+		Expect(err).To(BeNil())
+		Expect(paid).To(Equal(int64(40)))
+		Expect(escrow.Claimed).To(Equal(int64(40)))
+		_, paid, err = s.ClaimEscrowTx(ctx, store.ClaimEscrowTxParams{Jti: jti, Claimed: 30})
+		Expect(err).To(BeNil())
+		Expect(paid).To(Equal(int64(0)))
+		_, _, err = s.ClaimEscrowTx(ctx, store.ClaimEscrowTxParams{Jti: jti, Claimed: 50})
+		Expect(err).To(MatchError(store.ErrReceiptExceedsConsumed))
+		_, _, err = s.ClaimEscrowTx(ctx, store.ClaimEscrowTxParams{Jti: jti, Claimed: 101})
+		Expect(err).To(MatchError(store.ErrReceiptExceedsEscrow))
+
+		settled, err := s.SettleEscrowsTx(ctx, time.Now(), 100)
+		Expect(err).To(BeNil())
+		Expect(settled).To(BeEmpty())
+		settled, err = s.SettleEscrowsTx(ctx, time.Now().Add(2*time.Hour), 100)
+		Expect(err).To(BeNil())
+		Expect(settled).To(HaveLen(1))
+		_, _, err = s.ClaimEscrowTx(ctx, store.ClaimEscrowTxParams{Jti: jti, Claimed: 50})
+		Expect(err).To(MatchError(store.ErrEscrowUnavailable))
+
+		account, err := s.QueryBalance(ctx, buyer.AccountID)
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(Equal(int64(960)))
+		account, err = s.QueryBalance(ctx, seller.AccountID)
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(Equal(int64(1_040)))
+	})
+})
+
+var _ = Describe("Pruning sellers of escrowed tokens", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	It("should pay the unclaimed rest back before deleting the seller", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		buyer := NewAccounts(ctx, s, "test_buyer")[0]
+		seller, err := s.UpsertAccountTx(ctx, &store.UpsertAccountTxParams{
+			Digest: "DdzbG47u5MDUrSVArmVYmvnpDvspgKeAXxzgq2cNnhp5",
+			UpsertAccountParams: db.UpsertAccountParams{
+				Username: "test_seller",
+				Password: "",
+				Balance:  1_000,
+				Ttl: pgtype.Interval{
+					Microseconds: -3600 * 1000 * 1000,
+					Valid:        true,
+				},
+				Privilege: "user",
+			},
+		})
+		Expect(err).To(BeNil())
+		_, err = s.BuyTokenTx(ctx, s.Queries, &store.BuyTokenTxParams{
+			Req: &pb.BuyTokenRequest{
+				Audience: seller.Username,
+				Amount:   100,
+				Escrow:   true,
+			},
+			BuyerID: buyer.AccountID,
+		})
+		Expect(err).To(BeNil())
+		jti := "4d5e6f70-8192-4a3b-8c4d-5e6f708192a3"
+		expireTime := pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+		_, err = s.CreateQuotaToken(ctx, db.CreateQuotaTokenParams{
+			Jti:          jti,
+			BuyerID:      pgtype.Int8{Int64: buyer.AccountID, Valid: true},
+			Audience:     seller.Username,
+			Quantity:     100,
+			ExpireTime:   expireTime,
+			RefundMode:   store.REFUND_MODE_NONE,
+			RefundWindow: pgtype.Interval{Valid: true},
+		})
+		Expect(err).To(BeNil())
+		_, err = s.CreateEscrow(ctx, db.CreateEscrowParams{
+			Jti:        jti,
+			BuyerID:    buyer.AccountID,
+			Amount:     100,
+			ExpireTime: expireTime,
+			Audience:   seller.Username,
+		})
+		Expect(err).To(BeNil())
+
+		By("refusing to delete the seller while the escrow is open")
+		_, err = s.DeleteInvalidAccounts(ctx)
+		Expect(err).NotTo(BeNil())
+
+		pruned, err := s.PruneAccountsTx(ctx)
+		Expect(err).To(BeNil())
+		Expect(pruned).To(ConsistOf(seller.AccountID))
+		account, err := s.QueryBalance(ctx, buyer.AccountID)
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(Equal(int64(1_000)))
+		_, _, err = s.ClaimEscrowTx(ctx, store.ClaimEscrowTxParams{Jti: jti, Claimed: 10})
+		Expect(err).To(MatchError(store.ErrEscrowUnavailable))
+	})
+})
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrQuotaExhausted = errors.New("not enough quota left on token")
+
+// QuotaLimit caps the quantity redeemed with an attenuated token. It is
+// tracked as a ledger entry of its own next to the token.
+type QuotaLimit struct {
+	Id       string
+	Quantity int64
+}
+
+type RedeemTokenParams struct {
+	Jti        string
+	Audience   string
+	Quantity   int64
+	ExpireTime pgtype.Timestamptz
+	// Consume is how much to consume, or all left if 0
+	Consume int64
+	Limits  []QuotaLimit
+}
+
+// RedeemToken atomically consumes quota of a token so that it cannot be
+// spent twice across instances of a service.
+func (s *Store) RedeemToken(ctx context.Context, arg RedeemTokenParams) (db.QuotaToken, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return db.QuotaToken{}, err
+	}
+	defer tx.Rollback(context.Background())
+	quotaToken, _, err := redeemQuota(ctx, s.Queries.WithTx(tx), arg)
+	if err != nil {
+		return db.QuotaToken{}, err
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return db.QuotaToken{}, err
+	}
+	return quotaToken, nil
+}
+
+// redeemQuota consumes quota of a token and all its limits and returns how
+// much. It fails rather than consume less if they are redeemed concurrently.
+func redeemQuota(ctx context.Context, qtx *db.Queries, arg RedeemTokenParams) (db.QuotaToken, int64, error) {
+	entries := append([]QuotaLimit{{Id: arg.Jti, Quantity: arg.Quantity}}, arg.Limits...)
+	consume := arg.Consume
+	if consume == 0 {
+		consume = arg.Quantity
+		for _, entry := range entries {
+			remaining := entry.Quantity
+			quotaToken, err := qtx.GetQuotaToken(ctx, entry.Id)
+			if err == nil {
+				remaining = quotaToken.Quantity - quotaToken.Consumed
+			} else if !errors.Is(err, pgx.ErrNoRows) {
+				return db.QuotaToken{}, 0, err
+			}
+			consume = min(consume, remaining)
+		}
+	}
+	if consume <= 0 {
+		return db.QuotaToken{}, 0, ErrQuotaExhausted
+	}
+	var quotaToken db.QuotaToken
+	for i, entry := range entries {
+		if consume > entry.Quantity {
+			return db.QuotaToken{}, 0, fmt.Errorf(
+				"%w: cannot consume %d of %d", ErrQuotaExhausted, consume, entry.Quantity)
+		}
+		redeemed, err := qtx.RedeemQuotaToken(ctx, db.RedeemQuotaTokenParams{
+			Jti:        entry.Id,
+			Audience:   arg.Audience,
+			Quantity:   entry.Quantity,
+			Consume:    consume,
+			ExpireTime: arg.ExpireTime,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.QuotaToken{}, 0, ErrQuotaExhausted
+		} else if err != nil {
+			return db.QuotaToken{}, 0, err
+		}
+		if i == 0 {
+			quotaToken = redeemed
+		}
+	}
+	return quotaToken, consume, nil
+}
@@ -0,0 +1,44 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/jackc/pgx/v5/pgtype"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redeeming quota tokens", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	It("should consume quota at most once", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		params := store.RedeemTokenParams{
+			Jti:        "7d0a8a5e-4b8f-4c1e-9a51-0e3f2c1d6b7a",
+			Audience:   "did:key:z6MkSeller",
+			Quantity:   10,
+			ExpireTime: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+			Consume:    4,
+		}
+		quotaToken, err := s.RedeemToken(ctx, params)
+		Expect(err).To(BeNil())
+		Expect(quotaToken.Consumed).To(Equal(int64(4)))
+
+		params.Consume = 7
+		_, err = s.RedeemToken(ctx, params)
+		Expect(err).To(MatchError(store.ErrQuotaExhausted))
+
+		params.Consume = 0
+		quotaToken, err = s.RedeemToken(ctx, params)
+		Expect(err).To(BeNil())
+		Expect(quotaToken.Consumed).To(Equal(int64(10)))
+
+		_, err = s.RedeemToken(ctx, params)
+		Expect(err).To(MatchError(store.ErrQuotaExhausted))
+	})
+})
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	REFUND_MODE_NONE       = "none"
+	REFUND_MODE_UNREDEEMED = "unredeemed"
+	REFUND_MODE_UNCONSUMED = "unconsumed"
+)
+
+var (
+	ErrTokenNotRefundable = errors.New("token is expired, revoked or not bought by the caller")
+	ErrRefundDenied       = errors.New("refund denied")
+)
+
+type RefundTokenTxParams struct {
+	Jti     string
+	BuyerID int64
+	Now     time.Time
+}
+
+// GetRefundPolicy returns the refund policy of audience. Audiences without
+// one do not refund.
+func GetRefundPolicy(ctx context.Context, qtx *db.Queries, audience string) (*db.RefundPolicy, error) {
+	policy, err := qtx.GetRefundPolicyByUsername(ctx, audience)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &db.RefundPolicy{
+			RefundMode:   REFUND_MODE_NONE,
+			RefundWindow: pgtype.Interval{Valid: true},
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// RefundTokenTx revokes a token and pays its unconsumed quota back to the
+// buyer from its audience, as far as the refund policy of the audience at
+// purchase allows. It returns the revoked token and the refunded amount.
+func (s *Store) RefundTokenTx(ctx context.Context, arg RefundTokenTxParams) (*db.QuotaToken, int64, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	quotaToken, err := qtx.RevokeQuotaToken(ctx, db.RevokeQuotaTokenParams{
+		Jti:     arg.Jti,
+		BuyerID: pgtype.Int8{Int64: arg.BuyerID, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, ErrTokenNotRefundable
+	} else if err != nil {
+		return nil, 0, err
+	}
+	if err := checkRefundPolicy(&quotaToken, arg.Now); err != nil {
+		return nil, 0, err
+	}
+	amount := quotaToken.Quantity - quotaToken.Consumed
+	if amount <= 0 {
+		return nil, 0, fmt.Errorf("%w: no quota left on token", ErrRefundDenied)
+	}
+	if amount, err = deductRefund(ctx, qtx, &quotaToken, amount); err != nil {
+		return nil, 0, err
+	}
+	if _, err = qtx.ChangeBalance(ctx, db.ChangeBalanceParams{
+		AccountID:     arg.BuyerID,
+		BalanceChange: amount,
+	}); err != nil {
+		return nil, 0, err
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, 0, err
+	}
+	return &quotaToken, amount, nil
+}
+
+// deductRefund takes up to amount from the escrow of a token, or from its
+// audience if it was paid at once, and returns how much it took.
+func deductRefund(ctx context.Context, qtx *db.Queries, quotaToken *db.QuotaToken, amount int64) (int64, error) {
+	escrow, err := qtx.GetEscrowForUpdate(ctx, quotaToken.Jti)
+	if err == nil {
+		amount = min(amount, escrow.Amount-escrow.Claimed)
+		if amount <= 0 {
+			return 0, fmt.Errorf("%w: escrow is fully claimed", ErrRefundDenied)
+		}
+		if _, err = qtx.ReduceEscrow(ctx, db.ReduceEscrowParams{
+			Amount: amount,
+			Jti:    quotaToken.Jti,
+		}); errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: escrow is settled", ErrRefundDenied)
+		} else if err != nil {
+			return 0, err
+		}
+		return amount, nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+	if _, err = qtx.DeductBalanceByUsername(ctx, db.DeductBalanceByUsernameParams{
+		Amount:   amount,
+		Username: quotaToken.Audience,
+	}); errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: audience cannot cover %d", ErrRefundDenied, amount)
+	} else if err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+// checkRefundPolicy checks the policy snapshotted on the token at purchase.
+func checkRefundPolicy(quotaToken *db.QuotaToken, now time.Time) error {
+	switch quotaToken.RefundMode {
+	case REFUND_MODE_UNREDEEMED:
+		if quotaToken.Consumed > 0 {
+			return fmt.Errorf("%w: %s only refunds unredeemed tokens", ErrRefundDenied, quotaToken.Audience)
+		}
+	case REFUND_MODE_UNCONSUMED:
+	default:
+		return fmt.Errorf("%w: %s does not refund tokens", ErrRefundDenied, quotaToken.Audience)
+	}
+	window := time.Duration(quotaToken.RefundWindow.Microseconds)*time.Microsecond +
+		time.Duration(quotaToken.RefundWindow.Days)*24*time.Hour
+	if window > 0 && now.After(quotaToken.IssueTime.Time.Add(window)) {
+		return fmt.Errorf("%w: refund window of %v has passed", ErrRefundDenied, window)
+	}
+	return nil
+}
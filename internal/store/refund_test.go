@@ -0,0 +1,94 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/jackc/pgx/v5/pgtype"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Refunding quota tokens", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	It("should pay back unconsumed quota as the seller allowed at purchase", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		accounts := NewAccounts(ctx, s, "test_buyer", "test_seller")
+		buyer, seller := accounts[0], accounts[1]
+		// buyToken records a token under the refund policy of the seller at
+		// the time and redeems 30 of it
+		buyToken := func(jti string, mode string) {
+			_, err := s.UpsertRefundPolicy(ctx, db.UpsertRefundPolicyParams{
+				AccountID:    seller.AccountID,
+				RefundMode:   mode,
+				RefundWindow: pgtype.Interval{Valid: true},
+			})
+			Expect(err).To(BeNil())
+			policy, err := store.GetRefundPolicy(ctx, s.Queries, seller.Username)
+			Expect(err).To(BeNil())
+			_, err = s.CreateQuotaToken(ctx, db.CreateQuotaTokenParams{
+				Jti:          jti,
+				BuyerID:      pgtype.Int8{Int64: buyer.AccountID, Valid: true},
+				Audience:     seller.Username,
+				Quantity:     100,
+				ExpireTime:   pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+				RefundMode:   policy.RefundMode,
+				RefundWindow: policy.RefundWindow,
+			})
+			Expect(err).To(BeNil())
+			_, err = s.RedeemToken(ctx, store.RedeemTokenParams{
+				Jti:      jti,
+				Audience: seller.Username,
+				Quantity: 100,
+				Consume:  30,
+			})
+			Expect(err).To(BeNil())
+		}
+		unredeemedJti := "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+		buyToken(unredeemedJti, store.REFUND_MODE_UNREDEEMED)
+		jti := "2b3c4d5e-6f70-4a8b-9cad-1e2f3a4b5c6d"
+		buyToken(jti, store.REFUND_MODE_UNCONSUMED)
+		noneJti := "3c4d5e6f-7081-4a9b-8cad-2f3a4b5c6d7e"
+		buyToken(noneJti, store.REFUND_MODE_NONE)
+
+		By("denying tokens bought under stricter policies")
+		_, _, err := s.RefundTokenTx(ctx, store.RefundTokenTxParams{
+			Jti: noneJti, BuyerID: buyer.AccountID, Now: time.Now(),
+		})
+		Expect(err).To(MatchError(store.ErrRefundDenied))
+		_, _, err = s.RefundTokenTx(ctx, store.RefundTokenTxParams{
+			Jti: unredeemedJti, BuyerID: buyer.AccountID, Now: time.Now(),
+		})
+		Expect(err).To(MatchError(store.ErrRefundDenied))
+
+		By("honoring the policy at purchase though it has changed since")
+		params := store.RefundTokenTxParams{Jti: jti, BuyerID: buyer.AccountID, Now: time.Now()}
+		quotaToken, amount, err := s.RefundTokenTx(ctx, params)
+		Expect(err).To(BeNil())
+		Expect(amount).To(Equal(int64(70)))
+		Expect(quotaToken.RevokeTime.Valid).To(BeTrue())
+
+		account, err := s.QueryBalance(ctx, buyer.AccountID)
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(Equal(int64(1_070)))
+		account, err = s.QueryBalance(ctx, seller.AccountID)
+		Expect(err).To(BeNil())
+		Expect(account.Balance).To(Equal(int64(930)))
+
+		_, _, err = s.RefundTokenTx(ctx, params)
+		Expect(err).To(MatchError(store.ErrTokenNotRefundable))
+		_, err = s.RedeemToken(ctx, store.RedeemTokenParams{
+			Jti:      jti,
+			Audience: seller.Username,
+			Quantity: 100,
+			Consume:  1,
+		})
+		Expect(err).To(MatchError(store.ErrQuotaExhausted))
+	})
+})
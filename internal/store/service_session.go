@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrServiceSessionUnavailable = errors.New("service session is not open to the audience")
+
+// CreateServiceSessionTx moves the quota left on a token into a new session
+// of its audience lasting as long as the token.
+func (s *Store) CreateServiceSessionTx(ctx context.Context, token RedeemTokenParams) (*db.ServiceSession, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	_, quantity, err := redeemQuota(ctx, qtx, token)
+	if err != nil {
+		return nil, err
+	}
+	serviceSession, err := qtx.CreateServiceSession(ctx, db.CreateServiceSessionParams{
+		Audience:   token.Audience,
+		Quantity:   quantity,
+		ExpireTime: token.ExpireTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return &serviceSession, nil
+}
+
+type TopUpServiceSessionTxParams struct {
+	ServiceSessionID int64
+	Token            RedeemTokenParams
+}
+
+// TopUpServiceSessionTx moves the quota left on a token into an open session
+// of the same audience and extends the session to the token expiry.
+func (s *Store) TopUpServiceSessionTx(ctx context.Context, arg TopUpServiceSessionTxParams) (*db.ServiceSession, error) {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+	qtx := s.Queries.WithTx(tx)
+	_, quantity, err := redeemQuota(ctx, qtx, arg.Token)
+	if err != nil {
+		return nil, err
+	}
+	serviceSession, err := qtx.TopUpServiceSession(ctx, db.TopUpServiceSessionParams{
+		Quantity:         quantity,
+		ExpireTime:       arg.Token.ExpireTime,
+		ServiceSessionID: arg.ServiceSessionID,
+		Audience:         arg.Token.Audience,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrServiceSessionUnavailable
+	} else if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return &serviceSession, nil
+}
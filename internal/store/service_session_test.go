@@ -0,0 +1,69 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/jackc/pgx/v5/pgtype"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service sessions", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	It("should move token quota into sessions", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		token := store.RedeemTokenParams{
+			Jti:        "0f3a2b1c-5d6e-4f70-8a9b-0c1d2e3f4a5b",
+			Audience:   "did:key:z6MkSeller",
+			Quantity:   10,
+			ExpireTime: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+			Consume:    3,
+		}
+		_, err := s.RedeemToken(ctx, token)
+		Expect(err).To(BeNil())
+
+		serviceSession, err := s.CreateServiceSessionTx(ctx, token)
+		Expect(err).To(BeNil())
+		Expect(serviceSession.Quantity).To(Equal(int64(7)))
+		Expect(serviceSession.SessionState).To(Equal("active"))
+
+		_, err = s.CreateServiceSessionTx(ctx, token)
+		Expect(err).To(MatchError(store.ErrQuotaExhausted))
+
+		topUp := token
+		topUp.Jti = "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+		topUp.ExpireTime.Time = token.ExpireTime.Time.Add(time.Hour)
+		serviceSession, err = s.TopUpServiceSessionTx(ctx, store.TopUpServiceSessionTxParams{
+			ServiceSessionID: serviceSession.ServiceSessionID,
+			Token:            topUp,
+		})
+		Expect(err).To(BeNil())
+		Expect(serviceSession.Quantity).To(Equal(int64(17)))
+		Expect(serviceSession.ExpireTime.Time).To(BeTemporally("~", topUp.ExpireTime.Time, time.Millisecond))
+
+		consumeParams := db.ConsumeServiceSessionParams{
+			Quantity:         5,
+			ServiceSessionID: serviceSession.ServiceSessionID,
+			Audience:         token.Audience,
+		}
+		consumed, err := s.ConsumeServiceSession(ctx, consumeParams)
+		Expect(err).To(BeNil())
+		Expect(consumed.Consumed).To(Equal(int64(5)))
+
+		_, err = s.SetServiceSessionState(ctx, db.SetServiceSessionStateParams{
+			SessionState:     "paused",
+			ServiceSessionID: serviceSession.ServiceSessionID,
+			FromStates:       []string{"active"},
+		})
+		Expect(err).To(BeNil())
+		_, err = s.ConsumeServiceSession(ctx, consumeParams)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -2,13 +2,17 @@ package store_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"log"
 	"testing"
 
 	"github.com/atticplaygroup/prex/internal/config"
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
 	"github.com/atticplaygroup/prex/internal/store"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mr-tron/base58"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	migrate "github.com/rubenv/sql-migrate"
@@ -62,3 +66,27 @@ func RefreshDb(testDb *sql.DB, migrations *migrate.FileMigrationSource) {
 		log.Fatalf("Failed to migrate up: %v\n", err)
 	}
 }
+
+// NewAccounts deposits 1_000 into a new user account for each of usernames.
+func NewAccounts(ctx context.Context, s store.Store, usernames ...string) []*db.Account {
+	accounts := make([]*db.Account, 0, len(usernames))
+	for _, username := range usernames {
+		digest := sha256.Sum256([]byte(username))
+		account, err := s.UpsertAccountTx(ctx, &store.UpsertAccountTxParams{
+			Digest: base58.Encode(digest[:]),
+			UpsertAccountParams: db.UpsertAccountParams{
+				Username: username,
+				Password: "",
+				Balance:  1_000,
+				Ttl: pgtype.Interval{
+					Microseconds: 3600 * 1000 * 1000,
+					Valid:        true,
+				},
+				Privilege: "user",
+			},
+		})
+		Expect(err).To(BeNil())
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
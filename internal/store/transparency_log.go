@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+
+	db "github.com/atticplaygroup/prex/internal/db/sqlc"
+	"github.com/atticplaygroup/prex/pkg/transparency"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type logNodeReader struct {
+	qtx *db.Queries
+}
+
+func (r logNodeReader) Node(ctx context.Context, level int32, index int64) ([]byte, error) {
+	return r.qtx.GetLogNode(ctx, db.GetLogNodeParams{Level: level, NodeIndex: index})
+}
+
+// LogInclusion proves an entry is in the tree of TreeSize entries.
+type LogInclusion struct {
+	Entry     db.TransparencyLogEntry
+	TreeSize  int64
+	RootHash  []byte
+	AuditPath [][]byte
+}
+
+// AppendLogEntries appends issued tokens to the transparency log in the
+// transaction of qtx and proves each of them in the resulting tree.
+// Purchases are serialized on the log size until the transaction ends so
+// entries are numbered in commit order.
+func (s *Store) AppendLogEntries(
+	ctx context.Context,
+	qtx *db.Queries,
+	entries []transparency.Entry,
+) ([]LogInclusion, error) {
+	reader := logNodeReader{qtx: qtx}
+	inclusions := make([]LogInclusion, 0, len(entries))
+	var treeSize int64
+	for _, entry := range entries {
+		var err error
+		treeSize, err = qtx.ReserveLogIndex(ctx)
+		if err != nil {
+			return nil, err
+		}
+		leafIndex := treeSize - 1
+		logEntry, err := qtx.AppendLogEntry(ctx, db.AppendLogEntryParams{
+			LeafIndex: leafIndex,
+			TokenHash: entry.TokenHash,
+			Audience:  entry.Audience,
+			Quantity:  entry.Quantity,
+			IssueTime: pgtype.Timestamptz{Time: entry.IssueTime, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		nodes, err := transparency.AppendNodes(ctx, reader, leafIndex, entry.LeafHash())
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			if err := qtx.PutLogNode(ctx, db.PutLogNodeParams{
+				Level:     node.Level,
+				NodeIndex: node.Index,
+				Hash:      node.Hash,
+			}); err != nil {
+				return nil, err
+			}
+		}
+		inclusions = append(inclusions, LogInclusion{Entry: logEntry})
+	}
+	rootHash, err := transparency.RootHash(ctx, reader, treeSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range inclusions {
+		inclusions[i].TreeSize = treeSize
+		inclusions[i].RootHash = rootHash
+		inclusions[i].AuditPath, err = transparency.InclusionProof(
+			ctx, reader, inclusions[i].Entry.LeafIndex, treeSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return inclusions, nil
+}
+
+// GetLogTreeHead returns the size and root hash of the committed log.
+func (s *Store) GetLogTreeHead(ctx context.Context) (int64, []byte, error) {
+	treeSize, err := s.Queries.GetLogSize(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	rootHash, err := transparency.RootHash(ctx, logNodeReader{qtx: s.Queries}, treeSize)
+	if err != nil {
+		return 0, nil, err
+	}
+	return treeSize, rootHash, nil
+}
+
+func (s *Store) GetLogInclusionProof(ctx context.Context, leafIndex int64, treeSize int64) ([][]byte, error) {
+	return transparency.InclusionProof(ctx, logNodeReader{qtx: s.Queries}, leafIndex, treeSize)
+}
+
+func (s *Store) GetLogConsistencyProof(ctx context.Context, oldSize int64, newSize int64) ([][]byte, error) {
+	return transparency.ConsistencyProof(ctx, logNodeReader{qtx: s.Queries}, oldSize, newSize)
+}
@@ -0,0 +1,88 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/transparency"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transparency log", Label("db"), func() {
+	BeforeEach(func() {
+		RefreshDb(StoreTestDb, Migrations)
+	})
+
+	It("should prove every appended token", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		entries := make([]transparency.Entry, 0)
+		for i := range 5 {
+			entry := transparency.Entry{
+				TokenHash: transparency.HashToken(fmt.Sprintf("token-%d", i)),
+				Audience:  "did:key:z6MkSeller",
+				Quantity:  int64(10 * (i + 1)),
+				IssueTime: time.UnixMilli(1_700_000_000_000 + int64(i)),
+			}
+			tx, err := s.GetConn().Begin(ctx)
+			Expect(err).To(BeNil())
+			inclusions, err := s.AppendLogEntries(ctx, s.Queries.WithTx(tx), []transparency.Entry{entry})
+			Expect(err).To(BeNil())
+			inclusion := inclusions[0]
+			Expect(tx.Commit(ctx)).To(Succeed())
+			Expect(inclusion.Entry.LeafIndex).To(Equal(int64(i)))
+			Expect(inclusion.TreeSize).To(Equal(int64(i + 1)))
+			Expect(transparency.VerifyInclusion(
+				entry.LeafHash(), int64(i), inclusion.TreeSize, inclusion.AuditPath, inclusion.RootHash,
+			)).To(Succeed())
+			entries = append(entries, entry)
+		}
+
+		treeSize, rootHash, err := s.GetLogTreeHead(ctx)
+		Expect(err).To(BeNil())
+		Expect(treeSize).To(Equal(int64(5)))
+		auditPath, err := s.GetLogInclusionProof(ctx, 1, treeSize)
+		Expect(err).To(BeNil())
+		Expect(transparency.VerifyInclusion(
+			entries[1].LeafHash(), 1, treeSize, auditPath, rootHash,
+		)).To(Succeed())
+
+		tx, err := s.GetConn().Begin(ctx)
+		Expect(err).To(BeNil())
+		_, err = s.AppendLogEntries(ctx, s.Queries.WithTx(tx), entries[:1])
+		Expect(err).To(BeNil())
+		Expect(tx.Rollback(ctx)).To(Succeed())
+		newSize, _, err := s.GetLogTreeHead(ctx)
+		Expect(err).To(BeNil())
+		Expect(newSize).To(Equal(treeSize))
+	})
+
+	It("should prove a batch against the tree it ends", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		entries := make([]transparency.Entry, 0)
+		for i := range 3 {
+			entries = append(entries, transparency.Entry{
+				TokenHash: transparency.HashToken(fmt.Sprintf("token-%d", i)),
+				Audience:  "did:key:z6MkSeller",
+				Quantity:  5,
+				IssueTime: time.UnixMilli(1_700_000_000_000),
+			})
+		}
+		tx, err := s.GetConn().Begin(ctx)
+		Expect(err).To(BeNil())
+		inclusions, err := s.AppendLogEntries(ctx, s.Queries.WithTx(tx), entries)
+		Expect(err).To(BeNil())
+		Expect(tx.Commit(ctx)).To(Succeed())
+		Expect(inclusions).To(HaveLen(3))
+		for i, inclusion := range inclusions {
+			Expect(inclusion.Entry.LeafIndex).To(Equal(int64(i)))
+			Expect(inclusion.TreeSize).To(Equal(int64(3)))
+			Expect(transparency.VerifyInclusion(
+				entries[i].LeafHash(), int64(i), inclusion.TreeSize, inclusion.AuditPath, inclusion.RootHash,
+			)).To(Succeed())
+		}
+	})
+})
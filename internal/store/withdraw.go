@@ -2,14 +2,44 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	db "github.com/atticplaygroup/prex/internal/db/sqlc"
 )
 
+var ErrWithdrawCooldown = errors.New("withdrawals are paused after a password reset")
+
+// WALLET_LOCK_ID keys the advisory lock held while spending from the hot wallet
+const WALLET_LOCK_ID int64 = 0x70726578
+
+// AcquireWalletLock takes the advisory lock of the hot wallet on a dedicated
+// connection, so that it spans on-chain transactions and other processes
+// sharing the database.
+func (s *Store) AcquireWalletLock(ctx context.Context) (func(), error) {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.New(conn).LockWallet(ctx, WALLET_LOCK_ID); err != nil {
+		conn.Release()
+		return nil, err
+	}
+	return func() {
+		if err := db.New(conn).UnlockWallet(context.Background(), WALLET_LOCK_ID); err != nil {
+			// Closing the session drops its advisory locks
+			conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}, nil
+}
+
 type WithdrawTxParams struct {
 	db.StartWithdrawalParams
 	WithdrawAll bool
+	// ApiKeyID is charged the amount and fee if the account used an API key
+	ApiKeyID int64
 }
 
 // Account is not deleted even if all balance is withdrawn. Account is only deleted when expire_time is reached
@@ -24,6 +54,14 @@ func (s *Store) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (*db.Withd
 	}
 	defer tx.Rollback(context.Background())
 	qtx := s.Queries.WithTx(tx)
+	// Share lock so a concurrent password reset cannot slip in a cool-down
+	cooldownUntil, err := qtx.GetWithdrawCooldownForShare(ctx, arg.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if cooldownUntil.Valid && cooldownUntil.Time.After(time.Now()) {
+		return nil, fmt.Errorf("%w until %v", ErrWithdrawCooldown, cooldownUntil.Time)
+	}
 	if arg.WithdrawAll {
 		if account, err := qtx.QueryBalanceForShare(ctx, arg.AccountID); err != nil {
 			return nil, err
@@ -36,6 +74,9 @@ func (s *Store) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (*db.Withd
 			"expect withdraw amount to be positive but got %d", arg.Amount,
 		)
 	}
+	if err := chargeApiKey(ctx, qtx, arg.ApiKeyID, arg.Amount+arg.PriorityFee); err != nil {
+		return nil, err
+	}
 	withdraw, err := qtx.StartWithdrawal(ctx, arg.StartWithdrawalParams)
 	if err != nil {
 		return nil, err
@@ -204,3 +204,22 @@ var _ = Describe("Record withdrawal and set at waiting status", Label("db"), fun
 		})
 	})
 })
+
+var _ = Describe("Locking the hot wallet", Label("db"), func() {
+	It("should let one holder spend from the hot wallet at a time", func() {
+		ctx := context.Background()
+		s := *StoreInstance
+		unlock, err := s.AcquireWalletLock(ctx)
+		Expect(err).To(BeNil())
+
+		waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		_, err = s.AcquireWalletLock(waitCtx)
+		Expect(err).NotTo(BeNil())
+
+		unlock()
+		unlock, err = s.AcquireWalletLock(ctx)
+		Expect(err).To(BeNil())
+		unlock()
+	})
+})
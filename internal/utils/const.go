@@ -15,4 +15,29 @@ type CtxKey int64
 
 const (
 	KEY_ACCOUNT_ID CtxKey = iota
+	KEY_ROLES
+	KEY_SESSION_ID
+	// KEY_API_KEY_ID is only set for calls authenticated by an API key
+	KEY_API_KEY_ID
+)
+
+// Roles match the privilege column of accounts
+const (
+	ROLE_USER  = "user"
+	ROLE_ADMIN = "admin"
+)
+
+const (
+	// REDIS_KEY_SESSIONS_NOT_BEFORE holds the unix time in microseconds before
+	// which session tokens of an account are revoked.
+	REDIS_KEY_SESSIONS_NOT_BEFORE = "sessions-not-before:%d"
+	REDIS_KEY_SESSION             = "session:%s"
+	REDIS_KEY_ACCOUNT_SESSIONS    = "account-sessions:%d"
+	REDIS_KEY_REVOKED_JTI         = "revoked-jti:%s"
+	// REDIS_KEY_CHALLENGE marks an issued and not yet used challenge
+	REDIS_KEY_CHALLENGE = "challenge:%s"
+	// REDIS_KEY_RATE_LIMIT counts calls of a method by a subject
+	REDIS_KEY_RATE_LIMIT       = "rate-limit:%s:%s"
+	REDIS_KEY_LOCKOUT          = "lockout:%s"
+	REDIS_KEY_LOCKOUT_FAILURES = "lockout-failures:%s"
 )
@@ -34,6 +34,10 @@ const (
 	RESOURCE_PATTERN_ORDER           = "accounts/%d/sell-orders/%d"
 	RESOURCE_PATTERN_FULFILLED_ORDER = "services/%d/fulfilled-orders/%d"
 	RESOURCE_PATTERN_SERVICE         = "services/%d"
+	RESOURCE_PATTERN_PAYMENT_METHOD  = "payment-methods/%s-%s"
+	RESOURCE_PATTERN_SESSION         = "accounts/%d/sessions/%s"
+	RESOURCE_PATTERN_API_KEY         = "accounts/%d/api-keys/%d"
+	RESOURCE_PATTERN_SERVICE_SESSION = "service-sessions/%d"
 )
 
 type ResourceInfo struct {
@@ -230,22 +234,6 @@ func GetSuiFaucetHost(network string) string {
 	return ""
 }
 
-func GetSuiFullNodeHost(network string) string {
-	switch network {
-	case "mainnet":
-		return "https://fullnode.mainnet.sui.io:443"
-	case "devnet":
-		return "https://fullnode.devnet.sui.io:443"
-	case "testnet":
-		return "https://fullnode.testnet.sui.io:443"
-	case "localnet":
-		return "http://127.0.0.1:9000"
-	default:
-	}
-	log.Fatalf("faucet network should in [mainnet, devnet, testnet, localnet]")
-	return ""
-}
-
 const (
 	faucetUriGasV0 = "/gas"
 	faucetUriGasV1 = "/v1/gas"
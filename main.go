@@ -7,6 +7,7 @@ import (
 	"github.com/atticplaygroup/prex/cmd"
 	_ "github.com/atticplaygroup/prex/cmd/client"
 	_ "github.com/atticplaygroup/prex/cmd/server"
+	_ "github.com/atticplaygroup/prex/cmd/signer"
 )
 
 func main() {
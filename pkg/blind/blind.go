@@ -0,0 +1,218 @@
+// Package blind implements unlinkable quota tokens with RSA full domain hash
+// blind signatures. A buyer blinds a random nonce together with the audience
+// the token is for, has Prex sign it with the key of a fixed denomination and
+// unblinds the signature. Prex never sees the nonce or audience before the
+// token is redeemed, so it cannot tell which purchase a token came from. Only
+// the audience can redeem it. Services verify tokens offline with the public
+// key of the denomination.
+package blind
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// TOKEN_PREFIX tells blind tokens apart from other token formats.
+	TOKEN_PREFIX = "pxb1."
+	NONCE_SIZE   = 32
+
+	hashDomain = "prex-blind-token-v1"
+)
+
+var (
+	ErrMalformedToken = errors.New("malformed blind token")
+	ErrBadSignature   = errors.New("invalid blind token signature")
+)
+
+// KeyId identifies the public key of a denomination.
+func KeyId(publicKey *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:16]), nil
+}
+
+// tokenMessage is what a token signs. The audience is length-prefixed so that
+// no two audience and nonce pairs share a message.
+func tokenMessage(audience string, nonce []byte) []byte {
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(audience)))
+	buf = append(buf, audience...)
+	return append(buf, nonce...)
+}
+
+// hashToInt maps message to an integer modulo the public key, expanding
+// SHA-384 to the full size of the modulus.
+func hashToInt(publicKey *rsa.PublicKey, message []byte) *big.Int {
+	size := publicKey.Size()
+	buf := make([]byte, 0, size+sha512.Size384)
+	for counter := uint32(0); len(buf) < size; counter++ {
+		h := sha512.New384()
+		h.Write([]byte(hashDomain))
+		h.Write(binary.BigEndian.AppendUint32(nil, counter))
+		h.Write(message)
+		buf = h.Sum(buf)
+	}
+	m := new(big.Int).SetBytes(buf[:size])
+	return m.Mod(m, publicKey.N)
+}
+
+func encryptInt(publicKey *rsa.PublicKey, x *big.Int) *big.Int {
+	return new(big.Int).Exp(x, big.NewInt(int64(publicKey.E)), publicKey.N)
+}
+
+// CheckBlinded rejects blinded messages that no key could sign. It is cheap
+// compared to Sign.
+func CheckBlinded(publicKey *rsa.PublicKey, blinded []byte) error {
+	x := new(big.Int).SetBytes(blinded)
+	if x.Sign() <= 0 || x.Cmp(publicKey.N) >= 0 {
+		return fmt.Errorf("blinded message out of range")
+	}
+	return nil
+}
+
+// Sign signs a blinded message. It is run by Prex and learns nothing about
+// the message.
+func Sign(privateKey *rsa.PrivateKey, blinded []byte) ([]byte, error) {
+	if err := CheckBlinded(&privateKey.PublicKey, blinded); err != nil {
+		return nil, err
+	}
+	x := new(big.Int).SetBytes(blinded)
+	s := new(big.Int).Exp(x, privateKey.D, privateKey.N)
+	// Guards against faulty computations leaking the key
+	if encryptInt(&privateKey.PublicKey, s).Cmp(x) != 0 {
+		return nil, fmt.Errorf("failed to verify blind signature")
+	}
+	return s.FillBytes(make([]byte, privateKey.Size())), nil
+}
+
+// Request is a token waiting for its blind signature.
+type Request struct {
+	KeyId     string
+	Audience  string
+	Nonce     []byte
+	Blinded   []byte
+	publicKey *rsa.PublicKey
+	inverse   *big.Int
+}
+
+// NewRequest draws a random nonce and blinds it with audience for the key of
+// a denomination. Send Blinded to Prex and keep the request to finalize it.
+func NewRequest(publicKey *rsa.PublicKey, audience string) (*Request, error) {
+	keyId, err := KeyId(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, NONCE_SIZE)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	for {
+		r, err := rand.Int(rand.Reader, publicKey.N)
+		if err != nil {
+			return nil, err
+		}
+		inverse := new(big.Int).ModInverse(r, publicKey.N)
+		if r.Sign() == 0 || inverse == nil {
+			continue
+		}
+		message := hashToInt(publicKey, tokenMessage(audience, nonce))
+		blinded := new(big.Int).Mul(message, encryptInt(publicKey, r))
+		blinded.Mod(blinded, publicKey.N)
+		return &Request{
+			KeyId:     keyId,
+			Audience:  audience,
+			Nonce:     nonce,
+			Blinded:   blinded.FillBytes(make([]byte, publicKey.Size())),
+			publicKey: publicKey,
+			inverse:   inverse,
+		}, nil
+	}
+}
+
+// Finalize unblinds the signature Prex returned for the request.
+func (r *Request) Finalize(blindSignature []byte) (*Token, error) {
+	s := new(big.Int).Mul(new(big.Int).SetBytes(blindSignature), r.inverse)
+	s.Mod(s, r.publicKey.N)
+	token := &Token{
+		KeyId:     r.KeyId,
+		Audience:  r.Audience,
+		Nonce:     r.Nonce,
+		Signature: s.FillBytes(make([]byte, r.publicKey.Size())),
+	}
+	if err := token.Verify(r.publicKey); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Token is an unblinded token worth the denomination of its key to its
+// audience.
+type Token struct {
+	KeyId     string `json:"kid"`
+	Audience  string `json:"aud"`
+	Nonce     []byte `json:"nonce"`
+	Signature []byte `json:"sig"`
+}
+
+// Verify checks the token is signed by publicKey. It does not check whether
+// the token was spent, which only redeeming with Prex does.
+func (t *Token) Verify(publicKey *rsa.PublicKey) error {
+	keyId, err := KeyId(publicKey)
+	if err != nil {
+		return err
+	}
+	if keyId != t.KeyId {
+		return fmt.Errorf("%w: token of key %s verified with %s", ErrBadSignature, t.KeyId, keyId)
+	}
+	if len(t.Nonce) != NONCE_SIZE || len(t.Signature) != publicKey.Size() {
+		return ErrMalformedToken
+	}
+	s := new(big.Int).SetBytes(t.Signature)
+	message := hashToInt(publicKey, tokenMessage(t.Audience, t.Nonce))
+	if s.Cmp(publicKey.N) >= 0 || encryptInt(publicKey, s).Cmp(message) != 0 {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// String encodes the token to be passed on.
+func (t *Token) String() string {
+	buf, _ := json.Marshal(t)
+	return TOKEN_PREFIX + base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// IsToken reports whether s looks like a blind token.
+func IsToken(s string) bool {
+	return strings.HasPrefix(s, TOKEN_PREFIX)
+}
+
+// ParseToken decodes a token without verifying it.
+func ParseToken(s string) (*Token, error) {
+	encoded, found := strings.CutPrefix(s, TOKEN_PREFIX)
+	if !found {
+		return nil, fmt.Errorf("%w: expect prefix %s", ErrMalformedToken, TOKEN_PREFIX)
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+	var token Token
+	if err := json.Unmarshal(buf, &token); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+	return &token, nil
+}
@@ -0,0 +1,14 @@
+package blind_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBlind(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Blind Suite")
+}
@@ -0,0 +1,46 @@
+package blind_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+
+	"github.com/atticplaygroup/prex/pkg/blind"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Blind tokens", Label("blind"), func() {
+	It("should verify unblinded signatures only", func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).To(BeNil())
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).To(BeNil())
+
+		request, err := blind.NewRequest(&privateKey.PublicKey, "did:key:z6MkSeller")
+		Expect(err).To(BeNil())
+		blindSignature, err := blind.Sign(privateKey, request.Blinded)
+		Expect(err).To(BeNil())
+
+		token, err := request.Finalize(blindSignature)
+		Expect(err).To(BeNil())
+		Expect(token.Signature).NotTo(Equal(blindSignature))
+
+		parsed, err := blind.ParseToken(token.String())
+		Expect(err).To(BeNil())
+		Expect(parsed.Verify(&privateKey.PublicKey)).To(Succeed())
+		Expect(parsed.Verify(&otherKey.PublicKey)).To(MatchError(blind.ErrBadSignature))
+
+		parsed.Nonce[0] ^= 1
+		Expect(parsed.Verify(&privateKey.PublicKey)).To(MatchError(blind.ErrBadSignature))
+		parsed.Nonce[0] ^= 1
+
+		By("binding the token to its audience")
+		parsed.Audience = "did:key:z6MkOther"
+		Expect(parsed.Verify(&privateKey.PublicKey)).To(MatchError(blind.ErrBadSignature))
+
+		forged, err := blind.Sign(otherKey, request.Blinded[:otherKey.Size()-1])
+		Expect(err).To(BeNil())
+		_, err = request.Finalize(forged)
+		Expect(err).To(MatchError(blind.ErrBadSignature))
+	})
+})
@@ -0,0 +1,316 @@
+// Package caveat implements attenuable quota tokens. Prex signs the first
+// block of a token with its token key. Every block names the public key that
+// must sign the next one and the holder keeps the matching private key, so it
+// can append blocks of caveats that only ever narrow the token without asking
+// Prex. Service providers verify tokens offline with the published token keys.
+package caveat
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/didkey"
+)
+
+// TOKEN_PREFIX tells caveat tokens apart from JWTs.
+const TOKEN_PREFIX = "pxc1."
+
+// AUTHORITY_SIGNING_PREFIX is prepended to the first block when the token key
+// signs it. It tells the block apart from JWTs signed by the same key, so a
+// remote signer knows which policy applies.
+const AUTHORITY_SIGNING_PREFIX = "pxc1-authority:"
+
+var (
+	ErrMalformedToken = errors.New("malformed caveat token")
+	ErrBadSignature   = errors.New("invalid caveat token signature")
+	ErrUnauthorized   = errors.New("request not allowed by caveat token")
+)
+
+// ISigner signs the first block of a token. The token signers of Prex
+// implement it.
+type ISigner interface {
+	GetPublicKey() ed25519.PublicKey
+	SignToken(ctx context.Context, signingInput string) ([]byte, error)
+}
+
+// KeyResolver returns the public key of the issuer kid if it is trusted.
+type KeyResolver func(kid string) (ed25519.PublicKey, error)
+
+// TrustedKeys trusts the token keys of the given did:key ids, e.g. as served
+// by /.well-known/jwks.json of Prex.
+func TrustedKeys(kids ...string) KeyResolver {
+	return func(kid string) (ed25519.PublicKey, error) {
+		if !slices.Contains(kids, kid) {
+			return nil, fmt.Errorf("untrusted issuer %s", kid)
+		}
+		return didkey.ParseKeyId(kid)
+	}
+}
+
+// Authority is what Prex grants in the first block of a token.
+type Authority struct {
+	Issuer     string `json:"iss"`
+	Jti        string `json:"jti"`
+	Audience   string `json:"aud"`
+	Quantity   int64  `json:"quantity"`
+	IssuedAt   int64  `json:"iat"`
+	ExpireTime int64  `json:"exp"`
+}
+
+// Caveat restricts a token. Unset fields do not restrict it.
+type Caveat struct {
+	// MaxQuantity caps the total quantity redeemed with this and derived tokens
+	MaxQuantity int64 `json:"max_quantity,omitempty"`
+	// Endpoints the token may be redeemed for
+	Endpoints []string `json:"endpoints,omitempty"`
+	// ExpireTime in unix seconds
+	ExpireTime int64 `json:"exp,omitempty"`
+}
+
+type blockPayload struct {
+	Authority *Authority `json:"authority,omitempty"`
+	Caveats   []Caveat   `json:"caveats,omitempty"`
+	NextKey   []byte     `json:"next_key"`
+}
+
+type block struct {
+	Payload   []byte `json:"p"`
+	Signature []byte `json:"s"`
+}
+
+type encodedToken struct {
+	Blocks []block `json:"blocks"`
+	// Proof is the seed of the key to sign the next block with
+	Proof []byte `json:"proof"`
+}
+
+// Token is a verified chain of blocks.
+type Token struct {
+	encoded   encodedToken
+	authority Authority
+	caveats   [][]Caveat
+}
+
+// Issue signs a token granting authority with signer.
+func Issue(ctx context.Context, signer ISigner, authority Authority) (*Token, error) {
+	authority.Issuer = didkey.KeyId(signer.GetPublicKey())
+	nextKey, proof, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(blockPayload{Authority: &authority, NextKey: nextKey})
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.SignToken(ctx, AUTHORITY_SIGNING_PREFIX+string(payload))
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		encoded: encodedToken{
+			Blocks: []block{{Payload: payload, Signature: signature}},
+			Proof:  proof.Seed(),
+		},
+		authority: authority,
+		caveats:   [][]Caveat{nil},
+	}, nil
+}
+
+// Attenuate returns a token further restricted by caveats. The receiver
+// remains valid.
+func (t *Token) Attenuate(caveats ...Caveat) (*Token, error) {
+	if len(caveats) == 0 {
+		return nil, fmt.Errorf("no caveats to add")
+	}
+	nextKey, proof, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(blockPayload{Caveats: caveats, NextKey: nextKey})
+	if err != nil {
+		return nil, err
+	}
+	signature := ed25519.Sign(ed25519.NewKeyFromSeed(t.encoded.Proof), payload)
+	return &Token{
+		encoded: encodedToken{
+			Blocks: append(slices.Clone(t.encoded.Blocks), block{Payload: payload, Signature: signature}),
+			Proof:  proof.Seed(),
+		},
+		authority: t.authority,
+		caveats:   append(slices.Clone(t.caveats), caveats),
+	}, nil
+}
+
+// String encodes the token to be passed on.
+func (t *Token) String() string {
+	buf, _ := json.Marshal(t.encoded)
+	return TOKEN_PREFIX + base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// IsToken reports whether s looks like a caveat token rather than a JWT.
+func IsToken(s string) bool {
+	return strings.HasPrefix(s, TOKEN_PREFIX)
+}
+
+func decodePayload(buf []byte) (*blockPayload, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(buf)))
+	// Caveats unknown to this version must not be silently dropped
+	decoder.DisallowUnknownFields()
+	var payload blockPayload
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+	if len(payload.NextKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: bad next key", ErrMalformedToken)
+	}
+	return &payload, nil
+}
+
+// IsAuthoritySigningInput reports whether a token key is asked to sign the
+// first block of a caveat token.
+func IsAuthoritySigningInput(signingInput string) bool {
+	return strings.HasPrefix(signingInput, AUTHORITY_SIGNING_PREFIX)
+}
+
+// ParseAuthoritySigningInput returns what the first block of a token to be
+// signed grants, so that signers can check it against their policy.
+func ParseAuthoritySigningInput(signingInput string) (*Authority, error) {
+	buf, found := strings.CutPrefix(signingInput, AUTHORITY_SIGNING_PREFIX)
+	if !found {
+		return nil, fmt.Errorf("%w: expect prefix %s", ErrMalformedToken, AUTHORITY_SIGNING_PREFIX)
+	}
+	payload, err := decodePayload([]byte(buf))
+	if err != nil {
+		return nil, err
+	}
+	if payload.Authority == nil || len(payload.Caveats) != 0 {
+		return nil, fmt.Errorf("%w: first block must only grant authority", ErrMalformedToken)
+	}
+	return payload.Authority, nil
+}
+
+// Parse verifies the signatures of a token issued by a key resolve trusts. It
+// does not check the caveats, see Claims.Authorize.
+func Parse(s string, resolve KeyResolver) (*Token, error) {
+	encoded, found := strings.CutPrefix(s, TOKEN_PREFIX)
+	if !found {
+		return nil, fmt.Errorf("%w: expect prefix %s", ErrMalformedToken, TOKEN_PREFIX)
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+	t := &Token{}
+	if err := json.Unmarshal(buf, &t.encoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+	if len(t.encoded.Blocks) == 0 || len(t.encoded.Proof) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: no blocks or proof", ErrMalformedToken)
+	}
+	var verificationKey ed25519.PublicKey
+	for i, b := range t.encoded.Blocks {
+		payload, err := decodePayload(b.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			if payload.Authority == nil || len(payload.Caveats) != 0 {
+				return nil, fmt.Errorf("%w: first block must only grant authority", ErrMalformedToken)
+			}
+			if verificationKey, err = resolve(payload.Authority.Issuer); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrBadSignature, err)
+			}
+			t.authority = *payload.Authority
+		} else if payload.Authority != nil {
+			return nil, fmt.Errorf("%w: block %d grants authority", ErrMalformedToken, i)
+		}
+		signed := b.Payload
+		if i == 0 {
+			signed = append([]byte(AUTHORITY_SIGNING_PREFIX), b.Payload...)
+		}
+		if !ed25519.Verify(verificationKey, signed, b.Signature) {
+			return nil, fmt.Errorf("%w: block %d", ErrBadSignature, i)
+		}
+		t.caveats = append(t.caveats, payload.Caveats)
+		verificationKey = payload.NextKey
+	}
+	proofKey := ed25519.NewKeyFromSeed(t.encoded.Proof).Public().(ed25519.PublicKey)
+	if !proofKey.Equal(verificationKey) {
+		return nil, fmt.Errorf("%w: proof does not match last block", ErrBadSignature)
+	}
+	return t, nil
+}
+
+// Limit is a quantity cap of a block, redeemed against its own ledger entry.
+type Limit struct {
+	// Id is unique to the block that added the cap
+	Id       string
+	Quantity int64
+}
+
+// Claims are what a token allows after applying all caveats.
+type Claims struct {
+	Authority
+	// ExpiresAt is the earliest of all expiry times
+	ExpiresAt time.Time
+	Limits    []Limit
+	// Endpoints lists the allowed endpoints of every block restricting them
+	Endpoints [][]string
+}
+
+// Claims folds the caveats of the token into what it allows.
+func (t *Token) Claims() *Claims {
+	claims := &Claims{
+		Authority: t.authority,
+		ExpiresAt: time.Unix(t.authority.ExpireTime, 0),
+	}
+	for i, caveats := range t.caveats {
+		for _, caveat := range caveats {
+			if caveat.MaxQuantity > 0 {
+				digest := sha256.Sum256(t.encoded.Blocks[i].Signature)
+				claims.Limits = append(claims.Limits, Limit{
+					Id:       fmt.Sprintf("%s.%x", t.authority.Jti, digest[:8]),
+					Quantity: caveat.MaxQuantity,
+				})
+			}
+			if len(caveat.Endpoints) > 0 {
+				claims.Endpoints = append(claims.Endpoints, caveat.Endpoints)
+			}
+			if caveat.ExpireTime > 0 && time.Unix(caveat.ExpireTime, 0).Before(claims.ExpiresAt) {
+				claims.ExpiresAt = time.Unix(caveat.ExpireTime, 0)
+			}
+		}
+	}
+	return claims
+}
+
+// Authorize checks that redeeming quantity for endpoint at now is allowed.
+// Cumulative quantity caps are left to the ledger of Prex.
+func (c *Claims) Authorize(endpoint string, quantity int64, now time.Time) error {
+	if !now.Before(c.ExpiresAt) {
+		return fmt.Errorf("%w: expired at %v", ErrUnauthorized, c.ExpiresAt)
+	}
+	if quantity > c.Quantity {
+		return fmt.Errorf("%w: %d exceeds quantity %d", ErrUnauthorized, quantity, c.Quantity)
+	}
+	for _, limit := range c.Limits {
+		if quantity > limit.Quantity {
+			return fmt.Errorf("%w: %d exceeds cap %d", ErrUnauthorized, quantity, limit.Quantity)
+		}
+	}
+	for _, endpoints := range c.Endpoints {
+		if !slices.Contains(endpoints, endpoint) {
+			return fmt.Errorf("%w: endpoint %q not in %v", ErrUnauthorized, endpoint, endpoints)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package caveat_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCaveat(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Caveat Suite")
+}
@@ -0,0 +1,91 @@
+package caveat_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Caveat tokens", Label("caveat"), func() {
+	ctx := context.Background()
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	signer := signing.NewMemoryTokenSigner(privateKey)
+	resolve := caveat.TrustedKeys(didkey.KeyId(signer.GetPublicKey()))
+	now := time.Now()
+	authority := caveat.Authority{
+		Jti:        "3c4d5e6f-7081-4b9c-adbe-2f3a4b5c6d7e",
+		Audience:   "did:key:z6MkSeller",
+		Quantity:   100,
+		IssuedAt:   now.Unix(),
+		ExpireTime: now.Add(time.Hour).Unix(),
+	}
+
+	It("should narrow a token offline", func() {
+		token, err := caveat.Issue(ctx, signer, authority)
+		Expect(err).To(BeNil())
+		attenuated, err := token.Attenuate(caveat.Caveat{
+			MaxQuantity: 10,
+			Endpoints:   []string{"/v1/chat"},
+			ExpireTime:  now.Add(5 * time.Minute).Unix(),
+		})
+		Expect(err).To(BeNil())
+		attenuated, err = attenuated.Attenuate(caveat.Caveat{MaxQuantity: 20})
+		Expect(err).To(BeNil())
+
+		parsed, err := caveat.Parse(attenuated.String(), resolve)
+		Expect(err).To(BeNil())
+		claims := parsed.Claims()
+		Expect(claims.Jti).To(Equal(authority.Jti))
+		Expect(claims.Limits).To(HaveLen(2))
+		Expect(claims.Limits[0].Id).To(HavePrefix(authority.Jti + "."))
+		Expect(claims.ExpiresAt.Unix()).To(Equal(now.Add(5 * time.Minute).Unix()))
+
+		Expect(claims.Authorize("/v1/chat", 10, now)).To(Succeed())
+		Expect(claims.Authorize("/v1/chat", 11, now)).To(MatchError(caveat.ErrUnauthorized))
+		Expect(claims.Authorize("/v1/image", 1, now)).To(MatchError(caveat.ErrUnauthorized))
+		Expect(claims.Authorize("/v1/chat", 1, now.Add(10*time.Minute))).To(MatchError(caveat.ErrUnauthorized))
+
+		// The original token is unaffected
+		parsed, err = caveat.Parse(token.String(), resolve)
+		Expect(err).To(BeNil())
+		Expect(parsed.Claims().Authorize("/v1/image", 100, now)).To(Succeed())
+	})
+
+	It("should reject untrusted issuers and stripped caveats", func() {
+		otherSigner := signing.NewMemoryTokenSigner(
+			ed25519.NewKeyFromSeed([]byte(strings.Repeat("x", ed25519.SeedSize))))
+		forged, err := caveat.Issue(ctx, otherSigner, authority)
+		Expect(err).To(BeNil())
+		_, err = caveat.Parse(forged.String(), resolve)
+		Expect(err).To(MatchError(caveat.ErrBadSignature))
+
+		token, err := caveat.Issue(ctx, signer, authority)
+		Expect(err).To(BeNil())
+		attenuated, err := token.Attenuate(caveat.Caveat{MaxQuantity: 10})
+		Expect(err).To(BeNil())
+
+		// Dropping the last block leaves a proof not matching the chain
+		var encoded map[string]json.RawMessage
+		buf, err := base64.RawURLEncoding.DecodeString(
+			strings.TrimPrefix(attenuated.String(), caveat.TOKEN_PREFIX))
+		Expect(err).To(BeNil())
+		Expect(json.Unmarshal(buf, &encoded)).To(Succeed())
+		var blocks []json.RawMessage
+		Expect(json.Unmarshal(encoded["blocks"], &blocks)).To(Succeed())
+		encoded["blocks"], err = json.Marshal(blocks[:1])
+		Expect(err).To(BeNil())
+		buf, err = json.Marshal(encoded)
+		Expect(err).To(BeNil())
+		_, err = caveat.Parse(caveat.TOKEN_PREFIX+base64.RawURLEncoding.EncodeToString(buf), resolve)
+		Expect(err).To(MatchError(caveat.ErrBadSignature))
+	})
+})
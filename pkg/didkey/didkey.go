@@ -0,0 +1,34 @@
+// Package didkey converts between ed25519 public keys and the did:key ids
+// Prex uses as kid of its token keys.
+package didkey
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	"github.com/mr-tron/base58"
+)
+
+// KeyId returns the did:key identifying an ed25519 public key.
+func KeyId(publicKey ed25519.PublicKey) string {
+	buf := []byte{0xed, 0x01}
+	buf = append(buf, []byte(publicKey)...)
+	return fmt.Sprintf("did:key:z%s", base58.Encode(buf))
+}
+
+// ParseKeyId returns the ed25519 public key of a did:key.
+func ParseKeyId(keyId string) (ed25519.PublicKey, error) {
+	encoded, found := strings.CutPrefix(keyId, "did:key:z")
+	if !found {
+		return nil, fmt.Errorf("expect did:key with base58btc encoding but got %s", keyId)
+	}
+	buf, err := base58.Decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %v", keyId, err)
+	}
+	if len(buf) != 2+ed25519.PublicKeySize || buf[0] != 0xed || buf[1] != 0x01 {
+		return nil, fmt.Errorf("%s is not an ed25519 public key", keyId)
+	}
+	return ed25519.PublicKey(buf[2:]), nil
+}
@@ -8,6 +8,7 @@ package exchange
 
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "github.com/atticplaygroup/prex/pkg/proto/gen/go/prex/v1"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -25,6 +26,164 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TokenFormat int32
+
+const (
+	TokenFormat_TOKEN_FORMAT_UNSPECIFIED TokenFormat = 0
+	TokenFormat_TOKEN_FORMAT_JWT         TokenFormat = 1
+	// Tokens holders can restrict with caveats before passing them on. See the
+	// pkg/caveat package to verify them.
+	TokenFormat_TOKEN_FORMAT_CAVEAT TokenFormat = 2
+)
+
+// Enum value maps for TokenFormat.
+var (
+	TokenFormat_name = map[int32]string{
+		0: "TOKEN_FORMAT_UNSPECIFIED",
+		1: "TOKEN_FORMAT_JWT",
+		2: "TOKEN_FORMAT_CAVEAT",
+	}
+	TokenFormat_value = map[string]int32{
+		"TOKEN_FORMAT_UNSPECIFIED": 0,
+		"TOKEN_FORMAT_JWT":         1,
+		"TOKEN_FORMAT_CAVEAT":      2,
+	}
+)
+
+func (x TokenFormat) Enum() *TokenFormat {
+	p := new(TokenFormat)
+	*p = x
+	return p
+}
+
+func (x TokenFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TokenFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_exchange_v1_exchange_proto_enumTypes[0].Descriptor()
+}
+
+func (TokenFormat) Type() protoreflect.EnumType {
+	return &file_exchange_v1_exchange_proto_enumTypes[0]
+}
+
+func (x TokenFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TokenFormat.Descriptor instead.
+func (TokenFormat) EnumDescriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{0}
+}
+
+type RefundMode int32
+
+const (
+	RefundMode_REFUND_MODE_UNSPECIFIED RefundMode = 0
+	// Tokens are not refundable. The default without a policy.
+	RefundMode_REFUND_MODE_NONE RefundMode = 1
+	// Only tokens never redeemed are refundable
+	RefundMode_REFUND_MODE_UNREDEEMED RefundMode = 2
+	// The unconsumed part of any token is refundable
+	RefundMode_REFUND_MODE_UNCONSUMED RefundMode = 3
+)
+
+// Enum value maps for RefundMode.
+var (
+	RefundMode_name = map[int32]string{
+		0: "REFUND_MODE_UNSPECIFIED",
+		1: "REFUND_MODE_NONE",
+		2: "REFUND_MODE_UNREDEEMED",
+		3: "REFUND_MODE_UNCONSUMED",
+	}
+	RefundMode_value = map[string]int32{
+		"REFUND_MODE_UNSPECIFIED": 0,
+		"REFUND_MODE_NONE":        1,
+		"REFUND_MODE_UNREDEEMED":  2,
+		"REFUND_MODE_UNCONSUMED":  3,
+	}
+)
+
+func (x RefundMode) Enum() *RefundMode {
+	p := new(RefundMode)
+	*p = x
+	return p
+}
+
+func (x RefundMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RefundMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_exchange_v1_exchange_proto_enumTypes[1].Descriptor()
+}
+
+func (RefundMode) Type() protoreflect.EnumType {
+	return &file_exchange_v1_exchange_proto_enumTypes[1]
+}
+
+func (x RefundMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RefundMode.Descriptor instead.
+func (RefundMode) EnumDescriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{1}
+}
+
+type ServiceSessionState int32
+
+const (
+	ServiceSessionState_SERVICE_SESSION_STATE_UNSPECIFIED ServiceSessionState = 0
+	ServiceSessionState_SERVICE_SESSION_STATE_ACTIVE      ServiceSessionState = 1
+	ServiceSessionState_SERVICE_SESSION_STATE_PAUSED      ServiceSessionState = 2
+	ServiceSessionState_SERVICE_SESSION_STATE_CLOSED      ServiceSessionState = 3
+)
+
+// Enum value maps for ServiceSessionState.
+var (
+	ServiceSessionState_name = map[int32]string{
+		0: "SERVICE_SESSION_STATE_UNSPECIFIED",
+		1: "SERVICE_SESSION_STATE_ACTIVE",
+		2: "SERVICE_SESSION_STATE_PAUSED",
+		3: "SERVICE_SESSION_STATE_CLOSED",
+	}
+	ServiceSessionState_value = map[string]int32{
+		"SERVICE_SESSION_STATE_UNSPECIFIED": 0,
+		"SERVICE_SESSION_STATE_ACTIVE":      1,
+		"SERVICE_SESSION_STATE_PAUSED":      2,
+		"SERVICE_SESSION_STATE_CLOSED":      3,
+	}
+)
+
+func (x ServiceSessionState) Enum() *ServiceSessionState {
+	p := new(ServiceSessionState)
+	*p = x
+	return p
+}
+
+func (x ServiceSessionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceSessionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_exchange_v1_exchange_proto_enumTypes[2].Descriptor()
+}
+
+func (ServiceSessionState) Type() protoreflect.EnumType {
+	return &file_exchange_v1_exchange_proto_enumTypes[2]
+}
+
+func (x ServiceSessionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceSessionState.Descriptor instead.
+func (ServiceSessionState) EnumDescriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{2}
+}
+
 type PaymentCoin int32
 
 const (
@@ -55,11 +214,11 @@ func (x PaymentCoin) String() string {
 }
 
 func (PaymentCoin) Descriptor() protoreflect.EnumDescriptor {
-	return file_exchange_v1_exchange_proto_enumTypes[0].Descriptor()
+	return file_exchange_v1_exchange_proto_enumTypes[3].Descriptor()
 }
 
 func (PaymentCoin) Type() protoreflect.EnumType {
-	return &file_exchange_v1_exchange_proto_enumTypes[0]
+	return &file_exchange_v1_exchange_proto_enumTypes[3]
 }
 
 func (x PaymentCoin) Number() protoreflect.EnumNumber {
@@ -68,7 +227,7 @@ func (x PaymentCoin) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PaymentCoin.Descriptor instead.
 func (PaymentCoin) EnumDescriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{0}
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{3}
 }
 
 type PaymentEnvironment int32
@@ -79,6 +238,7 @@ const (
 	PaymentEnvironment_PAYMENT_ENVIRONMENT_DEVNET      PaymentEnvironment = 2
 	PaymentEnvironment_PAYMENT_ENVIRONMENT_TESTNET     PaymentEnvironment = 3
 	PaymentEnvironment_PAYMENT_ENVIRONMENT_LOCALNET    PaymentEnvironment = 4
+	PaymentEnvironment_PAYMENT_ENVIRONMENT_SIMULATED   PaymentEnvironment = 5
 )
 
 // Enum value maps for PaymentEnvironment.
@@ -89,6 +249,7 @@ var (
 		2: "PAYMENT_ENVIRONMENT_DEVNET",
 		3: "PAYMENT_ENVIRONMENT_TESTNET",
 		4: "PAYMENT_ENVIRONMENT_LOCALNET",
+		5: "PAYMENT_ENVIRONMENT_SIMULATED",
 	}
 	PaymentEnvironment_value = map[string]int32{
 		"PAYMENT_ENVIRONMENT_UNSPECIFIED": 0,
@@ -96,6 +257,7 @@ var (
 		"PAYMENT_ENVIRONMENT_DEVNET":      2,
 		"PAYMENT_ENVIRONMENT_TESTNET":     3,
 		"PAYMENT_ENVIRONMENT_LOCALNET":    4,
+		"PAYMENT_ENVIRONMENT_SIMULATED":   5,
 	}
 )
 
@@ -110,11 +272,11 @@ func (x PaymentEnvironment) String() string {
 }
 
 func (PaymentEnvironment) Descriptor() protoreflect.EnumDescriptor {
-	return file_exchange_v1_exchange_proto_enumTypes[1].Descriptor()
+	return file_exchange_v1_exchange_proto_enumTypes[4].Descriptor()
 }
 
 func (PaymentEnvironment) Type() protoreflect.EnumType {
-	return &file_exchange_v1_exchange_proto_enumTypes[1]
+	return &file_exchange_v1_exchange_proto_enumTypes[4]
 }
 
 func (x PaymentEnvironment) Number() protoreflect.EnumNumber {
@@ -123,15 +285,18 @@ func (x PaymentEnvironment) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PaymentEnvironment.Descriptor instead.
 func (PaymentEnvironment) EnumDescriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{1}
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{4}
 }
 
 type JwtUsage int32
 
 const (
-	JwtUsage_JWT_USAGE_UNSPECIFIED    JwtUsage = 0
-	JwtUsage_JWT_USAGE_CREATE_SESSION JwtUsage = 1
-	JwtUsage_JWT_USAGE_MANAGE_SESSION JwtUsage = 2
+	JwtUsage_JWT_USAGE_UNSPECIFIED      JwtUsage = 0
+	JwtUsage_JWT_USAGE_CREATE_SESSION   JwtUsage = 1
+	JwtUsage_JWT_USAGE_MANAGE_SESSION   JwtUsage = 2
+	JwtUsage_JWT_USAGE_PURCHASE_RECEIPT JwtUsage = 3
+	// Access tokens of Prex login sessions
+	JwtUsage_JWT_USAGE_ACCESS JwtUsage = 4
 )
 
 // Enum value maps for JwtUsage.
@@ -140,11 +305,15 @@ var (
 		0: "JWT_USAGE_UNSPECIFIED",
 		1: "JWT_USAGE_CREATE_SESSION",
 		2: "JWT_USAGE_MANAGE_SESSION",
+		3: "JWT_USAGE_PURCHASE_RECEIPT",
+		4: "JWT_USAGE_ACCESS",
 	}
 	JwtUsage_value = map[string]int32{
-		"JWT_USAGE_UNSPECIFIED":    0,
-		"JWT_USAGE_CREATE_SESSION": 1,
-		"JWT_USAGE_MANAGE_SESSION": 2,
+		"JWT_USAGE_UNSPECIFIED":      0,
+		"JWT_USAGE_CREATE_SESSION":   1,
+		"JWT_USAGE_MANAGE_SESSION":   2,
+		"JWT_USAGE_PURCHASE_RECEIPT": 3,
+		"JWT_USAGE_ACCESS":           4,
 	}
 )
 
@@ -159,11 +328,11 @@ func (x JwtUsage) String() string {
 }
 
 func (JwtUsage) Descriptor() protoreflect.EnumDescriptor {
-	return file_exchange_v1_exchange_proto_enumTypes[2].Descriptor()
+	return file_exchange_v1_exchange_proto_enumTypes[5].Descriptor()
 }
 
 func (JwtUsage) Type() protoreflect.EnumType {
-	return &file_exchange_v1_exchange_proto_enumTypes[2]
+	return &file_exchange_v1_exchange_proto_enumTypes[5]
 }
 
 func (x JwtUsage) Number() protoreflect.EnumNumber {
@@ -172,13 +341,26 @@ func (x JwtUsage) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use JwtUsage.Descriptor instead.
 func (JwtUsage) EnumDescriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{2}
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{5}
 }
 
 type BuyTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Audience      string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Audience string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
+	Amount   int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Defaults to a JWT
+	Format TokenFormat `protobuf:"varint,3,opt,name=format,proto3,enum=exchange.v1.TokenFormat" json:"format,omitempty"`
+	// Holds the amount in escrow until the audience claims it with consumption
+	// receipts instead of paying it at once. What is unclaimed when the token
+	// expires goes back to the caller. Requires a did:key audience, which signs
+	// the receipts.
+	Escrow bool `protobuf:"varint,4,opt,name=escrow,proto3" json:"escrow,omitempty"`
+	// How long the tokens last. May not exceed the max_token_ttl of the
+	// audience. Defaults to TOKEN_TTL, or max_token_ttl if shorter.
+	Ttl *durationpb.Duration `protobuf:"bytes,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Splits amount over this many tokens, the first ones taking the remainder.
+	// Defaults to one token.
+	SplitCount    int32 `protobuf:"varint,6,opt,name=split_count,json=splitCount,proto3" json:"split_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -227,9 +409,44 @@ func (x *BuyTokenRequest) GetAmount() int64 {
 	return 0
 }
 
+func (x *BuyTokenRequest) GetFormat() TokenFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TokenFormat_TOKEN_FORMAT_UNSPECIFIED
+}
+
+func (x *BuyTokenRequest) GetEscrow() bool {
+	if x != nil {
+		return x.Escrow
+	}
+	return false
+}
+
+func (x *BuyTokenRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *BuyTokenRequest) GetSplitCount() int32 {
+	if x != nil {
+		return x.SplitCount
+	}
+	return 0
+}
+
 type BuyTokenResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Proves the token is logged in the transparency log
+	LogInclusion *LogInclusion `protobuf:"bytes,2,opt,name=log_inclusion,json=logInclusion,proto3" json:"log_inclusion,omitempty"`
+	// JWT signed by the token key stating what was paid to whom for the token.
+	// See pkg/receipt to verify it.
+	Receipt string `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// Every token of a split purchase. The fields above are those of the first.
+	Tokens        []*PurchasedToken `protobuf:"bytes,4,rep,name=tokens,proto3" json:"tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -271,26 +488,50 @@ func (x *BuyTokenResponse) GetToken() string {
 	return ""
 }
 
-type ListPaymentMethodsRequest struct {
+func (x *BuyTokenResponse) GetLogInclusion() *LogInclusion {
+	if x != nil {
+		return x.LogInclusion
+	}
+	return nil
+}
+
+func (x *BuyTokenResponse) GetReceipt() string {
+	if x != nil {
+		return x.Receipt
+	}
+	return ""
+}
+
+func (x *BuyTokenResponse) GetTokens() []*PurchasedToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type PurchasedToken struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	LogInclusion  *LogInclusion          `protobuf:"bytes,2,opt,name=log_inclusion,json=logInclusion,proto3" json:"log_inclusion,omitempty"`
+	Receipt       string                 `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPaymentMethodsRequest) Reset() {
-	*x = ListPaymentMethodsRequest{}
+func (x *PurchasedToken) Reset() {
+	*x = PurchasedToken{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPaymentMethodsRequest) String() string {
+func (x *PurchasedToken) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPaymentMethodsRequest) ProtoMessage() {}
+func (*PurchasedToken) ProtoMessage() {}
 
-func (x *ListPaymentMethodsRequest) ProtoReflect() protoreflect.Message {
+func (x *PurchasedToken) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -302,32 +543,58 @@ func (x *ListPaymentMethodsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPaymentMethodsRequest.ProtoReflect.Descriptor instead.
-func (*ListPaymentMethodsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PurchasedToken.ProtoReflect.Descriptor instead.
+func (*PurchasedToken) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{2}
 }
 
-type ListPaymentMethodsResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	PaymentMethods []*PaymentMethod       `protobuf:"bytes,1,rep,name=payment_methods,json=paymentMethods,proto3" json:"payment_methods,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *PurchasedToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
 }
 
-func (x *ListPaymentMethodsResponse) Reset() {
-	*x = ListPaymentMethodsResponse{}
+func (x *PurchasedToken) GetLogInclusion() *LogInclusion {
+	if x != nil {
+		return x.LogInclusion
+	}
+	return nil
+}
+
+func (x *PurchasedToken) GetReceipt() string {
+	if x != nil {
+		return x.Receipt
+	}
+	return ""
+}
+
+type RedeemTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Consumes all quota left if unset
+	Quantity *int64 `protobuf:"varint,2,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	// Endpoint of the service the quota is spent on. Required if the token is
+	// restricted to some endpoints.
+	Endpoint      string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemTokenRequest) Reset() {
+	*x = RedeemTokenRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPaymentMethodsResponse) String() string {
+func (x *RedeemTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPaymentMethodsResponse) ProtoMessage() {}
+func (*RedeemTokenRequest) ProtoMessage() {}
 
-func (x *ListPaymentMethodsResponse) ProtoReflect() protoreflect.Message {
+func (x *RedeemTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -339,41 +606,53 @@ func (x *ListPaymentMethodsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPaymentMethodsResponse.ProtoReflect.Descriptor instead.
-func (*ListPaymentMethodsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RedeemTokenRequest.ProtoReflect.Descriptor instead.
+func (*RedeemTokenRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ListPaymentMethodsResponse) GetPaymentMethods() []*PaymentMethod {
+func (x *RedeemTokenRequest) GetToken() string {
 	if x != nil {
-		return x.PaymentMethods
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-type PaymentMethod struct {
+func (x *RedeemTokenRequest) GetQuantity() int64 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return 0
+}
+
+func (x *RedeemTokenRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+type RedeemTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Coin          PaymentCoin            `protobuf:"varint,2,opt,name=coin,proto3,enum=exchange.v1.PaymentCoin" json:"coin,omitempty"`
-	Environment   PaymentEnvironment     `protobuf:"varint,3,opt,name=environment,proto3,enum=exchange.v1.PaymentEnvironment" json:"environment,omitempty"`
-	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	TokenStatus   *TokenStatus           `protobuf:"bytes,1,opt,name=token_status,json=tokenStatus,proto3" json:"token_status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaymentMethod) Reset() {
-	*x = PaymentMethod{}
+func (x *RedeemTokenResponse) Reset() {
+	*x = RedeemTokenResponse{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaymentMethod) String() string {
+func (x *RedeemTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaymentMethod) ProtoMessage() {}
+func (*RedeemTokenResponse) ProtoMessage() {}
 
-func (x *PaymentMethod) ProtoReflect() protoreflect.Message {
+func (x *RedeemTokenResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -385,54 +664,39 @@ func (x *PaymentMethod) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaymentMethod.ProtoReflect.Descriptor instead.
-func (*PaymentMethod) Descriptor() ([]byte, []int) {
+// Deprecated: Use RedeemTokenResponse.ProtoReflect.Descriptor instead.
+func (*RedeemTokenResponse) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PaymentMethod) GetCoin() PaymentCoin {
-	if x != nil {
-		return x.Coin
-	}
-	return PaymentCoin_PAYMENT_COIN_UNSPECIFIED
-}
-
-func (x *PaymentMethod) GetEnvironment() PaymentEnvironment {
-	if x != nil {
-		return x.Environment
-	}
-	return PaymentEnvironment_PAYMENT_ENVIRONMENT_UNSPECIFIED
-}
-
-func (x *PaymentMethod) GetAddress() string {
+func (x *RedeemTokenResponse) GetTokenStatus() *TokenStatus {
 	if x != nil {
-		return x.Address
+		return x.TokenStatus
 	}
-	return ""
+	return nil
 }
 
-type PingRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// To check effectiveness of buf validation
-	Dummy         int64 `protobuf:"varint,1,opt,name=dummy,proto3" json:"dummy,omitempty"`
+type IntrospectTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingRequest) Reset() {
-	*x = PingRequest{}
+func (x *IntrospectTokenRequest) Reset() {
+	*x = IntrospectTokenRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingRequest) String() string {
+func (x *IntrospectTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingRequest) ProtoMessage() {}
+func (*IntrospectTokenRequest) ProtoMessage() {}
 
-func (x *PingRequest) ProtoReflect() protoreflect.Message {
+func (x *IntrospectTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -444,39 +708,39 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
-func (*PingRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use IntrospectTokenRequest.ProtoReflect.Descriptor instead.
+func (*IntrospectTokenRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PingRequest) GetDummy() int64 {
+func (x *IntrospectTokenRequest) GetToken() string {
 	if x != nil {
-		return x.Dummy
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-type PingResponse struct {
+type IntrospectTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pong          string                 `protobuf:"bytes,1,opt,name=pong,proto3" json:"pong,omitempty"`
+	TokenStatus   *TokenStatus           `protobuf:"bytes,1,opt,name=token_status,json=tokenStatus,proto3" json:"token_status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingResponse) Reset() {
-	*x = PingResponse{}
+func (x *IntrospectTokenResponse) Reset() {
+	*x = IntrospectTokenResponse{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingResponse) String() string {
+func (x *IntrospectTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingResponse) ProtoMessage() {}
+func (*IntrospectTokenResponse) ProtoMessage() {}
 
-func (x *PingResponse) ProtoReflect() protoreflect.Message {
+func (x *IntrospectTokenResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -488,39 +752,49 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
-func (*PingResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use IntrospectTokenResponse.ProtoReflect.Descriptor instead.
+func (*IntrospectTokenResponse) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PingResponse) GetPong() string {
+func (x *IntrospectTokenResponse) GetTokenStatus() *TokenStatus {
 	if x != nil {
-		return x.Pong
+		return x.TokenStatus
 	}
-	return ""
+	return nil
 }
 
-type BatchMarkWithdrawsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+// TokenStatus is the quota left on a token
+type TokenStatus struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Jti        string                 `protobuf:"bytes,1,opt,name=jti,proto3" json:"jti,omitempty"`
+	Audience   string                 `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`
+	Quantity   int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Consumed   int64                  `protobuf:"varint,4,opt,name=consumed,proto3" json:"consumed,omitempty"`
+	Remaining  int64                  `protobuf:"varint,5,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// Whether the token is unexpired, unrevoked and has quota left
+	Active bool `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	// Set once the token is refunded
+	RevokeTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=revoke_time,json=revokeTime,proto3" json:"revoke_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchMarkWithdrawsRequest) Reset() {
-	*x = BatchMarkWithdrawsRequest{}
+func (x *TokenStatus) Reset() {
+	*x = TokenStatus{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchMarkWithdrawsRequest) String() string {
+func (x *TokenStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchMarkWithdrawsRequest) ProtoMessage() {}
+func (*TokenStatus) ProtoMessage() {}
 
-func (x *BatchMarkWithdrawsRequest) ProtoReflect() protoreflect.Message {
+func (x *TokenStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -532,39 +806,88 @@ func (x *BatchMarkWithdrawsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchMarkWithdrawsRequest.ProtoReflect.Descriptor instead.
-func (*BatchMarkWithdrawsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TokenStatus.ProtoReflect.Descriptor instead.
+func (*TokenStatus) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BatchMarkWithdrawsRequest) GetLimit() int32 {
+func (x *TokenStatus) GetJti() string {
 	if x != nil {
-		return x.Limit
+		return x.Jti
+	}
+	return ""
+}
+
+func (x *TokenStatus) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *TokenStatus) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
 	}
 	return 0
 }
 
-type BatchMarkWithdrawsResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	SuccessWithdrawIds []int64                `protobuf:"varint,1,rep,packed,name=success_withdraw_ids,json=successWithdrawIds,proto3" json:"success_withdraw_ids,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *TokenStatus) GetConsumed() int64 {
+	if x != nil {
+		return x.Consumed
+	}
+	return 0
 }
 
-func (x *BatchMarkWithdrawsResponse) Reset() {
-	*x = BatchMarkWithdrawsResponse{}
+func (x *TokenStatus) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *TokenStatus) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *TokenStatus) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *TokenStatus) GetRevokeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokeTime
+	}
+	return nil
+}
+
+type RefundTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundTokenRequest) Reset() {
+	*x = RefundTokenRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchMarkWithdrawsResponse) String() string {
+func (x *RefundTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchMarkWithdrawsResponse) ProtoMessage() {}
+func (*RefundTokenRequest) ProtoMessage() {}
 
-func (x *BatchMarkWithdrawsResponse) ProtoReflect() protoreflect.Message {
+func (x *RefundTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -576,39 +899,41 @@ func (x *BatchMarkWithdrawsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchMarkWithdrawsResponse.ProtoReflect.Descriptor instead.
-func (*BatchMarkWithdrawsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefundTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefundTokenRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *BatchMarkWithdrawsResponse) GetSuccessWithdrawIds() []int64 {
+func (x *RefundTokenRequest) GetToken() string {
 	if x != nil {
-		return x.SuccessWithdrawIds
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-type BatchProcessWithdrawsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+type RefundTokenResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TokenStatus *TokenStatus           `protobuf:"bytes,1,opt,name=token_status,json=tokenStatus,proto3" json:"token_status,omitempty"`
+	// Amount paid back to the caller
+	Amount        int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchProcessWithdrawsRequest) Reset() {
-	*x = BatchProcessWithdrawsRequest{}
+func (x *RefundTokenResponse) Reset() {
+	*x = RefundTokenResponse{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchProcessWithdrawsRequest) String() string {
+func (x *RefundTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchProcessWithdrawsRequest) ProtoMessage() {}
+func (*RefundTokenResponse) ProtoMessage() {}
 
-func (x *BatchProcessWithdrawsRequest) ProtoReflect() protoreflect.Message {
+func (x *RefundTokenResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -620,40 +945,51 @@ func (x *BatchProcessWithdrawsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchProcessWithdrawsRequest.ProtoReflect.Descriptor instead.
-func (*BatchProcessWithdrawsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefundTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefundTokenResponse) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *BatchProcessWithdrawsRequest) GetLimit() int32 {
+func (x *RefundTokenResponse) GetTokenStatus() *TokenStatus {
 	if x != nil {
-		return x.Limit
+		return x.TokenStatus
+	}
+	return nil
+}
+
+func (x *RefundTokenResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
 	}
 	return 0
 }
 
-type BatchProcessWithdrawsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Digest        string                 `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
-	BatchSize     int64                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+// RefundPolicy is how tokens bought for an audience may be refunded.
+type RefundPolicy struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Audience string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
+	Mode     RefundMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=exchange.v1.RefundMode" json:"mode,omitempty"`
+	// How long after purchase tokens are refundable. Until they expire if unset.
+	RefundWindow  *durationpb.Duration   `protobuf:"bytes,3,opt,name=refund_window,json=refundWindow,proto3" json:"refund_window,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchProcessWithdrawsResponse) Reset() {
-	*x = BatchProcessWithdrawsResponse{}
+func (x *RefundPolicy) Reset() {
+	*x = RefundPolicy{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchProcessWithdrawsResponse) String() string {
+func (x *RefundPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchProcessWithdrawsResponse) ProtoMessage() {}
+func (*RefundPolicy) ProtoMessage() {}
 
-func (x *BatchProcessWithdrawsResponse) ProtoReflect() protoreflect.Message {
+func (x *RefundPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -665,46 +1001,60 @@ func (x *BatchProcessWithdrawsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchProcessWithdrawsResponse.ProtoReflect.Descriptor instead.
-func (*BatchProcessWithdrawsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefundPolicy.ProtoReflect.Descriptor instead.
+func (*RefundPolicy) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *BatchProcessWithdrawsResponse) GetDigest() string {
+func (x *RefundPolicy) GetAudience() string {
 	if x != nil {
-		return x.Digest
+		return x.Audience
 	}
 	return ""
 }
 
-func (x *BatchProcessWithdrawsResponse) GetBatchSize() int64 {
+func (x *RefundPolicy) GetMode() RefundMode {
 	if x != nil {
-		return x.BatchSize
+		return x.Mode
 	}
-	return 0
+	return RefundMode_REFUND_MODE_UNSPECIFIED
 }
 
-type CancelWithdrawRequest struct {
+func (x *RefundPolicy) GetRefundWindow() *durationpb.Duration {
+	if x != nil {
+		return x.RefundWindow
+	}
+	return nil
+}
+
+func (x *RefundPolicy) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type GetRefundPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Audience      string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelWithdrawRequest) Reset() {
-	*x = CancelWithdrawRequest{}
+func (x *GetRefundPolicyRequest) Reset() {
+	*x = GetRefundPolicyRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelWithdrawRequest) String() string {
+func (x *GetRefundPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelWithdrawRequest) ProtoMessage() {}
+func (*GetRefundPolicyRequest) ProtoMessage() {}
 
-func (x *CancelWithdrawRequest) ProtoReflect() protoreflect.Message {
+func (x *GetRefundPolicyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -716,39 +1066,39 @@ func (x *CancelWithdrawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelWithdrawRequest.ProtoReflect.Descriptor instead.
-func (*CancelWithdrawRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetRefundPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetRefundPolicyRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CancelWithdrawRequest) GetName() string {
+func (x *GetRefundPolicyRequest) GetAudience() string {
 	if x != nil {
-		return x.Name
+		return x.Audience
 	}
 	return ""
 }
 
-type GetWithdrawRequest struct {
+type UpdateRefundPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RefundPolicy  *RefundPolicy          `protobuf:"bytes,1,opt,name=refund_policy,json=refundPolicy,proto3" json:"refund_policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetWithdrawRequest) Reset() {
-	*x = GetWithdrawRequest{}
+func (x *UpdateRefundPolicyRequest) Reset() {
+	*x = UpdateRefundPolicyRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetWithdrawRequest) String() string {
+func (x *UpdateRefundPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetWithdrawRequest) ProtoMessage() {}
+func (*UpdateRefundPolicyRequest) ProtoMessage() {}
 
-func (x *GetWithdrawRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateRefundPolicyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -760,42 +1110,44 @@ func (x *GetWithdrawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetWithdrawRequest.ProtoReflect.Descriptor instead.
-func (*GetWithdrawRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateRefundPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRefundPolicyRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetWithdrawRequest) GetName() string {
+func (x *UpdateRefundPolicyRequest) GetRefundPolicy() *RefundPolicy {
 	if x != nil {
-		return x.Name
+		return x.RefundPolicy
 	}
-	return ""
+	return nil
 }
 
-type Withdrawal struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	AddressTo     string                 `protobuf:"bytes,2,opt,name=address_to,json=addressTo,proto3" json:"address_to,omitempty"`
-	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	PriorityFee   int64                  `protobuf:"varint,4,opt,name=priority_fee,json=priorityFee,proto3" json:"priority_fee,omitempty"`
+// TokenPolicy is how long tokens bought for an audience may last.
+type TokenPolicy struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Audience string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
+	// Longest ttl buyers may request, up to MAX_TOKEN_TTL of the server.
+	// TOKEN_TTL if the audience has no policy.
+	MaxTokenTtl   *durationpb.Duration   `protobuf:"bytes,2,opt,name=max_token_ttl,json=maxTokenTtl,proto3" json:"max_token_ttl,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Withdrawal) Reset() {
-	*x = Withdrawal{}
+func (x *TokenPolicy) Reset() {
+	*x = TokenPolicy{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Withdrawal) String() string {
+func (x *TokenPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Withdrawal) ProtoMessage() {}
+func (*TokenPolicy) ProtoMessage() {}
 
-func (x *Withdrawal) ProtoReflect() protoreflect.Message {
+func (x *TokenPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -807,61 +1159,53 @@ func (x *Withdrawal) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Withdrawal.ProtoReflect.Descriptor instead.
-func (*Withdrawal) Descriptor() ([]byte, []int) {
+// Deprecated: Use TokenPolicy.ProtoReflect.Descriptor instead.
+func (*TokenPolicy) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Withdrawal) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Withdrawal) GetAddressTo() string {
+func (x *TokenPolicy) GetAudience() string {
 	if x != nil {
-		return x.AddressTo
+		return x.Audience
 	}
 	return ""
 }
 
-func (x *Withdrawal) GetAmount() int64 {
+func (x *TokenPolicy) GetMaxTokenTtl() *durationpb.Duration {
 	if x != nil {
-		return x.Amount
+		return x.MaxTokenTtl
 	}
-	return 0
+	return nil
 }
 
-func (x *Withdrawal) GetPriorityFee() int64 {
+func (x *TokenPolicy) GetUpdateTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PriorityFee
+		return x.UpdateTime
 	}
-	return 0
+	return nil
 }
 
-type CreateWithdrawRequest struct {
+type GetTokenPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Withdrawal    *Withdrawal            `protobuf:"bytes,2,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
-	WithdrawAll   bool                   `protobuf:"varint,3,opt,name=withdraw_all,json=withdrawAll,proto3" json:"withdraw_all,omitempty"`
+	Audience      string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateWithdrawRequest) Reset() {
-	*x = CreateWithdrawRequest{}
+func (x *GetTokenPolicyRequest) Reset() {
+	*x = GetTokenPolicyRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateWithdrawRequest) String() string {
+func (x *GetTokenPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateWithdrawRequest) ProtoMessage() {}
+func (*GetTokenPolicyRequest) ProtoMessage() {}
 
-func (x *CreateWithdrawRequest) ProtoReflect() protoreflect.Message {
+func (x *GetTokenPolicyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -873,46 +1217,39 @@ func (x *CreateWithdrawRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateWithdrawRequest.ProtoReflect.Descriptor instead.
-func (*CreateWithdrawRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTokenPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenPolicyRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *CreateWithdrawRequest) GetWithdrawal() *Withdrawal {
-	if x != nil {
-		return x.Withdrawal
-	}
-	return nil
-}
-
-func (x *CreateWithdrawRequest) GetWithdrawAll() bool {
+func (x *GetTokenPolicyRequest) GetAudience() string {
 	if x != nil {
-		return x.WithdrawAll
+		return x.Audience
 	}
-	return false
+	return ""
 }
 
-type CreateWithdrawResponse struct {
+type UpdateTokenPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	TokenPolicy   *TokenPolicy           `protobuf:"bytes,1,opt,name=token_policy,json=tokenPolicy,proto3" json:"token_policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateWithdrawResponse) Reset() {
-	*x = CreateWithdrawResponse{}
+func (x *UpdateTokenPolicyRequest) Reset() {
+	*x = UpdateTokenPolicyRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateWithdrawResponse) String() string {
+func (x *UpdateTokenPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateWithdrawResponse) ProtoMessage() {}
+func (*UpdateTokenPolicyRequest) ProtoMessage() {}
 
-func (x *CreateWithdrawResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateTokenPolicyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -924,38 +1261,47 @@ func (x *CreateWithdrawResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateWithdrawResponse.ProtoReflect.Descriptor instead.
-func (*CreateWithdrawResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateTokenPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTokenPolicyRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CreateWithdrawResponse) GetWithdrawal() *Withdrawal {
+func (x *UpdateTokenPolicyRequest) GetTokenPolicy() *TokenPolicy {
 	if x != nil {
-		return x.Withdrawal
+		return x.TokenPolicy
 	}
 	return nil
 }
 
-type PruneAccountsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// BlindTokenKey signs blind tokens worth its denomination.
+type BlindTokenKey struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	KeyId        string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Denomination int64                  `protobuf:"varint,2,opt,name=denomination,proto3" json:"denomination,omitempty"`
+	// PKIX DER of the RSA public key
+	PublicKey []byte `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// The key issues no tokens from then on
+	IssueEndTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=issue_end_time,json=issueEndTime,proto3" json:"issue_end_time,omitempty"`
+	// Tokens of the key are not redeemable from then on
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PruneAccountsRequest) Reset() {
-	*x = PruneAccountsRequest{}
+func (x *BlindTokenKey) Reset() {
+	*x = BlindTokenKey{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PruneAccountsRequest) String() string {
+func (x *BlindTokenKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PruneAccountsRequest) ProtoMessage() {}
+func (*BlindTokenKey) ProtoMessage() {}
 
-func (x *PruneAccountsRequest) ProtoReflect() protoreflect.Message {
+func (x *BlindTokenKey) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -967,32 +1313,66 @@ func (x *PruneAccountsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PruneAccountsRequest.ProtoReflect.Descriptor instead.
-func (*PruneAccountsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BlindTokenKey.ProtoReflect.Descriptor instead.
+func (*BlindTokenKey) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{16}
 }
 
-type PruneAccountsResponse struct {
+func (x *BlindTokenKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *BlindTokenKey) GetDenomination() int64 {
+	if x != nil {
+		return x.Denomination
+	}
+	return 0
+}
+
+func (x *BlindTokenKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *BlindTokenKey) GetIssueEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueEndTime
+	}
+	return nil
+}
+
+func (x *BlindTokenKey) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type ListBlindTokenKeysRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PruneAccountsResponse) Reset() {
-	*x = PruneAccountsResponse{}
+func (x *ListBlindTokenKeysRequest) Reset() {
+	*x = ListBlindTokenKeysRequest{}
 	mi := &file_exchange_v1_exchange_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PruneAccountsResponse) String() string {
+func (x *ListBlindTokenKeysRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PruneAccountsResponse) ProtoMessage() {}
+func (*ListBlindTokenKeysRequest) ProtoMessage() {}
 
-func (x *PruneAccountsResponse) ProtoReflect() protoreflect.Message {
+func (x *ListBlindTokenKeysRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_exchange_v1_exchange_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1004,43 +1384,4520 @@ func (x *PruneAccountsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PruneAccountsResponse.ProtoReflect.Descriptor instead.
-func (*PruneAccountsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListBlindTokenKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListBlindTokenKeysRequest) Descriptor() ([]byte, []int) {
 	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PruneAccountsResponse) GetAccounts() []*Account {
-	if x != nil {
-		return x.Accounts
-	}
-	return nil
+type ListBlindTokenKeysResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BlindTokenKeys []*BlindTokenKey       `protobuf:"bytes,1,rep,name=blind_token_keys,json=blindTokenKeys,proto3" json:"blind_token_keys,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-type SuiDepositProof struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainDigest   string                 `protobuf:"bytes,1,opt,name=chain_digest,json=chainDigest,proto3" json:"chain_digest,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+func (x *ListBlindTokenKeysResponse) Reset() {
+	*x = ListBlindTokenKeysResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlindTokenKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlindTokenKeysResponse) ProtoMessage() {}
+
+func (x *ListBlindTokenKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlindTokenKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListBlindTokenKeysResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListBlindTokenKeysResponse) GetBlindTokenKeys() []*BlindTokenKey {
+	if x != nil {
+		return x.BlindTokenKeys
+	}
+	return nil
+}
+
+type IssueBlindTokensRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	KeyId           string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	BlindedMessages [][]byte               `protobuf:"bytes,2,rep,name=blinded_messages,json=blindedMessages,proto3" json:"blinded_messages,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *IssueBlindTokensRequest) Reset() {
+	*x = IssueBlindTokensRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueBlindTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueBlindTokensRequest) ProtoMessage() {}
+
+func (x *IssueBlindTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueBlindTokensRequest.ProtoReflect.Descriptor instead.
+func (*IssueBlindTokensRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *IssueBlindTokensRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *IssueBlindTokensRequest) GetBlindedMessages() [][]byte {
+	if x != nil {
+		return x.BlindedMessages
+	}
+	return nil
+}
+
+type IssueBlindTokensResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of blinded_messages
+	BlindSignatures [][]byte `protobuf:"bytes,1,rep,name=blind_signatures,json=blindSignatures,proto3" json:"blind_signatures,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *IssueBlindTokensResponse) Reset() {
+	*x = IssueBlindTokensResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueBlindTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueBlindTokensResponse) ProtoMessage() {}
+
+func (x *IssueBlindTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueBlindTokensResponse.ProtoReflect.Descriptor instead.
+func (*IssueBlindTokensResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IssueBlindTokensResponse) GetBlindSignatures() [][]byte {
+	if x != nil {
+		return x.BlindSignatures
+	}
+	return nil
+}
+
+type RedeemBlindTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemBlindTokenRequest) Reset() {
+	*x = RedeemBlindTokenRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemBlindTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemBlindTokenRequest) ProtoMessage() {}
+
+func (x *RedeemBlindTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemBlindTokenRequest.ProtoReflect.Descriptor instead.
+func (*RedeemBlindTokenRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RedeemBlindTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RedeemBlindTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Amount paid to the caller
+	Denomination  int64 `protobuf:"varint,1,opt,name=denomination,proto3" json:"denomination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemBlindTokenResponse) Reset() {
+	*x = RedeemBlindTokenResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemBlindTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemBlindTokenResponse) ProtoMessage() {}
+
+func (x *RedeemBlindTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemBlindTokenResponse.ProtoReflect.Descriptor instead.
+func (*RedeemBlindTokenResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RedeemBlindTokenResponse) GetDenomination() int64 {
+	if x != nil {
+		return x.Denomination
+	}
+	return 0
+}
+
+type PruneSpentBlindTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneSpentBlindTokensRequest) Reset() {
+	*x = PruneSpentBlindTokensRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneSpentBlindTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneSpentBlindTokensRequest) ProtoMessage() {}
+
+func (x *PruneSpentBlindTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneSpentBlindTokensRequest.ProtoReflect.Descriptor instead.
+func (*PruneSpentBlindTokensRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{23}
+}
+
+type PruneSpentBlindTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrunedCount   int64                  `protobuf:"varint,1,opt,name=pruned_count,json=prunedCount,proto3" json:"pruned_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneSpentBlindTokensResponse) Reset() {
+	*x = PruneSpentBlindTokensResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneSpentBlindTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneSpentBlindTokensResponse) ProtoMessage() {}
+
+func (x *PruneSpentBlindTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneSpentBlindTokensResponse.ProtoReflect.Descriptor instead.
+func (*PruneSpentBlindTokensResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PruneSpentBlindTokensResponse) GetPrunedCount() int64 {
+	if x != nil {
+		return x.PrunedCount
+	}
+	return 0
+}
+
+type ExpiredBlindTokenKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Denomination  int64                  `protobuf:"varint,2,opt,name=denomination,proto3" json:"denomination,omitempty"`
+	IssuedCount   int64                  `protobuf:"varint,3,opt,name=issued_count,json=issuedCount,proto3" json:"issued_count,omitempty"`
+	RedeemedCount int64                  `protobuf:"varint,4,opt,name=redeemed_count,json=redeemedCount,proto3" json:"redeemed_count,omitempty"`
+	// Denomination times the tokens issued but never redeemed
+	UnredeemedAmount int64                  `protobuf:"varint,5,opt,name=unredeemed_amount,json=unredeemedAmount,proto3" json:"unredeemed_amount,omitempty"`
+	ExpireTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExpiredBlindTokenKey) Reset() {
+	*x = ExpiredBlindTokenKey{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiredBlindTokenKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiredBlindTokenKey) ProtoMessage() {}
+
+func (x *ExpiredBlindTokenKey) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiredBlindTokenKey.ProtoReflect.Descriptor instead.
+func (*ExpiredBlindTokenKey) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExpiredBlindTokenKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ExpiredBlindTokenKey) GetDenomination() int64 {
+	if x != nil {
+		return x.Denomination
+	}
+	return 0
+}
+
+func (x *ExpiredBlindTokenKey) GetIssuedCount() int64 {
+	if x != nil {
+		return x.IssuedCount
+	}
+	return 0
+}
+
+func (x *ExpiredBlindTokenKey) GetRedeemedCount() int64 {
+	if x != nil {
+		return x.RedeemedCount
+	}
+	return 0
+}
+
+func (x *ExpiredBlindTokenKey) GetUnredeemedAmount() int64 {
+	if x != nil {
+		return x.UnredeemedAmount
+	}
+	return 0
+}
+
+func (x *ExpiredBlindTokenKey) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type ListExpiredBlindTokenKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiredBlindTokenKeysRequest) Reset() {
+	*x = ListExpiredBlindTokenKeysRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiredBlindTokenKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiredBlindTokenKeysRequest) ProtoMessage() {}
+
+func (x *ListExpiredBlindTokenKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiredBlindTokenKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListExpiredBlindTokenKeysRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{26}
+}
+
+type ListExpiredBlindTokenKeysResponse struct {
+	state                 protoimpl.MessageState  `protogen:"open.v1"`
+	ExpiredBlindTokenKeys []*ExpiredBlindTokenKey `protobuf:"bytes,1,rep,name=expired_blind_token_keys,json=expiredBlindTokenKeys,proto3" json:"expired_blind_token_keys,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListExpiredBlindTokenKeysResponse) Reset() {
+	*x = ListExpiredBlindTokenKeysResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiredBlindTokenKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiredBlindTokenKeysResponse) ProtoMessage() {}
+
+func (x *ListExpiredBlindTokenKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiredBlindTokenKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListExpiredBlindTokenKeysResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListExpiredBlindTokenKeysResponse) GetExpiredBlindTokenKeys() []*ExpiredBlindTokenKey {
+	if x != nil {
+		return x.ExpiredBlindTokenKeys
+	}
+	return nil
+}
+
+// ServiceSession is quota of create-session tokens redeemed with a service.
+// The service consumes it as it serves the holder of the manage-session token.
+type ServiceSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Audience      string                 `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Consumed      int64                  `protobuf:"varint,4,opt,name=consumed,proto3" json:"consumed,omitempty"`
+	Remaining     int64                  `protobuf:"varint,5,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	State         ServiceSessionState    `protobuf:"varint,6,opt,name=state,proto3,enum=exchange.v1.ServiceSessionState" json:"state,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceSession) Reset() {
+	*x = ServiceSession{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSession) ProtoMessage() {}
+
+func (x *ServiceSession) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSession.ProtoReflect.Descriptor instead.
+func (*ServiceSession) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ServiceSession) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceSession) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *ServiceSession) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ServiceSession) GetConsumed() int64 {
+	if x != nil {
+		return x.Consumed
+	}
+	return 0
+}
+
+func (x *ServiceSession) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *ServiceSession) GetState() ServiceSessionState {
+	if x != nil {
+		return x.State
+	}
+	return ServiceSessionState_SERVICE_SESSION_STATE_UNSPECIFIED
+}
+
+func (x *ServiceSession) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *ServiceSession) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *ServiceSession) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type CreateServiceSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Create-session token bought for the calling service
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceSessionRequest) Reset() {
+	*x = CreateServiceSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceSessionRequest) ProtoMessage() {}
+
+func (x *CreateServiceSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateServiceSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateServiceSessionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CreateServiceSessionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceSession *ServiceSession        `protobuf:"bytes,1,opt,name=service_session,json=serviceSession,proto3" json:"service_session,omitempty"`
+	ManageToken    string                 `protobuf:"bytes,2,opt,name=manage_token,json=manageToken,proto3" json:"manage_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateServiceSessionResponse) Reset() {
+	*x = CreateServiceSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceSessionResponse) ProtoMessage() {}
+
+func (x *CreateServiceSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateServiceSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateServiceSessionResponse) GetServiceSession() *ServiceSession {
+	if x != nil {
+		return x.ServiceSession
+	}
+	return nil
+}
+
+func (x *CreateServiceSessionResponse) GetManageToken() string {
+	if x != nil {
+		return x.ManageToken
+	}
+	return ""
+}
+
+type ConsumeServiceSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeServiceSessionRequest) Reset() {
+	*x = ConsumeServiceSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeServiceSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeServiceSessionRequest) ProtoMessage() {}
+
+func (x *ConsumeServiceSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeServiceSessionRequest.ProtoReflect.Descriptor instead.
+func (*ConsumeServiceSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ConsumeServiceSessionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConsumeServiceSessionRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type ConsumeServiceSessionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceSession *ServiceSession        `protobuf:"bytes,1,opt,name=service_session,json=serviceSession,proto3" json:"service_session,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConsumeServiceSessionResponse) Reset() {
+	*x = ConsumeServiceSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeServiceSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeServiceSessionResponse) ProtoMessage() {}
+
+func (x *ConsumeServiceSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeServiceSessionResponse.ProtoReflect.Descriptor instead.
+func (*ConsumeServiceSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ConsumeServiceSessionResponse) GetServiceSession() *ServiceSession {
+	if x != nil {
+		return x.ServiceSession
+	}
+	return nil
+}
+
+type GetServiceSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ManageToken   string                 `protobuf:"bytes,1,opt,name=manage_token,json=manageToken,proto3" json:"manage_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceSessionRequest) Reset() {
+	*x = GetServiceSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceSessionRequest) ProtoMessage() {}
+
+func (x *GetServiceSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetServiceSessionRequest) GetManageToken() string {
+	if x != nil {
+		return x.ManageToken
+	}
+	return ""
+}
+
+type GetServiceSessionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceSession *ServiceSession        `protobuf:"bytes,1,opt,name=service_session,json=serviceSession,proto3" json:"service_session,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetServiceSessionResponse) Reset() {
+	*x = GetServiceSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceSessionResponse) ProtoMessage() {}
+
+func (x *GetServiceSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetServiceSessionResponse) GetServiceSession() *ServiceSession {
+	if x != nil {
+		return x.ServiceSession
+	}
+	return nil
+}
+
+type TopUpServiceSessionRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ManageToken string                 `protobuf:"bytes,1,opt,name=manage_token,json=manageToken,proto3" json:"manage_token,omitempty"`
+	// Create-session token for the audience of the session
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopUpServiceSessionRequest) Reset() {
+	*x = TopUpServiceSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopUpServiceSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopUpServiceSessionRequest) ProtoMessage() {}
+
+func (x *TopUpServiceSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopUpServiceSessionRequest.ProtoReflect.Descriptor instead.
+func (*TopUpServiceSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TopUpServiceSessionRequest) GetManageToken() string {
+	if x != nil {
+		return x.ManageToken
+	}
+	return ""
+}
+
+func (x *TopUpServiceSessionRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type TopUpServiceSessionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceSession *ServiceSession        `protobuf:"bytes,1,opt,name=service_session,json=serviceSession,proto3" json:"service_session,omitempty"`
+	// Replaces the manage-session token as the session may expire later now
+	ManageToken   string `protobuf:"bytes,2,opt,name=manage_token,json=manageToken,proto3" json:"manage_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopUpServiceSessionResponse) Reset() {
+	*x = TopUpServiceSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopUpServiceSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopUpServiceSessionResponse) ProtoMessage() {}
+
+func (x *TopUpServiceSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopUpServiceSessionResponse.ProtoReflect.Descriptor instead.
+func (*TopUpServiceSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TopUpServiceSessionResponse) GetServiceSession() *ServiceSession {
+	if x != nil {
+		return x.ServiceSession
+	}
+	return nil
+}
+
+func (x *TopUpServiceSessionResponse) GetManageToken() string {
+	if x != nil {
+		return x.ManageToken
+	}
+	return ""
+}
+
+type PauseServiceSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ManageToken   string                 `protobuf:"bytes,1,opt,name=manage_token,json=manageToken,proto3" json:"manage_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseServiceSessionRequest) Reset() {
+	*x = PauseServiceSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseServiceSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseServiceSessionRequest) ProtoMessage() {}
+
+func (x *PauseServiceSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseServiceSessionRequest.ProtoReflect.Descriptor instead.
+func (*PauseServiceSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PauseServiceSessionRequest) GetManageToken() string {
+	if x != nil {
+		return x.ManageToken
+	}
+	return ""
+}
+
+type PauseServiceSessionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceSession *ServiceSession        `protobuf:"bytes,1,opt,name=service_session,json=serviceSession,proto3" json:"service_session,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PauseServiceSessionResponse) Reset() {
+	*x = PauseServiceSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseServiceSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseServiceSessionResponse) ProtoMessage() {}
+
+func (x *PauseServiceSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseServiceSessionResponse.ProtoReflect.Descriptor instead.
+func (*PauseServiceSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PauseServiceSessionResponse) GetServiceSession() *ServiceSession {
+	if x != nil {
+		return x.ServiceSession
+	}
+	return nil
+}
+
+type ResumeServiceSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ManageToken   string                 `protobuf:"bytes,1,opt,name=manage_token,json=manageToken,proto3" json:"manage_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeServiceSessionRequest) Reset() {
+	*x = ResumeServiceSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeServiceSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeServiceSessionRequest) ProtoMessage() {}
+
+func (x *ResumeServiceSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeServiceSessionRequest.ProtoReflect.Descriptor instead.
+func (*ResumeServiceSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResumeServiceSessionRequest) GetManageToken() string {
+	if x != nil {
+		return x.ManageToken
+	}
+	return ""
+}
+
+type ResumeServiceSessionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceSession *ServiceSession        `protobuf:"bytes,1,opt,name=service_session,json=serviceSession,proto3" json:"service_session,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResumeServiceSessionResponse) Reset() {
+	*x = ResumeServiceSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeServiceSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeServiceSessionResponse) ProtoMessage() {}
+
+func (x *ResumeServiceSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeServiceSessionResponse.ProtoReflect.Descriptor instead.
+func (*ResumeServiceSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ResumeServiceSessionResponse) GetServiceSession() *ServiceSession {
+	if x != nil {
+		return x.ServiceSession
+	}
+	return nil
+}
+
+type CloseServiceSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ManageToken   string                 `protobuf:"bytes,1,opt,name=manage_token,json=manageToken,proto3" json:"manage_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseServiceSessionRequest) Reset() {
+	*x = CloseServiceSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseServiceSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseServiceSessionRequest) ProtoMessage() {}
+
+func (x *CloseServiceSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseServiceSessionRequest.ProtoReflect.Descriptor instead.
+func (*CloseServiceSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CloseServiceSessionRequest) GetManageToken() string {
+	if x != nil {
+		return x.ManageToken
+	}
+	return ""
+}
+
+type CloseServiceSessionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServiceSession *ServiceSession        `protobuf:"bytes,1,opt,name=service_session,json=serviceSession,proto3" json:"service_session,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CloseServiceSessionResponse) Reset() {
+	*x = CloseServiceSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseServiceSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseServiceSessionResponse) ProtoMessage() {}
+
+func (x *CloseServiceSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseServiceSessionResponse.ProtoReflect.Descriptor instead.
+func (*CloseServiceSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CloseServiceSessionResponse) GetServiceSession() *ServiceSession {
+	if x != nil {
+		return x.ServiceSession
+	}
+	return nil
+}
+
+// Escrow holds the amount paid for a token until its audience claims it.
+type Escrow struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Jti        string                 `protobuf:"bytes,1,opt,name=jti,proto3" json:"jti,omitempty"`
+	Audience   string                 `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`
+	Amount     int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Claimed    int64                  `protobuf:"varint,4,opt,name=claimed,proto3" json:"claimed,omitempty"`
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// Set once the unclaimed amount went back to the buyer
+	SettleTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=settle_time,json=settleTime,proto3" json:"settle_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Escrow) Reset() {
+	*x = Escrow{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Escrow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Escrow) ProtoMessage() {}
+
+func (x *Escrow) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Escrow.ProtoReflect.Descriptor instead.
+func (*Escrow) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Escrow) GetJti() string {
+	if x != nil {
+		return x.Jti
+	}
+	return ""
+}
+
+func (x *Escrow) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *Escrow) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Escrow) GetClaimed() int64 {
+	if x != nil {
+		return x.Claimed
+	}
+	return 0
+}
+
+func (x *Escrow) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *Escrow) GetSettleTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SettleTime
+	}
+	return nil
+}
+
+// ConsumptionReceipt states how much of a token the audience has served so
+// far. Receipts are cumulative so resubmitting or reordering them is harmless.
+type ConsumptionReceipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jti           string                 `protobuf:"bytes,1,opt,name=jti,proto3" json:"jti,omitempty"`
+	Consumed      int64                  `protobuf:"varint,2,opt,name=consumed,proto3" json:"consumed,omitempty"`
+	IssueTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issue_time,json=issueTime,proto3" json:"issue_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumptionReceipt) Reset() {
+	*x = ConsumptionReceipt{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumptionReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumptionReceipt) ProtoMessage() {}
+
+func (x *ConsumptionReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumptionReceipt.ProtoReflect.Descriptor instead.
+func (*ConsumptionReceipt) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ConsumptionReceipt) GetJti() string {
+	if x != nil {
+		return x.Jti
+	}
+	return ""
+}
+
+func (x *ConsumptionReceipt) GetConsumed() int64 {
+	if x != nil {
+		return x.Consumed
+	}
+	return 0
+}
+
+func (x *ConsumptionReceipt) GetIssueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueTime
+	}
+	return nil
+}
+
+type SignedConsumptionReceipt struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Serialized ConsumptionReceipt
+	Receipt []byte `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// Ed25519 signature by the did:key of the audience over
+	// "prex-consumption-receipt:v1\n" followed by receipt. See pkg/receipt.
+	Signature     []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedConsumptionReceipt) Reset() {
+	*x = SignedConsumptionReceipt{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedConsumptionReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedConsumptionReceipt) ProtoMessage() {}
+
+func (x *SignedConsumptionReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedConsumptionReceipt.ProtoReflect.Descriptor instead.
+func (*SignedConsumptionReceipt) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SignedConsumptionReceipt) GetReceipt() []byte {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *SignedConsumptionReceipt) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type SubmitConsumptionReceiptsRequest struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Receipts      []*SignedConsumptionReceipt `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitConsumptionReceiptsRequest) Reset() {
+	*x = SubmitConsumptionReceiptsRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitConsumptionReceiptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitConsumptionReceiptsRequest) ProtoMessage() {}
+
+func (x *SubmitConsumptionReceiptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitConsumptionReceiptsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitConsumptionReceiptsRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SubmitConsumptionReceiptsRequest) GetReceipts() []*SignedConsumptionReceipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+type SubmitConsumptionReceiptsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of receipts
+	Escrows []*Escrow `protobuf:"bytes,1,rep,name=escrows,proto3" json:"escrows,omitempty"`
+	// Total paid to the audiences for the receipts
+	Amount        int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitConsumptionReceiptsResponse) Reset() {
+	*x = SubmitConsumptionReceiptsResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitConsumptionReceiptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitConsumptionReceiptsResponse) ProtoMessage() {}
+
+func (x *SubmitConsumptionReceiptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitConsumptionReceiptsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitConsumptionReceiptsResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SubmitConsumptionReceiptsResponse) GetEscrows() []*Escrow {
+	if x != nil {
+		return x.Escrows
+	}
+	return nil
+}
+
+func (x *SubmitConsumptionReceiptsResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// LogEntry is a token in the transparency log
+type LogEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	LeafIndex int64                  `protobuf:"varint,1,opt,name=leaf_index,json=leafIndex,proto3" json:"leaf_index,omitempty"`
+	// SHA-256 of the token
+	TokenHash     []byte                 `protobuf:"bytes,2,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"`
+	Audience      string                 `protobuf:"bytes,3,opt,name=audience,proto3" json:"audience,omitempty"`
+	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	IssueTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=issue_time,json=issueTime,proto3" json:"issue_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *LogEntry) GetLeafIndex() int64 {
+	if x != nil {
+		return x.LeafIndex
+	}
+	return 0
+}
+
+func (x *LogEntry) GetTokenHash() []byte {
+	if x != nil {
+		return x.TokenHash
+	}
+	return nil
+}
+
+func (x *LogEntry) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *LogEntry) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *LogEntry) GetIssueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueTime
+	}
+	return nil
+}
+
+// SignedTreeHead commits to the first tree_size entries of the log
+type SignedTreeHead struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TreeSize  int64                  `protobuf:"varint,1,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	RootHash  []byte                 `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// did:key of the token key that signed the tree head
+	Kid           string `protobuf:"bytes,4,opt,name=kid,proto3" json:"kid,omitempty"`
+	Signature     []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedTreeHead) Reset() {
+	*x = SignedTreeHead{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedTreeHead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedTreeHead) ProtoMessage() {}
+
+func (x *SignedTreeHead) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedTreeHead.ProtoReflect.Descriptor instead.
+func (*SignedTreeHead) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SignedTreeHead) GetTreeSize() int64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+func (x *SignedTreeHead) GetRootHash() []byte {
+	if x != nil {
+		return x.RootHash
+	}
+	return nil
+}
+
+func (x *SignedTreeHead) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SignedTreeHead) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *SignedTreeHead) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type LogInclusion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *LogEntry              `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	AuditPath     [][]byte               `protobuf:"bytes,2,rep,name=audit_path,json=auditPath,proto3" json:"audit_path,omitempty"`
+	TreeHead      *SignedTreeHead        `protobuf:"bytes,3,opt,name=tree_head,json=treeHead,proto3" json:"tree_head,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogInclusion) Reset() {
+	*x = LogInclusion{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogInclusion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogInclusion) ProtoMessage() {}
+
+func (x *LogInclusion) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogInclusion.ProtoReflect.Descriptor instead.
+func (*LogInclusion) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *LogInclusion) GetEntry() *LogEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *LogInclusion) GetAuditPath() [][]byte {
+	if x != nil {
+		return x.AuditPath
+	}
+	return nil
+}
+
+func (x *LogInclusion) GetTreeHead() *SignedTreeHead {
+	if x != nil {
+		return x.TreeHead
+	}
+	return nil
+}
+
+type GetTreeHeadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTreeHeadRequest) Reset() {
+	*x = GetTreeHeadRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTreeHeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreeHeadRequest) ProtoMessage() {}
+
+func (x *GetTreeHeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreeHeadRequest.ProtoReflect.Descriptor instead.
+func (*GetTreeHeadRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{51}
+}
+
+type ListLogEntriesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	StartIndex int64                  `protobuf:"varint,1,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	// Defaults to 100
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLogEntriesRequest) Reset() {
+	*x = ListLogEntriesRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLogEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLogEntriesRequest) ProtoMessage() {}
+
+func (x *ListLogEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLogEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLogEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListLogEntriesRequest) GetStartIndex() int64 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *ListLogEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLogEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LogEntry            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLogEntriesResponse) Reset() {
+	*x = ListLogEntriesResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLogEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLogEntriesResponse) ProtoMessage() {}
+
+func (x *ListLogEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLogEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListLogEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListLogEntriesResponse) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type GetInclusionProofRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeafIndex     int64                  `protobuf:"varint,1,opt,name=leaf_index,json=leafIndex,proto3" json:"leaf_index,omitempty"`
+	TreeSize      int64                  `protobuf:"varint,2,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInclusionProofRequest) Reset() {
+	*x = GetInclusionProofRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInclusionProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInclusionProofRequest) ProtoMessage() {}
+
+func (x *GetInclusionProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInclusionProofRequest.ProtoReflect.Descriptor instead.
+func (*GetInclusionProofRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetInclusionProofRequest) GetLeafIndex() int64 {
+	if x != nil {
+		return x.LeafIndex
+	}
+	return 0
+}
+
+func (x *GetInclusionProofRequest) GetTreeSize() int64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+type GetInclusionProofResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuditPath     [][]byte               `protobuf:"bytes,1,rep,name=audit_path,json=auditPath,proto3" json:"audit_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInclusionProofResponse) Reset() {
+	*x = GetInclusionProofResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInclusionProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInclusionProofResponse) ProtoMessage() {}
+
+func (x *GetInclusionProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInclusionProofResponse.ProtoReflect.Descriptor instead.
+func (*GetInclusionProofResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetInclusionProofResponse) GetAuditPath() [][]byte {
+	if x != nil {
+		return x.AuditPath
+	}
+	return nil
+}
+
+type GetConsistencyProofRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldSize       int64                  `protobuf:"varint,1,opt,name=old_size,json=oldSize,proto3" json:"old_size,omitempty"`
+	NewSize       int64                  `protobuf:"varint,2,opt,name=new_size,json=newSize,proto3" json:"new_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsistencyProofRequest) Reset() {
+	*x = GetConsistencyProofRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsistencyProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsistencyProofRequest) ProtoMessage() {}
+
+func (x *GetConsistencyProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsistencyProofRequest.ProtoReflect.Descriptor instead.
+func (*GetConsistencyProofRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetConsistencyProofRequest) GetOldSize() int64 {
+	if x != nil {
+		return x.OldSize
+	}
+	return 0
+}
+
+func (x *GetConsistencyProofRequest) GetNewSize() int64 {
+	if x != nil {
+		return x.NewSize
+	}
+	return 0
+}
+
+type GetConsistencyProofResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Proof         [][]byte               `protobuf:"bytes,1,rep,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsistencyProofResponse) Reset() {
+	*x = GetConsistencyProofResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsistencyProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsistencyProofResponse) ProtoMessage() {}
+
+func (x *GetConsistencyProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsistencyProofResponse.ProtoReflect.Descriptor instead.
+func (*GetConsistencyProofResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetConsistencyProofResponse) GetProof() [][]byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type ListPaymentMethodsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentMethodsRequest) Reset() {
+	*x = ListPaymentMethodsRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentMethodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentMethodsRequest) ProtoMessage() {}
+
+func (x *ListPaymentMethodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentMethodsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentMethodsRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{58}
+}
+
+type ListPaymentMethodsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PaymentMethods []*PaymentMethod       `protobuf:"bytes,1,rep,name=payment_methods,json=paymentMethods,proto3" json:"payment_methods,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPaymentMethodsResponse) Reset() {
+	*x = ListPaymentMethodsResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentMethodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentMethodsResponse) ProtoMessage() {}
+
+func (x *ListPaymentMethodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentMethodsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentMethodsResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListPaymentMethodsResponse) GetPaymentMethods() []*PaymentMethod {
+	if x != nil {
+		return x.PaymentMethods
+	}
+	return nil
+}
+
+type ListSigningKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSigningKeysRequest) Reset() {
+	*x = ListSigningKeysRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSigningKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSigningKeysRequest) ProtoMessage() {}
+
+func (x *ListSigningKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSigningKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListSigningKeysRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{60}
+}
+
+type ListSigningKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SigningKeys   []*SigningKey          `protobuf:"bytes,1,rep,name=signing_keys,json=signingKeys,proto3" json:"signing_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSigningKeysResponse) Reset() {
+	*x = ListSigningKeysResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSigningKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSigningKeysResponse) ProtoMessage() {}
+
+func (x *ListSigningKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSigningKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListSigningKeysResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListSigningKeysResponse) GetSigningKeys() []*SigningKey {
+	if x != nil {
+		return x.SigningKeys
+	}
+	return nil
+}
+
+// SigningKey verifies JWTs issued by Prex with the same kid
+type SigningKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// did:key of the ed25519 public key
+	Kid       string `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Whether new tokens are signed with this key. Other keys only verify
+	// tokens issued before or are staged to become primary.
+	Primary bool `protobuf:"varint,3,opt,name=primary,proto3" json:"primary,omitempty"`
+	// Unset for keys active since the start
+	ActivateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=activate_time,json=activateTime,proto3" json:"activate_time,omitempty"`
+	// Unset for keys not scheduled for retirement
+	RetireTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=retire_time,json=retireTime,proto3" json:"retire_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SigningKey) Reset() {
+	*x = SigningKey{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SigningKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningKey) ProtoMessage() {}
+
+func (x *SigningKey) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningKey.ProtoReflect.Descriptor instead.
+func (*SigningKey) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SigningKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *SigningKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SigningKey) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+func (x *SigningKey) GetActivateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivateTime
+	}
+	return nil
+}
+
+func (x *SigningKey) GetRetireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetireTime
+	}
+	return nil
+}
+
+type PaymentMethod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Coin          PaymentCoin            `protobuf:"varint,2,opt,name=coin,proto3,enum=exchange.v1.PaymentCoin" json:"coin,omitempty"`
+	Environment   PaymentEnvironment     `protobuf:"varint,3,opt,name=environment,proto3,enum=exchange.v1.PaymentEnvironment" json:"environment,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentMethod) Reset() {
+	*x = PaymentMethod{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentMethod) ProtoMessage() {}
+
+func (x *PaymentMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentMethod.ProtoReflect.Descriptor instead.
+func (*PaymentMethod) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *PaymentMethod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PaymentMethod) GetCoin() PaymentCoin {
+	if x != nil {
+		return x.Coin
+	}
+	return PaymentCoin_PAYMENT_COIN_UNSPECIFIED
+}
+
+func (x *PaymentMethod) GetEnvironment() PaymentEnvironment {
+	if x != nil {
+		return x.Environment
+	}
+	return PaymentEnvironment_PAYMENT_ENVIRONMENT_UNSPECIFIED
+}
+
+func (x *PaymentMethod) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GetWalletStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletStatusRequest) Reset() {
+	*x = GetWalletStatusRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletStatusRequest) ProtoMessage() {}
+
+func (x *GetWalletStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletStatusRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{64}
+}
+
+type GetWalletStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hot wallet of the payment method paying out withdrawals
+	WalletStatus *WalletStatus `protobuf:"bytes,1,opt,name=wallet_status,json=walletStatus,proto3" json:"wallet_status,omitempty"`
+	// Hot wallets of all enabled payment methods, starting with wallet_status
+	WalletStatuses []*WalletStatus `protobuf:"bytes,2,rep,name=wallet_statuses,json=walletStatuses,proto3" json:"wallet_statuses,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetWalletStatusResponse) Reset() {
+	*x = GetWalletStatusResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletStatusResponse) ProtoMessage() {}
+
+func (x *GetWalletStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletStatusResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetWalletStatusResponse) GetWalletStatus() *WalletStatus {
+	if x != nil {
+		return x.WalletStatus
+	}
+	return nil
+}
+
+func (x *GetWalletStatusResponse) GetWalletStatuses() []*WalletStatus {
+	if x != nil {
+		return x.WalletStatuses
+	}
+	return nil
+}
+
+type WalletStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hot wallet signing withdrawals
+	HotAddress string `protobuf:"bytes,1,opt,name=hot_address,json=hotAddress,proto3" json:"hot_address,omitempty"`
+	// Watch-only address receiving sweeps. Empty if sweeping is disabled.
+	ColdAddress string `protobuf:"bytes,2,opt,name=cold_address,json=coldAddress,proto3" json:"cold_address,omitempty"`
+	HotBalance  int64  `protobuf:"varint,3,opt,name=hot_balance,json=hotBalance,proto3" json:"hot_balance,omitempty"`
+	// Withdrawals requested but not yet batched into a transaction
+	PendingWithdrawAmount int64                  `protobuf:"varint,4,opt,name=pending_withdraw_amount,json=pendingWithdrawAmount,proto3" json:"pending_withdraw_amount,omitempty"`
+	Float                 int64                  `protobuf:"varint,5,opt,name=float,proto3" json:"float,omitempty"`
+	HighWaterMark         int64                  `protobuf:"varint,6,opt,name=high_water_mark,json=highWaterMark,proto3" json:"high_water_mark,omitempty"`
+	LowWaterMark          int64                  `protobuf:"varint,7,opt,name=low_water_mark,json=lowWaterMark,proto3" json:"low_water_mark,omitempty"`
+	RefillNeeded          bool                   `protobuf:"varint,8,opt,name=refill_needed,json=refillNeeded,proto3" json:"refill_needed,omitempty"`
+	RefillAmount          int64                  `protobuf:"varint,9,opt,name=refill_amount,json=refillAmount,proto3" json:"refill_amount,omitempty"`
+	LastSweepDigest       string                 `protobuf:"bytes,10,opt,name=last_sweep_digest,json=lastSweepDigest,proto3" json:"last_sweep_digest,omitempty"`
+	LastSweepTime         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_sweep_time,json=lastSweepTime,proto3" json:"last_sweep_time,omitempty"`
+	LastCheckTime         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_check_time,json=lastCheckTime,proto3" json:"last_check_time,omitempty"`
+	// Resource name of the payment method, e.g. payment-methods/sui-devnet
+	PaymentMethod string `protobuf:"bytes,13,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletStatus) Reset() {
+	*x = WalletStatus{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletStatus) ProtoMessage() {}
+
+func (x *WalletStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletStatus.ProtoReflect.Descriptor instead.
+func (*WalletStatus) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *WalletStatus) GetHotAddress() string {
+	if x != nil {
+		return x.HotAddress
+	}
+	return ""
+}
+
+func (x *WalletStatus) GetColdAddress() string {
+	if x != nil {
+		return x.ColdAddress
+	}
+	return ""
+}
+
+func (x *WalletStatus) GetHotBalance() int64 {
+	if x != nil {
+		return x.HotBalance
+	}
+	return 0
+}
+
+func (x *WalletStatus) GetPendingWithdrawAmount() int64 {
+	if x != nil {
+		return x.PendingWithdrawAmount
+	}
+	return 0
+}
+
+func (x *WalletStatus) GetFloat() int64 {
+	if x != nil {
+		return x.Float
+	}
+	return 0
+}
+
+func (x *WalletStatus) GetHighWaterMark() int64 {
+	if x != nil {
+		return x.HighWaterMark
+	}
+	return 0
+}
+
+func (x *WalletStatus) GetLowWaterMark() int64 {
+	if x != nil {
+		return x.LowWaterMark
+	}
+	return 0
+}
+
+func (x *WalletStatus) GetRefillNeeded() bool {
+	if x != nil {
+		return x.RefillNeeded
+	}
+	return false
+}
+
+func (x *WalletStatus) GetRefillAmount() int64 {
+	if x != nil {
+		return x.RefillAmount
+	}
+	return 0
+}
+
+func (x *WalletStatus) GetLastSweepDigest() string {
+	if x != nil {
+		return x.LastSweepDigest
+	}
+	return ""
+}
+
+func (x *WalletStatus) GetLastSweepTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSweepTime
+	}
+	return nil
+}
+
+func (x *WalletStatus) GetLastCheckTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastCheckTime
+	}
+	return nil
+}
+
+func (x *WalletStatus) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+type PingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// To check effectiveness of buf validation
+	Dummy         int64 `protobuf:"varint,1,opt,name=dummy,proto3" json:"dummy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *PingRequest) GetDummy() int64 {
+	if x != nil {
+		return x.Dummy
+	}
+	return 0
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pong          string                 `protobuf:"bytes,1,opt,name=pong,proto3" json:"pong,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *PingResponse) GetPong() string {
+	if x != nil {
+		return x.Pong
+	}
+	return ""
+}
+
+type BatchMarkWithdrawsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchMarkWithdrawsRequest) Reset() {
+	*x = BatchMarkWithdrawsRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchMarkWithdrawsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchMarkWithdrawsRequest) ProtoMessage() {}
+
+func (x *BatchMarkWithdrawsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchMarkWithdrawsRequest.ProtoReflect.Descriptor instead.
+func (*BatchMarkWithdrawsRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *BatchMarkWithdrawsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type BatchMarkWithdrawsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SuccessWithdrawIds []int64                `protobuf:"varint,1,rep,packed,name=success_withdraw_ids,json=successWithdrawIds,proto3" json:"success_withdraw_ids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchMarkWithdrawsResponse) Reset() {
+	*x = BatchMarkWithdrawsResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchMarkWithdrawsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchMarkWithdrawsResponse) ProtoMessage() {}
+
+func (x *BatchMarkWithdrawsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchMarkWithdrawsResponse.ProtoReflect.Descriptor instead.
+func (*BatchMarkWithdrawsResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *BatchMarkWithdrawsResponse) GetSuccessWithdrawIds() []int64 {
+	if x != nil {
+		return x.SuccessWithdrawIds
+	}
+	return nil
+}
+
+type BatchProcessWithdrawsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchProcessWithdrawsRequest) Reset() {
+	*x = BatchProcessWithdrawsRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchProcessWithdrawsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchProcessWithdrawsRequest) ProtoMessage() {}
+
+func (x *BatchProcessWithdrawsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchProcessWithdrawsRequest.ProtoReflect.Descriptor instead.
+func (*BatchProcessWithdrawsRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *BatchProcessWithdrawsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type BatchProcessWithdrawsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Digest        string                 `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	BatchSize     int64                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchProcessWithdrawsResponse) Reset() {
+	*x = BatchProcessWithdrawsResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchProcessWithdrawsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchProcessWithdrawsResponse) ProtoMessage() {}
+
+func (x *BatchProcessWithdrawsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchProcessWithdrawsResponse.ProtoReflect.Descriptor instead.
+func (*BatchProcessWithdrawsResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *BatchProcessWithdrawsResponse) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *BatchProcessWithdrawsResponse) GetBatchSize() int64 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type CancelWithdrawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWithdrawRequest) Reset() {
+	*x = CancelWithdrawRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWithdrawRequest) ProtoMessage() {}
+
+func (x *CancelWithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWithdrawRequest.ProtoReflect.Descriptor instead.
+func (*CancelWithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CancelWithdrawRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetWithdrawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWithdrawRequest) Reset() {
+	*x = GetWithdrawRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWithdrawRequest) ProtoMessage() {}
+
+func (x *GetWithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWithdrawRequest.ProtoReflect.Descriptor instead.
+func (*GetWithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GetWithdrawRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Withdrawal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AddressTo     string                 `protobuf:"bytes,2,opt,name=address_to,json=addressTo,proto3" json:"address_to,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	PriorityFee   int64                  `protobuf:"varint,4,opt,name=priority_fee,json=priorityFee,proto3" json:"priority_fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Withdrawal) Reset() {
+	*x = Withdrawal{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Withdrawal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Withdrawal) ProtoMessage() {}
+
+func (x *Withdrawal) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Withdrawal.ProtoReflect.Descriptor instead.
+func (*Withdrawal) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *Withdrawal) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetAddressTo() string {
+	if x != nil {
+		return x.AddressTo
+	}
+	return ""
+}
+
+func (x *Withdrawal) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Withdrawal) GetPriorityFee() int64 {
+	if x != nil {
+		return x.PriorityFee
+	}
+	return 0
+}
+
+type EstimateWithdrawFeeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fee to predict the batch of
+	PriorityFee   int64 `protobuf:"varint,1,opt,name=priority_fee,json=priorityFee,proto3" json:"priority_fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateWithdrawFeeRequest) Reset() {
+	*x = EstimateWithdrawFeeRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateWithdrawFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateWithdrawFeeRequest) ProtoMessage() {}
+
+func (x *EstimateWithdrawFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateWithdrawFeeRequest.ProtoReflect.Descriptor instead.
+func (*EstimateWithdrawFeeRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *EstimateWithdrawFeeRequest) GetPriorityFee() int64 {
+	if x != nil {
+		return x.PriorityFee
+	}
+	return 0
+}
+
+type FeePercentile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percentile    int32                  `protobuf:"varint,1,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	PriorityFee   int64                  `protobuf:"varint,2,opt,name=priority_fee,json=priorityFee,proto3" json:"priority_fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeePercentile) Reset() {
+	*x = FeePercentile{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeePercentile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeePercentile) ProtoMessage() {}
+
+func (x *FeePercentile) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeePercentile.ProtoReflect.Descriptor instead.
+func (*FeePercentile) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *FeePercentile) GetPercentile() int32 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *FeePercentile) GetPriorityFee() int64 {
+	if x != nil {
+		return x.PriorityFee
+	}
+	return 0
+}
+
+type EstimateWithdrawFeeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A batch fails if its summed priority fee is below the reference gas price
+	ReferenceGasPrice int64 `protobuf:"varint,1,opt,name=reference_gas_price,json=referenceGasPrice,proto3" json:"reference_gas_price,omitempty"`
+	// Withdrawals not yet batched
+	QueueDepth     int64            `protobuf:"varint,2,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	FeePercentiles []*FeePercentile `protobuf:"bytes,3,rep,name=fee_percentiles,json=feePercentiles,proto3" json:"fee_percentiles,omitempty"`
+	// Max withdrawals in a batch
+	BatchSize int64 `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// 0-based batch a withdrawal paying priority_fee would land in
+	PredictedBatch int64 `protobuf:"varint,5,opt,name=predicted_batch,json=predictedBatch,proto3" json:"predicted_batch,omitempty"`
+	// Summed priority fee of the predicted batch
+	PredictedBatchFee int64 `protobuf:"varint,6,opt,name=predicted_batch_fee,json=predictedBatchFee,proto3" json:"predicted_batch_fee,omitempty"`
+	// Lowest fee landing in the next batch with a sufficient summed fee
+	NextBatchPriorityFee int64 `protobuf:"varint,7,opt,name=next_batch_priority_fee,json=nextBatchPriorityFee,proto3" json:"next_batch_priority_fee,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *EstimateWithdrawFeeResponse) Reset() {
+	*x = EstimateWithdrawFeeResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateWithdrawFeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateWithdrawFeeResponse) ProtoMessage() {}
+
+func (x *EstimateWithdrawFeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateWithdrawFeeResponse.ProtoReflect.Descriptor instead.
+func (*EstimateWithdrawFeeResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *EstimateWithdrawFeeResponse) GetReferenceGasPrice() int64 {
+	if x != nil {
+		return x.ReferenceGasPrice
+	}
+	return 0
+}
+
+func (x *EstimateWithdrawFeeResponse) GetQueueDepth() int64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *EstimateWithdrawFeeResponse) GetFeePercentiles() []*FeePercentile {
+	if x != nil {
+		return x.FeePercentiles
+	}
+	return nil
+}
+
+func (x *EstimateWithdrawFeeResponse) GetBatchSize() int64 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *EstimateWithdrawFeeResponse) GetPredictedBatch() int64 {
+	if x != nil {
+		return x.PredictedBatch
+	}
+	return 0
+}
+
+func (x *EstimateWithdrawFeeResponse) GetPredictedBatchFee() int64 {
+	if x != nil {
+		return x.PredictedBatchFee
+	}
+	return 0
+}
+
+func (x *EstimateWithdrawFeeResponse) GetNextBatchPriorityFee() int64 {
+	if x != nil {
+		return x.NextBatchPriorityFee
+	}
+	return 0
+}
+
+type CreateWithdrawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,2,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	WithdrawAll   bool                   `protobuf:"varint,3,opt,name=withdraw_all,json=withdrawAll,proto3" json:"withdraw_all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWithdrawRequest) Reset() {
+	*x = CreateWithdrawRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWithdrawRequest) ProtoMessage() {}
+
+func (x *CreateWithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWithdrawRequest.ProtoReflect.Descriptor instead.
+func (*CreateWithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *CreateWithdrawRequest) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+func (x *CreateWithdrawRequest) GetWithdrawAll() bool {
+	if x != nil {
+		return x.WithdrawAll
+	}
+	return false
+}
+
+type CreateWithdrawResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Withdrawal            `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWithdrawResponse) Reset() {
+	*x = CreateWithdrawResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWithdrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWithdrawResponse) ProtoMessage() {}
+
+func (x *CreateWithdrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWithdrawResponse.ProtoReflect.Descriptor instead.
+func (*CreateWithdrawResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *CreateWithdrawResponse) GetWithdrawal() *Withdrawal {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+type PruneAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneAccountsRequest) Reset() {
+	*x = PruneAccountsRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneAccountsRequest) ProtoMessage() {}
+
+func (x *PruneAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneAccountsRequest.ProtoReflect.Descriptor instead.
+func (*PruneAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{81}
+}
+
+type PruneAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PruneAccountsResponse) Reset() {
+	*x = PruneAccountsResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneAccountsResponse) ProtoMessage() {}
+
+func (x *PruneAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PruneAccountsResponse.ProtoReflect.Descriptor instead.
+func (*PruneAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *PruneAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type SuiDepositProof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainDigest   string                 `protobuf:"bytes,1,opt,name=chain_digest,json=chainDigest,proto3" json:"chain_digest,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
 	Challenge     []byte                 `protobuf:"bytes,3,opt,name=challenge,proto3" json:"challenge,omitempty"`
 	Signature     string                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuiDepositProof) Reset() {
-	*x = SuiDepositProof{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[18]
+func (x *SuiDepositProof) Reset() {
+	*x = SuiDepositProof{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuiDepositProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiDepositProof) ProtoMessage() {}
+
+func (x *SuiDepositProof) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiDepositProof.ProtoReflect.Descriptor instead.
+func (*SuiDepositProof) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *SuiDepositProof) GetChainDigest() string {
+	if x != nil {
+		return x.ChainDigest
+	}
+	return ""
+}
+
+func (x *SuiDepositProof) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *SuiDepositProof) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *SuiDepositProof) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type DepositRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Password for Login. Accounts created without one can only log in with
+	// LoginWithSignature. Ignored when topping up an existing account.
+	Password string               `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Ttl      *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Proof    *SuiDepositProof     `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
+	// Name of the payment method the deposit was made with. Defaults to the
+	// first method returned by ListPaymentMethods.
+	PaymentMethod string `protobuf:"bytes,5,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositRequest) Reset() {
+	*x = DepositRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositRequest) ProtoMessage() {}
+
+func (x *DepositRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositRequest.ProtoReflect.Descriptor instead.
+func (*DepositRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *DepositRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *DepositRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DepositRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *DepositRequest) GetProof() *SuiDepositProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *DepositRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+type DepositResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositResponse) Reset() {
+	*x = DepositResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositResponse) ProtoMessage() {}
+
+func (x *DepositResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositResponse.ProtoReflect.Descriptor instead.
+func (*DepositResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *DepositResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+type GetChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChallengeRequest) Reset() {
+	*x = GetChallengeRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChallengeRequest) ProtoMessage() {}
+
+func (x *GetChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GetChallengeRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GetChallengeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenge     []byte                 `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChallengeResponse) Reset() {
+	*x = GetChallengeResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChallengeResponse) ProtoMessage() {}
+
+func (x *GetChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChallengeResponse.ProtoReflect.Descriptor instead.
+func (*GetChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetChallengeResponse) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *GetChallengeResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+type Account struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AccountId     int64                  `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	Balance       int64                  `protobuf:"varint,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *Account) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Account) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *Account) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *LoginResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type SuiSignatureProof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Challenge     []byte                 `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Signature     string                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuiSignatureProof) Reset() {
+	*x = SuiSignatureProof{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuiSignatureProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiSignatureProof) ProtoMessage() {}
+
+func (x *SuiSignatureProof) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiSignatureProof.ProtoReflect.Descriptor instead.
+func (*SuiSignatureProof) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *SuiSignatureProof) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *SuiSignatureProof) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *SuiSignatureProof) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type LoginWithSignatureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Proof         *SuiSignatureProof     `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithSignatureRequest) Reset() {
+	*x = LoginWithSignatureRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithSignatureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithSignatureRequest) ProtoMessage() {}
+
+func (x *LoginWithSignatureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithSignatureRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithSignatureRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *LoginWithSignatureRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginWithSignatureRequest) GetProof() *SuiSignatureProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type LoginWithSignatureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithSignatureResponse) Reset() {
+	*x = LoginWithSignatureResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithSignatureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithSignatureResponse) ProtoMessage() {}
+
+func (x *LoginWithSignatureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithSignatureResponse.ProtoReflect.Descriptor instead.
+func (*LoginWithSignatureResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *LoginWithSignatureResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *LoginWithSignatureResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginWithSignatureResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type Session struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	RefreshTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=refresh_time,json=refreshTime,proto3" json:"refresh_time,omitempty"`
+	ExpireTime  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	// current is true for the session of the access token used to list.
+	Current       bool `protobuf:"varint,5,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *Session) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Session) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Session) GetRefreshTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshTime
+	}
+	return nil
+}
+
+func (x *Session) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type RefreshSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionRequest) Reset() {
+	*x = RefreshSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSessionRequest) ProtoMessage() {}
+
+func (x *RefreshSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSessionRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *RefreshSessionRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionResponse) Reset() {
+	*x = RefreshSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSessionResponse) ProtoMessage() {}
+
+func (x *RefreshSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSessionResponse.ProtoReflect.Descriptor instead.
+func (*RefreshSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *RefreshSessionResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshSessionResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{97}
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{98}
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{99}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *RevokeSessionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{102}
+}
+
+// ApiKey authenticates automated clients of an account in place of a session
+// token. It is sent as a bearer token of the form prex_<prefix>_<secret>.
+type ApiKey struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// scopes are the api_key_scope of RPCs the key may call, e.g. buy-token.
+	Scopes []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// spend_limit caps the total amount spent with the key. Unlimited if unset.
+	SpendLimit    *int64                 `protobuf:"varint,4,opt,name=spend_limit,json=spendLimit,proto3,oneof" json:"spend_limit,omitempty"`
+	Spent         int64                  `protobuf:"varint,5,opt,name=spent,proto3" json:"spent,omitempty"`
+	KeyPrefix     string                 `protobuf:"bytes,6,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	RevokeTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=revoke_time,json=revokeTime,proto3" json:"revoke_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuiDepositProof) String() string {
+func (x *ApiKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuiDepositProof) ProtoMessage() {}
+func (*ApiKey) ProtoMessage() {}
 
-func (x *SuiDepositProof) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[18]
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,129 +5908,96 @@ func (x *SuiDepositProof) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuiDepositProof.ProtoReflect.Descriptor instead.
-func (*SuiDepositProof) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *SuiDepositProof) GetChainDigest() string {
+func (x *ApiKey) GetName() string {
 	if x != nil {
-		return x.ChainDigest
+		return x.Name
 	}
 	return ""
 }
 
-func (x *SuiDepositProof) GetStartTime() *timestamppb.Timestamp {
+func (x *ApiKey) GetDisplayName() string {
 	if x != nil {
-		return x.StartTime
+		return x.DisplayName
 	}
-	return nil
+	return ""
 }
 
-func (x *SuiDepositProof) GetChallenge() []byte {
+func (x *ApiKey) GetScopes() []string {
 	if x != nil {
-		return x.Challenge
+		return x.Scopes
 	}
 	return nil
 }
 
-func (x *SuiDepositProof) GetSignature() string {
-	if x != nil {
-		return x.Signature
+func (x *ApiKey) GetSpendLimit() int64 {
+	if x != nil && x.SpendLimit != nil {
+		return *x.SpendLimit
 	}
-	return ""
-}
-
-type DepositRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	Ttl           *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	Proof         *SuiDepositProof       `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DepositRequest) Reset() {
-	*x = DepositRequest{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[19]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DepositRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return 0
 }
 
-func (*DepositRequest) ProtoMessage() {}
-
-func (x *DepositRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[19]
+func (x *ApiKey) GetSpent() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Spent
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DepositRequest.ProtoReflect.Descriptor instead.
-func (*DepositRequest) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{19}
+	return 0
 }
 
-func (x *DepositRequest) GetUsername() string {
+func (x *ApiKey) GetKeyPrefix() string {
 	if x != nil {
-		return x.Username
+		return x.KeyPrefix
 	}
 	return ""
 }
 
-func (x *DepositRequest) GetPassword() string {
+func (x *ApiKey) GetCreateTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Password
+		return x.CreateTime
 	}
-	return ""
+	return nil
 }
 
-func (x *DepositRequest) GetTtl() *durationpb.Duration {
+func (x *ApiKey) GetExpireTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Ttl
+		return x.ExpireTime
 	}
 	return nil
 }
 
-func (x *DepositRequest) GetProof() *SuiDepositProof {
+func (x *ApiKey) GetRevokeTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Proof
+		return x.RevokeTime
 	}
 	return nil
 }
 
-type DepositResponse struct {
+type CreateApiKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	ApiKey        *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DepositResponse) Reset() {
-	*x = DepositResponse{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[20]
+func (x *CreateApiKeyRequest) Reset() {
+	*x = CreateApiKeyRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DepositResponse) String() string {
+func (x *CreateApiKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DepositResponse) ProtoMessage() {}
+func (*CreateApiKeyRequest) ProtoMessage() {}
 
-func (x *DepositResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[20]
+func (x *CreateApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,40 +6008,42 @@ func (x *DepositResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DepositResponse.ProtoReflect.Descriptor instead.
-func (*DepositResponse) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use CreateApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *DepositResponse) GetAccount() *Account {
+func (x *CreateApiKeyRequest) GetApiKey() *ApiKey {
 	if x != nil {
-		return x.Account
+		return x.ApiKey
 	}
 	return nil
 }
 
-type GetChallengeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+type CreateApiKeyResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// key is only returned once. Prex only stores its hash.
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChallengeRequest) Reset() {
-	*x = GetChallengeRequest{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[21]
+func (x *CreateApiKeyResponse) Reset() {
+	*x = CreateApiKeyResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChallengeRequest) String() string {
+func (x *CreateApiKeyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChallengeRequest) ProtoMessage() {}
+func (*CreateApiKeyResponse) ProtoMessage() {}
 
-func (x *GetChallengeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[21]
+func (x *CreateApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1228,41 +6054,46 @@ func (x *GetChallengeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChallengeRequest.ProtoReflect.Descriptor instead.
-func (*GetChallengeRequest) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use CreateApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *GetChallengeRequest) GetAddress() string {
+func (x *CreateApiKeyResponse) GetApiKey() *ApiKey {
 	if x != nil {
-		return x.Address
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateApiKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
 	return ""
 }
 
-type GetChallengeResponse struct {
+type ListApiKeysRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Challenge     []byte                 `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChallengeResponse) Reset() {
-	*x = GetChallengeResponse{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[22]
+func (x *ListApiKeysRequest) Reset() {
+	*x = ListApiKeysRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChallengeResponse) String() string {
+func (x *ListApiKeysRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChallengeResponse) ProtoMessage() {}
+func (*ListApiKeysRequest) ProtoMessage() {}
 
-func (x *GetChallengeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[22]
+func (x *ListApiKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1273,51 +6104,77 @@ func (x *GetChallengeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChallengeResponse.ProtoReflect.Descriptor instead.
-func (*GetChallengeResponse) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ListApiKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *GetChallengeResponse) GetChallenge() []byte {
+type ListApiKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*ApiKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysResponse) Reset() {
+	*x = ListApiKeysResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysResponse) ProtoMessage() {}
+
+func (x *ListApiKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[107]
 	if x != nil {
-		return x.Challenge
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetChallengeResponse) GetStartTime() *timestamppb.Timestamp {
+// Deprecated: Use ListApiKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ListApiKeysResponse) GetApiKeys() []*ApiKey {
 	if x != nil {
-		return x.StartTime
+		return x.ApiKeys
 	}
 	return nil
 }
 
-type Account struct {
+type RevokeApiKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	AccountId     int64                  `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
-	Balance       int64                  `protobuf:"varint,5,opt,name=balance,proto3" json:"balance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Account) Reset() {
-	*x = Account{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[23]
+func (x *RevokeApiKeyRequest) Reset() {
+	*x = RevokeApiKeyRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Account) String() string {
+func (x *RevokeApiKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Account) ProtoMessage() {}
+func (*RevokeApiKeyRequest) ProtoMessage() {}
 
-func (x *Account) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[23]
+func (x *RevokeApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1328,69 +6185,86 @@ func (x *Account) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Account.ProtoReflect.Descriptor instead.
-func (*Account) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RevokeApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *Account) GetName() string {
+func (x *RevokeApiKeyRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Account) GetAccountId() int64 {
-	if x != nil {
-		return x.AccountId
-	}
-	return 0
+type RevokeApiKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *ApiKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Account) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
+func (x *RevokeApiKeyResponse) Reset() {
+	*x = RevokeApiKeyResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Account) GetExpireTime() *timestamppb.Timestamp {
+func (x *RevokeApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyResponse) ProtoMessage() {}
+
+func (x *RevokeApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[109]
 	if x != nil {
-		return x.ExpireTime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Account) GetBalance() int64 {
+// Deprecated: Use RevokeApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *RevokeApiKeyResponse) GetApiKey() *ApiKey {
 	if x != nil {
-		return x.Balance
+		return x.ApiKey
 	}
-	return 0
+	return nil
 }
 
-type LoginRequest struct {
+type ResetPasswordRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	Proof         *SuiSignatureProof     `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[24]
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginRequest) String() string {
+func (x *ResetPasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*ResetPasswordRequest) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[24]
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1401,48 +6275,55 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *LoginRequest) GetUsername() string {
+func (x *ResetPasswordRequest) GetUsername() string {
 	if x != nil {
 		return x.Username
 	}
 	return ""
 }
 
-func (x *LoginRequest) GetPassword() string {
+func (x *ResetPasswordRequest) GetNewPassword() string {
 	if x != nil {
-		return x.Password
+		return x.NewPassword
 	}
 	return ""
 }
 
-type LoginResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ResetPasswordRequest) GetProof() *SuiSignatureProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
 }
 
-func (x *LoginResponse) Reset() {
-	*x = LoginResponse{}
-	mi := &file_exchange_v1_exchange_proto_msgTypes[25]
+type ResetPasswordResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Account               *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	WithdrawCooldownUntil *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=withdraw_cooldown_until,json=withdrawCooldownUntil,proto3" json:"withdraw_cooldown_until,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
+	mi := &file_exchange_v1_exchange_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginResponse) String() string {
+func (x *ResetPasswordResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResponse) ProtoMessage() {}
+func (*ResetPasswordResponse) ProtoMessage() {}
 
-func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_exchange_v1_exchange_proto_msgTypes[25]
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exchange_v1_exchange_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1453,44 +6334,300 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
-func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_exchange_v1_exchange_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *LoginResponse) GetAccount() *Account {
+func (x *ResetPasswordResponse) GetAccount() *Account {
 	if x != nil {
 		return x.Account
 	}
 	return nil
 }
 
-func (x *LoginResponse) GetAccessToken() string {
+func (x *ResetPasswordResponse) GetWithdrawCooldownUntil() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AccessToken
+		return x.WithdrawCooldownUntil
 	}
-	return ""
+	return nil
 }
 
 var File_exchange_v1_exchange_proto protoreflect.FileDescriptor
 
 const file_exchange_v1_exchange_proto_rawDesc = "" +
 	"\n" +
-	"\x1aexchange/v1/exchange.proto\x12\vexchange.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x19google/api/resource.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bbuf/validate/validate.proto\"c\n" +
+	"\x1aexchange/v1/exchange.proto\x12\vexchange.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x19google/api/resource.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bbuf/validate/validate.proto\x1a\x15prex/v1/options.proto\"\xa8\x02\n" +
 	"\x0fBuyTokenRequest\x12,\n" +
 	"\baudience\x18\x01 \x01(\tB\x10\xe0A\x02\xbaH\n" +
 	"r\b2\x06did:.+R\baudience\x12\"\n" +
 	"\x06amount\x18\x02 \x01(\x03B\n" +
-	"\xe0A\x02\xbaH\x04\"\x02 \x00R\x06amount\"(\n" +
+	"\xe0A\x02\xbaH\x04\"\x02 \x00R\x06amount\x12=\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x18.exchange.v1.TokenFormatB\v\xe0A\x01\xbaH\x05\x82\x01\x02\x10\x01R\x06format\x12\x1b\n" +
+	"\x06escrow\x18\x04 \x01(\bB\x03\xe0A\x01R\x06escrow\x128\n" +
+	"\x03ttl\x18\x05 \x01(\v2\x19.google.protobuf.DurationB\v\xe0A\x01\xbaH\x05\xaa\x01\x02*\x00R\x03ttl\x12-\n" +
+	"\vsplit_count\x18\x06 \x01(\x05B\f\xe0A\x01\xbaH\x06\x1a\x04\x18d(\x00R\n" +
+	"splitCount\"\xb7\x01\n" +
 	"\x10BuyTokenResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\x1b\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12>\n" +
+	"\rlog_inclusion\x18\x02 \x01(\v2\x19.exchange.v1.LogInclusionR\flogInclusion\x12\x18\n" +
+	"\areceipt\x18\x03 \x01(\tR\areceipt\x123\n" +
+	"\x06tokens\x18\x04 \x03(\v2\x1b.exchange.v1.PurchasedTokenR\x06tokens\"\x80\x01\n" +
+	"\x0ePurchasedToken\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12>\n" +
+	"\rlog_inclusion\x18\x02 \x01(\v2\x19.exchange.v1.LogInclusionR\flogInclusion\x12\x18\n" +
+	"\areceipt\x18\x03 \x01(\tR\areceipt\"\x8e\x01\n" +
+	"\x12RedeemTokenRequest\x12 \n" +
+	"\x05token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x05token\x12(\n" +
+	"\bquantity\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\bquantity\x88\x01\x01\x12\x1f\n" +
+	"\bendpoint\x18\x03 \x01(\tB\x03\xe0A\x01R\bendpointB\v\n" +
+	"\t_quantity\"R\n" +
+	"\x13RedeemTokenResponse\x12;\n" +
+	"\ftoken_status\x18\x01 \x01(\v2\x18.exchange.v1.TokenStatusR\vtokenStatus\":\n" +
+	"\x16IntrospectTokenRequest\x12 \n" +
+	"\x05token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x05token\"V\n" +
+	"\x17IntrospectTokenResponse\x12;\n" +
+	"\ftoken_status\x18\x01 \x01(\v2\x18.exchange.v1.TokenStatusR\vtokenStatus\"\xa3\x02\n" +
+	"\vTokenStatus\x12\x10\n" +
+	"\x03jti\x18\x01 \x01(\tR\x03jti\x12\x1a\n" +
+	"\baudience\x18\x02 \x01(\tR\baudience\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\x12\x1a\n" +
+	"\bconsumed\x18\x04 \x01(\x03R\bconsumed\x12\x1c\n" +
+	"\tremaining\x18\x05 \x01(\x03R\tremaining\x12;\n" +
+	"\vexpire_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x12;\n" +
+	"\vrevoke_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"revokeTime\"6\n" +
+	"\x12RefundTokenRequest\x12 \n" +
+	"\x05token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x05token\"j\n" +
+	"\x13RefundTokenResponse\x12;\n" +
+	"\ftoken_status\x18\x01 \x01(\v2\x18.exchange.v1.TokenStatusR\vtokenStatus\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\xfa\x01\n" +
+	"\fRefundPolicy\x12\x1f\n" +
+	"\baudience\x18\x01 \x01(\tB\x03\xe0A\x03R\baudience\x12:\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x17.exchange.v1.RefundModeB\r\xe0A\x02\xbaH\a\x82\x01\x04\x10\x01 \x00R\x04mode\x12K\n" +
+	"\rrefund_window\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\v\xe0A\x01\xbaH\x05\xaa\x01\x022\x00R\frefundWindow\x12@\n" +
+	"\vupdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"updateTime\"@\n" +
+	"\x16GetRefundPolicyRequest\x12&\n" +
+	"\baudience\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\baudience\"f\n" +
+	"\x19UpdateRefundPolicyRequest\x12I\n" +
+	"\rrefund_policy\x18\x01 \x01(\v2\x19.exchange.v1.RefundPolicyB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\frefundPolicy\"\xbf\x01\n" +
+	"\vTokenPolicy\x12\x1f\n" +
+	"\baudience\x18\x01 \x01(\tB\x03\xe0A\x03R\baudience\x12M\n" +
+	"\rmax_token_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationB\x0e\xe0A\x02\xbaH\b\xc8\x01\x01\xaa\x01\x02*\x00R\vmaxTokenTtl\x12@\n" +
+	"\vupdate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"updateTime\"?\n" +
+	"\x15GetTokenPolicyRequest\x12&\n" +
+	"\baudience\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\baudience\"b\n" +
+	"\x18UpdateTokenPolicyRequest\x12F\n" +
+	"\ftoken_policy\x18\x01 \x01(\v2\x18.exchange.v1.TokenPolicyB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\vtokenPolicy\"\xe8\x01\n" +
+	"\rBlindTokenKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\"\n" +
+	"\fdenomination\x18\x02 \x01(\x03R\fdenomination\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\fR\tpublicKey\x12@\n" +
+	"\x0eissue_end_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fissueEndTime\x12;\n" +
+	"\vexpire_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\"\x1b\n" +
+	"\x19ListBlindTokenKeysRequest\"b\n" +
+	"\x1aListBlindTokenKeysResponse\x12D\n" +
+	"\x10blind_token_keys\x18\x01 \x03(\v2\x1a.exchange.v1.BlindTokenKeyR\x0eblindTokenKeys\"v\n" +
+	"\x17IssueBlindTokensRequest\x12!\n" +
+	"\x06key_id\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x05keyId\x128\n" +
+	"\x10blinded_messages\x18\x02 \x03(\fB\r\xe0A\x02\xbaH\a\x92\x01\x04\b\x01\x10dR\x0fblindedMessages\"E\n" +
+	"\x18IssueBlindTokensResponse\x12)\n" +
+	"\x10blind_signatures\x18\x01 \x03(\fR\x0fblindSignatures\";\n" +
+	"\x17RedeemBlindTokenRequest\x12 \n" +
+	"\x05token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x05token\">\n" +
+	"\x18RedeemBlindTokenResponse\x12\"\n" +
+	"\fdenomination\x18\x01 \x01(\x03R\fdenomination\"\x1e\n" +
+	"\x1cPruneSpentBlindTokensRequest\"B\n" +
+	"\x1dPruneSpentBlindTokensResponse\x12!\n" +
+	"\fpruned_count\x18\x01 \x01(\x03R\vprunedCount\"\x85\x02\n" +
+	"\x14ExpiredBlindTokenKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\"\n" +
+	"\fdenomination\x18\x02 \x01(\x03R\fdenomination\x12!\n" +
+	"\fissued_count\x18\x03 \x01(\x03R\vissuedCount\x12%\n" +
+	"\x0eredeemed_count\x18\x04 \x01(\x03R\rredeemedCount\x12+\n" +
+	"\x11unredeemed_amount\x18\x05 \x01(\x03R\x10unredeemedAmount\x12;\n" +
+	"\vexpire_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\"\"\n" +
+	" ListExpiredBlindTokenKeysRequest\"\x7f\n" +
+	"!ListExpiredBlindTokenKeysResponse\x12Z\n" +
+	"\x18expired_blind_token_keys\x18\x01 \x03(\v2!.exchange.v1.ExpiredBlindTokenKeyR\x15expiredBlindTokenKeys\"\xbe\x04\n" +
+	"\x0eServiceSession\x125\n" +
+	"\x04name\x18\x01 \x01(\tB!\xe0A\b\xbaH\x1br\x192\x17service-sessions/[0-9]+R\x04name\x12\x1f\n" +
+	"\baudience\x18\x02 \x01(\tB\x03\xe0A\x03R\baudience\x12\x1f\n" +
+	"\bquantity\x18\x03 \x01(\x03B\x03\xe0A\x03R\bquantity\x12\x1f\n" +
+	"\bconsumed\x18\x04 \x01(\x03B\x03\xe0A\x03R\bconsumed\x12!\n" +
+	"\tremaining\x18\x05 \x01(\x03B\x03\xe0A\x03R\tremaining\x12;\n" +
+	"\x05state\x18\x06 \x01(\x0e2 .exchange.v1.ServiceSessionStateB\x03\xe0A\x03R\x05state\x12@\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12@\n" +
+	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"updateTime\x12@\n" +
+	"\vexpire_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"expireTime:l\xeaAi\n" +
+	"Cgithub.com/atticplaygroup/prex/pkg/proto/exchange/v1/ServiceSession\x12\"service-sessions/{service_session}\"?\n" +
+	"\x1bCreateServiceSessionRequest\x12 \n" +
+	"\x05token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x05token\"\x87\x01\n" +
+	"\x1cCreateServiceSessionResponse\x12D\n" +
+	"\x0fservice_session\x18\x01 \x01(\v2\x1b.exchange.v1.ServiceSessionR\x0eserviceSession\x12!\n" +
+	"\fmanage_token\x18\x02 \x01(\tR\vmanageToken\"}\n" +
+	"\x1cConsumeServiceSessionRequest\x125\n" +
+	"\x04name\x18\x01 \x01(\tB!\xe0A\x02\xbaH\x1br\x192\x17service-sessions/[0-9]+R\x04name\x12&\n" +
+	"\bquantity\x18\x02 \x01(\x03B\n" +
+	"\xe0A\x02\xbaH\x04\"\x02 \x00R\bquantity\"e\n" +
+	"\x1dConsumeServiceSessionResponse\x12D\n" +
+	"\x0fservice_session\x18\x01 \x01(\v2\x1b.exchange.v1.ServiceSessionR\x0eserviceSession\"I\n" +
+	"\x18GetServiceSessionRequest\x12-\n" +
+	"\fmanage_token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\vmanageToken\"a\n" +
+	"\x19GetServiceSessionResponse\x12D\n" +
+	"\x0fservice_session\x18\x01 \x01(\v2\x1b.exchange.v1.ServiceSessionR\x0eserviceSession\"m\n" +
+	"\x1aTopUpServiceSessionRequest\x12-\n" +
+	"\fmanage_token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\vmanageToken\x12 \n" +
+	"\x05token\x18\x02 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x05token\"\x86\x01\n" +
+	"\x1bTopUpServiceSessionResponse\x12D\n" +
+	"\x0fservice_session\x18\x01 \x01(\v2\x1b.exchange.v1.ServiceSessionR\x0eserviceSession\x12!\n" +
+	"\fmanage_token\x18\x02 \x01(\tR\vmanageToken\"K\n" +
+	"\x1aPauseServiceSessionRequest\x12-\n" +
+	"\fmanage_token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\vmanageToken\"c\n" +
+	"\x1bPauseServiceSessionResponse\x12D\n" +
+	"\x0fservice_session\x18\x01 \x01(\v2\x1b.exchange.v1.ServiceSessionR\x0eserviceSession\"L\n" +
+	"\x1bResumeServiceSessionRequest\x12-\n" +
+	"\fmanage_token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\vmanageToken\"d\n" +
+	"\x1cResumeServiceSessionResponse\x12D\n" +
+	"\x0fservice_session\x18\x01 \x01(\v2\x1b.exchange.v1.ServiceSessionR\x0eserviceSession\"K\n" +
+	"\x1aCloseServiceSessionRequest\x12-\n" +
+	"\fmanage_token\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\vmanageToken\"c\n" +
+	"\x1bCloseServiceSessionResponse\x12D\n" +
+	"\x0fservice_session\x18\x01 \x01(\v2\x1b.exchange.v1.ServiceSessionR\x0eserviceSession\"\xe2\x01\n" +
+	"\x06Escrow\x12\x10\n" +
+	"\x03jti\x18\x01 \x01(\tR\x03jti\x12\x1a\n" +
+	"\baudience\x18\x02 \x01(\tR\baudience\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x18\n" +
+	"\aclaimed\x18\x04 \x01(\x03R\aclaimed\x12;\n" +
+	"\vexpire_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12;\n" +
+	"\vsettle_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"settleTime\"\x9a\x01\n" +
+	"\x12ConsumptionReceipt\x12\x1c\n" +
+	"\x03jti\x18\x01 \x01(\tB\n" +
+	"\xe0A\x02\xbaH\x04r\x02\x10\x01R\x03jti\x12&\n" +
+	"\bconsumed\x18\x02 \x01(\x03B\n" +
+	"\xe0A\x02\xbaH\x04\"\x02 \x00R\bconsumed\x12>\n" +
+	"\n" +
+	"issue_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\tissueTime\"j\n" +
+	"\x18SignedConsumptionReceipt\x12$\n" +
+	"\areceipt\x18\x01 \x01(\fB\n" +
+	"\xe0A\x02\xbaH\x04z\x02\x10\x01R\areceipt\x12(\n" +
+	"\tsignature\x18\x02 \x01(\fB\n" +
+	"\xe0A\x02\xbaH\x04z\x02h@R\tsignature\"t\n" +
+	" SubmitConsumptionReceiptsRequest\x12P\n" +
+	"\breceipts\x18\x01 \x03(\v2%.exchange.v1.SignedConsumptionReceiptB\r\xe0A\x02\xbaH\a\x92\x01\x04\b\x01\x10dR\breceipts\"j\n" +
+	"!SubmitConsumptionReceiptsResponse\x12-\n" +
+	"\aescrows\x18\x01 \x03(\v2\x13.exchange.v1.EscrowR\aescrows\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\xbb\x01\n" +
+	"\bLogEntry\x12\x1d\n" +
+	"\n" +
+	"leaf_index\x18\x01 \x01(\x03R\tleafIndex\x12\x1d\n" +
+	"\n" +
+	"token_hash\x18\x02 \x01(\fR\ttokenHash\x12\x1a\n" +
+	"\baudience\x18\x03 \x01(\tR\baudience\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x129\n" +
+	"\n" +
+	"issue_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tissueTime\"\xb4\x01\n" +
+	"\x0eSignedTreeHead\x12\x1b\n" +
+	"\ttree_size\x18\x01 \x01(\x03R\btreeSize\x12\x1b\n" +
+	"\troot_hash\x18\x02 \x01(\fR\brootHash\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x10\n" +
+	"\x03kid\x18\x04 \x01(\tR\x03kid\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignature\"\x94\x01\n" +
+	"\fLogInclusion\x12+\n" +
+	"\x05entry\x18\x01 \x01(\v2\x15.exchange.v1.LogEntryR\x05entry\x12\x1d\n" +
+	"\n" +
+	"audit_path\x18\x02 \x03(\fR\tauditPath\x128\n" +
+	"\ttree_head\x18\x03 \x01(\v2\x1b.exchange.v1.SignedTreeHeadR\btreeHead\"\x14\n" +
+	"\x12GetTreeHeadRequest\"f\n" +
+	"\x15ListLogEntriesRequest\x12(\n" +
+	"\vstart_index\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\n" +
+	"startIndex\x12#\n" +
+	"\x05limit\x18\x02 \x01(\x05B\r\xe0A\x01\xbaH\a\x1a\x05\x18\xe8\a(\x00R\x05limit\"I\n" +
+	"\x16ListLogEntriesResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.exchange.v1.LogEntryR\aentries\"k\n" +
+	"\x18GetInclusionProofRequest\x12&\n" +
+	"\n" +
+	"leaf_index\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\tleafIndex\x12'\n" +
+	"\ttree_size\x18\x02 \x01(\x03B\n" +
+	"\xe0A\x02\xbaH\x04\"\x02 \x00R\btreeSize\":\n" +
+	"\x19GetInclusionProofResponse\x12\x1d\n" +
+	"\n" +
+	"audit_path\x18\x01 \x03(\fR\tauditPath\"j\n" +
+	"\x1aGetConsistencyProofRequest\x12%\n" +
+	"\bold_size\x18\x01 \x01(\x03B\n" +
+	"\xe0A\x02\xbaH\x04\"\x02 \x00R\aoldSize\x12%\n" +
+	"\bnew_size\x18\x02 \x01(\x03B\n" +
+	"\xe0A\x02\xbaH\x04\"\x02 \x00R\anewSize\"3\n" +
+	"\x1bGetConsistencyProofResponse\x12\x14\n" +
+	"\x05proof\x18\x01 \x03(\fR\x05proof\"\x1b\n" +
 	"\x19ListPaymentMethodsRequest\"a\n" +
 	"\x1aListPaymentMethodsResponse\x12C\n" +
-	"\x0fpayment_methods\x18\x01 \x03(\v2\x1a.exchange.v1.PaymentMethodR\x0epaymentMethods\"\xc5\x01\n" +
-	"\rPaymentMethod\x126\n" +
+	"\x0fpayment_methods\x18\x01 \x03(\v2\x1a.exchange.v1.PaymentMethodR\x0epaymentMethods\"\x18\n" +
+	"\x16ListSigningKeysRequest\"U\n" +
+	"\x17ListSigningKeysResponse\x12:\n" +
+	"\fsigning_keys\x18\x01 \x03(\v2\x17.exchange.v1.SigningKeyR\vsigningKeys\"\xd5\x01\n" +
+	"\n" +
+	"SigningKey\x12\x10\n" +
+	"\x03kid\x18\x01 \x01(\tR\x03kid\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x18\n" +
+	"\aprimary\x18\x03 \x01(\bR\aprimary\x12?\n" +
+	"\ractivate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\factivateTime\x12;\n" +
+	"\vretire_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"retireTime\"\xea\x02\n" +
+	"\rPaymentMethod\x128\n" +
+	"\x04name\x18\x01 \x01(\tB$\xe0A\b\xbaH\x1er\x1c2\x1apayment-methods/[a-z0-9-]+R\x04name\x126\n" +
 	"\x04coin\x18\x02 \x01(\x0e2\x18.exchange.v1.PaymentCoinB\b\xbaH\x05\x82\x01\x02\x10\x01R\x04coin\x12K\n" +
 	"\venvironment\x18\x03 \x01(\x0e2\x1f.exchange.v1.PaymentEnvironmentB\b\xbaH\x05\x82\x01\x02\x10\x01R\venvironment\x12/\n" +
-	"\aaddress\x18\x04 \x01(\tB\x15\xbaH\x12r\x102\x0e0x[a-f0-9]{64}R\aaddress\"/\n" +
+	"\aaddress\x18\x04 \x01(\tB\x15\xbaH\x12r\x102\x0e0x[a-f0-9]{64}R\aaddress:i\xeaAf\n" +
+	"Bgithub.com/atticplaygroup/prex/pkg/proto/exchange/v1/PaymentMethod\x12 payment-methods/{payment_method}\"\x18\n" +
+	"\x16GetWalletStatusRequest\"\x9d\x01\n" +
+	"\x17GetWalletStatusResponse\x12>\n" +
+	"\rwallet_status\x18\x01 \x01(\v2\x19.exchange.v1.WalletStatusR\fwalletStatus\x12B\n" +
+	"\x0fwallet_statuses\x18\x02 \x03(\v2\x19.exchange.v1.WalletStatusR\x0ewalletStatuses\"\xb4\x04\n" +
+	"\fWalletStatus\x12\x1f\n" +
+	"\vhot_address\x18\x01 \x01(\tR\n" +
+	"hotAddress\x12!\n" +
+	"\fcold_address\x18\x02 \x01(\tR\vcoldAddress\x12\x1f\n" +
+	"\vhot_balance\x18\x03 \x01(\x03R\n" +
+	"hotBalance\x126\n" +
+	"\x17pending_withdraw_amount\x18\x04 \x01(\x03R\x15pendingWithdrawAmount\x12\x14\n" +
+	"\x05float\x18\x05 \x01(\x03R\x05float\x12&\n" +
+	"\x0fhigh_water_mark\x18\x06 \x01(\x03R\rhighWaterMark\x12$\n" +
+	"\x0elow_water_mark\x18\a \x01(\x03R\flowWaterMark\x12#\n" +
+	"\rrefill_needed\x18\b \x01(\bR\frefillNeeded\x12#\n" +
+	"\rrefill_amount\x18\t \x01(\x03R\frefillAmount\x12*\n" +
+	"\x11last_sweep_digest\x18\n" +
+	" \x01(\tR\x0flastSweepDigest\x12B\n" +
+	"\x0flast_sweep_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\rlastSweepTime\x12B\n" +
+	"\x0flast_check_time\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\rlastCheckTime\x12%\n" +
+	"\x0epayment_method\x18\r \x01(\tR\rpaymentMethod\"/\n" +
 	"\vPingRequest\x12 \n" +
 	"\x05dummy\x18\x01 \x01(\x03B\n" +
 	"\xbaH\a\xd8\x01\x01\"\x02 \x00R\x05dummy\"\"\n" +
@@ -1522,7 +6659,25 @@ const file_exchange_v1_exchange_proto_rawDesc = "" +
 	"\xe0A\x02\xbaH\x04\"\x02 \x00R\x06amount\x12-\n" +
 	"\fpriority_fee\x18\x04 \x01(\x03B\n" +
 	"\xe0A\x02\xbaH\x04\"\x02 \x00R\vpriorityFee:q\xeaAn\n" +
-	"?github.com/atticplaygroup/prex/pkg/proto/exchange/v1/Withdrawal\x12+accounts/{account}/withdrawals/{withdrawal}\"~\n" +
+	"?github.com/atticplaygroup/prex/pkg/proto/exchange/v1/Withdrawal\x12+accounts/{account}/withdrawals/{withdrawal}\"K\n" +
+	"\x1aEstimateWithdrawFeeRequest\x12-\n" +
+	"\fpriority_fee\x18\x01 \x01(\x03B\n" +
+	"\xe0A\x01\xbaH\x04\"\x02(\x00R\vpriorityFee\"R\n" +
+	"\rFeePercentile\x12\x1e\n" +
+	"\n" +
+	"percentile\x18\x01 \x01(\x05R\n" +
+	"percentile\x12!\n" +
+	"\fpriority_fee\x18\x02 \x01(\x03R\vpriorityFee\"\xe2\x02\n" +
+	"\x1bEstimateWithdrawFeeResponse\x12.\n" +
+	"\x13reference_gas_price\x18\x01 \x01(\x03R\x11referenceGasPrice\x12\x1f\n" +
+	"\vqueue_depth\x18\x02 \x01(\x03R\n" +
+	"queueDepth\x12C\n" +
+	"\x0ffee_percentiles\x18\x03 \x03(\v2\x1a.exchange.v1.FeePercentileR\x0efeePercentiles\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x03R\tbatchSize\x12'\n" +
+	"\x0fpredicted_batch\x18\x05 \x01(\x03R\x0epredictedBatch\x12.\n" +
+	"\x13predicted_batch_fee\x18\x06 \x01(\x03R\x11predictedBatchFee\x125\n" +
+	"\x17next_batch_priority_fee\x18\a \x01(\x03R\x14nextBatchPriorityFee\"~\n" +
 	"\x15CreateWithdrawRequest\x12B\n" +
 	"\n" +
 	"withdrawal\x18\x02 \x01(\v2\x17.exchange.v1.WithdrawalB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\n" +
@@ -1540,13 +6695,15 @@ const file_exchange_v1_exchange_proto_rawDesc = "" +
 	"\n" +
 	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\b\xbaH\x05\xb2\x01\x028\x01R\tstartTime\x12%\n" +
 	"\tchallenge\x18\x03 \x01(\fB\a\xbaH\x04z\x02h R\tchallenge\x12$\n" +
-	"\tsignature\x18\x04 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tsignature\"\xea\x01\n" +
+	"\tsignature\x18\x04 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tsignature\"\xb8\x02\n" +
 	"\x0eDepositRequest\x120\n" +
-	"\busername\x18\x01 \x01(\tB\x14\xe0A\x02\xbaH\x0er\f\x10\x01\x18@2\x06did:.*R\busername\x12(\n" +
-	"\bpassword\x18\x02 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\bpassword\x12=\n" +
+	"\busername\x18\x01 \x01(\tB\x14\xe0A\x02\xbaH\x0er\f\x10\x01\x18@2\x06did:.*R\busername\x12&\n" +
+	"\bpassword\x18\x02 \x01(\tB\n" +
+	"\xe0A\x01\xbaH\x04r\x02\x18@R\bpassword\x12=\n" +
 	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\x10\xe0A\x02\xbaH\n" +
 	"\xaa\x01\a\"\x05\b\x80\x9a\x9e\x01R\x03ttl\x12=\n" +
-	"\x05proof\x18\x04 \x01(\v2\x1c.exchange.v1.SuiDepositProofB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\x05proof\"A\n" +
+	"\x05proof\x18\x04 \x01(\v2\x1c.exchange.v1.SuiDepositProofB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\x05proof\x12N\n" +
+	"\x0epayment_method\x18\x05 \x01(\tB'\xe0A\x01\xbaH!\xd8\x01\x01r\x1c2\x1apayment-methods/[a-z0-9-]+R\rpaymentMethod\"A\n" +
 	"\x0fDepositResponse\x12.\n" +
 	"\aaccount\x18\x01 \x01(\v2\x14.exchange.v1.AccountR\aaccount\"F\n" +
 	"\x13GetChallengeRequest\x12/\n" +
@@ -1568,37 +6725,164 @@ const file_exchange_v1_exchange_proto_rawDesc = "" +
 	"<github.com/atticplaygroup/prex/pkg/proto/exchange/v1/Account\x12\x12accounts/{account}\"b\n" +
 	"\fLoginRequest\x12(\n" +
 	"\busername\x18\x01 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\busername\x12(\n" +
-	"\bpassword\x18\x02 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\bpassword\"b\n" +
+	"\bpassword\x18\x02 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\bpassword\"\x87\x01\n" +
 	"\rLoginResponse\x12.\n" +
 	"\aaccount\x18\x01 \x01(\v2\x14.exchange.v1.AccountR\aaccount\x12!\n" +
-	"\faccess_token\x18\x02 \x01(\tR\vaccessToken*A\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"\xa5\x01\n" +
+	"\x11SuiSignatureProof\x12C\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampB\b\xbaH\x05\xb2\x01\x028\x01R\tstartTime\x12%\n" +
+	"\tchallenge\x18\x02 \x01(\fB\a\xbaH\x04z\x02h R\tchallenge\x12$\n" +
+	"\tsignature\x18\x03 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tsignature\"\x86\x01\n" +
+	"\x19LoginWithSignatureRequest\x12(\n" +
+	"\busername\x18\x01 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\busername\x12?\n" +
+	"\x05proof\x18\x02 \x01(\v2\x1e.exchange.v1.SuiSignatureProofB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\x05proof\"\x94\x01\n" +
+	"\x1aLoginWithSignatureResponse\x12.\n" +
+	"\aaccount\x18\x01 \x01(\v2\x14.exchange.v1.AccountR\aaccount\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"\x9f\x03\n" +
+	"\aSession\x12C\n" +
+	"\x04name\x18\x01 \x01(\tB/\xe0A\b\xbaH)r'2%accounts/[0-9]+/sessions/[a-f0-9]{32}R\x04name\x12@\n" +
+	"\vcreate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12B\n" +
+	"\frefresh_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\vrefreshTime\x12@\n" +
+	"\vexpire_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"expireTime\x12\x1d\n" +
+	"\acurrent\x18\x05 \x01(\bB\x03\xe0A\x03R\acurrent:h\xeaAe\n" +
+	"<github.com/atticplaygroup/prex/pkg/proto/exchange/v1/Session\x12%accounts/{account}/sessions/{session}\"K\n" +
+	"\x15RefreshSessionRequest\x122\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\r\xe0A\x02\xbaH\ar\x05\x10\x01\x18\x80\x01R\frefreshToken\"`\n" +
+	"\x16RefreshSessionResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"\x0f\n" +
+	"\rLogoutRequest\"\x10\n" +
+	"\x0eLogoutResponse\"\x15\n" +
+	"\x13ListSessionsRequest\"H\n" +
+	"\x14ListSessionsResponse\x120\n" +
+	"\bsessions\x18\x01 \x03(\v2\x14.exchange.v1.SessionR\bsessions\"[\n" +
+	"\x14RevokeSessionRequest\x12C\n" +
+	"\x04name\x18\x01 \x01(\tB/\xe0A\x02\xbaH)r'2%accounts/[0-9]+/sessions/[a-f0-9]{32}R\x04name\"\x17\n" +
+	"\x15RevokeSessionResponse\"\xe4\x04\n" +
+	"\x06ApiKey\x12@\n" +
+	"\x04name\x18\x01 \x01(\tB,\xe0A\b\xbaH&\xd8\x01\x01r!2\x1faccounts/[0-9]+/api-keys/[0-9]+R\x04name\x12/\n" +
+	"\fdisplay_name\x18\x02 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\vdisplayName\x127\n" +
+	"\x06scopes\x18\x03 \x03(\tB\x1f\xe0A\x02\xbaH\x19\x92\x01\x16\b\x01\x18\x01\"\x10r\x0e2\f^[a-z0-9-]+$R\x06scopes\x120\n" +
+	"\vspend_limit\x18\x04 \x01(\x03B\n" +
+	"\xe0A\x01\xbaH\x04\"\x02(\x00H\x00R\n" +
+	"spendLimit\x88\x01\x01\x12\x19\n" +
+	"\x05spent\x18\x05 \x01(\x03B\x03\xe0A\x03R\x05spent\x12\"\n" +
+	"\n" +
+	"key_prefix\x18\x06 \x01(\tB\x03\xe0A\x03R\tkeyPrefix\x12@\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12@\n" +
+	"\vexpire_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\n" +
+	"expireTime\x12@\n" +
+	"\vrevoke_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"revokeTime:g\xeaAd\n" +
+	";github.com/atticplaygroup/prex/pkg/proto/exchange/v1/ApiKey\x12%accounts/{account}/api-keys/{api_key}B\x0e\n" +
+	"\f_spend_limit\"N\n" +
+	"\x13CreateApiKeyRequest\x127\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x13.exchange.v1.ApiKeyB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\x06apiKey\"V\n" +
+	"\x14CreateApiKeyResponse\x12,\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x13.exchange.v1.ApiKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"\x14\n" +
+	"\x12ListApiKeysRequest\"E\n" +
+	"\x13ListApiKeysResponse\x12.\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x13.exchange.v1.ApiKeyR\aapiKeys\"T\n" +
+	"\x13RevokeApiKeyRequest\x12=\n" +
+	"\x04name\x18\x01 \x01(\tB)\xe0A\x02\xbaH#r!2\x1faccounts/[0-9]+/api-keys/[0-9]+R\x04name\"D\n" +
+	"\x14RevokeApiKeyResponse\x12,\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x13.exchange.v1.ApiKeyR\x06apiKey\"\xb2\x01\n" +
+	"\x14ResetPasswordRequest\x12(\n" +
+	"\busername\x18\x01 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\busername\x12/\n" +
+	"\fnew_password\x18\x02 \x01(\tB\f\xe0A\x02\xbaH\x06r\x04\x10\x01\x18@R\vnewPassword\x12?\n" +
+	"\x05proof\x18\x03 \x01(\v2\x1e.exchange.v1.SuiSignatureProofB\t\xe0A\x02\xbaH\x03\xc8\x01\x01R\x05proof\"\x9b\x01\n" +
+	"\x15ResetPasswordResponse\x12.\n" +
+	"\aaccount\x18\x01 \x01(\v2\x14.exchange.v1.AccountR\aaccount\x12R\n" +
+	"\x17withdraw_cooldown_until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x15withdrawCooldownUntil*Z\n" +
+	"\vTokenFormat\x12\x1c\n" +
+	"\x18TOKEN_FORMAT_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TOKEN_FORMAT_JWT\x10\x01\x12\x17\n" +
+	"\x13TOKEN_FORMAT_CAVEAT\x10\x02*w\n" +
+	"\n" +
+	"RefundMode\x12\x1b\n" +
+	"\x17REFUND_MODE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10REFUND_MODE_NONE\x10\x01\x12\x1a\n" +
+	"\x16REFUND_MODE_UNREDEEMED\x10\x02\x12\x1a\n" +
+	"\x16REFUND_MODE_UNCONSUMED\x10\x03*\xa2\x01\n" +
+	"\x13ServiceSessionState\x12%\n" +
+	"!SERVICE_SESSION_STATE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cSERVICE_SESSION_STATE_ACTIVE\x10\x01\x12 \n" +
+	"\x1cSERVICE_SESSION_STATE_PAUSED\x10\x02\x12 \n" +
+	"\x1cSERVICE_SESSION_STATE_CLOSED\x10\x03*A\n" +
 	"\vPaymentCoin\x12\x1c\n" +
 	"\x18PAYMENT_COIN_UNSPECIFIED\x10\x00\x12\x14\n" +
-	"\x10PAYMENT_COIN_SUI\x10\x01*\xbd\x01\n" +
+	"\x10PAYMENT_COIN_SUI\x10\x01*\xe0\x01\n" +
 	"\x12PaymentEnvironment\x12#\n" +
 	"\x1fPAYMENT_ENVIRONMENT_UNSPECIFIED\x10\x00\x12\x1f\n" +
 	"\x1bPAYMENT_ENVIRONMENT_MAINNET\x10\x01\x12\x1e\n" +
 	"\x1aPAYMENT_ENVIRONMENT_DEVNET\x10\x02\x12\x1f\n" +
 	"\x1bPAYMENT_ENVIRONMENT_TESTNET\x10\x03\x12 \n" +
-	"\x1cPAYMENT_ENVIRONMENT_LOCALNET\x10\x04*a\n" +
+	"\x1cPAYMENT_ENVIRONMENT_LOCALNET\x10\x04\x12!\n" +
+	"\x1dPAYMENT_ENVIRONMENT_SIMULATED\x10\x05*\x97\x01\n" +
 	"\bJwtUsage\x12\x19\n" +
 	"\x15JWT_USAGE_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18JWT_USAGE_CREATE_SESSION\x10\x01\x12\x1c\n" +
-	"\x18JWT_USAGE_MANAGE_SESSION\x10\x022\xb9\t\n" +
-	"\x0fExchangeService\x12T\n" +
-	"\x05Login\x12\x19.exchange.v1.LoginRequest\x1a\x1a.exchange.v1.LoginResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/login\x12t\n" +
-	"\fGetChallenge\x12 .exchange.v1.GetChallengeRequest\x1a!.exchange.v1.GetChallengeResponse\"\x1f\xdaA\aaddress\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/challenge\x12\\\n" +
-	"\aDeposit\x12\x1b.exchange.v1.DepositRequest\x1a\x1c.exchange.v1.DepositResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/deposit\x12u\n" +
-	"\rPruneAccounts\x12!.exchange.v1.PruneAccountsRequest\x1a\".exchange.v1.PruneAccountsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/accounts:prune\x12\x90\x01\n" +
-	"\x0eCreateWithdraw\x12\".exchange.v1.CreateWithdrawRequest\x1a#.exchange.v1.CreateWithdrawResponse\"5\xdaA\n" +
-	"withdrawal\x82\xd3\xe4\x93\x02\":\n" +
-	"withdrawal\"\x14/v1/withdraws:create\x12\x9d\x01\n" +
-	"\x15BatchProcessWithdraws\x12).exchange.v1.BatchProcessWithdrawsRequest\x1a*.exchange.v1.BatchProcessWithdrawsResponse\"-\xdaA\x05limit\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/withdraws:batchProcess\x12\x91\x01\n" +
-	"\x12BatchMarkWithdraws\x12&.exchange.v1.BatchMarkWithdrawsRequest\x1a'.exchange.v1.BatchMarkWithdrawsResponse\"*\xdaA\x05limit\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/withdraws:batchMark\x12P\n" +
-	"\x04Ping\x12\x18.exchange.v1.PingRequest\x1a\x19.exchange.v1.PingResponse\"\x13\xdaA\x00\x82\xd3\xe4\x93\x02\n" +
-	"\x12\b/v1/ping\x12\x85\x01\n" +
-	"\x12ListPaymentMethods\x12&.exchange.v1.ListPaymentMethodsRequest\x1a'.exchange.v1.ListPaymentMethodsResponse\"\x1e\xdaA\x00\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/payment-methods\x12d\n" +
-	"\bBuyToken\x12\x1c.exchange.v1.BuyTokenRequest\x1a\x1d.exchange.v1.BuyTokenResponse\"\x1b\xdaA\x00\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/buy-tokenBFZDgithub.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1;exchangeb\x06proto3"
+	"\x18JWT_USAGE_MANAGE_SESSION\x10\x02\x12\x1e\n" +
+	"\x1aJWT_USAGE_PURCHASE_RECEIPT\x10\x03\x12\x14\n" +
+	"\x10JWT_USAGE_ACCESS\x10\x042\xc44\n" +
+	"\x0fExchangeService\x12Z\n" +
+	"\x05Login\x12\x19.exchange.v1.LoginRequest\x1a\x1a.exchange.v1.LoginResponse\"\x1a\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/login\x12\x8f\x01\n" +
+	"\x12LoginWithSignature\x12&.exchange.v1.LoginWithSignatureRequest\x1a'.exchange.v1.LoginWithSignatureResponse\"(\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/login:withSignature\x12{\n" +
+	"\rResetPassword\x12!.exchange.v1.ResetPasswordRequest\x1a\".exchange.v1.ResetPasswordResponse\"#\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/password:reset\x12\x80\x01\n" +
+	"\x0eRefreshSession\x12\".exchange.v1.RefreshSessionRequest\x1a#.exchange.v1.RefreshSessionResponse\"%\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/sessions:refresh\x12^\n" +
+	"\x06Logout\x12\x1a.exchange.v1.LogoutRequest\x1a\x1b.exchange.v1.LogoutResponse\"\x1b\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/logout\x12r\n" +
+	"\fListSessions\x12 .exchange.v1.ListSessionsRequest\x1a!.exchange.v1.ListSessionsResponse\"\x1d\xdaA\x00\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/sessions\x12\x97\x01\n" +
+	"\rRevokeSession\x12!.exchange.v1.RevokeSessionRequest\x1a\".exchange.v1.RevokeSessionResponse\"?\xdaA\x04name\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/{name=accounts/*/sessions/*}:revoke\x12\x82\x01\n" +
+	"\fCreateApiKey\x12 .exchange.v1.CreateApiKeyRequest\x1a!.exchange.v1.CreateApiKeyResponse\"-\xdaA\aapi_key\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02\x17:\aapi_key\"\f/v1/api-keys\x12o\n" +
+	"\vListApiKeys\x12\x1f.exchange.v1.ListApiKeysRequest\x1a .exchange.v1.ListApiKeysResponse\"\x1d\xdaA\x00\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/api-keys\x12\x94\x01\n" +
+	"\fRevokeApiKey\x12 .exchange.v1.RevokeApiKeyRequest\x1a!.exchange.v1.RevokeApiKeyResponse\"?\xdaA\x04name\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/{name=accounts/*/api-keys/*}:revoke\x12z\n" +
+	"\fGetChallenge\x12 .exchange.v1.GetChallengeRequest\x1a!.exchange.v1.GetChallengeResponse\"%\xdaA\aaddress\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/challenge\x12b\n" +
+	"\aDeposit\x12\x1b.exchange.v1.DepositRequest\x1a\x1c.exchange.v1.DepositResponse\"\x1c\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/deposit\x12{\n" +
+	"\rPruneAccounts\x12!.exchange.v1.PruneAccountsRequest\x1a\".exchange.v1.PruneAccountsResponse\"#\xa2\xbb\x18\x02\x10\x02\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/accounts:prune\x12\xa0\x01\n" +
+	"\x0eCreateWithdraw\x12\".exchange.v1.CreateWithdrawRequest\x1a#.exchange.v1.CreateWithdrawResponse\"E\xdaA\n" +
+	"withdrawal\xa2\xbb\x18\f\x10\x01\x1a\bwithdraw\x82\xd3\xe4\x93\x02\":\n" +
+	"withdrawal\"\x14/v1/withdraws:create\x12\xaa\x01\n" +
+	"\x13EstimateWithdrawFee\x12'.exchange.v1.EstimateWithdrawFeeRequest\x1a(.exchange.v1.EstimateWithdrawFeeResponse\"@\xdaA\fpriority_fee\xa2\xbb\x18\f\x10\x01\x1a\bwithdraw\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/withdraws:estimateFee\x12\xa3\x01\n" +
+	"\x15BatchProcessWithdraws\x12).exchange.v1.BatchProcessWithdrawsRequest\x1a*.exchange.v1.BatchProcessWithdrawsResponse\"3\xdaA\x05limit\xa2\xbb\x18\x02\x10\x02\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/withdraws:batchProcess\x12\x97\x01\n" +
+	"\x12BatchMarkWithdraws\x12&.exchange.v1.BatchMarkWithdrawsRequest\x1a'.exchange.v1.BatchMarkWithdrawsResponse\"0\xdaA\x05limit\xa2\xbb\x18\x02\x10\x02\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/withdraws:batchMark\x12\x80\x01\n" +
+	"\x0fGetWalletStatus\x12#.exchange.v1.GetWalletStatusRequest\x1a$.exchange.v1.GetWalletStatusResponse\"\"\xdaA\x00\xa2\xbb\x18\x02\x10\x02\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/wallet-status\x12V\n" +
+	"\x04Ping\x12\x18.exchange.v1.PingRequest\x1a\x19.exchange.v1.PingResponse\"\x19\xdaA\x00\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/ping\x12\x8b\x01\n" +
+	"\x12ListPaymentMethods\x12&.exchange.v1.ListPaymentMethodsRequest\x1a'.exchange.v1.ListPaymentMethodsResponse\"$\xdaA\x00\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/payment-methods\x12\x7f\n" +
+	"\x0fListSigningKeys\x12#.exchange.v1.ListSigningKeysRequest\x1a$.exchange.v1.ListSigningKeysResponse\"!\xdaA\x00\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/signing-keys\x12u\n" +
+	"\bBuyToken\x12\x1c.exchange.v1.BuyTokenRequest\x1a\x1d.exchange.v1.BuyTokenResponse\",\xdaA\x00\xa2\xbb\x18\r\x10\x01\x1a\tbuy-token\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/buy-token\x12\x93\x01\n" +
+	"\vRedeemToken\x12\x1f.exchange.v1.RedeemTokenRequest\x1a .exchange.v1.RedeemTokenResponse\"A\xdaA\x0etoken,quantity\xa2\xbb\x18\x10\x10\x01\x1a\fredeem-token\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/tokens:redeem\x12\x8c\x01\n" +
+	"\x0fIntrospectToken\x12#.exchange.v1.IntrospectTokenRequest\x1a$.exchange.v1.IntrospectTokenResponse\".\xdaA\x05token\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/tokens:introspect\x12\x87\x01\n" +
+	"\vRefundToken\x12\x1f.exchange.v1.RefundTokenRequest\x1a .exchange.v1.RefundTokenResponse\"5\xdaA\x05token\xa2\xbb\x18\r\x10\x01\x1a\tbuy-token\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/tokens:refund\x12\x8a\x01\n" +
+	"\x0fGetRefundPolicy\x12#.exchange.v1.GetRefundPolicyRequest\x1a\x19.exchange.v1.RefundPolicy\"7\xdaA\baudience\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02 \x12\x1e/v1/refund-policies/{audience}\x12\x97\x01\n" +
+	"\x12UpdateRefundPolicy\x12&.exchange.v1.UpdateRefundPolicyRequest\x1a\x19.exchange.v1.RefundPolicy\">\xdaA\rrefund_policy\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02\":\rrefund_policy2\x11/v1/refund-policy\x12\x86\x01\n" +
+	"\x0eGetTokenPolicy\x12\".exchange.v1.GetTokenPolicyRequest\x1a\x18.exchange.v1.TokenPolicy\"6\xdaA\baudience\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/token-policies/{audience}\x12\x91\x01\n" +
+	"\x11UpdateTokenPolicy\x12%.exchange.v1.UpdateTokenPolicyRequest\x1a\x18.exchange.v1.TokenPolicy\";\xdaA\ftoken_policy\xa2\xbb\x18\x02\x10\x01\x82\xd3\xe4\x93\x02 :\ftoken_policy2\x10/v1/token-policy\x12\x8c\x01\n" +
+	"\x12ListBlindTokenKeys\x12&.exchange.v1.ListBlindTokenKeysRequest\x1a'.exchange.v1.ListBlindTokenKeysResponse\"%\xdaA\x00\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/blind-token-keys\x12\xad\x01\n" +
+	"\x10IssueBlindTokens\x12$.exchange.v1.IssueBlindTokensRequest\x1a%.exchange.v1.IssueBlindTokensResponse\"L\xdaA\x17key_id,blinded_messages\xa2\xbb\x18\r\x10\x01\x1a\tbuy-token\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/blind-tokens:issue\x12\x9f\x01\n" +
+	"\x10RedeemBlindToken\x12$.exchange.v1.RedeemBlindTokenRequest\x1a%.exchange.v1.RedeemBlindTokenResponse\">\xdaA\x05token\xa2\xbb\x18\x10\x10\x01\x1a\fredeem-token\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/blind-tokens:redeem\x12\x97\x01\n" +
+	"\x15PruneSpentBlindTokens\x12).exchange.v1.PruneSpentBlindTokensRequest\x1a*.exchange.v1.PruneSpentBlindTokensResponse\"'\xa2\xbb\x18\x02\x10\x02\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/blind-tokens:prune\x12\xa9\x01\n" +
+	"\x19ListExpiredBlindTokenKeys\x12-.exchange.v1.ListExpiredBlindTokenKeysRequest\x1a..exchange.v1.ListExpiredBlindTokenKeysResponse\"-\xdaA\x00\xa2\xbb\x18\x02\x10\x02\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/blind-token-keys:expired\x12\xa8\x01\n" +
+	"\x14CreateServiceSession\x12(.exchange.v1.CreateServiceSessionRequest\x1a).exchange.v1.CreateServiceSessionResponse\";\xdaA\x05token\xa2\xbb\x18\x10\x10\x01\x1a\fredeem-token\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/service-sessions\x12\xc4\x01\n" +
+	"\x15ConsumeServiceSession\x12).exchange.v1.ConsumeServiceSessionRequest\x1a*.exchange.v1.ConsumeServiceSessionResponse\"T\xdaA\rname,quantity\xa2\xbb\x18\x10\x10\x01\x1a\fredeem-token\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/{name=service-sessions/*}:consume\x12\x9c\x01\n" +
+	"\x11GetServiceSession\x12%.exchange.v1.GetServiceSessionRequest\x1a&.exchange.v1.GetServiceSessionResponse\"8\xdaA\fmanage_token\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/service-sessions:get\x12\xaa\x01\n" +
+	"\x13TopUpServiceSession\x12'.exchange.v1.TopUpServiceSessionRequest\x1a(.exchange.v1.TopUpServiceSessionResponse\"@\xdaA\x12manage_token,token\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/service-sessions:topUp\x12\xa4\x01\n" +
+	"\x13PauseServiceSession\x12'.exchange.v1.PauseServiceSessionRequest\x1a(.exchange.v1.PauseServiceSessionResponse\":\xdaA\fmanage_token\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/service-sessions:pause\x12\xa8\x01\n" +
+	"\x14ResumeServiceSession\x12(.exchange.v1.ResumeServiceSessionRequest\x1a).exchange.v1.ResumeServiceSessionResponse\";\xdaA\fmanage_token\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/service-sessions:resume\x12\xa4\x01\n" +
+	"\x13CloseServiceSession\x12'.exchange.v1.CloseServiceSessionRequest\x1a(.exchange.v1.CloseServiceSessionResponse\":\xdaA\fmanage_token\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/service-sessions:close\x12\xa9\x01\n" +
+	"\x19SubmitConsumptionReceipts\x12-.exchange.v1.SubmitConsumptionReceiptsRequest\x1a..exchange.v1.SubmitConsumptionReceiptsResponse\"-\xdaA\breceipts\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/escrows:claim\x12|\n" +
+	"\vGetTreeHead\x12\x1f.exchange.v1.GetTreeHeadRequest\x1a\x1b.exchange.v1.SignedTreeHead\"/\xdaA\x00\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02 \x12\x1e/v1/transparency-log/tree-head\x12\x99\x01\n" +
+	"\x0eListLogEntries\x12\".exchange.v1.ListLogEntriesRequest\x1a#.exchange.v1.ListLogEntriesResponse\">\xdaA\x11start_index,limit\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/transparency-log/entries\x12\xad\x01\n" +
+	"\x11GetInclusionProof\x12%.exchange.v1.GetInclusionProofRequest\x1a&.exchange.v1.GetInclusionProofResponse\"I\xdaA\x14leaf_index,tree_size\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02&\x12$/v1/transparency-log/inclusion-proof\x12\xb2\x01\n" +
+	"\x13GetConsistencyProof\x12'.exchange.v1.GetConsistencyProofRequest\x1a(.exchange.v1.GetConsistencyProofResponse\"H\xdaA\x11old_size,new_size\xa2\xbb\x18\x02\b\x01\x82\xd3\xe4\x93\x02(\x12&/v1/transparency-log/consistency-proofBFZDgithub.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1;exchangeb\x06proto3"
 
 var (
 	file_exchange_v1_exchange_proto_rawDescOnce sync.Once
@@ -1612,80 +6896,309 @@ func file_exchange_v1_exchange_proto_rawDescGZIP() []byte {
 	return file_exchange_v1_exchange_proto_rawDescData
 }
 
-var file_exchange_v1_exchange_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_exchange_v1_exchange_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_exchange_v1_exchange_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_exchange_v1_exchange_proto_msgTypes = make([]protoimpl.MessageInfo, 112)
 var file_exchange_v1_exchange_proto_goTypes = []any{
-	(PaymentCoin)(0),                      // 0: exchange.v1.PaymentCoin
-	(PaymentEnvironment)(0),               // 1: exchange.v1.PaymentEnvironment
-	(JwtUsage)(0),                         // 2: exchange.v1.JwtUsage
-	(*BuyTokenRequest)(nil),               // 3: exchange.v1.BuyTokenRequest
-	(*BuyTokenResponse)(nil),              // 4: exchange.v1.BuyTokenResponse
-	(*ListPaymentMethodsRequest)(nil),     // 5: exchange.v1.ListPaymentMethodsRequest
-	(*ListPaymentMethodsResponse)(nil),    // 6: exchange.v1.ListPaymentMethodsResponse
-	(*PaymentMethod)(nil),                 // 7: exchange.v1.PaymentMethod
-	(*PingRequest)(nil),                   // 8: exchange.v1.PingRequest
-	(*PingResponse)(nil),                  // 9: exchange.v1.PingResponse
-	(*BatchMarkWithdrawsRequest)(nil),     // 10: exchange.v1.BatchMarkWithdrawsRequest
-	(*BatchMarkWithdrawsResponse)(nil),    // 11: exchange.v1.BatchMarkWithdrawsResponse
-	(*BatchProcessWithdrawsRequest)(nil),  // 12: exchange.v1.BatchProcessWithdrawsRequest
-	(*BatchProcessWithdrawsResponse)(nil), // 13: exchange.v1.BatchProcessWithdrawsResponse
-	(*CancelWithdrawRequest)(nil),         // 14: exchange.v1.CancelWithdrawRequest
-	(*GetWithdrawRequest)(nil),            // 15: exchange.v1.GetWithdrawRequest
-	(*Withdrawal)(nil),                    // 16: exchange.v1.Withdrawal
-	(*CreateWithdrawRequest)(nil),         // 17: exchange.v1.CreateWithdrawRequest
-	(*CreateWithdrawResponse)(nil),        // 18: exchange.v1.CreateWithdrawResponse
-	(*PruneAccountsRequest)(nil),          // 19: exchange.v1.PruneAccountsRequest
-	(*PruneAccountsResponse)(nil),         // 20: exchange.v1.PruneAccountsResponse
-	(*SuiDepositProof)(nil),               // 21: exchange.v1.SuiDepositProof
-	(*DepositRequest)(nil),                // 22: exchange.v1.DepositRequest
-	(*DepositResponse)(nil),               // 23: exchange.v1.DepositResponse
-	(*GetChallengeRequest)(nil),           // 24: exchange.v1.GetChallengeRequest
-	(*GetChallengeResponse)(nil),          // 25: exchange.v1.GetChallengeResponse
-	(*Account)(nil),                       // 26: exchange.v1.Account
-	(*LoginRequest)(nil),                  // 27: exchange.v1.LoginRequest
-	(*LoginResponse)(nil),                 // 28: exchange.v1.LoginResponse
-	(*timestamppb.Timestamp)(nil),         // 29: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),           // 30: google.protobuf.Duration
+	(TokenFormat)(0),                          // 0: exchange.v1.TokenFormat
+	(RefundMode)(0),                           // 1: exchange.v1.RefundMode
+	(ServiceSessionState)(0),                  // 2: exchange.v1.ServiceSessionState
+	(PaymentCoin)(0),                          // 3: exchange.v1.PaymentCoin
+	(PaymentEnvironment)(0),                   // 4: exchange.v1.PaymentEnvironment
+	(JwtUsage)(0),                             // 5: exchange.v1.JwtUsage
+	(*BuyTokenRequest)(nil),                   // 6: exchange.v1.BuyTokenRequest
+	(*BuyTokenResponse)(nil),                  // 7: exchange.v1.BuyTokenResponse
+	(*PurchasedToken)(nil),                    // 8: exchange.v1.PurchasedToken
+	(*RedeemTokenRequest)(nil),                // 9: exchange.v1.RedeemTokenRequest
+	(*RedeemTokenResponse)(nil),               // 10: exchange.v1.RedeemTokenResponse
+	(*IntrospectTokenRequest)(nil),            // 11: exchange.v1.IntrospectTokenRequest
+	(*IntrospectTokenResponse)(nil),           // 12: exchange.v1.IntrospectTokenResponse
+	(*TokenStatus)(nil),                       // 13: exchange.v1.TokenStatus
+	(*RefundTokenRequest)(nil),                // 14: exchange.v1.RefundTokenRequest
+	(*RefundTokenResponse)(nil),               // 15: exchange.v1.RefundTokenResponse
+	(*RefundPolicy)(nil),                      // 16: exchange.v1.RefundPolicy
+	(*GetRefundPolicyRequest)(nil),            // 17: exchange.v1.GetRefundPolicyRequest
+	(*UpdateRefundPolicyRequest)(nil),         // 18: exchange.v1.UpdateRefundPolicyRequest
+	(*TokenPolicy)(nil),                       // 19: exchange.v1.TokenPolicy
+	(*GetTokenPolicyRequest)(nil),             // 20: exchange.v1.GetTokenPolicyRequest
+	(*UpdateTokenPolicyRequest)(nil),          // 21: exchange.v1.UpdateTokenPolicyRequest
+	(*BlindTokenKey)(nil),                     // 22: exchange.v1.BlindTokenKey
+	(*ListBlindTokenKeysRequest)(nil),         // 23: exchange.v1.ListBlindTokenKeysRequest
+	(*ListBlindTokenKeysResponse)(nil),        // 24: exchange.v1.ListBlindTokenKeysResponse
+	(*IssueBlindTokensRequest)(nil),           // 25: exchange.v1.IssueBlindTokensRequest
+	(*IssueBlindTokensResponse)(nil),          // 26: exchange.v1.IssueBlindTokensResponse
+	(*RedeemBlindTokenRequest)(nil),           // 27: exchange.v1.RedeemBlindTokenRequest
+	(*RedeemBlindTokenResponse)(nil),          // 28: exchange.v1.RedeemBlindTokenResponse
+	(*PruneSpentBlindTokensRequest)(nil),      // 29: exchange.v1.PruneSpentBlindTokensRequest
+	(*PruneSpentBlindTokensResponse)(nil),     // 30: exchange.v1.PruneSpentBlindTokensResponse
+	(*ExpiredBlindTokenKey)(nil),              // 31: exchange.v1.ExpiredBlindTokenKey
+	(*ListExpiredBlindTokenKeysRequest)(nil),  // 32: exchange.v1.ListExpiredBlindTokenKeysRequest
+	(*ListExpiredBlindTokenKeysResponse)(nil), // 33: exchange.v1.ListExpiredBlindTokenKeysResponse
+	(*ServiceSession)(nil),                    // 34: exchange.v1.ServiceSession
+	(*CreateServiceSessionRequest)(nil),       // 35: exchange.v1.CreateServiceSessionRequest
+	(*CreateServiceSessionResponse)(nil),      // 36: exchange.v1.CreateServiceSessionResponse
+	(*ConsumeServiceSessionRequest)(nil),      // 37: exchange.v1.ConsumeServiceSessionRequest
+	(*ConsumeServiceSessionResponse)(nil),     // 38: exchange.v1.ConsumeServiceSessionResponse
+	(*GetServiceSessionRequest)(nil),          // 39: exchange.v1.GetServiceSessionRequest
+	(*GetServiceSessionResponse)(nil),         // 40: exchange.v1.GetServiceSessionResponse
+	(*TopUpServiceSessionRequest)(nil),        // 41: exchange.v1.TopUpServiceSessionRequest
+	(*TopUpServiceSessionResponse)(nil),       // 42: exchange.v1.TopUpServiceSessionResponse
+	(*PauseServiceSessionRequest)(nil),        // 43: exchange.v1.PauseServiceSessionRequest
+	(*PauseServiceSessionResponse)(nil),       // 44: exchange.v1.PauseServiceSessionResponse
+	(*ResumeServiceSessionRequest)(nil),       // 45: exchange.v1.ResumeServiceSessionRequest
+	(*ResumeServiceSessionResponse)(nil),      // 46: exchange.v1.ResumeServiceSessionResponse
+	(*CloseServiceSessionRequest)(nil),        // 47: exchange.v1.CloseServiceSessionRequest
+	(*CloseServiceSessionResponse)(nil),       // 48: exchange.v1.CloseServiceSessionResponse
+	(*Escrow)(nil),                            // 49: exchange.v1.Escrow
+	(*ConsumptionReceipt)(nil),                // 50: exchange.v1.ConsumptionReceipt
+	(*SignedConsumptionReceipt)(nil),          // 51: exchange.v1.SignedConsumptionReceipt
+	(*SubmitConsumptionReceiptsRequest)(nil),  // 52: exchange.v1.SubmitConsumptionReceiptsRequest
+	(*SubmitConsumptionReceiptsResponse)(nil), // 53: exchange.v1.SubmitConsumptionReceiptsResponse
+	(*LogEntry)(nil),                          // 54: exchange.v1.LogEntry
+	(*SignedTreeHead)(nil),                    // 55: exchange.v1.SignedTreeHead
+	(*LogInclusion)(nil),                      // 56: exchange.v1.LogInclusion
+	(*GetTreeHeadRequest)(nil),                // 57: exchange.v1.GetTreeHeadRequest
+	(*ListLogEntriesRequest)(nil),             // 58: exchange.v1.ListLogEntriesRequest
+	(*ListLogEntriesResponse)(nil),            // 59: exchange.v1.ListLogEntriesResponse
+	(*GetInclusionProofRequest)(nil),          // 60: exchange.v1.GetInclusionProofRequest
+	(*GetInclusionProofResponse)(nil),         // 61: exchange.v1.GetInclusionProofResponse
+	(*GetConsistencyProofRequest)(nil),        // 62: exchange.v1.GetConsistencyProofRequest
+	(*GetConsistencyProofResponse)(nil),       // 63: exchange.v1.GetConsistencyProofResponse
+	(*ListPaymentMethodsRequest)(nil),         // 64: exchange.v1.ListPaymentMethodsRequest
+	(*ListPaymentMethodsResponse)(nil),        // 65: exchange.v1.ListPaymentMethodsResponse
+	(*ListSigningKeysRequest)(nil),            // 66: exchange.v1.ListSigningKeysRequest
+	(*ListSigningKeysResponse)(nil),           // 67: exchange.v1.ListSigningKeysResponse
+	(*SigningKey)(nil),                        // 68: exchange.v1.SigningKey
+	(*PaymentMethod)(nil),                     // 69: exchange.v1.PaymentMethod
+	(*GetWalletStatusRequest)(nil),            // 70: exchange.v1.GetWalletStatusRequest
+	(*GetWalletStatusResponse)(nil),           // 71: exchange.v1.GetWalletStatusResponse
+	(*WalletStatus)(nil),                      // 72: exchange.v1.WalletStatus
+	(*PingRequest)(nil),                       // 73: exchange.v1.PingRequest
+	(*PingResponse)(nil),                      // 74: exchange.v1.PingResponse
+	(*BatchMarkWithdrawsRequest)(nil),         // 75: exchange.v1.BatchMarkWithdrawsRequest
+	(*BatchMarkWithdrawsResponse)(nil),        // 76: exchange.v1.BatchMarkWithdrawsResponse
+	(*BatchProcessWithdrawsRequest)(nil),      // 77: exchange.v1.BatchProcessWithdrawsRequest
+	(*BatchProcessWithdrawsResponse)(nil),     // 78: exchange.v1.BatchProcessWithdrawsResponse
+	(*CancelWithdrawRequest)(nil),             // 79: exchange.v1.CancelWithdrawRequest
+	(*GetWithdrawRequest)(nil),                // 80: exchange.v1.GetWithdrawRequest
+	(*Withdrawal)(nil),                        // 81: exchange.v1.Withdrawal
+	(*EstimateWithdrawFeeRequest)(nil),        // 82: exchange.v1.EstimateWithdrawFeeRequest
+	(*FeePercentile)(nil),                     // 83: exchange.v1.FeePercentile
+	(*EstimateWithdrawFeeResponse)(nil),       // 84: exchange.v1.EstimateWithdrawFeeResponse
+	(*CreateWithdrawRequest)(nil),             // 85: exchange.v1.CreateWithdrawRequest
+	(*CreateWithdrawResponse)(nil),            // 86: exchange.v1.CreateWithdrawResponse
+	(*PruneAccountsRequest)(nil),              // 87: exchange.v1.PruneAccountsRequest
+	(*PruneAccountsResponse)(nil),             // 88: exchange.v1.PruneAccountsResponse
+	(*SuiDepositProof)(nil),                   // 89: exchange.v1.SuiDepositProof
+	(*DepositRequest)(nil),                    // 90: exchange.v1.DepositRequest
+	(*DepositResponse)(nil),                   // 91: exchange.v1.DepositResponse
+	(*GetChallengeRequest)(nil),               // 92: exchange.v1.GetChallengeRequest
+	(*GetChallengeResponse)(nil),              // 93: exchange.v1.GetChallengeResponse
+	(*Account)(nil),                           // 94: exchange.v1.Account
+	(*LoginRequest)(nil),                      // 95: exchange.v1.LoginRequest
+	(*LoginResponse)(nil),                     // 96: exchange.v1.LoginResponse
+	(*SuiSignatureProof)(nil),                 // 97: exchange.v1.SuiSignatureProof
+	(*LoginWithSignatureRequest)(nil),         // 98: exchange.v1.LoginWithSignatureRequest
+	(*LoginWithSignatureResponse)(nil),        // 99: exchange.v1.LoginWithSignatureResponse
+	(*Session)(nil),                           // 100: exchange.v1.Session
+	(*RefreshSessionRequest)(nil),             // 101: exchange.v1.RefreshSessionRequest
+	(*RefreshSessionResponse)(nil),            // 102: exchange.v1.RefreshSessionResponse
+	(*LogoutRequest)(nil),                     // 103: exchange.v1.LogoutRequest
+	(*LogoutResponse)(nil),                    // 104: exchange.v1.LogoutResponse
+	(*ListSessionsRequest)(nil),               // 105: exchange.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),              // 106: exchange.v1.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),              // 107: exchange.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),             // 108: exchange.v1.RevokeSessionResponse
+	(*ApiKey)(nil),                            // 109: exchange.v1.ApiKey
+	(*CreateApiKeyRequest)(nil),               // 110: exchange.v1.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil),              // 111: exchange.v1.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),                // 112: exchange.v1.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),               // 113: exchange.v1.ListApiKeysResponse
+	(*RevokeApiKeyRequest)(nil),               // 114: exchange.v1.RevokeApiKeyRequest
+	(*RevokeApiKeyResponse)(nil),              // 115: exchange.v1.RevokeApiKeyResponse
+	(*ResetPasswordRequest)(nil),              // 116: exchange.v1.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),             // 117: exchange.v1.ResetPasswordResponse
+	(*durationpb.Duration)(nil),               // 118: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),             // 119: google.protobuf.Timestamp
 }
 var file_exchange_v1_exchange_proto_depIdxs = []int32{
-	7,  // 0: exchange.v1.ListPaymentMethodsResponse.payment_methods:type_name -> exchange.v1.PaymentMethod
-	0,  // 1: exchange.v1.PaymentMethod.coin:type_name -> exchange.v1.PaymentCoin
-	1,  // 2: exchange.v1.PaymentMethod.environment:type_name -> exchange.v1.PaymentEnvironment
-	16, // 3: exchange.v1.CreateWithdrawRequest.withdrawal:type_name -> exchange.v1.Withdrawal
-	16, // 4: exchange.v1.CreateWithdrawResponse.withdrawal:type_name -> exchange.v1.Withdrawal
-	26, // 5: exchange.v1.PruneAccountsResponse.accounts:type_name -> exchange.v1.Account
-	29, // 6: exchange.v1.SuiDepositProof.start_time:type_name -> google.protobuf.Timestamp
-	30, // 7: exchange.v1.DepositRequest.ttl:type_name -> google.protobuf.Duration
-	21, // 8: exchange.v1.DepositRequest.proof:type_name -> exchange.v1.SuiDepositProof
-	26, // 9: exchange.v1.DepositResponse.account:type_name -> exchange.v1.Account
-	29, // 10: exchange.v1.GetChallengeResponse.start_time:type_name -> google.protobuf.Timestamp
-	29, // 11: exchange.v1.Account.expire_time:type_name -> google.protobuf.Timestamp
-	26, // 12: exchange.v1.LoginResponse.account:type_name -> exchange.v1.Account
-	27, // 13: exchange.v1.ExchangeService.Login:input_type -> exchange.v1.LoginRequest
-	24, // 14: exchange.v1.ExchangeService.GetChallenge:input_type -> exchange.v1.GetChallengeRequest
-	22, // 15: exchange.v1.ExchangeService.Deposit:input_type -> exchange.v1.DepositRequest
-	19, // 16: exchange.v1.ExchangeService.PruneAccounts:input_type -> exchange.v1.PruneAccountsRequest
-	17, // 17: exchange.v1.ExchangeService.CreateWithdraw:input_type -> exchange.v1.CreateWithdrawRequest
-	12, // 18: exchange.v1.ExchangeService.BatchProcessWithdraws:input_type -> exchange.v1.BatchProcessWithdrawsRequest
-	10, // 19: exchange.v1.ExchangeService.BatchMarkWithdraws:input_type -> exchange.v1.BatchMarkWithdrawsRequest
-	8,  // 20: exchange.v1.ExchangeService.Ping:input_type -> exchange.v1.PingRequest
-	5,  // 21: exchange.v1.ExchangeService.ListPaymentMethods:input_type -> exchange.v1.ListPaymentMethodsRequest
-	3,  // 22: exchange.v1.ExchangeService.BuyToken:input_type -> exchange.v1.BuyTokenRequest
-	28, // 23: exchange.v1.ExchangeService.Login:output_type -> exchange.v1.LoginResponse
-	25, // 24: exchange.v1.ExchangeService.GetChallenge:output_type -> exchange.v1.GetChallengeResponse
-	23, // 25: exchange.v1.ExchangeService.Deposit:output_type -> exchange.v1.DepositResponse
-	20, // 26: exchange.v1.ExchangeService.PruneAccounts:output_type -> exchange.v1.PruneAccountsResponse
-	18, // 27: exchange.v1.ExchangeService.CreateWithdraw:output_type -> exchange.v1.CreateWithdrawResponse
-	13, // 28: exchange.v1.ExchangeService.BatchProcessWithdraws:output_type -> exchange.v1.BatchProcessWithdrawsResponse
-	11, // 29: exchange.v1.ExchangeService.BatchMarkWithdraws:output_type -> exchange.v1.BatchMarkWithdrawsResponse
-	9,  // 30: exchange.v1.ExchangeService.Ping:output_type -> exchange.v1.PingResponse
-	6,  // 31: exchange.v1.ExchangeService.ListPaymentMethods:output_type -> exchange.v1.ListPaymentMethodsResponse
-	4,  // 32: exchange.v1.ExchangeService.BuyToken:output_type -> exchange.v1.BuyTokenResponse
-	23, // [23:33] is the sub-list for method output_type
-	13, // [13:23] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	0,   // 0: exchange.v1.BuyTokenRequest.format:type_name -> exchange.v1.TokenFormat
+	118, // 1: exchange.v1.BuyTokenRequest.ttl:type_name -> google.protobuf.Duration
+	56,  // 2: exchange.v1.BuyTokenResponse.log_inclusion:type_name -> exchange.v1.LogInclusion
+	8,   // 3: exchange.v1.BuyTokenResponse.tokens:type_name -> exchange.v1.PurchasedToken
+	56,  // 4: exchange.v1.PurchasedToken.log_inclusion:type_name -> exchange.v1.LogInclusion
+	13,  // 5: exchange.v1.RedeemTokenResponse.token_status:type_name -> exchange.v1.TokenStatus
+	13,  // 6: exchange.v1.IntrospectTokenResponse.token_status:type_name -> exchange.v1.TokenStatus
+	119, // 7: exchange.v1.TokenStatus.expire_time:type_name -> google.protobuf.Timestamp
+	119, // 8: exchange.v1.TokenStatus.revoke_time:type_name -> google.protobuf.Timestamp
+	13,  // 9: exchange.v1.RefundTokenResponse.token_status:type_name -> exchange.v1.TokenStatus
+	1,   // 10: exchange.v1.RefundPolicy.mode:type_name -> exchange.v1.RefundMode
+	118, // 11: exchange.v1.RefundPolicy.refund_window:type_name -> google.protobuf.Duration
+	119, // 12: exchange.v1.RefundPolicy.update_time:type_name -> google.protobuf.Timestamp
+	16,  // 13: exchange.v1.UpdateRefundPolicyRequest.refund_policy:type_name -> exchange.v1.RefundPolicy
+	118, // 14: exchange.v1.TokenPolicy.max_token_ttl:type_name -> google.protobuf.Duration
+	119, // 15: exchange.v1.TokenPolicy.update_time:type_name -> google.protobuf.Timestamp
+	19,  // 16: exchange.v1.UpdateTokenPolicyRequest.token_policy:type_name -> exchange.v1.TokenPolicy
+	119, // 17: exchange.v1.BlindTokenKey.issue_end_time:type_name -> google.protobuf.Timestamp
+	119, // 18: exchange.v1.BlindTokenKey.expire_time:type_name -> google.protobuf.Timestamp
+	22,  // 19: exchange.v1.ListBlindTokenKeysResponse.blind_token_keys:type_name -> exchange.v1.BlindTokenKey
+	119, // 20: exchange.v1.ExpiredBlindTokenKey.expire_time:type_name -> google.protobuf.Timestamp
+	31,  // 21: exchange.v1.ListExpiredBlindTokenKeysResponse.expired_blind_token_keys:type_name -> exchange.v1.ExpiredBlindTokenKey
+	2,   // 22: exchange.v1.ServiceSession.state:type_name -> exchange.v1.ServiceSessionState
+	119, // 23: exchange.v1.ServiceSession.create_time:type_name -> google.protobuf.Timestamp
+	119, // 24: exchange.v1.ServiceSession.update_time:type_name -> google.protobuf.Timestamp
+	119, // 25: exchange.v1.ServiceSession.expire_time:type_name -> google.protobuf.Timestamp
+	34,  // 26: exchange.v1.CreateServiceSessionResponse.service_session:type_name -> exchange.v1.ServiceSession
+	34,  // 27: exchange.v1.ConsumeServiceSessionResponse.service_session:type_name -> exchange.v1.ServiceSession
+	34,  // 28: exchange.v1.GetServiceSessionResponse.service_session:type_name -> exchange.v1.ServiceSession
+	34,  // 29: exchange.v1.TopUpServiceSessionResponse.service_session:type_name -> exchange.v1.ServiceSession
+	34,  // 30: exchange.v1.PauseServiceSessionResponse.service_session:type_name -> exchange.v1.ServiceSession
+	34,  // 31: exchange.v1.ResumeServiceSessionResponse.service_session:type_name -> exchange.v1.ServiceSession
+	34,  // 32: exchange.v1.CloseServiceSessionResponse.service_session:type_name -> exchange.v1.ServiceSession
+	119, // 33: exchange.v1.Escrow.expire_time:type_name -> google.protobuf.Timestamp
+	119, // 34: exchange.v1.Escrow.settle_time:type_name -> google.protobuf.Timestamp
+	119, // 35: exchange.v1.ConsumptionReceipt.issue_time:type_name -> google.protobuf.Timestamp
+	51,  // 36: exchange.v1.SubmitConsumptionReceiptsRequest.receipts:type_name -> exchange.v1.SignedConsumptionReceipt
+	49,  // 37: exchange.v1.SubmitConsumptionReceiptsResponse.escrows:type_name -> exchange.v1.Escrow
+	119, // 38: exchange.v1.LogEntry.issue_time:type_name -> google.protobuf.Timestamp
+	119, // 39: exchange.v1.SignedTreeHead.timestamp:type_name -> google.protobuf.Timestamp
+	54,  // 40: exchange.v1.LogInclusion.entry:type_name -> exchange.v1.LogEntry
+	55,  // 41: exchange.v1.LogInclusion.tree_head:type_name -> exchange.v1.SignedTreeHead
+	54,  // 42: exchange.v1.ListLogEntriesResponse.entries:type_name -> exchange.v1.LogEntry
+	69,  // 43: exchange.v1.ListPaymentMethodsResponse.payment_methods:type_name -> exchange.v1.PaymentMethod
+	68,  // 44: exchange.v1.ListSigningKeysResponse.signing_keys:type_name -> exchange.v1.SigningKey
+	119, // 45: exchange.v1.SigningKey.activate_time:type_name -> google.protobuf.Timestamp
+	119, // 46: exchange.v1.SigningKey.retire_time:type_name -> google.protobuf.Timestamp
+	3,   // 47: exchange.v1.PaymentMethod.coin:type_name -> exchange.v1.PaymentCoin
+	4,   // 48: exchange.v1.PaymentMethod.environment:type_name -> exchange.v1.PaymentEnvironment
+	72,  // 49: exchange.v1.GetWalletStatusResponse.wallet_status:type_name -> exchange.v1.WalletStatus
+	72,  // 50: exchange.v1.GetWalletStatusResponse.wallet_statuses:type_name -> exchange.v1.WalletStatus
+	119, // 51: exchange.v1.WalletStatus.last_sweep_time:type_name -> google.protobuf.Timestamp
+	119, // 52: exchange.v1.WalletStatus.last_check_time:type_name -> google.protobuf.Timestamp
+	83,  // 53: exchange.v1.EstimateWithdrawFeeResponse.fee_percentiles:type_name -> exchange.v1.FeePercentile
+	81,  // 54: exchange.v1.CreateWithdrawRequest.withdrawal:type_name -> exchange.v1.Withdrawal
+	81,  // 55: exchange.v1.CreateWithdrawResponse.withdrawal:type_name -> exchange.v1.Withdrawal
+	94,  // 56: exchange.v1.PruneAccountsResponse.accounts:type_name -> exchange.v1.Account
+	119, // 57: exchange.v1.SuiDepositProof.start_time:type_name -> google.protobuf.Timestamp
+	118, // 58: exchange.v1.DepositRequest.ttl:type_name -> google.protobuf.Duration
+	89,  // 59: exchange.v1.DepositRequest.proof:type_name -> exchange.v1.SuiDepositProof
+	94,  // 60: exchange.v1.DepositResponse.account:type_name -> exchange.v1.Account
+	119, // 61: exchange.v1.GetChallengeResponse.start_time:type_name -> google.protobuf.Timestamp
+	119, // 62: exchange.v1.Account.expire_time:type_name -> google.protobuf.Timestamp
+	94,  // 63: exchange.v1.LoginResponse.account:type_name -> exchange.v1.Account
+	119, // 64: exchange.v1.SuiSignatureProof.start_time:type_name -> google.protobuf.Timestamp
+	97,  // 65: exchange.v1.LoginWithSignatureRequest.proof:type_name -> exchange.v1.SuiSignatureProof
+	94,  // 66: exchange.v1.LoginWithSignatureResponse.account:type_name -> exchange.v1.Account
+	119, // 67: exchange.v1.Session.create_time:type_name -> google.protobuf.Timestamp
+	119, // 68: exchange.v1.Session.refresh_time:type_name -> google.protobuf.Timestamp
+	119, // 69: exchange.v1.Session.expire_time:type_name -> google.protobuf.Timestamp
+	100, // 70: exchange.v1.ListSessionsResponse.sessions:type_name -> exchange.v1.Session
+	119, // 71: exchange.v1.ApiKey.create_time:type_name -> google.protobuf.Timestamp
+	119, // 72: exchange.v1.ApiKey.expire_time:type_name -> google.protobuf.Timestamp
+	119, // 73: exchange.v1.ApiKey.revoke_time:type_name -> google.protobuf.Timestamp
+	109, // 74: exchange.v1.CreateApiKeyRequest.api_key:type_name -> exchange.v1.ApiKey
+	109, // 75: exchange.v1.CreateApiKeyResponse.api_key:type_name -> exchange.v1.ApiKey
+	109, // 76: exchange.v1.ListApiKeysResponse.api_keys:type_name -> exchange.v1.ApiKey
+	109, // 77: exchange.v1.RevokeApiKeyResponse.api_key:type_name -> exchange.v1.ApiKey
+	97,  // 78: exchange.v1.ResetPasswordRequest.proof:type_name -> exchange.v1.SuiSignatureProof
+	94,  // 79: exchange.v1.ResetPasswordResponse.account:type_name -> exchange.v1.Account
+	119, // 80: exchange.v1.ResetPasswordResponse.withdraw_cooldown_until:type_name -> google.protobuf.Timestamp
+	95,  // 81: exchange.v1.ExchangeService.Login:input_type -> exchange.v1.LoginRequest
+	98,  // 82: exchange.v1.ExchangeService.LoginWithSignature:input_type -> exchange.v1.LoginWithSignatureRequest
+	116, // 83: exchange.v1.ExchangeService.ResetPassword:input_type -> exchange.v1.ResetPasswordRequest
+	101, // 84: exchange.v1.ExchangeService.RefreshSession:input_type -> exchange.v1.RefreshSessionRequest
+	103, // 85: exchange.v1.ExchangeService.Logout:input_type -> exchange.v1.LogoutRequest
+	105, // 86: exchange.v1.ExchangeService.ListSessions:input_type -> exchange.v1.ListSessionsRequest
+	107, // 87: exchange.v1.ExchangeService.RevokeSession:input_type -> exchange.v1.RevokeSessionRequest
+	110, // 88: exchange.v1.ExchangeService.CreateApiKey:input_type -> exchange.v1.CreateApiKeyRequest
+	112, // 89: exchange.v1.ExchangeService.ListApiKeys:input_type -> exchange.v1.ListApiKeysRequest
+	114, // 90: exchange.v1.ExchangeService.RevokeApiKey:input_type -> exchange.v1.RevokeApiKeyRequest
+	92,  // 91: exchange.v1.ExchangeService.GetChallenge:input_type -> exchange.v1.GetChallengeRequest
+	90,  // 92: exchange.v1.ExchangeService.Deposit:input_type -> exchange.v1.DepositRequest
+	87,  // 93: exchange.v1.ExchangeService.PruneAccounts:input_type -> exchange.v1.PruneAccountsRequest
+	85,  // 94: exchange.v1.ExchangeService.CreateWithdraw:input_type -> exchange.v1.CreateWithdrawRequest
+	82,  // 95: exchange.v1.ExchangeService.EstimateWithdrawFee:input_type -> exchange.v1.EstimateWithdrawFeeRequest
+	77,  // 96: exchange.v1.ExchangeService.BatchProcessWithdraws:input_type -> exchange.v1.BatchProcessWithdrawsRequest
+	75,  // 97: exchange.v1.ExchangeService.BatchMarkWithdraws:input_type -> exchange.v1.BatchMarkWithdrawsRequest
+	70,  // 98: exchange.v1.ExchangeService.GetWalletStatus:input_type -> exchange.v1.GetWalletStatusRequest
+	73,  // 99: exchange.v1.ExchangeService.Ping:input_type -> exchange.v1.PingRequest
+	64,  // 100: exchange.v1.ExchangeService.ListPaymentMethods:input_type -> exchange.v1.ListPaymentMethodsRequest
+	66,  // 101: exchange.v1.ExchangeService.ListSigningKeys:input_type -> exchange.v1.ListSigningKeysRequest
+	6,   // 102: exchange.v1.ExchangeService.BuyToken:input_type -> exchange.v1.BuyTokenRequest
+	9,   // 103: exchange.v1.ExchangeService.RedeemToken:input_type -> exchange.v1.RedeemTokenRequest
+	11,  // 104: exchange.v1.ExchangeService.IntrospectToken:input_type -> exchange.v1.IntrospectTokenRequest
+	14,  // 105: exchange.v1.ExchangeService.RefundToken:input_type -> exchange.v1.RefundTokenRequest
+	17,  // 106: exchange.v1.ExchangeService.GetRefundPolicy:input_type -> exchange.v1.GetRefundPolicyRequest
+	18,  // 107: exchange.v1.ExchangeService.UpdateRefundPolicy:input_type -> exchange.v1.UpdateRefundPolicyRequest
+	20,  // 108: exchange.v1.ExchangeService.GetTokenPolicy:input_type -> exchange.v1.GetTokenPolicyRequest
+	21,  // 109: exchange.v1.ExchangeService.UpdateTokenPolicy:input_type -> exchange.v1.UpdateTokenPolicyRequest
+	23,  // 110: exchange.v1.ExchangeService.ListBlindTokenKeys:input_type -> exchange.v1.ListBlindTokenKeysRequest
+	25,  // 111: exchange.v1.ExchangeService.IssueBlindTokens:input_type -> exchange.v1.IssueBlindTokensRequest
+	27,  // 112: exchange.v1.ExchangeService.RedeemBlindToken:input_type -> exchange.v1.RedeemBlindTokenRequest
+	29,  // 113: exchange.v1.ExchangeService.PruneSpentBlindTokens:input_type -> exchange.v1.PruneSpentBlindTokensRequest
+	32,  // 114: exchange.v1.ExchangeService.ListExpiredBlindTokenKeys:input_type -> exchange.v1.ListExpiredBlindTokenKeysRequest
+	35,  // 115: exchange.v1.ExchangeService.CreateServiceSession:input_type -> exchange.v1.CreateServiceSessionRequest
+	37,  // 116: exchange.v1.ExchangeService.ConsumeServiceSession:input_type -> exchange.v1.ConsumeServiceSessionRequest
+	39,  // 117: exchange.v1.ExchangeService.GetServiceSession:input_type -> exchange.v1.GetServiceSessionRequest
+	41,  // 118: exchange.v1.ExchangeService.TopUpServiceSession:input_type -> exchange.v1.TopUpServiceSessionRequest
+	43,  // 119: exchange.v1.ExchangeService.PauseServiceSession:input_type -> exchange.v1.PauseServiceSessionRequest
+	45,  // 120: exchange.v1.ExchangeService.ResumeServiceSession:input_type -> exchange.v1.ResumeServiceSessionRequest
+	47,  // 121: exchange.v1.ExchangeService.CloseServiceSession:input_type -> exchange.v1.CloseServiceSessionRequest
+	52,  // 122: exchange.v1.ExchangeService.SubmitConsumptionReceipts:input_type -> exchange.v1.SubmitConsumptionReceiptsRequest
+	57,  // 123: exchange.v1.ExchangeService.GetTreeHead:input_type -> exchange.v1.GetTreeHeadRequest
+	58,  // 124: exchange.v1.ExchangeService.ListLogEntries:input_type -> exchange.v1.ListLogEntriesRequest
+	60,  // 125: exchange.v1.ExchangeService.GetInclusionProof:input_type -> exchange.v1.GetInclusionProofRequest
+	62,  // 126: exchange.v1.ExchangeService.GetConsistencyProof:input_type -> exchange.v1.GetConsistencyProofRequest
+	96,  // 127: exchange.v1.ExchangeService.Login:output_type -> exchange.v1.LoginResponse
+	99,  // 128: exchange.v1.ExchangeService.LoginWithSignature:output_type -> exchange.v1.LoginWithSignatureResponse
+	117, // 129: exchange.v1.ExchangeService.ResetPassword:output_type -> exchange.v1.ResetPasswordResponse
+	102, // 130: exchange.v1.ExchangeService.RefreshSession:output_type -> exchange.v1.RefreshSessionResponse
+	104, // 131: exchange.v1.ExchangeService.Logout:output_type -> exchange.v1.LogoutResponse
+	106, // 132: exchange.v1.ExchangeService.ListSessions:output_type -> exchange.v1.ListSessionsResponse
+	108, // 133: exchange.v1.ExchangeService.RevokeSession:output_type -> exchange.v1.RevokeSessionResponse
+	111, // 134: exchange.v1.ExchangeService.CreateApiKey:output_type -> exchange.v1.CreateApiKeyResponse
+	113, // 135: exchange.v1.ExchangeService.ListApiKeys:output_type -> exchange.v1.ListApiKeysResponse
+	115, // 136: exchange.v1.ExchangeService.RevokeApiKey:output_type -> exchange.v1.RevokeApiKeyResponse
+	93,  // 137: exchange.v1.ExchangeService.GetChallenge:output_type -> exchange.v1.GetChallengeResponse
+	91,  // 138: exchange.v1.ExchangeService.Deposit:output_type -> exchange.v1.DepositResponse
+	88,  // 139: exchange.v1.ExchangeService.PruneAccounts:output_type -> exchange.v1.PruneAccountsResponse
+	86,  // 140: exchange.v1.ExchangeService.CreateWithdraw:output_type -> exchange.v1.CreateWithdrawResponse
+	84,  // 141: exchange.v1.ExchangeService.EstimateWithdrawFee:output_type -> exchange.v1.EstimateWithdrawFeeResponse
+	78,  // 142: exchange.v1.ExchangeService.BatchProcessWithdraws:output_type -> exchange.v1.BatchProcessWithdrawsResponse
+	76,  // 143: exchange.v1.ExchangeService.BatchMarkWithdraws:output_type -> exchange.v1.BatchMarkWithdrawsResponse
+	71,  // 144: exchange.v1.ExchangeService.GetWalletStatus:output_type -> exchange.v1.GetWalletStatusResponse
+	74,  // 145: exchange.v1.ExchangeService.Ping:output_type -> exchange.v1.PingResponse
+	65,  // 146: exchange.v1.ExchangeService.ListPaymentMethods:output_type -> exchange.v1.ListPaymentMethodsResponse
+	67,  // 147: exchange.v1.ExchangeService.ListSigningKeys:output_type -> exchange.v1.ListSigningKeysResponse
+	7,   // 148: exchange.v1.ExchangeService.BuyToken:output_type -> exchange.v1.BuyTokenResponse
+	10,  // 149: exchange.v1.ExchangeService.RedeemToken:output_type -> exchange.v1.RedeemTokenResponse
+	12,  // 150: exchange.v1.ExchangeService.IntrospectToken:output_type -> exchange.v1.IntrospectTokenResponse
+	15,  // 151: exchange.v1.ExchangeService.RefundToken:output_type -> exchange.v1.RefundTokenResponse
+	16,  // 152: exchange.v1.ExchangeService.GetRefundPolicy:output_type -> exchange.v1.RefundPolicy
+	16,  // 153: exchange.v1.ExchangeService.UpdateRefundPolicy:output_type -> exchange.v1.RefundPolicy
+	19,  // 154: exchange.v1.ExchangeService.GetTokenPolicy:output_type -> exchange.v1.TokenPolicy
+	19,  // 155: exchange.v1.ExchangeService.UpdateTokenPolicy:output_type -> exchange.v1.TokenPolicy
+	24,  // 156: exchange.v1.ExchangeService.ListBlindTokenKeys:output_type -> exchange.v1.ListBlindTokenKeysResponse
+	26,  // 157: exchange.v1.ExchangeService.IssueBlindTokens:output_type -> exchange.v1.IssueBlindTokensResponse
+	28,  // 158: exchange.v1.ExchangeService.RedeemBlindToken:output_type -> exchange.v1.RedeemBlindTokenResponse
+	30,  // 159: exchange.v1.ExchangeService.PruneSpentBlindTokens:output_type -> exchange.v1.PruneSpentBlindTokensResponse
+	33,  // 160: exchange.v1.ExchangeService.ListExpiredBlindTokenKeys:output_type -> exchange.v1.ListExpiredBlindTokenKeysResponse
+	36,  // 161: exchange.v1.ExchangeService.CreateServiceSession:output_type -> exchange.v1.CreateServiceSessionResponse
+	38,  // 162: exchange.v1.ExchangeService.ConsumeServiceSession:output_type -> exchange.v1.ConsumeServiceSessionResponse
+	40,  // 163: exchange.v1.ExchangeService.GetServiceSession:output_type -> exchange.v1.GetServiceSessionResponse
+	42,  // 164: exchange.v1.ExchangeService.TopUpServiceSession:output_type -> exchange.v1.TopUpServiceSessionResponse
+	44,  // 165: exchange.v1.ExchangeService.PauseServiceSession:output_type -> exchange.v1.PauseServiceSessionResponse
+	46,  // 166: exchange.v1.ExchangeService.ResumeServiceSession:output_type -> exchange.v1.ResumeServiceSessionResponse
+	48,  // 167: exchange.v1.ExchangeService.CloseServiceSession:output_type -> exchange.v1.CloseServiceSessionResponse
+	53,  // 168: exchange.v1.ExchangeService.SubmitConsumptionReceipts:output_type -> exchange.v1.SubmitConsumptionReceiptsResponse
+	55,  // 169: exchange.v1.ExchangeService.GetTreeHead:output_type -> exchange.v1.SignedTreeHead
+	59,  // 170: exchange.v1.ExchangeService.ListLogEntries:output_type -> exchange.v1.ListLogEntriesResponse
+	61,  // 171: exchange.v1.ExchangeService.GetInclusionProof:output_type -> exchange.v1.GetInclusionProofResponse
+	63,  // 172: exchange.v1.ExchangeService.GetConsistencyProof:output_type -> exchange.v1.GetConsistencyProofResponse
+	127, // [127:173] is the sub-list for method output_type
+	81,  // [81:127] is the sub-list for method input_type
+	81,  // [81:81] is the sub-list for extension type_name
+	81,  // [81:81] is the sub-list for extension extendee
+	0,   // [0:81] is the sub-list for field type_name
 }
 
 func init() { file_exchange_v1_exchange_proto_init() }
@@ -1693,13 +7206,15 @@ func file_exchange_v1_exchange_proto_init() {
 	if File_exchange_v1_exchange_proto != nil {
 		return
 	}
+	file_exchange_v1_exchange_proto_msgTypes[3].OneofWrappers = []any{}
+	file_exchange_v1_exchange_proto_msgTypes[103].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_exchange_v1_exchange_proto_rawDesc), len(file_exchange_v1_exchange_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   26,
+			NumEnums:      6,
+			NumMessages:   112,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
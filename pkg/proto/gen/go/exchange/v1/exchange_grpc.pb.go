@@ -19,16 +19,52 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ExchangeService_Login_FullMethodName                 = "/exchange.v1.ExchangeService/Login"
-	ExchangeService_GetChallenge_FullMethodName          = "/exchange.v1.ExchangeService/GetChallenge"
-	ExchangeService_Deposit_FullMethodName               = "/exchange.v1.ExchangeService/Deposit"
-	ExchangeService_PruneAccounts_FullMethodName         = "/exchange.v1.ExchangeService/PruneAccounts"
-	ExchangeService_CreateWithdraw_FullMethodName        = "/exchange.v1.ExchangeService/CreateWithdraw"
-	ExchangeService_BatchProcessWithdraws_FullMethodName = "/exchange.v1.ExchangeService/BatchProcessWithdraws"
-	ExchangeService_BatchMarkWithdraws_FullMethodName    = "/exchange.v1.ExchangeService/BatchMarkWithdraws"
-	ExchangeService_Ping_FullMethodName                  = "/exchange.v1.ExchangeService/Ping"
-	ExchangeService_ListPaymentMethods_FullMethodName    = "/exchange.v1.ExchangeService/ListPaymentMethods"
-	ExchangeService_BuyToken_FullMethodName              = "/exchange.v1.ExchangeService/BuyToken"
+	ExchangeService_Login_FullMethodName                     = "/exchange.v1.ExchangeService/Login"
+	ExchangeService_LoginWithSignature_FullMethodName        = "/exchange.v1.ExchangeService/LoginWithSignature"
+	ExchangeService_ResetPassword_FullMethodName             = "/exchange.v1.ExchangeService/ResetPassword"
+	ExchangeService_RefreshSession_FullMethodName            = "/exchange.v1.ExchangeService/RefreshSession"
+	ExchangeService_Logout_FullMethodName                    = "/exchange.v1.ExchangeService/Logout"
+	ExchangeService_ListSessions_FullMethodName              = "/exchange.v1.ExchangeService/ListSessions"
+	ExchangeService_RevokeSession_FullMethodName             = "/exchange.v1.ExchangeService/RevokeSession"
+	ExchangeService_CreateApiKey_FullMethodName              = "/exchange.v1.ExchangeService/CreateApiKey"
+	ExchangeService_ListApiKeys_FullMethodName               = "/exchange.v1.ExchangeService/ListApiKeys"
+	ExchangeService_RevokeApiKey_FullMethodName              = "/exchange.v1.ExchangeService/RevokeApiKey"
+	ExchangeService_GetChallenge_FullMethodName              = "/exchange.v1.ExchangeService/GetChallenge"
+	ExchangeService_Deposit_FullMethodName                   = "/exchange.v1.ExchangeService/Deposit"
+	ExchangeService_PruneAccounts_FullMethodName             = "/exchange.v1.ExchangeService/PruneAccounts"
+	ExchangeService_CreateWithdraw_FullMethodName            = "/exchange.v1.ExchangeService/CreateWithdraw"
+	ExchangeService_EstimateWithdrawFee_FullMethodName       = "/exchange.v1.ExchangeService/EstimateWithdrawFee"
+	ExchangeService_BatchProcessWithdraws_FullMethodName     = "/exchange.v1.ExchangeService/BatchProcessWithdraws"
+	ExchangeService_BatchMarkWithdraws_FullMethodName        = "/exchange.v1.ExchangeService/BatchMarkWithdraws"
+	ExchangeService_GetWalletStatus_FullMethodName           = "/exchange.v1.ExchangeService/GetWalletStatus"
+	ExchangeService_Ping_FullMethodName                      = "/exchange.v1.ExchangeService/Ping"
+	ExchangeService_ListPaymentMethods_FullMethodName        = "/exchange.v1.ExchangeService/ListPaymentMethods"
+	ExchangeService_ListSigningKeys_FullMethodName           = "/exchange.v1.ExchangeService/ListSigningKeys"
+	ExchangeService_BuyToken_FullMethodName                  = "/exchange.v1.ExchangeService/BuyToken"
+	ExchangeService_RedeemToken_FullMethodName               = "/exchange.v1.ExchangeService/RedeemToken"
+	ExchangeService_IntrospectToken_FullMethodName           = "/exchange.v1.ExchangeService/IntrospectToken"
+	ExchangeService_RefundToken_FullMethodName               = "/exchange.v1.ExchangeService/RefundToken"
+	ExchangeService_GetRefundPolicy_FullMethodName           = "/exchange.v1.ExchangeService/GetRefundPolicy"
+	ExchangeService_UpdateRefundPolicy_FullMethodName        = "/exchange.v1.ExchangeService/UpdateRefundPolicy"
+	ExchangeService_GetTokenPolicy_FullMethodName            = "/exchange.v1.ExchangeService/GetTokenPolicy"
+	ExchangeService_UpdateTokenPolicy_FullMethodName         = "/exchange.v1.ExchangeService/UpdateTokenPolicy"
+	ExchangeService_ListBlindTokenKeys_FullMethodName        = "/exchange.v1.ExchangeService/ListBlindTokenKeys"
+	ExchangeService_IssueBlindTokens_FullMethodName          = "/exchange.v1.ExchangeService/IssueBlindTokens"
+	ExchangeService_RedeemBlindToken_FullMethodName          = "/exchange.v1.ExchangeService/RedeemBlindToken"
+	ExchangeService_PruneSpentBlindTokens_FullMethodName     = "/exchange.v1.ExchangeService/PruneSpentBlindTokens"
+	ExchangeService_ListExpiredBlindTokenKeys_FullMethodName = "/exchange.v1.ExchangeService/ListExpiredBlindTokenKeys"
+	ExchangeService_CreateServiceSession_FullMethodName      = "/exchange.v1.ExchangeService/CreateServiceSession"
+	ExchangeService_ConsumeServiceSession_FullMethodName     = "/exchange.v1.ExchangeService/ConsumeServiceSession"
+	ExchangeService_GetServiceSession_FullMethodName         = "/exchange.v1.ExchangeService/GetServiceSession"
+	ExchangeService_TopUpServiceSession_FullMethodName       = "/exchange.v1.ExchangeService/TopUpServiceSession"
+	ExchangeService_PauseServiceSession_FullMethodName       = "/exchange.v1.ExchangeService/PauseServiceSession"
+	ExchangeService_ResumeServiceSession_FullMethodName      = "/exchange.v1.ExchangeService/ResumeServiceSession"
+	ExchangeService_CloseServiceSession_FullMethodName       = "/exchange.v1.ExchangeService/CloseServiceSession"
+	ExchangeService_SubmitConsumptionReceipts_FullMethodName = "/exchange.v1.ExchangeService/SubmitConsumptionReceipts"
+	ExchangeService_GetTreeHead_FullMethodName               = "/exchange.v1.ExchangeService/GetTreeHead"
+	ExchangeService_ListLogEntries_FullMethodName            = "/exchange.v1.ExchangeService/ListLogEntries"
+	ExchangeService_GetInclusionProof_FullMethodName         = "/exchange.v1.ExchangeService/GetInclusionProof"
+	ExchangeService_GetConsistencyProof_FullMethodName       = "/exchange.v1.ExchangeService/GetConsistencyProof"
 )
 
 // ExchangeServiceClient is the client API for ExchangeService service.
@@ -36,15 +72,86 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ExchangeServiceClient interface {
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// LoginWithSignature logs in with a challenge signed by the wallet that
+	// made the first deposit of the account instead of a password.
+	LoginWithSignature(ctx context.Context, in *LoginWithSignatureRequest, opts ...grpc.CallOption) (*LoginWithSignatureResponse, error)
+	// ResetPassword sets a new password after proving control of a wallet that
+	// deposited to the account. Existing sessions are revoked and withdrawals
+	// are paused for a cool-down.
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
+	// RefreshSession exchanges a refresh token for a new access token and a
+	// new refresh token. The old refresh token must not be used again.
+	RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error)
+	RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error)
 	GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*GetChallengeResponse, error)
 	Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error)
+	// PruneAccounts deletes expired accounts. Escrows held for them are
+	// settled first, paying the unclaimed rest back to the buyers.
 	PruneAccounts(ctx context.Context, in *PruneAccountsRequest, opts ...grpc.CallOption) (*PruneAccountsResponse, error)
 	CreateWithdraw(ctx context.Context, in *CreateWithdrawRequest, opts ...grpc.CallOption) (*CreateWithdrawResponse, error)
+	EstimateWithdrawFee(ctx context.Context, in *EstimateWithdrawFeeRequest, opts ...grpc.CallOption) (*EstimateWithdrawFeeResponse, error)
 	BatchProcessWithdraws(ctx context.Context, in *BatchProcessWithdrawsRequest, opts ...grpc.CallOption) (*BatchProcessWithdrawsResponse, error)
 	BatchMarkWithdraws(ctx context.Context, in *BatchMarkWithdrawsRequest, opts ...grpc.CallOption) (*BatchMarkWithdrawsResponse, error)
+	// GetWalletStatus reports the hot wallets as of their last periodic check.
+	// It does not query the chain or raise refill alerts itself.
+	GetWalletStatus(ctx context.Context, in *GetWalletStatusRequest, opts ...grpc.CallOption) (*GetWalletStatusResponse, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 	ListPaymentMethods(ctx context.Context, in *ListPaymentMethodsRequest, opts ...grpc.CallOption) (*ListPaymentMethodsResponse, error)
+	ListSigningKeys(ctx context.Context, in *ListSigningKeysRequest, opts ...grpc.CallOption) (*ListSigningKeysResponse, error)
 	BuyToken(ctx context.Context, in *BuyTokenRequest, opts ...grpc.CallOption) (*BuyTokenResponse, error)
+	// RedeemToken consumes quota of a token. Only its audience may call it.
+	RedeemToken(ctx context.Context, in *RedeemTokenRequest, opts ...grpc.CallOption) (*RedeemTokenResponse, error)
+	IntrospectToken(ctx context.Context, in *IntrospectTokenRequest, opts ...grpc.CallOption) (*IntrospectTokenResponse, error)
+	// RefundToken revokes a token of the caller and pays back its unconsumed
+	// quota if the refund policy of its audience allows.
+	RefundToken(ctx context.Context, in *RefundTokenRequest, opts ...grpc.CallOption) (*RefundTokenResponse, error)
+	GetRefundPolicy(ctx context.Context, in *GetRefundPolicyRequest, opts ...grpc.CallOption) (*RefundPolicy, error)
+	// UpdateRefundPolicy sets the refund policy of tokens bought for the caller.
+	UpdateRefundPolicy(ctx context.Context, in *UpdateRefundPolicyRequest, opts ...grpc.CallOption) (*RefundPolicy, error)
+	GetTokenPolicy(ctx context.Context, in *GetTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicy, error)
+	// UpdateTokenPolicy sets how long tokens bought for the caller may last.
+	UpdateTokenPolicy(ctx context.Context, in *UpdateTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicy, error)
+	ListBlindTokenKeys(ctx context.Context, in *ListBlindTokenKeysRequest, opts ...grpc.CallOption) (*ListBlindTokenKeysResponse, error)
+	// IssueBlindTokens signs blinded messages with the key of a denomination and
+	// charges the caller the denomination for each. See pkg/blind. Tokens not
+	// redeemed before the key expires are not refunded, since Prex cannot tell
+	// who holds them. ListExpiredBlindTokenKeys reports the value left behind.
+	IssueBlindTokens(ctx context.Context, in *IssueBlindTokensRequest, opts ...grpc.CallOption) (*IssueBlindTokensResponse, error)
+	// RedeemBlindToken spends a blind token and pays its denomination to the
+	// caller, who must be the audience the token was bought for.
+	RedeemBlindToken(ctx context.Context, in *RedeemBlindTokenRequest, opts ...grpc.CallOption) (*RedeemBlindTokenResponse, error)
+	// PruneSpentBlindTokens forgets the spent tokens of expired keys, which can
+	// no longer be redeemed anyway.
+	PruneSpentBlindTokens(ctx context.Context, in *PruneSpentBlindTokensRequest, opts ...grpc.CallOption) (*PruneSpentBlindTokensResponse, error)
+	// ListExpiredBlindTokenKeys reports the expired keys with unredeemed tokens
+	// so operators can reconcile the value Prex kept from them.
+	ListExpiredBlindTokenKeys(ctx context.Context, in *ListExpiredBlindTokenKeysRequest, opts ...grpc.CallOption) (*ListExpiredBlindTokenKeysResponse, error)
+	// CreateServiceSession redeems a create-session token into a session
+	// metered by its audience and returns a manage-session token for the user.
+	CreateServiceSession(ctx context.Context, in *CreateServiceSessionRequest, opts ...grpc.CallOption) (*CreateServiceSessionResponse, error)
+	ConsumeServiceSession(ctx context.Context, in *ConsumeServiceSessionRequest, opts ...grpc.CallOption) (*ConsumeServiceSessionResponse, error)
+	// The methods below are authorized by the manage-session token in the
+	// request.
+	GetServiceSession(ctx context.Context, in *GetServiceSessionRequest, opts ...grpc.CallOption) (*GetServiceSessionResponse, error)
+	TopUpServiceSession(ctx context.Context, in *TopUpServiceSessionRequest, opts ...grpc.CallOption) (*TopUpServiceSessionResponse, error)
+	PauseServiceSession(ctx context.Context, in *PauseServiceSessionRequest, opts ...grpc.CallOption) (*PauseServiceSessionResponse, error)
+	ResumeServiceSession(ctx context.Context, in *ResumeServiceSessionRequest, opts ...grpc.CallOption) (*ResumeServiceSessionResponse, error)
+	CloseServiceSession(ctx context.Context, in *CloseServiceSessionRequest, opts ...grpc.CallOption) (*CloseServiceSessionResponse, error)
+	// SubmitConsumptionReceipts pays the audience of escrowed tokens what its
+	// receipts claim, up to the quota it consumed with RedeemToken. Receipts
+	// are authorized by the signature of the audience, so anyone may relay them.
+	SubmitConsumptionReceipts(ctx context.Context, in *SubmitConsumptionReceiptsRequest, opts ...grpc.CallOption) (*SubmitConsumptionReceiptsResponse, error)
+	// The methods below serve the transparency log of issued tokens for
+	// auditing. See pkg/transparency to verify what they return.
+	GetTreeHead(ctx context.Context, in *GetTreeHeadRequest, opts ...grpc.CallOption) (*SignedTreeHead, error)
+	ListLogEntries(ctx context.Context, in *ListLogEntriesRequest, opts ...grpc.CallOption) (*ListLogEntriesResponse, error)
+	GetInclusionProof(ctx context.Context, in *GetInclusionProofRequest, opts ...grpc.CallOption) (*GetInclusionProofResponse, error)
+	GetConsistencyProof(ctx context.Context, in *GetConsistencyProofRequest, opts ...grpc.CallOption) (*GetConsistencyProofResponse, error)
 }
 
 type exchangeServiceClient struct {
@@ -65,6 +172,96 @@ func (c *exchangeServiceClient) Login(ctx context.Context, in *LoginRequest, opt
 	return out, nil
 }
 
+func (c *exchangeServiceClient) LoginWithSignature(ctx context.Context, in *LoginWithSignatureRequest, opts ...grpc.CallOption) (*LoginWithSignatureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginWithSignatureResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_LoginWithSignature_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_RefreshSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateApiKeyResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_CreateApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListApiKeys(ctx context.Context, in *ListApiKeysRequest, opts ...grpc.CallOption) (*ListApiKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListApiKeysResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ListApiKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RevokeApiKey(ctx context.Context, in *RevokeApiKeyRequest, opts ...grpc.CallOption) (*RevokeApiKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeApiKeyResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_RevokeApiKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *exchangeServiceClient) GetChallenge(ctx context.Context, in *GetChallengeRequest, opts ...grpc.CallOption) (*GetChallengeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetChallengeResponse)
@@ -105,6 +302,16 @@ func (c *exchangeServiceClient) CreateWithdraw(ctx context.Context, in *CreateWi
 	return out, nil
 }
 
+func (c *exchangeServiceClient) EstimateWithdrawFee(ctx context.Context, in *EstimateWithdrawFeeRequest, opts ...grpc.CallOption) (*EstimateWithdrawFeeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EstimateWithdrawFeeResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_EstimateWithdrawFee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *exchangeServiceClient) BatchProcessWithdraws(ctx context.Context, in *BatchProcessWithdrawsRequest, opts ...grpc.CallOption) (*BatchProcessWithdrawsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BatchProcessWithdrawsResponse)
@@ -115,297 +322,1384 @@ func (c *exchangeServiceClient) BatchProcessWithdraws(ctx context.Context, in *B
 	return out, nil
 }
 
-func (c *exchangeServiceClient) BatchMarkWithdraws(ctx context.Context, in *BatchMarkWithdrawsRequest, opts ...grpc.CallOption) (*BatchMarkWithdrawsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(BatchMarkWithdrawsResponse)
-	err := c.cc.Invoke(ctx, ExchangeService_BatchMarkWithdraws_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func (c *exchangeServiceClient) BatchMarkWithdraws(ctx context.Context, in *BatchMarkWithdrawsRequest, opts ...grpc.CallOption) (*BatchMarkWithdrawsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchMarkWithdrawsResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_BatchMarkWithdraws_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetWalletStatus(ctx context.Context, in *GetWalletStatusRequest, opts ...grpc.CallOption) (*GetWalletStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWalletStatusResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_GetWalletStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListPaymentMethods(ctx context.Context, in *ListPaymentMethodsRequest, opts ...grpc.CallOption) (*ListPaymentMethodsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPaymentMethodsResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ListPaymentMethods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListSigningKeys(ctx context.Context, in *ListSigningKeysRequest, opts ...grpc.CallOption) (*ListSigningKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSigningKeysResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ListSigningKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) BuyToken(ctx context.Context, in *BuyTokenRequest, opts ...grpc.CallOption) (*BuyTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuyTokenResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_BuyToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RedeemToken(ctx context.Context, in *RedeemTokenRequest, opts ...grpc.CallOption) (*RedeemTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemTokenResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_RedeemToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) IntrospectToken(ctx context.Context, in *IntrospectTokenRequest, opts ...grpc.CallOption) (*IntrospectTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IntrospectTokenResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_IntrospectToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RefundToken(ctx context.Context, in *RefundTokenRequest, opts ...grpc.CallOption) (*RefundTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundTokenResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_RefundToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetRefundPolicy(ctx context.Context, in *GetRefundPolicyRequest, opts ...grpc.CallOption) (*RefundPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPolicy)
+	err := c.cc.Invoke(ctx, ExchangeService_GetRefundPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) UpdateRefundPolicy(ctx context.Context, in *UpdateRefundPolicyRequest, opts ...grpc.CallOption) (*RefundPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPolicy)
+	err := c.cc.Invoke(ctx, ExchangeService_UpdateRefundPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetTokenPolicy(ctx context.Context, in *GetTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenPolicy)
+	err := c.cc.Invoke(ctx, ExchangeService_GetTokenPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) UpdateTokenPolicy(ctx context.Context, in *UpdateTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenPolicy)
+	err := c.cc.Invoke(ctx, ExchangeService_UpdateTokenPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListBlindTokenKeys(ctx context.Context, in *ListBlindTokenKeysRequest, opts ...grpc.CallOption) (*ListBlindTokenKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlindTokenKeysResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ListBlindTokenKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) IssueBlindTokens(ctx context.Context, in *IssueBlindTokensRequest, opts ...grpc.CallOption) (*IssueBlindTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueBlindTokensResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_IssueBlindTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) RedeemBlindToken(ctx context.Context, in *RedeemBlindTokenRequest, opts ...grpc.CallOption) (*RedeemBlindTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemBlindTokenResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_RedeemBlindToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) PruneSpentBlindTokens(ctx context.Context, in *PruneSpentBlindTokensRequest, opts ...grpc.CallOption) (*PruneSpentBlindTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PruneSpentBlindTokensResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_PruneSpentBlindTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListExpiredBlindTokenKeys(ctx context.Context, in *ListExpiredBlindTokenKeysRequest, opts ...grpc.CallOption) (*ListExpiredBlindTokenKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExpiredBlindTokenKeysResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ListExpiredBlindTokenKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) CreateServiceSession(ctx context.Context, in *CreateServiceSessionRequest, opts ...grpc.CallOption) (*CreateServiceSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateServiceSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_CreateServiceSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ConsumeServiceSession(ctx context.Context, in *ConsumeServiceSessionRequest, opts ...grpc.CallOption) (*ConsumeServiceSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsumeServiceSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ConsumeServiceSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetServiceSession(ctx context.Context, in *GetServiceSessionRequest, opts ...grpc.CallOption) (*GetServiceSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServiceSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_GetServiceSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) TopUpServiceSession(ctx context.Context, in *TopUpServiceSessionRequest, opts ...grpc.CallOption) (*TopUpServiceSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopUpServiceSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_TopUpServiceSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) PauseServiceSession(ctx context.Context, in *PauseServiceSessionRequest, opts ...grpc.CallOption) (*PauseServiceSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseServiceSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_PauseServiceSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ResumeServiceSession(ctx context.Context, in *ResumeServiceSessionRequest, opts ...grpc.CallOption) (*ResumeServiceSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeServiceSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ResumeServiceSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) CloseServiceSession(ctx context.Context, in *CloseServiceSessionRequest, opts ...grpc.CallOption) (*CloseServiceSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseServiceSessionResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_CloseServiceSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) SubmitConsumptionReceipts(ctx context.Context, in *SubmitConsumptionReceiptsRequest, opts ...grpc.CallOption) (*SubmitConsumptionReceiptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitConsumptionReceiptsResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_SubmitConsumptionReceipts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetTreeHead(ctx context.Context, in *GetTreeHeadRequest, opts ...grpc.CallOption) (*SignedTreeHead, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SignedTreeHead)
+	err := c.cc.Invoke(ctx, ExchangeService_GetTreeHead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) ListLogEntries(ctx context.Context, in *ListLogEntriesRequest, opts ...grpc.CallOption) (*ListLogEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLogEntriesResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_ListLogEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetInclusionProof(ctx context.Context, in *GetInclusionProofRequest, opts ...grpc.CallOption) (*GetInclusionProofResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInclusionProofResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_GetInclusionProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeServiceClient) GetConsistencyProof(ctx context.Context, in *GetConsistencyProofRequest, opts ...grpc.CallOption) (*GetConsistencyProofResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConsistencyProofResponse)
+	err := c.cc.Invoke(ctx, ExchangeService_GetConsistencyProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExchangeServiceServer is the server API for ExchangeService service.
+// All implementations must embed UnimplementedExchangeServiceServer
+// for forward compatibility.
+type ExchangeServiceServer interface {
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// LoginWithSignature logs in with a challenge signed by the wallet that
+	// made the first deposit of the account instead of a password.
+	LoginWithSignature(context.Context, *LoginWithSignatureRequest) (*LoginWithSignatureResponse, error)
+	// ResetPassword sets a new password after proving control of a wallet that
+	// deposited to the account. Existing sessions are revoked and withdrawals
+	// are paused for a cool-down.
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	// RefreshSession exchanges a refresh token for a new access token and a
+	// new refresh token. The old refresh token must not be used again.
+	RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error)
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error)
+	RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error)
+	GetChallenge(context.Context, *GetChallengeRequest) (*GetChallengeResponse, error)
+	Deposit(context.Context, *DepositRequest) (*DepositResponse, error)
+	// PruneAccounts deletes expired accounts. Escrows held for them are
+	// settled first, paying the unclaimed rest back to the buyers.
+	PruneAccounts(context.Context, *PruneAccountsRequest) (*PruneAccountsResponse, error)
+	CreateWithdraw(context.Context, *CreateWithdrawRequest) (*CreateWithdrawResponse, error)
+	EstimateWithdrawFee(context.Context, *EstimateWithdrawFeeRequest) (*EstimateWithdrawFeeResponse, error)
+	BatchProcessWithdraws(context.Context, *BatchProcessWithdrawsRequest) (*BatchProcessWithdrawsResponse, error)
+	BatchMarkWithdraws(context.Context, *BatchMarkWithdrawsRequest) (*BatchMarkWithdrawsResponse, error)
+	// GetWalletStatus reports the hot wallets as of their last periodic check.
+	// It does not query the chain or raise refill alerts itself.
+	GetWalletStatus(context.Context, *GetWalletStatusRequest) (*GetWalletStatusResponse, error)
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	ListPaymentMethods(context.Context, *ListPaymentMethodsRequest) (*ListPaymentMethodsResponse, error)
+	ListSigningKeys(context.Context, *ListSigningKeysRequest) (*ListSigningKeysResponse, error)
+	BuyToken(context.Context, *BuyTokenRequest) (*BuyTokenResponse, error)
+	// RedeemToken consumes quota of a token. Only its audience may call it.
+	RedeemToken(context.Context, *RedeemTokenRequest) (*RedeemTokenResponse, error)
+	IntrospectToken(context.Context, *IntrospectTokenRequest) (*IntrospectTokenResponse, error)
+	// RefundToken revokes a token of the caller and pays back its unconsumed
+	// quota if the refund policy of its audience allows.
+	RefundToken(context.Context, *RefundTokenRequest) (*RefundTokenResponse, error)
+	GetRefundPolicy(context.Context, *GetRefundPolicyRequest) (*RefundPolicy, error)
+	// UpdateRefundPolicy sets the refund policy of tokens bought for the caller.
+	UpdateRefundPolicy(context.Context, *UpdateRefundPolicyRequest) (*RefundPolicy, error)
+	GetTokenPolicy(context.Context, *GetTokenPolicyRequest) (*TokenPolicy, error)
+	// UpdateTokenPolicy sets how long tokens bought for the caller may last.
+	UpdateTokenPolicy(context.Context, *UpdateTokenPolicyRequest) (*TokenPolicy, error)
+	ListBlindTokenKeys(context.Context, *ListBlindTokenKeysRequest) (*ListBlindTokenKeysResponse, error)
+	// IssueBlindTokens signs blinded messages with the key of a denomination and
+	// charges the caller the denomination for each. See pkg/blind. Tokens not
+	// redeemed before the key expires are not refunded, since Prex cannot tell
+	// who holds them. ListExpiredBlindTokenKeys reports the value left behind.
+	IssueBlindTokens(context.Context, *IssueBlindTokensRequest) (*IssueBlindTokensResponse, error)
+	// RedeemBlindToken spends a blind token and pays its denomination to the
+	// caller, who must be the audience the token was bought for.
+	RedeemBlindToken(context.Context, *RedeemBlindTokenRequest) (*RedeemBlindTokenResponse, error)
+	// PruneSpentBlindTokens forgets the spent tokens of expired keys, which can
+	// no longer be redeemed anyway.
+	PruneSpentBlindTokens(context.Context, *PruneSpentBlindTokensRequest) (*PruneSpentBlindTokensResponse, error)
+	// ListExpiredBlindTokenKeys reports the expired keys with unredeemed tokens
+	// so operators can reconcile the value Prex kept from them.
+	ListExpiredBlindTokenKeys(context.Context, *ListExpiredBlindTokenKeysRequest) (*ListExpiredBlindTokenKeysResponse, error)
+	// CreateServiceSession redeems a create-session token into a session
+	// metered by its audience and returns a manage-session token for the user.
+	CreateServiceSession(context.Context, *CreateServiceSessionRequest) (*CreateServiceSessionResponse, error)
+	ConsumeServiceSession(context.Context, *ConsumeServiceSessionRequest) (*ConsumeServiceSessionResponse, error)
+	// The methods below are authorized by the manage-session token in the
+	// request.
+	GetServiceSession(context.Context, *GetServiceSessionRequest) (*GetServiceSessionResponse, error)
+	TopUpServiceSession(context.Context, *TopUpServiceSessionRequest) (*TopUpServiceSessionResponse, error)
+	PauseServiceSession(context.Context, *PauseServiceSessionRequest) (*PauseServiceSessionResponse, error)
+	ResumeServiceSession(context.Context, *ResumeServiceSessionRequest) (*ResumeServiceSessionResponse, error)
+	CloseServiceSession(context.Context, *CloseServiceSessionRequest) (*CloseServiceSessionResponse, error)
+	// SubmitConsumptionReceipts pays the audience of escrowed tokens what its
+	// receipts claim, up to the quota it consumed with RedeemToken. Receipts
+	// are authorized by the signature of the audience, so anyone may relay them.
+	SubmitConsumptionReceipts(context.Context, *SubmitConsumptionReceiptsRequest) (*SubmitConsumptionReceiptsResponse, error)
+	// The methods below serve the transparency log of issued tokens for
+	// auditing. See pkg/transparency to verify what they return.
+	GetTreeHead(context.Context, *GetTreeHeadRequest) (*SignedTreeHead, error)
+	ListLogEntries(context.Context, *ListLogEntriesRequest) (*ListLogEntriesResponse, error)
+	GetInclusionProof(context.Context, *GetInclusionProofRequest) (*GetInclusionProofResponse, error)
+	GetConsistencyProof(context.Context, *GetConsistencyProofRequest) (*GetConsistencyProofResponse, error)
+	mustEmbedUnimplementedExchangeServiceServer()
+}
+
+// UnimplementedExchangeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExchangeServiceServer struct{}
+
+func (UnimplementedExchangeServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedExchangeServiceServer) LoginWithSignature(context.Context, *LoginWithSignatureRequest) (*LoginWithSignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginWithSignature not implemented")
+}
+func (UnimplementedExchangeServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedExchangeServiceServer) RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedExchangeServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiKey not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListApiKeys(context.Context, *ListApiKeysRequest) (*ListApiKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiKeys not implemented")
+}
+func (UnimplementedExchangeServiceServer) RevokeApiKey(context.Context, *RevokeApiKeyRequest) (*RevokeApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiKey not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetChallenge(context.Context, *GetChallengeRequest) (*GetChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChallenge not implemented")
+}
+func (UnimplementedExchangeServiceServer) Deposit(context.Context, *DepositRequest) (*DepositResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedExchangeServiceServer) PruneAccounts(context.Context, *PruneAccountsRequest) (*PruneAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneAccounts not implemented")
+}
+func (UnimplementedExchangeServiceServer) CreateWithdraw(context.Context, *CreateWithdrawRequest) (*CreateWithdrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWithdraw not implemented")
+}
+func (UnimplementedExchangeServiceServer) EstimateWithdrawFee(context.Context, *EstimateWithdrawFeeRequest) (*EstimateWithdrawFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateWithdrawFee not implemented")
+}
+func (UnimplementedExchangeServiceServer) BatchProcessWithdraws(context.Context, *BatchProcessWithdrawsRequest) (*BatchProcessWithdrawsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchProcessWithdraws not implemented")
+}
+func (UnimplementedExchangeServiceServer) BatchMarkWithdraws(context.Context, *BatchMarkWithdrawsRequest) (*BatchMarkWithdrawsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchMarkWithdraws not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetWalletStatus(context.Context, *GetWalletStatusRequest) (*GetWalletStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletStatus not implemented")
+}
+func (UnimplementedExchangeServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListPaymentMethods(context.Context, *ListPaymentMethodsRequest) (*ListPaymentMethodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPaymentMethods not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListSigningKeys(context.Context, *ListSigningKeysRequest) (*ListSigningKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSigningKeys not implemented")
+}
+func (UnimplementedExchangeServiceServer) BuyToken(context.Context, *BuyTokenRequest) (*BuyTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuyToken not implemented")
+}
+func (UnimplementedExchangeServiceServer) RedeemToken(context.Context, *RedeemTokenRequest) (*RedeemTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemToken not implemented")
+}
+func (UnimplementedExchangeServiceServer) IntrospectToken(context.Context, *IntrospectTokenRequest) (*IntrospectTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IntrospectToken not implemented")
+}
+func (UnimplementedExchangeServiceServer) RefundToken(context.Context, *RefundTokenRequest) (*RefundTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundToken not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetRefundPolicy(context.Context, *GetRefundPolicyRequest) (*RefundPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefundPolicy not implemented")
+}
+func (UnimplementedExchangeServiceServer) UpdateRefundPolicy(context.Context, *UpdateRefundPolicyRequest) (*RefundPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRefundPolicy not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetTokenPolicy(context.Context, *GetTokenPolicyRequest) (*TokenPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokenPolicy not implemented")
+}
+func (UnimplementedExchangeServiceServer) UpdateTokenPolicy(context.Context, *UpdateTokenPolicyRequest) (*TokenPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTokenPolicy not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListBlindTokenKeys(context.Context, *ListBlindTokenKeysRequest) (*ListBlindTokenKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlindTokenKeys not implemented")
+}
+func (UnimplementedExchangeServiceServer) IssueBlindTokens(context.Context, *IssueBlindTokensRequest) (*IssueBlindTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueBlindTokens not implemented")
+}
+func (UnimplementedExchangeServiceServer) RedeemBlindToken(context.Context, *RedeemBlindTokenRequest) (*RedeemBlindTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemBlindToken not implemented")
+}
+func (UnimplementedExchangeServiceServer) PruneSpentBlindTokens(context.Context, *PruneSpentBlindTokensRequest) (*PruneSpentBlindTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneSpentBlindTokens not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListExpiredBlindTokenKeys(context.Context, *ListExpiredBlindTokenKeysRequest) (*ListExpiredBlindTokenKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiredBlindTokenKeys not implemented")
+}
+func (UnimplementedExchangeServiceServer) CreateServiceSession(context.Context, *CreateServiceSessionRequest) (*CreateServiceSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) ConsumeServiceSession(context.Context, *ConsumeServiceSessionRequest) (*ConsumeServiceSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsumeServiceSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetServiceSession(context.Context, *GetServiceSessionRequest) (*GetServiceSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) TopUpServiceSession(context.Context, *TopUpServiceSessionRequest) (*TopUpServiceSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopUpServiceSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) PauseServiceSession(context.Context, *PauseServiceSessionRequest) (*PauseServiceSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseServiceSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) ResumeServiceSession(context.Context, *ResumeServiceSessionRequest) (*ResumeServiceSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeServiceSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) CloseServiceSession(context.Context, *CloseServiceSessionRequest) (*CloseServiceSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseServiceSession not implemented")
+}
+func (UnimplementedExchangeServiceServer) SubmitConsumptionReceipts(context.Context, *SubmitConsumptionReceiptsRequest) (*SubmitConsumptionReceiptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitConsumptionReceipts not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetTreeHead(context.Context, *GetTreeHeadRequest) (*SignedTreeHead, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTreeHead not implemented")
+}
+func (UnimplementedExchangeServiceServer) ListLogEntries(context.Context, *ListLogEntriesRequest) (*ListLogEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLogEntries not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetInclusionProof(context.Context, *GetInclusionProofRequest) (*GetInclusionProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInclusionProof not implemented")
+}
+func (UnimplementedExchangeServiceServer) GetConsistencyProof(context.Context, *GetConsistencyProofRequest) (*GetConsistencyProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsistencyProof not implemented")
+}
+func (UnimplementedExchangeServiceServer) mustEmbedUnimplementedExchangeServiceServer() {}
+func (UnimplementedExchangeServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeExchangeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExchangeServiceServer will
+// result in compilation errors.
+type UnsafeExchangeServiceServer interface {
+	mustEmbedUnimplementedExchangeServiceServer()
+}
+
+func RegisterExchangeServiceServer(s grpc.ServiceRegistrar, srv ExchangeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedExchangeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExchangeService_ServiceDesc, srv)
+}
+
+func _ExchangeService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_LoginWithSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).LoginWithSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_LoginWithSignature_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).LoginWithSignature(ctx, req.(*LoginWithSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RefreshSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RefreshSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RefreshSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RefreshSession(ctx, req.(*RefreshSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).CreateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_CreateApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).CreateApiKey(ctx, req.(*CreateApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ListApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ListApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ListApiKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ListApiKeys(ctx, req.(*ListApiKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RevokeApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RevokeApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RevokeApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RevokeApiKey(ctx, req.(*RevokeApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_GetChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).GetChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_GetChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).GetChallenge(ctx, req.(*GetChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_Deposit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).Deposit(ctx, req.(*DepositRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_PruneAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).PruneAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_PruneAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).PruneAccounts(ctx, req.(*PruneAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_CreateWithdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWithdrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).CreateWithdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_CreateWithdraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).CreateWithdraw(ctx, req.(*CreateWithdrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_EstimateWithdrawFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateWithdrawFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).EstimateWithdrawFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_EstimateWithdrawFee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).EstimateWithdrawFee(ctx, req.(*EstimateWithdrawFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_BatchProcessWithdraws_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchProcessWithdrawsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).BatchProcessWithdraws(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_BatchProcessWithdraws_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).BatchProcessWithdraws(ctx, req.(*BatchProcessWithdrawsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_BatchMarkWithdraws_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchMarkWithdrawsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).BatchMarkWithdraws(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_BatchMarkWithdraws_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).BatchMarkWithdraws(ctx, req.(*BatchMarkWithdrawsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_GetWalletStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).GetWalletStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_GetWalletStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).GetWalletStatus(ctx, req.(*GetWalletStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ListPaymentMethods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentMethodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ListPaymentMethods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ListPaymentMethods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ListPaymentMethods(ctx, req.(*ListPaymentMethodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ListSigningKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSigningKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ListSigningKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ListSigningKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ListSigningKeys(ctx, req.(*ListSigningKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_BuyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuyTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).BuyToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_BuyToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).BuyToken(ctx, req.(*BuyTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RedeemToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RedeemToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RedeemToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RedeemToken(ctx, req.(*RedeemTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_IntrospectToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).IntrospectToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_IntrospectToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).IntrospectToken(ctx, req.(*IntrospectTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RefundToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RefundToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RefundToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RefundToken(ctx, req.(*RefundTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_GetRefundPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefundPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).GetRefundPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_GetRefundPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).GetRefundPolicy(ctx, req.(*GetRefundPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_UpdateRefundPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRefundPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).UpdateRefundPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_UpdateRefundPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).UpdateRefundPolicy(ctx, req.(*UpdateRefundPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_GetTokenPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).GetTokenPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_GetTokenPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).GetTokenPolicy(ctx, req.(*GetTokenPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_UpdateTokenPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTokenPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).UpdateTokenPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_UpdateTokenPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).UpdateTokenPolicy(ctx, req.(*UpdateTokenPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_ListBlindTokenKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlindTokenKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ListBlindTokenKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ListBlindTokenKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ListBlindTokenKeys(ctx, req.(*ListBlindTokenKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_IssueBlindTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueBlindTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).IssueBlindTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_IssueBlindTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).IssueBlindTokens(ctx, req.(*IssueBlindTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_RedeemBlindToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemBlindTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).RedeemBlindToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_RedeemBlindToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).RedeemBlindToken(ctx, req.(*RedeemBlindTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeService_PruneSpentBlindTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneSpentBlindTokensRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).PruneSpentBlindTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_PruneSpentBlindTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).PruneSpentBlindTokens(ctx, req.(*PruneSpentBlindTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *exchangeServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PingResponse)
-	err := c.cc.Invoke(ctx, ExchangeService_Ping_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ExchangeService_ListExpiredBlindTokenKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiredBlindTokenKeysRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ListExpiredBlindTokenKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ListExpiredBlindTokenKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ListExpiredBlindTokenKeys(ctx, req.(*ListExpiredBlindTokenKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *exchangeServiceClient) ListPaymentMethods(ctx context.Context, in *ListPaymentMethodsRequest, opts ...grpc.CallOption) (*ListPaymentMethodsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListPaymentMethodsResponse)
-	err := c.cc.Invoke(ctx, ExchangeService_ListPaymentMethods_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ExchangeService_CreateServiceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceSessionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).CreateServiceSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_CreateServiceSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).CreateServiceSession(ctx, req.(*CreateServiceSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *exchangeServiceClient) BuyToken(ctx context.Context, in *BuyTokenRequest, opts ...grpc.CallOption) (*BuyTokenResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(BuyTokenResponse)
-	err := c.cc.Invoke(ctx, ExchangeService_BuyToken_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ExchangeService_ConsumeServiceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsumeServiceSessionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// ExchangeServiceServer is the server API for ExchangeService service.
-// All implementations must embed UnimplementedExchangeServiceServer
-// for forward compatibility.
-type ExchangeServiceServer interface {
-	Login(context.Context, *LoginRequest) (*LoginResponse, error)
-	GetChallenge(context.Context, *GetChallengeRequest) (*GetChallengeResponse, error)
-	Deposit(context.Context, *DepositRequest) (*DepositResponse, error)
-	PruneAccounts(context.Context, *PruneAccountsRequest) (*PruneAccountsResponse, error)
-	CreateWithdraw(context.Context, *CreateWithdrawRequest) (*CreateWithdrawResponse, error)
-	BatchProcessWithdraws(context.Context, *BatchProcessWithdrawsRequest) (*BatchProcessWithdrawsResponse, error)
-	BatchMarkWithdraws(context.Context, *BatchMarkWithdrawsRequest) (*BatchMarkWithdrawsResponse, error)
-	Ping(context.Context, *PingRequest) (*PingResponse, error)
-	ListPaymentMethods(context.Context, *ListPaymentMethodsRequest) (*ListPaymentMethodsResponse, error)
-	BuyToken(context.Context, *BuyTokenRequest) (*BuyTokenResponse, error)
-	mustEmbedUnimplementedExchangeServiceServer()
-}
-
-// UnimplementedExchangeServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedExchangeServiceServer struct{}
-
-func (UnimplementedExchangeServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
-}
-func (UnimplementedExchangeServiceServer) GetChallenge(context.Context, *GetChallengeRequest) (*GetChallengeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetChallenge not implemented")
-}
-func (UnimplementedExchangeServiceServer) Deposit(context.Context, *DepositRequest) (*DepositResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
-}
-func (UnimplementedExchangeServiceServer) PruneAccounts(context.Context, *PruneAccountsRequest) (*PruneAccountsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PruneAccounts not implemented")
-}
-func (UnimplementedExchangeServiceServer) CreateWithdraw(context.Context, *CreateWithdrawRequest) (*CreateWithdrawResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateWithdraw not implemented")
-}
-func (UnimplementedExchangeServiceServer) BatchProcessWithdraws(context.Context, *BatchProcessWithdrawsRequest) (*BatchProcessWithdrawsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BatchProcessWithdraws not implemented")
-}
-func (UnimplementedExchangeServiceServer) BatchMarkWithdraws(context.Context, *BatchMarkWithdrawsRequest) (*BatchMarkWithdrawsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BatchMarkWithdraws not implemented")
-}
-func (UnimplementedExchangeServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
-}
-func (UnimplementedExchangeServiceServer) ListPaymentMethods(context.Context, *ListPaymentMethodsRequest) (*ListPaymentMethodsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListPaymentMethods not implemented")
-}
-func (UnimplementedExchangeServiceServer) BuyToken(context.Context, *BuyTokenRequest) (*BuyTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BuyToken not implemented")
-}
-func (UnimplementedExchangeServiceServer) mustEmbedUnimplementedExchangeServiceServer() {}
-func (UnimplementedExchangeServiceServer) testEmbeddedByValue()                         {}
-
-// UnsafeExchangeServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ExchangeServiceServer will
-// result in compilation errors.
-type UnsafeExchangeServiceServer interface {
-	mustEmbedUnimplementedExchangeServiceServer()
-}
-
-func RegisterExchangeServiceServer(s grpc.ServiceRegistrar, srv ExchangeServiceServer) {
-	// If the following call pancis, it indicates UnimplementedExchangeServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+	if interceptor == nil {
+		return srv.(ExchangeServiceServer).ConsumeServiceSession(ctx, in)
 	}
-	s.RegisterService(&ExchangeService_ServiceDesc, srv)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExchangeService_ConsumeServiceSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeServiceServer).ConsumeServiceSession(ctx, req.(*ConsumeServiceSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _ExchangeService_GetServiceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).Login(ctx, in)
+		return srv.(ExchangeServiceServer).GetServiceSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_Login_FullMethodName,
+		FullMethod: ExchangeService_GetServiceSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).Login(ctx, req.(*LoginRequest))
+		return srv.(ExchangeServiceServer).GetServiceSession(ctx, req.(*GetServiceSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_GetChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChallengeRequest)
+func _ExchangeService_TopUpServiceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopUpServiceSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).GetChallenge(ctx, in)
+		return srv.(ExchangeServiceServer).TopUpServiceSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_GetChallenge_FullMethodName,
+		FullMethod: ExchangeService_TopUpServiceSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).GetChallenge(ctx, req.(*GetChallengeRequest))
+		return srv.(ExchangeServiceServer).TopUpServiceSession(ctx, req.(*TopUpServiceSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DepositRequest)
+func _ExchangeService_PauseServiceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseServiceSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).Deposit(ctx, in)
+		return srv.(ExchangeServiceServer).PauseServiceSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_Deposit_FullMethodName,
+		FullMethod: ExchangeService_PauseServiceSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).Deposit(ctx, req.(*DepositRequest))
+		return srv.(ExchangeServiceServer).PauseServiceSession(ctx, req.(*PauseServiceSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_PruneAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PruneAccountsRequest)
+func _ExchangeService_ResumeServiceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeServiceSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).PruneAccounts(ctx, in)
+		return srv.(ExchangeServiceServer).ResumeServiceSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_PruneAccounts_FullMethodName,
+		FullMethod: ExchangeService_ResumeServiceSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).PruneAccounts(ctx, req.(*PruneAccountsRequest))
+		return srv.(ExchangeServiceServer).ResumeServiceSession(ctx, req.(*ResumeServiceSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_CreateWithdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateWithdrawRequest)
+func _ExchangeService_CloseServiceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseServiceSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).CreateWithdraw(ctx, in)
+		return srv.(ExchangeServiceServer).CloseServiceSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_CreateWithdraw_FullMethodName,
+		FullMethod: ExchangeService_CloseServiceSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).CreateWithdraw(ctx, req.(*CreateWithdrawRequest))
+		return srv.(ExchangeServiceServer).CloseServiceSession(ctx, req.(*CloseServiceSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_BatchProcessWithdraws_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BatchProcessWithdrawsRequest)
+func _ExchangeService_SubmitConsumptionReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitConsumptionReceiptsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).BatchProcessWithdraws(ctx, in)
+		return srv.(ExchangeServiceServer).SubmitConsumptionReceipts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_BatchProcessWithdraws_FullMethodName,
+		FullMethod: ExchangeService_SubmitConsumptionReceipts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).BatchProcessWithdraws(ctx, req.(*BatchProcessWithdrawsRequest))
+		return srv.(ExchangeServiceServer).SubmitConsumptionReceipts(ctx, req.(*SubmitConsumptionReceiptsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_BatchMarkWithdraws_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BatchMarkWithdrawsRequest)
+func _ExchangeService_GetTreeHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTreeHeadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).BatchMarkWithdraws(ctx, in)
+		return srv.(ExchangeServiceServer).GetTreeHead(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_BatchMarkWithdraws_FullMethodName,
+		FullMethod: ExchangeService_GetTreeHead_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).BatchMarkWithdraws(ctx, req.(*BatchMarkWithdrawsRequest))
+		return srv.(ExchangeServiceServer).GetTreeHead(ctx, req.(*GetTreeHeadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PingRequest)
+func _ExchangeService_ListLogEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLogEntriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).Ping(ctx, in)
+		return srv.(ExchangeServiceServer).ListLogEntries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_Ping_FullMethodName,
+		FullMethod: ExchangeService_ListLogEntries_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).Ping(ctx, req.(*PingRequest))
+		return srv.(ExchangeServiceServer).ListLogEntries(ctx, req.(*ListLogEntriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_ListPaymentMethods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPaymentMethodsRequest)
+func _ExchangeService_GetInclusionProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInclusionProofRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).ListPaymentMethods(ctx, in)
+		return srv.(ExchangeServiceServer).GetInclusionProof(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_ListPaymentMethods_FullMethodName,
+		FullMethod: ExchangeService_GetInclusionProof_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).ListPaymentMethods(ctx, req.(*ListPaymentMethodsRequest))
+		return srv.(ExchangeServiceServer).GetInclusionProof(ctx, req.(*GetInclusionProofRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ExchangeService_BuyToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BuyTokenRequest)
+func _ExchangeService_GetConsistencyProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsistencyProofRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ExchangeServiceServer).BuyToken(ctx, in)
+		return srv.(ExchangeServiceServer).GetConsistencyProof(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ExchangeService_BuyToken_FullMethodName,
+		FullMethod: ExchangeService_GetConsistencyProof_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExchangeServiceServer).BuyToken(ctx, req.(*BuyTokenRequest))
+		return srv.(ExchangeServiceServer).GetConsistencyProof(ctx, req.(*GetConsistencyProofRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -421,6 +1715,42 @@ var ExchangeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _ExchangeService_Login_Handler,
 		},
+		{
+			MethodName: "LoginWithSignature",
+			Handler:    _ExchangeService_LoginWithSignature_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _ExchangeService_ResetPassword_Handler,
+		},
+		{
+			MethodName: "RefreshSession",
+			Handler:    _ExchangeService_RefreshSession_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _ExchangeService_Logout_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _ExchangeService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _ExchangeService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "CreateApiKey",
+			Handler:    _ExchangeService_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "ListApiKeys",
+			Handler:    _ExchangeService_ListApiKeys_Handler,
+		},
+		{
+			MethodName: "RevokeApiKey",
+			Handler:    _ExchangeService_RevokeApiKey_Handler,
+		},
 		{
 			MethodName: "GetChallenge",
 			Handler:    _ExchangeService_GetChallenge_Handler,
@@ -437,6 +1767,10 @@ var ExchangeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateWithdraw",
 			Handler:    _ExchangeService_CreateWithdraw_Handler,
 		},
+		{
+			MethodName: "EstimateWithdrawFee",
+			Handler:    _ExchangeService_EstimateWithdrawFee_Handler,
+		},
 		{
 			MethodName: "BatchProcessWithdraws",
 			Handler:    _ExchangeService_BatchProcessWithdraws_Handler,
@@ -445,6 +1779,10 @@ var ExchangeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchMarkWithdraws",
 			Handler:    _ExchangeService_BatchMarkWithdraws_Handler,
 		},
+		{
+			MethodName: "GetWalletStatus",
+			Handler:    _ExchangeService_GetWalletStatus_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _ExchangeService_Ping_Handler,
@@ -453,10 +1791,110 @@ var ExchangeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListPaymentMethods",
 			Handler:    _ExchangeService_ListPaymentMethods_Handler,
 		},
+		{
+			MethodName: "ListSigningKeys",
+			Handler:    _ExchangeService_ListSigningKeys_Handler,
+		},
 		{
 			MethodName: "BuyToken",
 			Handler:    _ExchangeService_BuyToken_Handler,
 		},
+		{
+			MethodName: "RedeemToken",
+			Handler:    _ExchangeService_RedeemToken_Handler,
+		},
+		{
+			MethodName: "IntrospectToken",
+			Handler:    _ExchangeService_IntrospectToken_Handler,
+		},
+		{
+			MethodName: "RefundToken",
+			Handler:    _ExchangeService_RefundToken_Handler,
+		},
+		{
+			MethodName: "GetRefundPolicy",
+			Handler:    _ExchangeService_GetRefundPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateRefundPolicy",
+			Handler:    _ExchangeService_UpdateRefundPolicy_Handler,
+		},
+		{
+			MethodName: "GetTokenPolicy",
+			Handler:    _ExchangeService_GetTokenPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateTokenPolicy",
+			Handler:    _ExchangeService_UpdateTokenPolicy_Handler,
+		},
+		{
+			MethodName: "ListBlindTokenKeys",
+			Handler:    _ExchangeService_ListBlindTokenKeys_Handler,
+		},
+		{
+			MethodName: "IssueBlindTokens",
+			Handler:    _ExchangeService_IssueBlindTokens_Handler,
+		},
+		{
+			MethodName: "RedeemBlindToken",
+			Handler:    _ExchangeService_RedeemBlindToken_Handler,
+		},
+		{
+			MethodName: "PruneSpentBlindTokens",
+			Handler:    _ExchangeService_PruneSpentBlindTokens_Handler,
+		},
+		{
+			MethodName: "ListExpiredBlindTokenKeys",
+			Handler:    _ExchangeService_ListExpiredBlindTokenKeys_Handler,
+		},
+		{
+			MethodName: "CreateServiceSession",
+			Handler:    _ExchangeService_CreateServiceSession_Handler,
+		},
+		{
+			MethodName: "ConsumeServiceSession",
+			Handler:    _ExchangeService_ConsumeServiceSession_Handler,
+		},
+		{
+			MethodName: "GetServiceSession",
+			Handler:    _ExchangeService_GetServiceSession_Handler,
+		},
+		{
+			MethodName: "TopUpServiceSession",
+			Handler:    _ExchangeService_TopUpServiceSession_Handler,
+		},
+		{
+			MethodName: "PauseServiceSession",
+			Handler:    _ExchangeService_PauseServiceSession_Handler,
+		},
+		{
+			MethodName: "ResumeServiceSession",
+			Handler:    _ExchangeService_ResumeServiceSession_Handler,
+		},
+		{
+			MethodName: "CloseServiceSession",
+			Handler:    _ExchangeService_CloseServiceSession_Handler,
+		},
+		{
+			MethodName: "SubmitConsumptionReceipts",
+			Handler:    _ExchangeService_SubmitConsumptionReceipts_Handler,
+		},
+		{
+			MethodName: "GetTreeHead",
+			Handler:    _ExchangeService_GetTreeHead_Handler,
+		},
+		{
+			MethodName: "ListLogEntries",
+			Handler:    _ExchangeService_ListLogEntries_Handler,
+		},
+		{
+			MethodName: "GetInclusionProof",
+			Handler:    _ExchangeService_GetInclusionProof_Handler,
+		},
+		{
+			MethodName: "GetConsistencyProof",
+			Handler:    _ExchangeService_GetConsistencyProof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "exchange/v1/exchange.proto",
@@ -35,6 +35,32 @@ const (
 const (
 	// ExchangeServiceLoginProcedure is the fully-qualified name of the ExchangeService's Login RPC.
 	ExchangeServiceLoginProcedure = "/exchange.v1.ExchangeService/Login"
+	// ExchangeServiceLoginWithSignatureProcedure is the fully-qualified name of the ExchangeService's
+	// LoginWithSignature RPC.
+	ExchangeServiceLoginWithSignatureProcedure = "/exchange.v1.ExchangeService/LoginWithSignature"
+	// ExchangeServiceResetPasswordProcedure is the fully-qualified name of the ExchangeService's
+	// ResetPassword RPC.
+	ExchangeServiceResetPasswordProcedure = "/exchange.v1.ExchangeService/ResetPassword"
+	// ExchangeServiceRefreshSessionProcedure is the fully-qualified name of the ExchangeService's
+	// RefreshSession RPC.
+	ExchangeServiceRefreshSessionProcedure = "/exchange.v1.ExchangeService/RefreshSession"
+	// ExchangeServiceLogoutProcedure is the fully-qualified name of the ExchangeService's Logout RPC.
+	ExchangeServiceLogoutProcedure = "/exchange.v1.ExchangeService/Logout"
+	// ExchangeServiceListSessionsProcedure is the fully-qualified name of the ExchangeService's
+	// ListSessions RPC.
+	ExchangeServiceListSessionsProcedure = "/exchange.v1.ExchangeService/ListSessions"
+	// ExchangeServiceRevokeSessionProcedure is the fully-qualified name of the ExchangeService's
+	// RevokeSession RPC.
+	ExchangeServiceRevokeSessionProcedure = "/exchange.v1.ExchangeService/RevokeSession"
+	// ExchangeServiceCreateApiKeyProcedure is the fully-qualified name of the ExchangeService's
+	// CreateApiKey RPC.
+	ExchangeServiceCreateApiKeyProcedure = "/exchange.v1.ExchangeService/CreateApiKey"
+	// ExchangeServiceListApiKeysProcedure is the fully-qualified name of the ExchangeService's
+	// ListApiKeys RPC.
+	ExchangeServiceListApiKeysProcedure = "/exchange.v1.ExchangeService/ListApiKeys"
+	// ExchangeServiceRevokeApiKeyProcedure is the fully-qualified name of the ExchangeService's
+	// RevokeApiKey RPC.
+	ExchangeServiceRevokeApiKeyProcedure = "/exchange.v1.ExchangeService/RevokeApiKey"
 	// ExchangeServiceGetChallengeProcedure is the fully-qualified name of the ExchangeService's
 	// GetChallenge RPC.
 	ExchangeServiceGetChallengeProcedure = "/exchange.v1.ExchangeService/GetChallenge"
@@ -46,34 +72,186 @@ const (
 	// ExchangeServiceCreateWithdrawProcedure is the fully-qualified name of the ExchangeService's
 	// CreateWithdraw RPC.
 	ExchangeServiceCreateWithdrawProcedure = "/exchange.v1.ExchangeService/CreateWithdraw"
+	// ExchangeServiceEstimateWithdrawFeeProcedure is the fully-qualified name of the ExchangeService's
+	// EstimateWithdrawFee RPC.
+	ExchangeServiceEstimateWithdrawFeeProcedure = "/exchange.v1.ExchangeService/EstimateWithdrawFee"
 	// ExchangeServiceBatchProcessWithdrawsProcedure is the fully-qualified name of the
 	// ExchangeService's BatchProcessWithdraws RPC.
 	ExchangeServiceBatchProcessWithdrawsProcedure = "/exchange.v1.ExchangeService/BatchProcessWithdraws"
 	// ExchangeServiceBatchMarkWithdrawsProcedure is the fully-qualified name of the ExchangeService's
 	// BatchMarkWithdraws RPC.
 	ExchangeServiceBatchMarkWithdrawsProcedure = "/exchange.v1.ExchangeService/BatchMarkWithdraws"
+	// ExchangeServiceGetWalletStatusProcedure is the fully-qualified name of the ExchangeService's
+	// GetWalletStatus RPC.
+	ExchangeServiceGetWalletStatusProcedure = "/exchange.v1.ExchangeService/GetWalletStatus"
 	// ExchangeServicePingProcedure is the fully-qualified name of the ExchangeService's Ping RPC.
 	ExchangeServicePingProcedure = "/exchange.v1.ExchangeService/Ping"
 	// ExchangeServiceListPaymentMethodsProcedure is the fully-qualified name of the ExchangeService's
 	// ListPaymentMethods RPC.
 	ExchangeServiceListPaymentMethodsProcedure = "/exchange.v1.ExchangeService/ListPaymentMethods"
+	// ExchangeServiceListSigningKeysProcedure is the fully-qualified name of the ExchangeService's
+	// ListSigningKeys RPC.
+	ExchangeServiceListSigningKeysProcedure = "/exchange.v1.ExchangeService/ListSigningKeys"
 	// ExchangeServiceBuyTokenProcedure is the fully-qualified name of the ExchangeService's BuyToken
 	// RPC.
 	ExchangeServiceBuyTokenProcedure = "/exchange.v1.ExchangeService/BuyToken"
+	// ExchangeServiceRedeemTokenProcedure is the fully-qualified name of the ExchangeService's
+	// RedeemToken RPC.
+	ExchangeServiceRedeemTokenProcedure = "/exchange.v1.ExchangeService/RedeemToken"
+	// ExchangeServiceIntrospectTokenProcedure is the fully-qualified name of the ExchangeService's
+	// IntrospectToken RPC.
+	ExchangeServiceIntrospectTokenProcedure = "/exchange.v1.ExchangeService/IntrospectToken"
+	// ExchangeServiceRefundTokenProcedure is the fully-qualified name of the ExchangeService's
+	// RefundToken RPC.
+	ExchangeServiceRefundTokenProcedure = "/exchange.v1.ExchangeService/RefundToken"
+	// ExchangeServiceGetRefundPolicyProcedure is the fully-qualified name of the ExchangeService's
+	// GetRefundPolicy RPC.
+	ExchangeServiceGetRefundPolicyProcedure = "/exchange.v1.ExchangeService/GetRefundPolicy"
+	// ExchangeServiceUpdateRefundPolicyProcedure is the fully-qualified name of the ExchangeService's
+	// UpdateRefundPolicy RPC.
+	ExchangeServiceUpdateRefundPolicyProcedure = "/exchange.v1.ExchangeService/UpdateRefundPolicy"
+	// ExchangeServiceGetTokenPolicyProcedure is the fully-qualified name of the ExchangeService's
+	// GetTokenPolicy RPC.
+	ExchangeServiceGetTokenPolicyProcedure = "/exchange.v1.ExchangeService/GetTokenPolicy"
+	// ExchangeServiceUpdateTokenPolicyProcedure is the fully-qualified name of the ExchangeService's
+	// UpdateTokenPolicy RPC.
+	ExchangeServiceUpdateTokenPolicyProcedure = "/exchange.v1.ExchangeService/UpdateTokenPolicy"
+	// ExchangeServiceListBlindTokenKeysProcedure is the fully-qualified name of the ExchangeService's
+	// ListBlindTokenKeys RPC.
+	ExchangeServiceListBlindTokenKeysProcedure = "/exchange.v1.ExchangeService/ListBlindTokenKeys"
+	// ExchangeServiceIssueBlindTokensProcedure is the fully-qualified name of the ExchangeService's
+	// IssueBlindTokens RPC.
+	ExchangeServiceIssueBlindTokensProcedure = "/exchange.v1.ExchangeService/IssueBlindTokens"
+	// ExchangeServiceRedeemBlindTokenProcedure is the fully-qualified name of the ExchangeService's
+	// RedeemBlindToken RPC.
+	ExchangeServiceRedeemBlindTokenProcedure = "/exchange.v1.ExchangeService/RedeemBlindToken"
+	// ExchangeServicePruneSpentBlindTokensProcedure is the fully-qualified name of the
+	// ExchangeService's PruneSpentBlindTokens RPC.
+	ExchangeServicePruneSpentBlindTokensProcedure = "/exchange.v1.ExchangeService/PruneSpentBlindTokens"
+	// ExchangeServiceListExpiredBlindTokenKeysProcedure is the fully-qualified name of the
+	// ExchangeService's ListExpiredBlindTokenKeys RPC.
+	ExchangeServiceListExpiredBlindTokenKeysProcedure = "/exchange.v1.ExchangeService/ListExpiredBlindTokenKeys"
+	// ExchangeServiceCreateServiceSessionProcedure is the fully-qualified name of the ExchangeService's
+	// CreateServiceSession RPC.
+	ExchangeServiceCreateServiceSessionProcedure = "/exchange.v1.ExchangeService/CreateServiceSession"
+	// ExchangeServiceConsumeServiceSessionProcedure is the fully-qualified name of the
+	// ExchangeService's ConsumeServiceSession RPC.
+	ExchangeServiceConsumeServiceSessionProcedure = "/exchange.v1.ExchangeService/ConsumeServiceSession"
+	// ExchangeServiceGetServiceSessionProcedure is the fully-qualified name of the ExchangeService's
+	// GetServiceSession RPC.
+	ExchangeServiceGetServiceSessionProcedure = "/exchange.v1.ExchangeService/GetServiceSession"
+	// ExchangeServiceTopUpServiceSessionProcedure is the fully-qualified name of the ExchangeService's
+	// TopUpServiceSession RPC.
+	ExchangeServiceTopUpServiceSessionProcedure = "/exchange.v1.ExchangeService/TopUpServiceSession"
+	// ExchangeServicePauseServiceSessionProcedure is the fully-qualified name of the ExchangeService's
+	// PauseServiceSession RPC.
+	ExchangeServicePauseServiceSessionProcedure = "/exchange.v1.ExchangeService/PauseServiceSession"
+	// ExchangeServiceResumeServiceSessionProcedure is the fully-qualified name of the ExchangeService's
+	// ResumeServiceSession RPC.
+	ExchangeServiceResumeServiceSessionProcedure = "/exchange.v1.ExchangeService/ResumeServiceSession"
+	// ExchangeServiceCloseServiceSessionProcedure is the fully-qualified name of the ExchangeService's
+	// CloseServiceSession RPC.
+	ExchangeServiceCloseServiceSessionProcedure = "/exchange.v1.ExchangeService/CloseServiceSession"
+	// ExchangeServiceSubmitConsumptionReceiptsProcedure is the fully-qualified name of the
+	// ExchangeService's SubmitConsumptionReceipts RPC.
+	ExchangeServiceSubmitConsumptionReceiptsProcedure = "/exchange.v1.ExchangeService/SubmitConsumptionReceipts"
+	// ExchangeServiceGetTreeHeadProcedure is the fully-qualified name of the ExchangeService's
+	// GetTreeHead RPC.
+	ExchangeServiceGetTreeHeadProcedure = "/exchange.v1.ExchangeService/GetTreeHead"
+	// ExchangeServiceListLogEntriesProcedure is the fully-qualified name of the ExchangeService's
+	// ListLogEntries RPC.
+	ExchangeServiceListLogEntriesProcedure = "/exchange.v1.ExchangeService/ListLogEntries"
+	// ExchangeServiceGetInclusionProofProcedure is the fully-qualified name of the ExchangeService's
+	// GetInclusionProof RPC.
+	ExchangeServiceGetInclusionProofProcedure = "/exchange.v1.ExchangeService/GetInclusionProof"
+	// ExchangeServiceGetConsistencyProofProcedure is the fully-qualified name of the ExchangeService's
+	// GetConsistencyProof RPC.
+	ExchangeServiceGetConsistencyProofProcedure = "/exchange.v1.ExchangeService/GetConsistencyProof"
 )
 
 // ExchangeServiceClient is a client for the exchange.v1.ExchangeService service.
 type ExchangeServiceClient interface {
 	Login(context.Context, *connect.Request[v1.LoginRequest]) (*connect.Response[v1.LoginResponse], error)
+	// LoginWithSignature logs in with a challenge signed by the wallet that
+	// made the first deposit of the account instead of a password.
+	LoginWithSignature(context.Context, *connect.Request[v1.LoginWithSignatureRequest]) (*connect.Response[v1.LoginWithSignatureResponse], error)
+	// ResetPassword sets a new password after proving control of a wallet that
+	// deposited to the account. Existing sessions are revoked and withdrawals
+	// are paused for a cool-down.
+	ResetPassword(context.Context, *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error)
+	// RefreshSession exchanges a refresh token for a new access token and a
+	// new refresh token. The old refresh token must not be used again.
+	RefreshSession(context.Context, *connect.Request[v1.RefreshSessionRequest]) (*connect.Response[v1.RefreshSessionResponse], error)
+	Logout(context.Context, *connect.Request[v1.LogoutRequest]) (*connect.Response[v1.LogoutResponse], error)
+	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
+	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	CreateApiKey(context.Context, *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error)
+	ListApiKeys(context.Context, *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error)
+	RevokeApiKey(context.Context, *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error)
 	GetChallenge(context.Context, *connect.Request[v1.GetChallengeRequest]) (*connect.Response[v1.GetChallengeResponse], error)
 	Deposit(context.Context, *connect.Request[v1.DepositRequest]) (*connect.Response[v1.DepositResponse], error)
+	// PruneAccounts deletes expired accounts. Escrows held for them are
+	// settled first, paying the unclaimed rest back to the buyers.
 	PruneAccounts(context.Context, *connect.Request[v1.PruneAccountsRequest]) (*connect.Response[v1.PruneAccountsResponse], error)
 	CreateWithdraw(context.Context, *connect.Request[v1.CreateWithdrawRequest]) (*connect.Response[v1.CreateWithdrawResponse], error)
+	EstimateWithdrawFee(context.Context, *connect.Request[v1.EstimateWithdrawFeeRequest]) (*connect.Response[v1.EstimateWithdrawFeeResponse], error)
 	BatchProcessWithdraws(context.Context, *connect.Request[v1.BatchProcessWithdrawsRequest]) (*connect.Response[v1.BatchProcessWithdrawsResponse], error)
 	BatchMarkWithdraws(context.Context, *connect.Request[v1.BatchMarkWithdrawsRequest]) (*connect.Response[v1.BatchMarkWithdrawsResponse], error)
+	// GetWalletStatus reports the hot wallets as of their last periodic check.
+	// It does not query the chain or raise refill alerts itself.
+	GetWalletStatus(context.Context, *connect.Request[v1.GetWalletStatusRequest]) (*connect.Response[v1.GetWalletStatusResponse], error)
 	Ping(context.Context, *connect.Request[v1.PingRequest]) (*connect.Response[v1.PingResponse], error)
 	ListPaymentMethods(context.Context, *connect.Request[v1.ListPaymentMethodsRequest]) (*connect.Response[v1.ListPaymentMethodsResponse], error)
+	ListSigningKeys(context.Context, *connect.Request[v1.ListSigningKeysRequest]) (*connect.Response[v1.ListSigningKeysResponse], error)
 	BuyToken(context.Context, *connect.Request[v1.BuyTokenRequest]) (*connect.Response[v1.BuyTokenResponse], error)
+	// RedeemToken consumes quota of a token. Only its audience may call it.
+	RedeemToken(context.Context, *connect.Request[v1.RedeemTokenRequest]) (*connect.Response[v1.RedeemTokenResponse], error)
+	IntrospectToken(context.Context, *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error)
+	// RefundToken revokes a token of the caller and pays back its unconsumed
+	// quota if the refund policy of its audience allows.
+	RefundToken(context.Context, *connect.Request[v1.RefundTokenRequest]) (*connect.Response[v1.RefundTokenResponse], error)
+	GetRefundPolicy(context.Context, *connect.Request[v1.GetRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error)
+	// UpdateRefundPolicy sets the refund policy of tokens bought for the caller.
+	UpdateRefundPolicy(context.Context, *connect.Request[v1.UpdateRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error)
+	GetTokenPolicy(context.Context, *connect.Request[v1.GetTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error)
+	// UpdateTokenPolicy sets how long tokens bought for the caller may last.
+	UpdateTokenPolicy(context.Context, *connect.Request[v1.UpdateTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error)
+	ListBlindTokenKeys(context.Context, *connect.Request[v1.ListBlindTokenKeysRequest]) (*connect.Response[v1.ListBlindTokenKeysResponse], error)
+	// IssueBlindTokens signs blinded messages with the key of a denomination and
+	// charges the caller the denomination for each. See pkg/blind. Tokens not
+	// redeemed before the key expires are not refunded, since Prex cannot tell
+	// who holds them. ListExpiredBlindTokenKeys reports the value left behind.
+	IssueBlindTokens(context.Context, *connect.Request[v1.IssueBlindTokensRequest]) (*connect.Response[v1.IssueBlindTokensResponse], error)
+	// RedeemBlindToken spends a blind token and pays its denomination to the
+	// caller, who must be the audience the token was bought for.
+	RedeemBlindToken(context.Context, *connect.Request[v1.RedeemBlindTokenRequest]) (*connect.Response[v1.RedeemBlindTokenResponse], error)
+	// PruneSpentBlindTokens forgets the spent tokens of expired keys, which can
+	// no longer be redeemed anyway.
+	PruneSpentBlindTokens(context.Context, *connect.Request[v1.PruneSpentBlindTokensRequest]) (*connect.Response[v1.PruneSpentBlindTokensResponse], error)
+	// ListExpiredBlindTokenKeys reports the expired keys with unredeemed tokens
+	// so operators can reconcile the value Prex kept from them.
+	ListExpiredBlindTokenKeys(context.Context, *connect.Request[v1.ListExpiredBlindTokenKeysRequest]) (*connect.Response[v1.ListExpiredBlindTokenKeysResponse], error)
+	// CreateServiceSession redeems a create-session token into a session
+	// metered by its audience and returns a manage-session token for the user.
+	CreateServiceSession(context.Context, *connect.Request[v1.CreateServiceSessionRequest]) (*connect.Response[v1.CreateServiceSessionResponse], error)
+	ConsumeServiceSession(context.Context, *connect.Request[v1.ConsumeServiceSessionRequest]) (*connect.Response[v1.ConsumeServiceSessionResponse], error)
+	// The methods below are authorized by the manage-session token in the
+	// request.
+	GetServiceSession(context.Context, *connect.Request[v1.GetServiceSessionRequest]) (*connect.Response[v1.GetServiceSessionResponse], error)
+	TopUpServiceSession(context.Context, *connect.Request[v1.TopUpServiceSessionRequest]) (*connect.Response[v1.TopUpServiceSessionResponse], error)
+	PauseServiceSession(context.Context, *connect.Request[v1.PauseServiceSessionRequest]) (*connect.Response[v1.PauseServiceSessionResponse], error)
+	ResumeServiceSession(context.Context, *connect.Request[v1.ResumeServiceSessionRequest]) (*connect.Response[v1.ResumeServiceSessionResponse], error)
+	CloseServiceSession(context.Context, *connect.Request[v1.CloseServiceSessionRequest]) (*connect.Response[v1.CloseServiceSessionResponse], error)
+	// SubmitConsumptionReceipts pays the audience of escrowed tokens what its
+	// receipts claim, up to the quota it consumed with RedeemToken. Receipts
+	// are authorized by the signature of the audience, so anyone may relay them.
+	SubmitConsumptionReceipts(context.Context, *connect.Request[v1.SubmitConsumptionReceiptsRequest]) (*connect.Response[v1.SubmitConsumptionReceiptsResponse], error)
+	// The methods below serve the transparency log of issued tokens for
+	// auditing. See pkg/transparency to verify what they return.
+	GetTreeHead(context.Context, *connect.Request[v1.GetTreeHeadRequest]) (*connect.Response[v1.SignedTreeHead], error)
+	ListLogEntries(context.Context, *connect.Request[v1.ListLogEntriesRequest]) (*connect.Response[v1.ListLogEntriesResponse], error)
+	GetInclusionProof(context.Context, *connect.Request[v1.GetInclusionProofRequest]) (*connect.Response[v1.GetInclusionProofResponse], error)
+	GetConsistencyProof(context.Context, *connect.Request[v1.GetConsistencyProofRequest]) (*connect.Response[v1.GetConsistencyProofResponse], error)
 }
 
 // NewExchangeServiceClient constructs a client for the exchange.v1.ExchangeService service. By
@@ -93,6 +271,60 @@ func NewExchangeServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(exchangeServiceMethods.ByName("Login")),
 			connect.WithClientOptions(opts...),
 		),
+		loginWithSignature: connect.NewClient[v1.LoginWithSignatureRequest, v1.LoginWithSignatureResponse](
+			httpClient,
+			baseURL+ExchangeServiceLoginWithSignatureProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("LoginWithSignature")),
+			connect.WithClientOptions(opts...),
+		),
+		resetPassword: connect.NewClient[v1.ResetPasswordRequest, v1.ResetPasswordResponse](
+			httpClient,
+			baseURL+ExchangeServiceResetPasswordProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ResetPassword")),
+			connect.WithClientOptions(opts...),
+		),
+		refreshSession: connect.NewClient[v1.RefreshSessionRequest, v1.RefreshSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceRefreshSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("RefreshSession")),
+			connect.WithClientOptions(opts...),
+		),
+		logout: connect.NewClient[v1.LogoutRequest, v1.LogoutResponse](
+			httpClient,
+			baseURL+ExchangeServiceLogoutProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("Logout")),
+			connect.WithClientOptions(opts...),
+		),
+		listSessions: connect.NewClient[v1.ListSessionsRequest, v1.ListSessionsResponse](
+			httpClient,
+			baseURL+ExchangeServiceListSessionsProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ListSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeSession: connect.NewClient[v1.RevokeSessionRequest, v1.RevokeSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceRevokeSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("RevokeSession")),
+			connect.WithClientOptions(opts...),
+		),
+		createApiKey: connect.NewClient[v1.CreateApiKeyRequest, v1.CreateApiKeyResponse](
+			httpClient,
+			baseURL+ExchangeServiceCreateApiKeyProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("CreateApiKey")),
+			connect.WithClientOptions(opts...),
+		),
+		listApiKeys: connect.NewClient[v1.ListApiKeysRequest, v1.ListApiKeysResponse](
+			httpClient,
+			baseURL+ExchangeServiceListApiKeysProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ListApiKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeApiKey: connect.NewClient[v1.RevokeApiKeyRequest, v1.RevokeApiKeyResponse](
+			httpClient,
+			baseURL+ExchangeServiceRevokeApiKeyProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("RevokeApiKey")),
+			connect.WithClientOptions(opts...),
+		),
 		getChallenge: connect.NewClient[v1.GetChallengeRequest, v1.GetChallengeResponse](
 			httpClient,
 			baseURL+ExchangeServiceGetChallengeProcedure,
@@ -117,6 +349,12 @@ func NewExchangeServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(exchangeServiceMethods.ByName("CreateWithdraw")),
 			connect.WithClientOptions(opts...),
 		),
+		estimateWithdrawFee: connect.NewClient[v1.EstimateWithdrawFeeRequest, v1.EstimateWithdrawFeeResponse](
+			httpClient,
+			baseURL+ExchangeServiceEstimateWithdrawFeeProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("EstimateWithdrawFee")),
+			connect.WithClientOptions(opts...),
+		),
 		batchProcessWithdraws: connect.NewClient[v1.BatchProcessWithdrawsRequest, v1.BatchProcessWithdrawsResponse](
 			httpClient,
 			baseURL+ExchangeServiceBatchProcessWithdrawsProcedure,
@@ -129,6 +367,12 @@ func NewExchangeServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(exchangeServiceMethods.ByName("BatchMarkWithdraws")),
 			connect.WithClientOptions(opts...),
 		),
+		getWalletStatus: connect.NewClient[v1.GetWalletStatusRequest, v1.GetWalletStatusResponse](
+			httpClient,
+			baseURL+ExchangeServiceGetWalletStatusProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("GetWalletStatus")),
+			connect.WithClientOptions(opts...),
+		),
 		ping: connect.NewClient[v1.PingRequest, v1.PingResponse](
 			httpClient,
 			baseURL+ExchangeServicePingProcedure,
@@ -141,27 +385,213 @@ func NewExchangeServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(exchangeServiceMethods.ByName("ListPaymentMethods")),
 			connect.WithClientOptions(opts...),
 		),
+		listSigningKeys: connect.NewClient[v1.ListSigningKeysRequest, v1.ListSigningKeysResponse](
+			httpClient,
+			baseURL+ExchangeServiceListSigningKeysProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ListSigningKeys")),
+			connect.WithClientOptions(opts...),
+		),
 		buyToken: connect.NewClient[v1.BuyTokenRequest, v1.BuyTokenResponse](
 			httpClient,
 			baseURL+ExchangeServiceBuyTokenProcedure,
 			connect.WithSchema(exchangeServiceMethods.ByName("BuyToken")),
 			connect.WithClientOptions(opts...),
 		),
+		redeemToken: connect.NewClient[v1.RedeemTokenRequest, v1.RedeemTokenResponse](
+			httpClient,
+			baseURL+ExchangeServiceRedeemTokenProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("RedeemToken")),
+			connect.WithClientOptions(opts...),
+		),
+		introspectToken: connect.NewClient[v1.IntrospectTokenRequest, v1.IntrospectTokenResponse](
+			httpClient,
+			baseURL+ExchangeServiceIntrospectTokenProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("IntrospectToken")),
+			connect.WithClientOptions(opts...),
+		),
+		refundToken: connect.NewClient[v1.RefundTokenRequest, v1.RefundTokenResponse](
+			httpClient,
+			baseURL+ExchangeServiceRefundTokenProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("RefundToken")),
+			connect.WithClientOptions(opts...),
+		),
+		getRefundPolicy: connect.NewClient[v1.GetRefundPolicyRequest, v1.RefundPolicy](
+			httpClient,
+			baseURL+ExchangeServiceGetRefundPolicyProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("GetRefundPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		updateRefundPolicy: connect.NewClient[v1.UpdateRefundPolicyRequest, v1.RefundPolicy](
+			httpClient,
+			baseURL+ExchangeServiceUpdateRefundPolicyProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("UpdateRefundPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		getTokenPolicy: connect.NewClient[v1.GetTokenPolicyRequest, v1.TokenPolicy](
+			httpClient,
+			baseURL+ExchangeServiceGetTokenPolicyProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("GetTokenPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		updateTokenPolicy: connect.NewClient[v1.UpdateTokenPolicyRequest, v1.TokenPolicy](
+			httpClient,
+			baseURL+ExchangeServiceUpdateTokenPolicyProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("UpdateTokenPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		listBlindTokenKeys: connect.NewClient[v1.ListBlindTokenKeysRequest, v1.ListBlindTokenKeysResponse](
+			httpClient,
+			baseURL+ExchangeServiceListBlindTokenKeysProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ListBlindTokenKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		issueBlindTokens: connect.NewClient[v1.IssueBlindTokensRequest, v1.IssueBlindTokensResponse](
+			httpClient,
+			baseURL+ExchangeServiceIssueBlindTokensProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("IssueBlindTokens")),
+			connect.WithClientOptions(opts...),
+		),
+		redeemBlindToken: connect.NewClient[v1.RedeemBlindTokenRequest, v1.RedeemBlindTokenResponse](
+			httpClient,
+			baseURL+ExchangeServiceRedeemBlindTokenProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("RedeemBlindToken")),
+			connect.WithClientOptions(opts...),
+		),
+		pruneSpentBlindTokens: connect.NewClient[v1.PruneSpentBlindTokensRequest, v1.PruneSpentBlindTokensResponse](
+			httpClient,
+			baseURL+ExchangeServicePruneSpentBlindTokensProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("PruneSpentBlindTokens")),
+			connect.WithClientOptions(opts...),
+		),
+		listExpiredBlindTokenKeys: connect.NewClient[v1.ListExpiredBlindTokenKeysRequest, v1.ListExpiredBlindTokenKeysResponse](
+			httpClient,
+			baseURL+ExchangeServiceListExpiredBlindTokenKeysProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ListExpiredBlindTokenKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		createServiceSession: connect.NewClient[v1.CreateServiceSessionRequest, v1.CreateServiceSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceCreateServiceSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("CreateServiceSession")),
+			connect.WithClientOptions(opts...),
+		),
+		consumeServiceSession: connect.NewClient[v1.ConsumeServiceSessionRequest, v1.ConsumeServiceSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceConsumeServiceSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ConsumeServiceSession")),
+			connect.WithClientOptions(opts...),
+		),
+		getServiceSession: connect.NewClient[v1.GetServiceSessionRequest, v1.GetServiceSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceGetServiceSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("GetServiceSession")),
+			connect.WithClientOptions(opts...),
+		),
+		topUpServiceSession: connect.NewClient[v1.TopUpServiceSessionRequest, v1.TopUpServiceSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceTopUpServiceSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("TopUpServiceSession")),
+			connect.WithClientOptions(opts...),
+		),
+		pauseServiceSession: connect.NewClient[v1.PauseServiceSessionRequest, v1.PauseServiceSessionResponse](
+			httpClient,
+			baseURL+ExchangeServicePauseServiceSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("PauseServiceSession")),
+			connect.WithClientOptions(opts...),
+		),
+		resumeServiceSession: connect.NewClient[v1.ResumeServiceSessionRequest, v1.ResumeServiceSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceResumeServiceSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ResumeServiceSession")),
+			connect.WithClientOptions(opts...),
+		),
+		closeServiceSession: connect.NewClient[v1.CloseServiceSessionRequest, v1.CloseServiceSessionResponse](
+			httpClient,
+			baseURL+ExchangeServiceCloseServiceSessionProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("CloseServiceSession")),
+			connect.WithClientOptions(opts...),
+		),
+		submitConsumptionReceipts: connect.NewClient[v1.SubmitConsumptionReceiptsRequest, v1.SubmitConsumptionReceiptsResponse](
+			httpClient,
+			baseURL+ExchangeServiceSubmitConsumptionReceiptsProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("SubmitConsumptionReceipts")),
+			connect.WithClientOptions(opts...),
+		),
+		getTreeHead: connect.NewClient[v1.GetTreeHeadRequest, v1.SignedTreeHead](
+			httpClient,
+			baseURL+ExchangeServiceGetTreeHeadProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("GetTreeHead")),
+			connect.WithClientOptions(opts...),
+		),
+		listLogEntries: connect.NewClient[v1.ListLogEntriesRequest, v1.ListLogEntriesResponse](
+			httpClient,
+			baseURL+ExchangeServiceListLogEntriesProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("ListLogEntries")),
+			connect.WithClientOptions(opts...),
+		),
+		getInclusionProof: connect.NewClient[v1.GetInclusionProofRequest, v1.GetInclusionProofResponse](
+			httpClient,
+			baseURL+ExchangeServiceGetInclusionProofProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("GetInclusionProof")),
+			connect.WithClientOptions(opts...),
+		),
+		getConsistencyProof: connect.NewClient[v1.GetConsistencyProofRequest, v1.GetConsistencyProofResponse](
+			httpClient,
+			baseURL+ExchangeServiceGetConsistencyProofProcedure,
+			connect.WithSchema(exchangeServiceMethods.ByName("GetConsistencyProof")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // exchangeServiceClient implements ExchangeServiceClient.
 type exchangeServiceClient struct {
-	login                 *connect.Client[v1.LoginRequest, v1.LoginResponse]
-	getChallenge          *connect.Client[v1.GetChallengeRequest, v1.GetChallengeResponse]
-	deposit               *connect.Client[v1.DepositRequest, v1.DepositResponse]
-	pruneAccounts         *connect.Client[v1.PruneAccountsRequest, v1.PruneAccountsResponse]
-	createWithdraw        *connect.Client[v1.CreateWithdrawRequest, v1.CreateWithdrawResponse]
-	batchProcessWithdraws *connect.Client[v1.BatchProcessWithdrawsRequest, v1.BatchProcessWithdrawsResponse]
-	batchMarkWithdraws    *connect.Client[v1.BatchMarkWithdrawsRequest, v1.BatchMarkWithdrawsResponse]
-	ping                  *connect.Client[v1.PingRequest, v1.PingResponse]
-	listPaymentMethods    *connect.Client[v1.ListPaymentMethodsRequest, v1.ListPaymentMethodsResponse]
-	buyToken              *connect.Client[v1.BuyTokenRequest, v1.BuyTokenResponse]
+	login                     *connect.Client[v1.LoginRequest, v1.LoginResponse]
+	loginWithSignature        *connect.Client[v1.LoginWithSignatureRequest, v1.LoginWithSignatureResponse]
+	resetPassword             *connect.Client[v1.ResetPasswordRequest, v1.ResetPasswordResponse]
+	refreshSession            *connect.Client[v1.RefreshSessionRequest, v1.RefreshSessionResponse]
+	logout                    *connect.Client[v1.LogoutRequest, v1.LogoutResponse]
+	listSessions              *connect.Client[v1.ListSessionsRequest, v1.ListSessionsResponse]
+	revokeSession             *connect.Client[v1.RevokeSessionRequest, v1.RevokeSessionResponse]
+	createApiKey              *connect.Client[v1.CreateApiKeyRequest, v1.CreateApiKeyResponse]
+	listApiKeys               *connect.Client[v1.ListApiKeysRequest, v1.ListApiKeysResponse]
+	revokeApiKey              *connect.Client[v1.RevokeApiKeyRequest, v1.RevokeApiKeyResponse]
+	getChallenge              *connect.Client[v1.GetChallengeRequest, v1.GetChallengeResponse]
+	deposit                   *connect.Client[v1.DepositRequest, v1.DepositResponse]
+	pruneAccounts             *connect.Client[v1.PruneAccountsRequest, v1.PruneAccountsResponse]
+	createWithdraw            *connect.Client[v1.CreateWithdrawRequest, v1.CreateWithdrawResponse]
+	estimateWithdrawFee       *connect.Client[v1.EstimateWithdrawFeeRequest, v1.EstimateWithdrawFeeResponse]
+	batchProcessWithdraws     *connect.Client[v1.BatchProcessWithdrawsRequest, v1.BatchProcessWithdrawsResponse]
+	batchMarkWithdraws        *connect.Client[v1.BatchMarkWithdrawsRequest, v1.BatchMarkWithdrawsResponse]
+	getWalletStatus           *connect.Client[v1.GetWalletStatusRequest, v1.GetWalletStatusResponse]
+	ping                      *connect.Client[v1.PingRequest, v1.PingResponse]
+	listPaymentMethods        *connect.Client[v1.ListPaymentMethodsRequest, v1.ListPaymentMethodsResponse]
+	listSigningKeys           *connect.Client[v1.ListSigningKeysRequest, v1.ListSigningKeysResponse]
+	buyToken                  *connect.Client[v1.BuyTokenRequest, v1.BuyTokenResponse]
+	redeemToken               *connect.Client[v1.RedeemTokenRequest, v1.RedeemTokenResponse]
+	introspectToken           *connect.Client[v1.IntrospectTokenRequest, v1.IntrospectTokenResponse]
+	refundToken               *connect.Client[v1.RefundTokenRequest, v1.RefundTokenResponse]
+	getRefundPolicy           *connect.Client[v1.GetRefundPolicyRequest, v1.RefundPolicy]
+	updateRefundPolicy        *connect.Client[v1.UpdateRefundPolicyRequest, v1.RefundPolicy]
+	getTokenPolicy            *connect.Client[v1.GetTokenPolicyRequest, v1.TokenPolicy]
+	updateTokenPolicy         *connect.Client[v1.UpdateTokenPolicyRequest, v1.TokenPolicy]
+	listBlindTokenKeys        *connect.Client[v1.ListBlindTokenKeysRequest, v1.ListBlindTokenKeysResponse]
+	issueBlindTokens          *connect.Client[v1.IssueBlindTokensRequest, v1.IssueBlindTokensResponse]
+	redeemBlindToken          *connect.Client[v1.RedeemBlindTokenRequest, v1.RedeemBlindTokenResponse]
+	pruneSpentBlindTokens     *connect.Client[v1.PruneSpentBlindTokensRequest, v1.PruneSpentBlindTokensResponse]
+	listExpiredBlindTokenKeys *connect.Client[v1.ListExpiredBlindTokenKeysRequest, v1.ListExpiredBlindTokenKeysResponse]
+	createServiceSession      *connect.Client[v1.CreateServiceSessionRequest, v1.CreateServiceSessionResponse]
+	consumeServiceSession     *connect.Client[v1.ConsumeServiceSessionRequest, v1.ConsumeServiceSessionResponse]
+	getServiceSession         *connect.Client[v1.GetServiceSessionRequest, v1.GetServiceSessionResponse]
+	topUpServiceSession       *connect.Client[v1.TopUpServiceSessionRequest, v1.TopUpServiceSessionResponse]
+	pauseServiceSession       *connect.Client[v1.PauseServiceSessionRequest, v1.PauseServiceSessionResponse]
+	resumeServiceSession      *connect.Client[v1.ResumeServiceSessionRequest, v1.ResumeServiceSessionResponse]
+	closeServiceSession       *connect.Client[v1.CloseServiceSessionRequest, v1.CloseServiceSessionResponse]
+	submitConsumptionReceipts *connect.Client[v1.SubmitConsumptionReceiptsRequest, v1.SubmitConsumptionReceiptsResponse]
+	getTreeHead               *connect.Client[v1.GetTreeHeadRequest, v1.SignedTreeHead]
+	listLogEntries            *connect.Client[v1.ListLogEntriesRequest, v1.ListLogEntriesResponse]
+	getInclusionProof         *connect.Client[v1.GetInclusionProofRequest, v1.GetInclusionProofResponse]
+	getConsistencyProof       *connect.Client[v1.GetConsistencyProofRequest, v1.GetConsistencyProofResponse]
 }
 
 // Login calls exchange.v1.ExchangeService.Login.
@@ -169,6 +599,51 @@ func (c *exchangeServiceClient) Login(ctx context.Context, req *connect.Request[
 	return c.login.CallUnary(ctx, req)
 }
 
+// LoginWithSignature calls exchange.v1.ExchangeService.LoginWithSignature.
+func (c *exchangeServiceClient) LoginWithSignature(ctx context.Context, req *connect.Request[v1.LoginWithSignatureRequest]) (*connect.Response[v1.LoginWithSignatureResponse], error) {
+	return c.loginWithSignature.CallUnary(ctx, req)
+}
+
+// ResetPassword calls exchange.v1.ExchangeService.ResetPassword.
+func (c *exchangeServiceClient) ResetPassword(ctx context.Context, req *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error) {
+	return c.resetPassword.CallUnary(ctx, req)
+}
+
+// RefreshSession calls exchange.v1.ExchangeService.RefreshSession.
+func (c *exchangeServiceClient) RefreshSession(ctx context.Context, req *connect.Request[v1.RefreshSessionRequest]) (*connect.Response[v1.RefreshSessionResponse], error) {
+	return c.refreshSession.CallUnary(ctx, req)
+}
+
+// Logout calls exchange.v1.ExchangeService.Logout.
+func (c *exchangeServiceClient) Logout(ctx context.Context, req *connect.Request[v1.LogoutRequest]) (*connect.Response[v1.LogoutResponse], error) {
+	return c.logout.CallUnary(ctx, req)
+}
+
+// ListSessions calls exchange.v1.ExchangeService.ListSessions.
+func (c *exchangeServiceClient) ListSessions(ctx context.Context, req *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error) {
+	return c.listSessions.CallUnary(ctx, req)
+}
+
+// RevokeSession calls exchange.v1.ExchangeService.RevokeSession.
+func (c *exchangeServiceClient) RevokeSession(ctx context.Context, req *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error) {
+	return c.revokeSession.CallUnary(ctx, req)
+}
+
+// CreateApiKey calls exchange.v1.ExchangeService.CreateApiKey.
+func (c *exchangeServiceClient) CreateApiKey(ctx context.Context, req *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error) {
+	return c.createApiKey.CallUnary(ctx, req)
+}
+
+// ListApiKeys calls exchange.v1.ExchangeService.ListApiKeys.
+func (c *exchangeServiceClient) ListApiKeys(ctx context.Context, req *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error) {
+	return c.listApiKeys.CallUnary(ctx, req)
+}
+
+// RevokeApiKey calls exchange.v1.ExchangeService.RevokeApiKey.
+func (c *exchangeServiceClient) RevokeApiKey(ctx context.Context, req *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error) {
+	return c.revokeApiKey.CallUnary(ctx, req)
+}
+
 // GetChallenge calls exchange.v1.ExchangeService.GetChallenge.
 func (c *exchangeServiceClient) GetChallenge(ctx context.Context, req *connect.Request[v1.GetChallengeRequest]) (*connect.Response[v1.GetChallengeResponse], error) {
 	return c.getChallenge.CallUnary(ctx, req)
@@ -189,6 +664,11 @@ func (c *exchangeServiceClient) CreateWithdraw(ctx context.Context, req *connect
 	return c.createWithdraw.CallUnary(ctx, req)
 }
 
+// EstimateWithdrawFee calls exchange.v1.ExchangeService.EstimateWithdrawFee.
+func (c *exchangeServiceClient) EstimateWithdrawFee(ctx context.Context, req *connect.Request[v1.EstimateWithdrawFeeRequest]) (*connect.Response[v1.EstimateWithdrawFeeResponse], error) {
+	return c.estimateWithdrawFee.CallUnary(ctx, req)
+}
+
 // BatchProcessWithdraws calls exchange.v1.ExchangeService.BatchProcessWithdraws.
 func (c *exchangeServiceClient) BatchProcessWithdraws(ctx context.Context, req *connect.Request[v1.BatchProcessWithdrawsRequest]) (*connect.Response[v1.BatchProcessWithdrawsResponse], error) {
 	return c.batchProcessWithdraws.CallUnary(ctx, req)
@@ -199,6 +679,11 @@ func (c *exchangeServiceClient) BatchMarkWithdraws(ctx context.Context, req *con
 	return c.batchMarkWithdraws.CallUnary(ctx, req)
 }
 
+// GetWalletStatus calls exchange.v1.ExchangeService.GetWalletStatus.
+func (c *exchangeServiceClient) GetWalletStatus(ctx context.Context, req *connect.Request[v1.GetWalletStatusRequest]) (*connect.Response[v1.GetWalletStatusResponse], error) {
+	return c.getWalletStatus.CallUnary(ctx, req)
+}
+
 // Ping calls exchange.v1.ExchangeService.Ping.
 func (c *exchangeServiceClient) Ping(ctx context.Context, req *connect.Request[v1.PingRequest]) (*connect.Response[v1.PingResponse], error) {
 	return c.ping.CallUnary(ctx, req)
@@ -209,23 +694,219 @@ func (c *exchangeServiceClient) ListPaymentMethods(ctx context.Context, req *con
 	return c.listPaymentMethods.CallUnary(ctx, req)
 }
 
+// ListSigningKeys calls exchange.v1.ExchangeService.ListSigningKeys.
+func (c *exchangeServiceClient) ListSigningKeys(ctx context.Context, req *connect.Request[v1.ListSigningKeysRequest]) (*connect.Response[v1.ListSigningKeysResponse], error) {
+	return c.listSigningKeys.CallUnary(ctx, req)
+}
+
 // BuyToken calls exchange.v1.ExchangeService.BuyToken.
 func (c *exchangeServiceClient) BuyToken(ctx context.Context, req *connect.Request[v1.BuyTokenRequest]) (*connect.Response[v1.BuyTokenResponse], error) {
 	return c.buyToken.CallUnary(ctx, req)
 }
 
+// RedeemToken calls exchange.v1.ExchangeService.RedeemToken.
+func (c *exchangeServiceClient) RedeemToken(ctx context.Context, req *connect.Request[v1.RedeemTokenRequest]) (*connect.Response[v1.RedeemTokenResponse], error) {
+	return c.redeemToken.CallUnary(ctx, req)
+}
+
+// IntrospectToken calls exchange.v1.ExchangeService.IntrospectToken.
+func (c *exchangeServiceClient) IntrospectToken(ctx context.Context, req *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error) {
+	return c.introspectToken.CallUnary(ctx, req)
+}
+
+// RefundToken calls exchange.v1.ExchangeService.RefundToken.
+func (c *exchangeServiceClient) RefundToken(ctx context.Context, req *connect.Request[v1.RefundTokenRequest]) (*connect.Response[v1.RefundTokenResponse], error) {
+	return c.refundToken.CallUnary(ctx, req)
+}
+
+// GetRefundPolicy calls exchange.v1.ExchangeService.GetRefundPolicy.
+func (c *exchangeServiceClient) GetRefundPolicy(ctx context.Context, req *connect.Request[v1.GetRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error) {
+	return c.getRefundPolicy.CallUnary(ctx, req)
+}
+
+// UpdateRefundPolicy calls exchange.v1.ExchangeService.UpdateRefundPolicy.
+func (c *exchangeServiceClient) UpdateRefundPolicy(ctx context.Context, req *connect.Request[v1.UpdateRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error) {
+	return c.updateRefundPolicy.CallUnary(ctx, req)
+}
+
+// GetTokenPolicy calls exchange.v1.ExchangeService.GetTokenPolicy.
+func (c *exchangeServiceClient) GetTokenPolicy(ctx context.Context, req *connect.Request[v1.GetTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error) {
+	return c.getTokenPolicy.CallUnary(ctx, req)
+}
+
+// UpdateTokenPolicy calls exchange.v1.ExchangeService.UpdateTokenPolicy.
+func (c *exchangeServiceClient) UpdateTokenPolicy(ctx context.Context, req *connect.Request[v1.UpdateTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error) {
+	return c.updateTokenPolicy.CallUnary(ctx, req)
+}
+
+// ListBlindTokenKeys calls exchange.v1.ExchangeService.ListBlindTokenKeys.
+func (c *exchangeServiceClient) ListBlindTokenKeys(ctx context.Context, req *connect.Request[v1.ListBlindTokenKeysRequest]) (*connect.Response[v1.ListBlindTokenKeysResponse], error) {
+	return c.listBlindTokenKeys.CallUnary(ctx, req)
+}
+
+// IssueBlindTokens calls exchange.v1.ExchangeService.IssueBlindTokens.
+func (c *exchangeServiceClient) IssueBlindTokens(ctx context.Context, req *connect.Request[v1.IssueBlindTokensRequest]) (*connect.Response[v1.IssueBlindTokensResponse], error) {
+	return c.issueBlindTokens.CallUnary(ctx, req)
+}
+
+// RedeemBlindToken calls exchange.v1.ExchangeService.RedeemBlindToken.
+func (c *exchangeServiceClient) RedeemBlindToken(ctx context.Context, req *connect.Request[v1.RedeemBlindTokenRequest]) (*connect.Response[v1.RedeemBlindTokenResponse], error) {
+	return c.redeemBlindToken.CallUnary(ctx, req)
+}
+
+// PruneSpentBlindTokens calls exchange.v1.ExchangeService.PruneSpentBlindTokens.
+func (c *exchangeServiceClient) PruneSpentBlindTokens(ctx context.Context, req *connect.Request[v1.PruneSpentBlindTokensRequest]) (*connect.Response[v1.PruneSpentBlindTokensResponse], error) {
+	return c.pruneSpentBlindTokens.CallUnary(ctx, req)
+}
+
+// ListExpiredBlindTokenKeys calls exchange.v1.ExchangeService.ListExpiredBlindTokenKeys.
+func (c *exchangeServiceClient) ListExpiredBlindTokenKeys(ctx context.Context, req *connect.Request[v1.ListExpiredBlindTokenKeysRequest]) (*connect.Response[v1.ListExpiredBlindTokenKeysResponse], error) {
+	return c.listExpiredBlindTokenKeys.CallUnary(ctx, req)
+}
+
+// CreateServiceSession calls exchange.v1.ExchangeService.CreateServiceSession.
+func (c *exchangeServiceClient) CreateServiceSession(ctx context.Context, req *connect.Request[v1.CreateServiceSessionRequest]) (*connect.Response[v1.CreateServiceSessionResponse], error) {
+	return c.createServiceSession.CallUnary(ctx, req)
+}
+
+// ConsumeServiceSession calls exchange.v1.ExchangeService.ConsumeServiceSession.
+func (c *exchangeServiceClient) ConsumeServiceSession(ctx context.Context, req *connect.Request[v1.ConsumeServiceSessionRequest]) (*connect.Response[v1.ConsumeServiceSessionResponse], error) {
+	return c.consumeServiceSession.CallUnary(ctx, req)
+}
+
+// GetServiceSession calls exchange.v1.ExchangeService.GetServiceSession.
+func (c *exchangeServiceClient) GetServiceSession(ctx context.Context, req *connect.Request[v1.GetServiceSessionRequest]) (*connect.Response[v1.GetServiceSessionResponse], error) {
+	return c.getServiceSession.CallUnary(ctx, req)
+}
+
+// TopUpServiceSession calls exchange.v1.ExchangeService.TopUpServiceSession.
+func (c *exchangeServiceClient) TopUpServiceSession(ctx context.Context, req *connect.Request[v1.TopUpServiceSessionRequest]) (*connect.Response[v1.TopUpServiceSessionResponse], error) {
+	return c.topUpServiceSession.CallUnary(ctx, req)
+}
+
+// PauseServiceSession calls exchange.v1.ExchangeService.PauseServiceSession.
+func (c *exchangeServiceClient) PauseServiceSession(ctx context.Context, req *connect.Request[v1.PauseServiceSessionRequest]) (*connect.Response[v1.PauseServiceSessionResponse], error) {
+	return c.pauseServiceSession.CallUnary(ctx, req)
+}
+
+// ResumeServiceSession calls exchange.v1.ExchangeService.ResumeServiceSession.
+func (c *exchangeServiceClient) ResumeServiceSession(ctx context.Context, req *connect.Request[v1.ResumeServiceSessionRequest]) (*connect.Response[v1.ResumeServiceSessionResponse], error) {
+	return c.resumeServiceSession.CallUnary(ctx, req)
+}
+
+// CloseServiceSession calls exchange.v1.ExchangeService.CloseServiceSession.
+func (c *exchangeServiceClient) CloseServiceSession(ctx context.Context, req *connect.Request[v1.CloseServiceSessionRequest]) (*connect.Response[v1.CloseServiceSessionResponse], error) {
+	return c.closeServiceSession.CallUnary(ctx, req)
+}
+
+// SubmitConsumptionReceipts calls exchange.v1.ExchangeService.SubmitConsumptionReceipts.
+func (c *exchangeServiceClient) SubmitConsumptionReceipts(ctx context.Context, req *connect.Request[v1.SubmitConsumptionReceiptsRequest]) (*connect.Response[v1.SubmitConsumptionReceiptsResponse], error) {
+	return c.submitConsumptionReceipts.CallUnary(ctx, req)
+}
+
+// GetTreeHead calls exchange.v1.ExchangeService.GetTreeHead.
+func (c *exchangeServiceClient) GetTreeHead(ctx context.Context, req *connect.Request[v1.GetTreeHeadRequest]) (*connect.Response[v1.SignedTreeHead], error) {
+	return c.getTreeHead.CallUnary(ctx, req)
+}
+
+// ListLogEntries calls exchange.v1.ExchangeService.ListLogEntries.
+func (c *exchangeServiceClient) ListLogEntries(ctx context.Context, req *connect.Request[v1.ListLogEntriesRequest]) (*connect.Response[v1.ListLogEntriesResponse], error) {
+	return c.listLogEntries.CallUnary(ctx, req)
+}
+
+// GetInclusionProof calls exchange.v1.ExchangeService.GetInclusionProof.
+func (c *exchangeServiceClient) GetInclusionProof(ctx context.Context, req *connect.Request[v1.GetInclusionProofRequest]) (*connect.Response[v1.GetInclusionProofResponse], error) {
+	return c.getInclusionProof.CallUnary(ctx, req)
+}
+
+// GetConsistencyProof calls exchange.v1.ExchangeService.GetConsistencyProof.
+func (c *exchangeServiceClient) GetConsistencyProof(ctx context.Context, req *connect.Request[v1.GetConsistencyProofRequest]) (*connect.Response[v1.GetConsistencyProofResponse], error) {
+	return c.getConsistencyProof.CallUnary(ctx, req)
+}
+
 // ExchangeServiceHandler is an implementation of the exchange.v1.ExchangeService service.
 type ExchangeServiceHandler interface {
 	Login(context.Context, *connect.Request[v1.LoginRequest]) (*connect.Response[v1.LoginResponse], error)
+	// LoginWithSignature logs in with a challenge signed by the wallet that
+	// made the first deposit of the account instead of a password.
+	LoginWithSignature(context.Context, *connect.Request[v1.LoginWithSignatureRequest]) (*connect.Response[v1.LoginWithSignatureResponse], error)
+	// ResetPassword sets a new password after proving control of a wallet that
+	// deposited to the account. Existing sessions are revoked and withdrawals
+	// are paused for a cool-down.
+	ResetPassword(context.Context, *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error)
+	// RefreshSession exchanges a refresh token for a new access token and a
+	// new refresh token. The old refresh token must not be used again.
+	RefreshSession(context.Context, *connect.Request[v1.RefreshSessionRequest]) (*connect.Response[v1.RefreshSessionResponse], error)
+	Logout(context.Context, *connect.Request[v1.LogoutRequest]) (*connect.Response[v1.LogoutResponse], error)
+	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
+	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	CreateApiKey(context.Context, *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error)
+	ListApiKeys(context.Context, *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error)
+	RevokeApiKey(context.Context, *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error)
 	GetChallenge(context.Context, *connect.Request[v1.GetChallengeRequest]) (*connect.Response[v1.GetChallengeResponse], error)
 	Deposit(context.Context, *connect.Request[v1.DepositRequest]) (*connect.Response[v1.DepositResponse], error)
+	// PruneAccounts deletes expired accounts. Escrows held for them are
+	// settled first, paying the unclaimed rest back to the buyers.
 	PruneAccounts(context.Context, *connect.Request[v1.PruneAccountsRequest]) (*connect.Response[v1.PruneAccountsResponse], error)
 	CreateWithdraw(context.Context, *connect.Request[v1.CreateWithdrawRequest]) (*connect.Response[v1.CreateWithdrawResponse], error)
+	EstimateWithdrawFee(context.Context, *connect.Request[v1.EstimateWithdrawFeeRequest]) (*connect.Response[v1.EstimateWithdrawFeeResponse], error)
 	BatchProcessWithdraws(context.Context, *connect.Request[v1.BatchProcessWithdrawsRequest]) (*connect.Response[v1.BatchProcessWithdrawsResponse], error)
 	BatchMarkWithdraws(context.Context, *connect.Request[v1.BatchMarkWithdrawsRequest]) (*connect.Response[v1.BatchMarkWithdrawsResponse], error)
+	// GetWalletStatus reports the hot wallets as of their last periodic check.
+	// It does not query the chain or raise refill alerts itself.
+	GetWalletStatus(context.Context, *connect.Request[v1.GetWalletStatusRequest]) (*connect.Response[v1.GetWalletStatusResponse], error)
 	Ping(context.Context, *connect.Request[v1.PingRequest]) (*connect.Response[v1.PingResponse], error)
 	ListPaymentMethods(context.Context, *connect.Request[v1.ListPaymentMethodsRequest]) (*connect.Response[v1.ListPaymentMethodsResponse], error)
+	ListSigningKeys(context.Context, *connect.Request[v1.ListSigningKeysRequest]) (*connect.Response[v1.ListSigningKeysResponse], error)
 	BuyToken(context.Context, *connect.Request[v1.BuyTokenRequest]) (*connect.Response[v1.BuyTokenResponse], error)
+	// RedeemToken consumes quota of a token. Only its audience may call it.
+	RedeemToken(context.Context, *connect.Request[v1.RedeemTokenRequest]) (*connect.Response[v1.RedeemTokenResponse], error)
+	IntrospectToken(context.Context, *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error)
+	// RefundToken revokes a token of the caller and pays back its unconsumed
+	// quota if the refund policy of its audience allows.
+	RefundToken(context.Context, *connect.Request[v1.RefundTokenRequest]) (*connect.Response[v1.RefundTokenResponse], error)
+	GetRefundPolicy(context.Context, *connect.Request[v1.GetRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error)
+	// UpdateRefundPolicy sets the refund policy of tokens bought for the caller.
+	UpdateRefundPolicy(context.Context, *connect.Request[v1.UpdateRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error)
+	GetTokenPolicy(context.Context, *connect.Request[v1.GetTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error)
+	// UpdateTokenPolicy sets how long tokens bought for the caller may last.
+	UpdateTokenPolicy(context.Context, *connect.Request[v1.UpdateTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error)
+	ListBlindTokenKeys(context.Context, *connect.Request[v1.ListBlindTokenKeysRequest]) (*connect.Response[v1.ListBlindTokenKeysResponse], error)
+	// IssueBlindTokens signs blinded messages with the key of a denomination and
+	// charges the caller the denomination for each. See pkg/blind. Tokens not
+	// redeemed before the key expires are not refunded, since Prex cannot tell
+	// who holds them. ListExpiredBlindTokenKeys reports the value left behind.
+	IssueBlindTokens(context.Context, *connect.Request[v1.IssueBlindTokensRequest]) (*connect.Response[v1.IssueBlindTokensResponse], error)
+	// RedeemBlindToken spends a blind token and pays its denomination to the
+	// caller, who must be the audience the token was bought for.
+	RedeemBlindToken(context.Context, *connect.Request[v1.RedeemBlindTokenRequest]) (*connect.Response[v1.RedeemBlindTokenResponse], error)
+	// PruneSpentBlindTokens forgets the spent tokens of expired keys, which can
+	// no longer be redeemed anyway.
+	PruneSpentBlindTokens(context.Context, *connect.Request[v1.PruneSpentBlindTokensRequest]) (*connect.Response[v1.PruneSpentBlindTokensResponse], error)
+	// ListExpiredBlindTokenKeys reports the expired keys with unredeemed tokens
+	// so operators can reconcile the value Prex kept from them.
+	ListExpiredBlindTokenKeys(context.Context, *connect.Request[v1.ListExpiredBlindTokenKeysRequest]) (*connect.Response[v1.ListExpiredBlindTokenKeysResponse], error)
+	// CreateServiceSession redeems a create-session token into a session
+	// metered by its audience and returns a manage-session token for the user.
+	CreateServiceSession(context.Context, *connect.Request[v1.CreateServiceSessionRequest]) (*connect.Response[v1.CreateServiceSessionResponse], error)
+	ConsumeServiceSession(context.Context, *connect.Request[v1.ConsumeServiceSessionRequest]) (*connect.Response[v1.ConsumeServiceSessionResponse], error)
+	// The methods below are authorized by the manage-session token in the
+	// request.
+	GetServiceSession(context.Context, *connect.Request[v1.GetServiceSessionRequest]) (*connect.Response[v1.GetServiceSessionResponse], error)
+	TopUpServiceSession(context.Context, *connect.Request[v1.TopUpServiceSessionRequest]) (*connect.Response[v1.TopUpServiceSessionResponse], error)
+	PauseServiceSession(context.Context, *connect.Request[v1.PauseServiceSessionRequest]) (*connect.Response[v1.PauseServiceSessionResponse], error)
+	ResumeServiceSession(context.Context, *connect.Request[v1.ResumeServiceSessionRequest]) (*connect.Response[v1.ResumeServiceSessionResponse], error)
+	CloseServiceSession(context.Context, *connect.Request[v1.CloseServiceSessionRequest]) (*connect.Response[v1.CloseServiceSessionResponse], error)
+	// SubmitConsumptionReceipts pays the audience of escrowed tokens what its
+	// receipts claim, up to the quota it consumed with RedeemToken. Receipts
+	// are authorized by the signature of the audience, so anyone may relay them.
+	SubmitConsumptionReceipts(context.Context, *connect.Request[v1.SubmitConsumptionReceiptsRequest]) (*connect.Response[v1.SubmitConsumptionReceiptsResponse], error)
+	// The methods below serve the transparency log of issued tokens for
+	// auditing. See pkg/transparency to verify what they return.
+	GetTreeHead(context.Context, *connect.Request[v1.GetTreeHeadRequest]) (*connect.Response[v1.SignedTreeHead], error)
+	ListLogEntries(context.Context, *connect.Request[v1.ListLogEntriesRequest]) (*connect.Response[v1.ListLogEntriesResponse], error)
+	GetInclusionProof(context.Context, *connect.Request[v1.GetInclusionProofRequest]) (*connect.Response[v1.GetInclusionProofResponse], error)
+	GetConsistencyProof(context.Context, *connect.Request[v1.GetConsistencyProofRequest]) (*connect.Response[v1.GetConsistencyProofResponse], error)
 }
 
 // NewExchangeServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -241,6 +922,60 @@ func NewExchangeServiceHandler(svc ExchangeServiceHandler, opts ...connect.Handl
 		connect.WithSchema(exchangeServiceMethods.ByName("Login")),
 		connect.WithHandlerOptions(opts...),
 	)
+	exchangeServiceLoginWithSignatureHandler := connect.NewUnaryHandler(
+		ExchangeServiceLoginWithSignatureProcedure,
+		svc.LoginWithSignature,
+		connect.WithSchema(exchangeServiceMethods.ByName("LoginWithSignature")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceResetPasswordHandler := connect.NewUnaryHandler(
+		ExchangeServiceResetPasswordProcedure,
+		svc.ResetPassword,
+		connect.WithSchema(exchangeServiceMethods.ByName("ResetPassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceRefreshSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceRefreshSessionProcedure,
+		svc.RefreshSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("RefreshSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceLogoutHandler := connect.NewUnaryHandler(
+		ExchangeServiceLogoutProcedure,
+		svc.Logout,
+		connect.WithSchema(exchangeServiceMethods.ByName("Logout")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceListSessionsHandler := connect.NewUnaryHandler(
+		ExchangeServiceListSessionsProcedure,
+		svc.ListSessions,
+		connect.WithSchema(exchangeServiceMethods.ByName("ListSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceRevokeSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceRevokeSessionProcedure,
+		svc.RevokeSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("RevokeSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceCreateApiKeyHandler := connect.NewUnaryHandler(
+		ExchangeServiceCreateApiKeyProcedure,
+		svc.CreateApiKey,
+		connect.WithSchema(exchangeServiceMethods.ByName("CreateApiKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceListApiKeysHandler := connect.NewUnaryHandler(
+		ExchangeServiceListApiKeysProcedure,
+		svc.ListApiKeys,
+		connect.WithSchema(exchangeServiceMethods.ByName("ListApiKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceRevokeApiKeyHandler := connect.NewUnaryHandler(
+		ExchangeServiceRevokeApiKeyProcedure,
+		svc.RevokeApiKey,
+		connect.WithSchema(exchangeServiceMethods.ByName("RevokeApiKey")),
+		connect.WithHandlerOptions(opts...),
+	)
 	exchangeServiceGetChallengeHandler := connect.NewUnaryHandler(
 		ExchangeServiceGetChallengeProcedure,
 		svc.GetChallenge,
@@ -265,6 +1000,12 @@ func NewExchangeServiceHandler(svc ExchangeServiceHandler, opts ...connect.Handl
 		connect.WithSchema(exchangeServiceMethods.ByName("CreateWithdraw")),
 		connect.WithHandlerOptions(opts...),
 	)
+	exchangeServiceEstimateWithdrawFeeHandler := connect.NewUnaryHandler(
+		ExchangeServiceEstimateWithdrawFeeProcedure,
+		svc.EstimateWithdrawFee,
+		connect.WithSchema(exchangeServiceMethods.ByName("EstimateWithdrawFee")),
+		connect.WithHandlerOptions(opts...),
+	)
 	exchangeServiceBatchProcessWithdrawsHandler := connect.NewUnaryHandler(
 		ExchangeServiceBatchProcessWithdrawsProcedure,
 		svc.BatchProcessWithdraws,
@@ -277,6 +1018,12 @@ func NewExchangeServiceHandler(svc ExchangeServiceHandler, opts ...connect.Handl
 		connect.WithSchema(exchangeServiceMethods.ByName("BatchMarkWithdraws")),
 		connect.WithHandlerOptions(opts...),
 	)
+	exchangeServiceGetWalletStatusHandler := connect.NewUnaryHandler(
+		ExchangeServiceGetWalletStatusProcedure,
+		svc.GetWalletStatus,
+		connect.WithSchema(exchangeServiceMethods.ByName("GetWalletStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
 	exchangeServicePingHandler := connect.NewUnaryHandler(
 		ExchangeServicePingProcedure,
 		svc.Ping,
@@ -289,16 +1036,184 @@ func NewExchangeServiceHandler(svc ExchangeServiceHandler, opts ...connect.Handl
 		connect.WithSchema(exchangeServiceMethods.ByName("ListPaymentMethods")),
 		connect.WithHandlerOptions(opts...),
 	)
+	exchangeServiceListSigningKeysHandler := connect.NewUnaryHandler(
+		ExchangeServiceListSigningKeysProcedure,
+		svc.ListSigningKeys,
+		connect.WithSchema(exchangeServiceMethods.ByName("ListSigningKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
 	exchangeServiceBuyTokenHandler := connect.NewUnaryHandler(
 		ExchangeServiceBuyTokenProcedure,
 		svc.BuyToken,
 		connect.WithSchema(exchangeServiceMethods.ByName("BuyToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	exchangeServiceRedeemTokenHandler := connect.NewUnaryHandler(
+		ExchangeServiceRedeemTokenProcedure,
+		svc.RedeemToken,
+		connect.WithSchema(exchangeServiceMethods.ByName("RedeemToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceIntrospectTokenHandler := connect.NewUnaryHandler(
+		ExchangeServiceIntrospectTokenProcedure,
+		svc.IntrospectToken,
+		connect.WithSchema(exchangeServiceMethods.ByName("IntrospectToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceRefundTokenHandler := connect.NewUnaryHandler(
+		ExchangeServiceRefundTokenProcedure,
+		svc.RefundToken,
+		connect.WithSchema(exchangeServiceMethods.ByName("RefundToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceGetRefundPolicyHandler := connect.NewUnaryHandler(
+		ExchangeServiceGetRefundPolicyProcedure,
+		svc.GetRefundPolicy,
+		connect.WithSchema(exchangeServiceMethods.ByName("GetRefundPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceUpdateRefundPolicyHandler := connect.NewUnaryHandler(
+		ExchangeServiceUpdateRefundPolicyProcedure,
+		svc.UpdateRefundPolicy,
+		connect.WithSchema(exchangeServiceMethods.ByName("UpdateRefundPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceGetTokenPolicyHandler := connect.NewUnaryHandler(
+		ExchangeServiceGetTokenPolicyProcedure,
+		svc.GetTokenPolicy,
+		connect.WithSchema(exchangeServiceMethods.ByName("GetTokenPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceUpdateTokenPolicyHandler := connect.NewUnaryHandler(
+		ExchangeServiceUpdateTokenPolicyProcedure,
+		svc.UpdateTokenPolicy,
+		connect.WithSchema(exchangeServiceMethods.ByName("UpdateTokenPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceListBlindTokenKeysHandler := connect.NewUnaryHandler(
+		ExchangeServiceListBlindTokenKeysProcedure,
+		svc.ListBlindTokenKeys,
+		connect.WithSchema(exchangeServiceMethods.ByName("ListBlindTokenKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceIssueBlindTokensHandler := connect.NewUnaryHandler(
+		ExchangeServiceIssueBlindTokensProcedure,
+		svc.IssueBlindTokens,
+		connect.WithSchema(exchangeServiceMethods.ByName("IssueBlindTokens")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceRedeemBlindTokenHandler := connect.NewUnaryHandler(
+		ExchangeServiceRedeemBlindTokenProcedure,
+		svc.RedeemBlindToken,
+		connect.WithSchema(exchangeServiceMethods.ByName("RedeemBlindToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServicePruneSpentBlindTokensHandler := connect.NewUnaryHandler(
+		ExchangeServicePruneSpentBlindTokensProcedure,
+		svc.PruneSpentBlindTokens,
+		connect.WithSchema(exchangeServiceMethods.ByName("PruneSpentBlindTokens")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceListExpiredBlindTokenKeysHandler := connect.NewUnaryHandler(
+		ExchangeServiceListExpiredBlindTokenKeysProcedure,
+		svc.ListExpiredBlindTokenKeys,
+		connect.WithSchema(exchangeServiceMethods.ByName("ListExpiredBlindTokenKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceCreateServiceSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceCreateServiceSessionProcedure,
+		svc.CreateServiceSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("CreateServiceSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceConsumeServiceSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceConsumeServiceSessionProcedure,
+		svc.ConsumeServiceSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("ConsumeServiceSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceGetServiceSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceGetServiceSessionProcedure,
+		svc.GetServiceSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("GetServiceSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceTopUpServiceSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceTopUpServiceSessionProcedure,
+		svc.TopUpServiceSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("TopUpServiceSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServicePauseServiceSessionHandler := connect.NewUnaryHandler(
+		ExchangeServicePauseServiceSessionProcedure,
+		svc.PauseServiceSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("PauseServiceSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceResumeServiceSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceResumeServiceSessionProcedure,
+		svc.ResumeServiceSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("ResumeServiceSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceCloseServiceSessionHandler := connect.NewUnaryHandler(
+		ExchangeServiceCloseServiceSessionProcedure,
+		svc.CloseServiceSession,
+		connect.WithSchema(exchangeServiceMethods.ByName("CloseServiceSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceSubmitConsumptionReceiptsHandler := connect.NewUnaryHandler(
+		ExchangeServiceSubmitConsumptionReceiptsProcedure,
+		svc.SubmitConsumptionReceipts,
+		connect.WithSchema(exchangeServiceMethods.ByName("SubmitConsumptionReceipts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceGetTreeHeadHandler := connect.NewUnaryHandler(
+		ExchangeServiceGetTreeHeadProcedure,
+		svc.GetTreeHead,
+		connect.WithSchema(exchangeServiceMethods.ByName("GetTreeHead")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceListLogEntriesHandler := connect.NewUnaryHandler(
+		ExchangeServiceListLogEntriesProcedure,
+		svc.ListLogEntries,
+		connect.WithSchema(exchangeServiceMethods.ByName("ListLogEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceGetInclusionProofHandler := connect.NewUnaryHandler(
+		ExchangeServiceGetInclusionProofProcedure,
+		svc.GetInclusionProof,
+		connect.WithSchema(exchangeServiceMethods.ByName("GetInclusionProof")),
+		connect.WithHandlerOptions(opts...),
+	)
+	exchangeServiceGetConsistencyProofHandler := connect.NewUnaryHandler(
+		ExchangeServiceGetConsistencyProofProcedure,
+		svc.GetConsistencyProof,
+		connect.WithSchema(exchangeServiceMethods.ByName("GetConsistencyProof")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/exchange.v1.ExchangeService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ExchangeServiceLoginProcedure:
 			exchangeServiceLoginHandler.ServeHTTP(w, r)
+		case ExchangeServiceLoginWithSignatureProcedure:
+			exchangeServiceLoginWithSignatureHandler.ServeHTTP(w, r)
+		case ExchangeServiceResetPasswordProcedure:
+			exchangeServiceResetPasswordHandler.ServeHTTP(w, r)
+		case ExchangeServiceRefreshSessionProcedure:
+			exchangeServiceRefreshSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceLogoutProcedure:
+			exchangeServiceLogoutHandler.ServeHTTP(w, r)
+		case ExchangeServiceListSessionsProcedure:
+			exchangeServiceListSessionsHandler.ServeHTTP(w, r)
+		case ExchangeServiceRevokeSessionProcedure:
+			exchangeServiceRevokeSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceCreateApiKeyProcedure:
+			exchangeServiceCreateApiKeyHandler.ServeHTTP(w, r)
+		case ExchangeServiceListApiKeysProcedure:
+			exchangeServiceListApiKeysHandler.ServeHTTP(w, r)
+		case ExchangeServiceRevokeApiKeyProcedure:
+			exchangeServiceRevokeApiKeyHandler.ServeHTTP(w, r)
 		case ExchangeServiceGetChallengeProcedure:
 			exchangeServiceGetChallengeHandler.ServeHTTP(w, r)
 		case ExchangeServiceDepositProcedure:
@@ -307,16 +1222,70 @@ func NewExchangeServiceHandler(svc ExchangeServiceHandler, opts ...connect.Handl
 			exchangeServicePruneAccountsHandler.ServeHTTP(w, r)
 		case ExchangeServiceCreateWithdrawProcedure:
 			exchangeServiceCreateWithdrawHandler.ServeHTTP(w, r)
+		case ExchangeServiceEstimateWithdrawFeeProcedure:
+			exchangeServiceEstimateWithdrawFeeHandler.ServeHTTP(w, r)
 		case ExchangeServiceBatchProcessWithdrawsProcedure:
 			exchangeServiceBatchProcessWithdrawsHandler.ServeHTTP(w, r)
 		case ExchangeServiceBatchMarkWithdrawsProcedure:
 			exchangeServiceBatchMarkWithdrawsHandler.ServeHTTP(w, r)
+		case ExchangeServiceGetWalletStatusProcedure:
+			exchangeServiceGetWalletStatusHandler.ServeHTTP(w, r)
 		case ExchangeServicePingProcedure:
 			exchangeServicePingHandler.ServeHTTP(w, r)
 		case ExchangeServiceListPaymentMethodsProcedure:
 			exchangeServiceListPaymentMethodsHandler.ServeHTTP(w, r)
+		case ExchangeServiceListSigningKeysProcedure:
+			exchangeServiceListSigningKeysHandler.ServeHTTP(w, r)
 		case ExchangeServiceBuyTokenProcedure:
 			exchangeServiceBuyTokenHandler.ServeHTTP(w, r)
+		case ExchangeServiceRedeemTokenProcedure:
+			exchangeServiceRedeemTokenHandler.ServeHTTP(w, r)
+		case ExchangeServiceIntrospectTokenProcedure:
+			exchangeServiceIntrospectTokenHandler.ServeHTTP(w, r)
+		case ExchangeServiceRefundTokenProcedure:
+			exchangeServiceRefundTokenHandler.ServeHTTP(w, r)
+		case ExchangeServiceGetRefundPolicyProcedure:
+			exchangeServiceGetRefundPolicyHandler.ServeHTTP(w, r)
+		case ExchangeServiceUpdateRefundPolicyProcedure:
+			exchangeServiceUpdateRefundPolicyHandler.ServeHTTP(w, r)
+		case ExchangeServiceGetTokenPolicyProcedure:
+			exchangeServiceGetTokenPolicyHandler.ServeHTTP(w, r)
+		case ExchangeServiceUpdateTokenPolicyProcedure:
+			exchangeServiceUpdateTokenPolicyHandler.ServeHTTP(w, r)
+		case ExchangeServiceListBlindTokenKeysProcedure:
+			exchangeServiceListBlindTokenKeysHandler.ServeHTTP(w, r)
+		case ExchangeServiceIssueBlindTokensProcedure:
+			exchangeServiceIssueBlindTokensHandler.ServeHTTP(w, r)
+		case ExchangeServiceRedeemBlindTokenProcedure:
+			exchangeServiceRedeemBlindTokenHandler.ServeHTTP(w, r)
+		case ExchangeServicePruneSpentBlindTokensProcedure:
+			exchangeServicePruneSpentBlindTokensHandler.ServeHTTP(w, r)
+		case ExchangeServiceListExpiredBlindTokenKeysProcedure:
+			exchangeServiceListExpiredBlindTokenKeysHandler.ServeHTTP(w, r)
+		case ExchangeServiceCreateServiceSessionProcedure:
+			exchangeServiceCreateServiceSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceConsumeServiceSessionProcedure:
+			exchangeServiceConsumeServiceSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceGetServiceSessionProcedure:
+			exchangeServiceGetServiceSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceTopUpServiceSessionProcedure:
+			exchangeServiceTopUpServiceSessionHandler.ServeHTTP(w, r)
+		case ExchangeServicePauseServiceSessionProcedure:
+			exchangeServicePauseServiceSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceResumeServiceSessionProcedure:
+			exchangeServiceResumeServiceSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceCloseServiceSessionProcedure:
+			exchangeServiceCloseServiceSessionHandler.ServeHTTP(w, r)
+		case ExchangeServiceSubmitConsumptionReceiptsProcedure:
+			exchangeServiceSubmitConsumptionReceiptsHandler.ServeHTTP(w, r)
+		case ExchangeServiceGetTreeHeadProcedure:
+			exchangeServiceGetTreeHeadHandler.ServeHTTP(w, r)
+		case ExchangeServiceListLogEntriesProcedure:
+			exchangeServiceListLogEntriesHandler.ServeHTTP(w, r)
+		case ExchangeServiceGetInclusionProofProcedure:
+			exchangeServiceGetInclusionProofHandler.ServeHTTP(w, r)
+		case ExchangeServiceGetConsistencyProofProcedure:
+			exchangeServiceGetConsistencyProofHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -330,6 +1299,42 @@ func (UnimplementedExchangeServiceHandler) Login(context.Context, *connect.Reque
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.Login is not implemented"))
 }
 
+func (UnimplementedExchangeServiceHandler) LoginWithSignature(context.Context, *connect.Request[v1.LoginWithSignatureRequest]) (*connect.Response[v1.LoginWithSignatureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.LoginWithSignature is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ResetPassword(context.Context, *connect.Request[v1.ResetPasswordRequest]) (*connect.Response[v1.ResetPasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ResetPassword is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) RefreshSession(context.Context, *connect.Request[v1.RefreshSessionRequest]) (*connect.Response[v1.RefreshSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.RefreshSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) Logout(context.Context, *connect.Request[v1.LogoutRequest]) (*connect.Response[v1.LogoutResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.Logout is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ListSessions is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.RevokeSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) CreateApiKey(context.Context, *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.CreateApiKey is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ListApiKeys(context.Context, *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ListApiKeys is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) RevokeApiKey(context.Context, *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.RevokeApiKey is not implemented"))
+}
+
 func (UnimplementedExchangeServiceHandler) GetChallenge(context.Context, *connect.Request[v1.GetChallengeRequest]) (*connect.Response[v1.GetChallengeResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetChallenge is not implemented"))
 }
@@ -346,6 +1351,10 @@ func (UnimplementedExchangeServiceHandler) CreateWithdraw(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.CreateWithdraw is not implemented"))
 }
 
+func (UnimplementedExchangeServiceHandler) EstimateWithdrawFee(context.Context, *connect.Request[v1.EstimateWithdrawFeeRequest]) (*connect.Response[v1.EstimateWithdrawFeeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.EstimateWithdrawFee is not implemented"))
+}
+
 func (UnimplementedExchangeServiceHandler) BatchProcessWithdraws(context.Context, *connect.Request[v1.BatchProcessWithdrawsRequest]) (*connect.Response[v1.BatchProcessWithdrawsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.BatchProcessWithdraws is not implemented"))
 }
@@ -354,6 +1363,10 @@ func (UnimplementedExchangeServiceHandler) BatchMarkWithdraws(context.Context, *
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.BatchMarkWithdraws is not implemented"))
 }
 
+func (UnimplementedExchangeServiceHandler) GetWalletStatus(context.Context, *connect.Request[v1.GetWalletStatusRequest]) (*connect.Response[v1.GetWalletStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetWalletStatus is not implemented"))
+}
+
 func (UnimplementedExchangeServiceHandler) Ping(context.Context, *connect.Request[v1.PingRequest]) (*connect.Response[v1.PingResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.Ping is not implemented"))
 }
@@ -362,6 +1375,106 @@ func (UnimplementedExchangeServiceHandler) ListPaymentMethods(context.Context, *
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ListPaymentMethods is not implemented"))
 }
 
+func (UnimplementedExchangeServiceHandler) ListSigningKeys(context.Context, *connect.Request[v1.ListSigningKeysRequest]) (*connect.Response[v1.ListSigningKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ListSigningKeys is not implemented"))
+}
+
 func (UnimplementedExchangeServiceHandler) BuyToken(context.Context, *connect.Request[v1.BuyTokenRequest]) (*connect.Response[v1.BuyTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.BuyToken is not implemented"))
 }
+
+func (UnimplementedExchangeServiceHandler) RedeemToken(context.Context, *connect.Request[v1.RedeemTokenRequest]) (*connect.Response[v1.RedeemTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.RedeemToken is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) IntrospectToken(context.Context, *connect.Request[v1.IntrospectTokenRequest]) (*connect.Response[v1.IntrospectTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.IntrospectToken is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) RefundToken(context.Context, *connect.Request[v1.RefundTokenRequest]) (*connect.Response[v1.RefundTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.RefundToken is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) GetRefundPolicy(context.Context, *connect.Request[v1.GetRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetRefundPolicy is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) UpdateRefundPolicy(context.Context, *connect.Request[v1.UpdateRefundPolicyRequest]) (*connect.Response[v1.RefundPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.UpdateRefundPolicy is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) GetTokenPolicy(context.Context, *connect.Request[v1.GetTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetTokenPolicy is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) UpdateTokenPolicy(context.Context, *connect.Request[v1.UpdateTokenPolicyRequest]) (*connect.Response[v1.TokenPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.UpdateTokenPolicy is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ListBlindTokenKeys(context.Context, *connect.Request[v1.ListBlindTokenKeysRequest]) (*connect.Response[v1.ListBlindTokenKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ListBlindTokenKeys is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) IssueBlindTokens(context.Context, *connect.Request[v1.IssueBlindTokensRequest]) (*connect.Response[v1.IssueBlindTokensResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.IssueBlindTokens is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) RedeemBlindToken(context.Context, *connect.Request[v1.RedeemBlindTokenRequest]) (*connect.Response[v1.RedeemBlindTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.RedeemBlindToken is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) PruneSpentBlindTokens(context.Context, *connect.Request[v1.PruneSpentBlindTokensRequest]) (*connect.Response[v1.PruneSpentBlindTokensResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.PruneSpentBlindTokens is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ListExpiredBlindTokenKeys(context.Context, *connect.Request[v1.ListExpiredBlindTokenKeysRequest]) (*connect.Response[v1.ListExpiredBlindTokenKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ListExpiredBlindTokenKeys is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) CreateServiceSession(context.Context, *connect.Request[v1.CreateServiceSessionRequest]) (*connect.Response[v1.CreateServiceSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.CreateServiceSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ConsumeServiceSession(context.Context, *connect.Request[v1.ConsumeServiceSessionRequest]) (*connect.Response[v1.ConsumeServiceSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ConsumeServiceSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) GetServiceSession(context.Context, *connect.Request[v1.GetServiceSessionRequest]) (*connect.Response[v1.GetServiceSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetServiceSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) TopUpServiceSession(context.Context, *connect.Request[v1.TopUpServiceSessionRequest]) (*connect.Response[v1.TopUpServiceSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.TopUpServiceSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) PauseServiceSession(context.Context, *connect.Request[v1.PauseServiceSessionRequest]) (*connect.Response[v1.PauseServiceSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.PauseServiceSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ResumeServiceSession(context.Context, *connect.Request[v1.ResumeServiceSessionRequest]) (*connect.Response[v1.ResumeServiceSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ResumeServiceSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) CloseServiceSession(context.Context, *connect.Request[v1.CloseServiceSessionRequest]) (*connect.Response[v1.CloseServiceSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.CloseServiceSession is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) SubmitConsumptionReceipts(context.Context, *connect.Request[v1.SubmitConsumptionReceiptsRequest]) (*connect.Response[v1.SubmitConsumptionReceiptsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.SubmitConsumptionReceipts is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) GetTreeHead(context.Context, *connect.Request[v1.GetTreeHeadRequest]) (*connect.Response[v1.SignedTreeHead], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetTreeHead is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) ListLogEntries(context.Context, *connect.Request[v1.ListLogEntriesRequest]) (*connect.Response[v1.ListLogEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.ListLogEntries is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) GetInclusionProof(context.Context, *connect.Request[v1.GetInclusionProofRequest]) (*connect.Response[v1.GetInclusionProofResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetInclusionProof is not implemented"))
+}
+
+func (UnimplementedExchangeServiceHandler) GetConsistencyProof(context.Context, *connect.Request[v1.GetConsistencyProofRequest]) (*connect.Response[v1.GetConsistencyProofResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exchange.v1.ExchangeService.GetConsistencyProof is not implemented"))
+}
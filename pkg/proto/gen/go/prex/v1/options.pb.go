@@ -0,0 +1,229 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: prex/v1/options.proto
+
+package prex
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Role a caller needs to be granted in its session token.
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_USER        Role = 1
+	Role_ROLE_ADMIN       Role = 2
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_USER",
+		2: "ROLE_ADMIN",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_USER":        1,
+		"ROLE_ADMIN":       2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_prex_v1_options_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_prex_v1_options_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_prex_v1_options_proto_rawDescGZIP(), []int{0}
+}
+
+// AuthPolicy declares who may call an RPC. Every RPC served by Prex must
+// declare one, otherwise the server refuses to start.
+type AuthPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// public RPCs are served without a session token.
+	Public bool `protobuf:"varint,1,opt,name=public,proto3" json:"public,omitempty"`
+	// role is required for non public RPCs.
+	Role Role `protobuf:"varint,2,opt,name=role,proto3,enum=prex.v1.Role" json:"role,omitempty"`
+	// api_key_scope lets API keys granted this scope call the RPC. RPCs
+	// without a scope only accept session tokens.
+	ApiKeyScope   string `protobuf:"bytes,3,opt,name=api_key_scope,json=apiKeyScope,proto3" json:"api_key_scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthPolicy) Reset() {
+	*x = AuthPolicy{}
+	mi := &file_prex_v1_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthPolicy) ProtoMessage() {}
+
+func (x *AuthPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_prex_v1_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthPolicy.ProtoReflect.Descriptor instead.
+func (*AuthPolicy) Descriptor() ([]byte, []int) {
+	return file_prex_v1_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthPolicy) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *AuthPolicy) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *AuthPolicy) GetApiKeyScope() string {
+	if x != nil {
+		return x.ApiKeyScope
+	}
+	return ""
+}
+
+var file_prex_v1_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*AuthPolicy)(nil),
+		Field:         50100,
+		Name:          "prex.v1.auth",
+		Tag:           "bytes,50100,opt,name=auth",
+		Filename:      "prex/v1/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional prex.v1.AuthPolicy auth = 50100;
+	E_Auth = &file_prex_v1_options_proto_extTypes[0]
+)
+
+var File_prex_v1_options_proto protoreflect.FileDescriptor
+
+const file_prex_v1_options_proto_rawDesc = "" +
+	"\n" +
+	"\x15prex/v1/options.proto\x12\aprex.v1\x1a google/protobuf/descriptor.proto\"k\n" +
+	"\n" +
+	"AuthPolicy\x12\x16\n" +
+	"\x06public\x18\x01 \x01(\bR\x06public\x12!\n" +
+	"\x04role\x18\x02 \x01(\x0e2\r.prex.v1.RoleR\x04role\x12\"\n" +
+	"\rapi_key_scope\x18\x03 \x01(\tR\vapiKeyScope*;\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tROLE_USER\x10\x01\x12\x0e\n" +
+	"\n" +
+	"ROLE_ADMIN\x10\x02:I\n" +
+	"\x04auth\x12\x1e.google.protobuf.MethodOptions\x18\xb4\x87\x03 \x01(\v2\x13.prex.v1.AuthPolicyR\x04authB>Z<github.com/atticplaygroup/prex/pkg/proto/gen/go/prex/v1;prexb\x06proto3"
+
+var (
+	file_prex_v1_options_proto_rawDescOnce sync.Once
+	file_prex_v1_options_proto_rawDescData []byte
+)
+
+func file_prex_v1_options_proto_rawDescGZIP() []byte {
+	file_prex_v1_options_proto_rawDescOnce.Do(func() {
+		file_prex_v1_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prex_v1_options_proto_rawDesc), len(file_prex_v1_options_proto_rawDesc)))
+	})
+	return file_prex_v1_options_proto_rawDescData
+}
+
+var file_prex_v1_options_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_prex_v1_options_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_prex_v1_options_proto_goTypes = []any{
+	(Role)(0),                          // 0: prex.v1.Role
+	(*AuthPolicy)(nil),                 // 1: prex.v1.AuthPolicy
+	(*descriptorpb.MethodOptions)(nil), // 2: google.protobuf.MethodOptions
+}
+var file_prex_v1_options_proto_depIdxs = []int32{
+	0, // 0: prex.v1.AuthPolicy.role:type_name -> prex.v1.Role
+	2, // 1: prex.v1.auth:extendee -> google.protobuf.MethodOptions
+	1, // 2: prex.v1.auth:type_name -> prex.v1.AuthPolicy
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	2, // [2:3] is the sub-list for extension type_name
+	1, // [1:2] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_prex_v1_options_proto_init() }
+func file_prex_v1_options_proto_init() {
+	if File_prex_v1_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prex_v1_options_proto_rawDesc), len(file_prex_v1_options_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_prex_v1_options_proto_goTypes,
+		DependencyIndexes: file_prex_v1_options_proto_depIdxs,
+		EnumInfos:         file_prex_v1_options_proto_enumTypes,
+		MessageInfos:      file_prex_v1_options_proto_msgTypes,
+		ExtensionInfos:    file_prex_v1_options_proto_extTypes,
+	}.Build()
+	File_prex_v1_options_proto = out.File
+	file_prex_v1_options_proto_goTypes = nil
+	file_prex_v1_options_proto_depIdxs = nil
+}
@@ -0,0 +1,1008 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: signer/v1/signer.proto
+
+package signer
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPublicKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeysRequest) Reset() {
+	*x = GetPublicKeysRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeysRequest) ProtoMessage() {}
+
+func (x *GetPublicKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeysRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{0}
+}
+
+type GetPublicKeysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sui address of the wallet signing withdrawals
+	WalletAddress string `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	// Raw ed25519 public key signing tokens
+	TokenPublicKey []byte `protobuf:"bytes,2,opt,name=token_public_key,json=tokenPublicKey,proto3" json:"token_public_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetPublicKeysResponse) Reset() {
+	*x = GetPublicKeysResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeysResponse) ProtoMessage() {}
+
+func (x *GetPublicKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeysResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPublicKeysResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetPublicKeysResponse) GetTokenPublicKey() []byte {
+	if x != nil {
+		return x.TokenPublicKey
+	}
+	return nil
+}
+
+type SignTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base64 encoded BCS transaction data
+	TxBytes       string `protobuf:"bytes,1,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTransactionRequest) Reset() {
+	*x = SignTransactionRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTransactionRequest) ProtoMessage() {}
+
+func (x *SignTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTransactionRequest.ProtoReflect.Descriptor instead.
+func (*SignTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SignTransactionRequest) GetTxBytes() string {
+	if x != nil {
+		return x.TxBytes
+	}
+	return ""
+}
+
+type SignTransactionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Serialized Sui signature in base64
+	Signature     string `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTransactionResponse) Reset() {
+	*x = SignTransactionResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTransactionResponse) ProtoMessage() {}
+
+func (x *SignTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTransactionResponse.ProtoReflect.Descriptor instead.
+func (*SignTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SignTransactionResponse) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type SignTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JWT signing input, i.e. base64url(header) || '.' || base64url(claims)
+	SigningInput string `protobuf:"bytes,1,opt,name=signing_input,json=signingInput,proto3" json:"signing_input,omitempty"`
+	// kid of the key to sign with. Empty for the key in GetPublicKeys.
+	KeyId         string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTokenRequest) Reset() {
+	*x = SignTokenRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTokenRequest) ProtoMessage() {}
+
+func (x *SignTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTokenRequest.ProtoReflect.Descriptor instead.
+func (*SignTokenRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignTokenRequest) GetSigningInput() string {
+	if x != nil {
+		return x.SigningInput
+	}
+	return ""
+}
+
+func (x *SignTokenRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type SignTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signature     []byte                 `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTokenResponse) Reset() {
+	*x = SignTokenResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTokenResponse) ProtoMessage() {}
+
+func (x *SignTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTokenResponse.ProtoReflect.Descriptor instead.
+func (*SignTokenResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SignTokenResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type SignTreeHeadRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TreeSize int64                  `protobuf:"varint,1,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	// SHA-256 root hash of the first tree_size entries
+	RootHash  []byte                 `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// kid of the key to sign with. Empty for the key in GetPublicKeys.
+	KeyId         string `protobuf:"bytes,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTreeHeadRequest) Reset() {
+	*x = SignTreeHeadRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTreeHeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTreeHeadRequest) ProtoMessage() {}
+
+func (x *SignTreeHeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTreeHeadRequest.ProtoReflect.Descriptor instead.
+func (*SignTreeHeadRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SignTreeHeadRequest) GetTreeSize() int64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+func (x *SignTreeHeadRequest) GetRootHash() []byte {
+	if x != nil {
+		return x.RootHash
+	}
+	return nil
+}
+
+func (x *SignTreeHeadRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SignTreeHeadRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type SignTreeHeadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signature     []byte                 `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignTreeHeadResponse) Reset() {
+	*x = SignTreeHeadResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignTreeHeadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignTreeHeadResponse) ProtoMessage() {}
+
+func (x *SignTreeHeadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignTreeHeadResponse.ProtoReflect.Descriptor instead.
+func (*SignTreeHeadResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SignTreeHeadResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type GenerateTokenKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateTokenKeyRequest) Reset() {
+	*x = GenerateTokenKeyRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateTokenKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTokenKeyRequest) ProtoMessage() {}
+
+func (x *GenerateTokenKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateTokenKeyRequest.ProtoReflect.Descriptor instead.
+func (*GenerateTokenKeyRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{8}
+}
+
+type GenerateTokenKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Raw ed25519 public key of the new token key
+	TokenPublicKey []byte `protobuf:"bytes,1,opt,name=token_public_key,json=tokenPublicKey,proto3" json:"token_public_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GenerateTokenKeyResponse) Reset() {
+	*x = GenerateTokenKeyResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateTokenKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTokenKeyResponse) ProtoMessage() {}
+
+func (x *GenerateTokenKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateTokenKeyResponse.ProtoReflect.Descriptor instead.
+func (*GenerateTokenKeyResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GenerateTokenKeyResponse) GetTokenPublicKey() []byte {
+	if x != nil {
+		return x.TokenPublicKey
+	}
+	return nil
+}
+
+type BlindKey struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	KeyId        string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Denomination int64                  `protobuf:"varint,2,opt,name=denomination,proto3" json:"denomination,omitempty"`
+	// PKIX DER of the RSA public key
+	PublicKey []byte `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// The key signs no tokens from then on
+	IssueEndTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=issue_end_time,json=issueEndTime,proto3" json:"issue_end_time,omitempty"`
+	// Tokens of the key are not redeemable from then on
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlindKey) Reset() {
+	*x = BlindKey{}
+	mi := &file_signer_v1_signer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlindKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlindKey) ProtoMessage() {}
+
+func (x *BlindKey) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlindKey.ProtoReflect.Descriptor instead.
+func (*BlindKey) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BlindKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *BlindKey) GetDenomination() int64 {
+	if x != nil {
+		return x.Denomination
+	}
+	return 0
+}
+
+func (x *BlindKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *BlindKey) GetIssueEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueEndTime
+	}
+	return nil
+}
+
+func (x *BlindKey) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type GenerateBlindKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Denomination  int64                  `protobuf:"varint,1,opt,name=denomination,proto3" json:"denomination,omitempty"`
+	IssueEndTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=issue_end_time,json=issueEndTime,proto3" json:"issue_end_time,omitempty"`
+	ExpireTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBlindKeyRequest) Reset() {
+	*x = GenerateBlindKeyRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBlindKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBlindKeyRequest) ProtoMessage() {}
+
+func (x *GenerateBlindKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBlindKeyRequest.ProtoReflect.Descriptor instead.
+func (*GenerateBlindKeyRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GenerateBlindKeyRequest) GetDenomination() int64 {
+	if x != nil {
+		return x.Denomination
+	}
+	return 0
+}
+
+func (x *GenerateBlindKeyRequest) GetIssueEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueEndTime
+	}
+	return nil
+}
+
+func (x *GenerateBlindKeyRequest) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+type GenerateBlindKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlindKey      *BlindKey              `protobuf:"bytes,1,opt,name=blind_key,json=blindKey,proto3" json:"blind_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBlindKeyResponse) Reset() {
+	*x = GenerateBlindKeyResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBlindKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBlindKeyResponse) ProtoMessage() {}
+
+func (x *GenerateBlindKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBlindKeyResponse.ProtoReflect.Descriptor instead.
+func (*GenerateBlindKeyResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GenerateBlindKeyResponse) GetBlindKey() *BlindKey {
+	if x != nil {
+		return x.BlindKey
+	}
+	return nil
+}
+
+type ListBlindKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlindKeysRequest) Reset() {
+	*x = ListBlindKeysRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlindKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlindKeysRequest) ProtoMessage() {}
+
+func (x *ListBlindKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlindKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListBlindKeysRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{13}
+}
+
+type ListBlindKeysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keys not expired yet
+	BlindKeys     []*BlindKey `protobuf:"bytes,1,rep,name=blind_keys,json=blindKeys,proto3" json:"blind_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlindKeysResponse) Reset() {
+	*x = ListBlindKeysResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlindKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlindKeysResponse) ProtoMessage() {}
+
+func (x *ListBlindKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlindKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListBlindKeysResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListBlindKeysResponse) GetBlindKeys() []*BlindKey {
+	if x != nil {
+		return x.BlindKeys
+	}
+	return nil
+}
+
+type SignBlindedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	KeyId           string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	BlindedMessages [][]byte               `protobuf:"bytes,2,rep,name=blinded_messages,json=blindedMessages,proto3" json:"blinded_messages,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SignBlindedRequest) Reset() {
+	*x = SignBlindedRequest{}
+	mi := &file_signer_v1_signer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignBlindedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBlindedRequest) ProtoMessage() {}
+
+func (x *SignBlindedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBlindedRequest.ProtoReflect.Descriptor instead.
+func (*SignBlindedRequest) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SignBlindedRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SignBlindedRequest) GetBlindedMessages() [][]byte {
+	if x != nil {
+		return x.BlindedMessages
+	}
+	return nil
+}
+
+type SignBlindedResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BlindSignatures [][]byte               `protobuf:"bytes,1,rep,name=blind_signatures,json=blindSignatures,proto3" json:"blind_signatures,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SignBlindedResponse) Reset() {
+	*x = SignBlindedResponse{}
+	mi := &file_signer_v1_signer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignBlindedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBlindedResponse) ProtoMessage() {}
+
+func (x *SignBlindedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signer_v1_signer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBlindedResponse.ProtoReflect.Descriptor instead.
+func (*SignBlindedResponse) Descriptor() ([]byte, []int) {
+	return file_signer_v1_signer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SignBlindedResponse) GetBlindSignatures() [][]byte {
+	if x != nil {
+		return x.BlindSignatures
+	}
+	return nil
+}
+
+var File_signer_v1_signer_proto protoreflect.FileDescriptor
+
+const file_signer_v1_signer_proto_rawDesc = "" +
+	"\n" +
+	"\x16signer/v1/signer.proto\x12\tsigner.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\x16\n" +
+	"\x14GetPublicKeysRequest\"q\n" +
+	"\x15GetPublicKeysResponse\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x121\n" +
+	"\x10token_public_key\x18\x02 \x01(\fB\a\xbaH\x04z\x02h R\x0etokenPublicKey\"<\n" +
+	"\x16SignTransactionRequest\x12\"\n" +
+	"\btx_bytes\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\atxBytes\"7\n" +
+	"\x17SignTransactionResponse\x12\x1c\n" +
+	"\tsignature\x18\x01 \x01(\tR\tsignature\"W\n" +
+	"\x10SignTokenRequest\x12,\n" +
+	"\rsigning_input\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\fsigningInput\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\":\n" +
+	"\x11SignTokenResponse\x12%\n" +
+	"\tsignature\x18\x01 \x01(\fB\a\xbaH\x04z\x02h@R\tsignature\"\xba\x01\n" +
+	"\x13SignTreeHeadRequest\x12$\n" +
+	"\ttree_size\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\btreeSize\x12$\n" +
+	"\troot_hash\x18\x02 \x01(\fB\a\xbaH\x04z\x02h R\brootHash\x12@\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\ttimestamp\x12\x15\n" +
+	"\x06key_id\x18\x04 \x01(\tR\x05keyId\"=\n" +
+	"\x14SignTreeHeadResponse\x12%\n" +
+	"\tsignature\x18\x01 \x01(\fB\a\xbaH\x04z\x02h@R\tsignature\"\x19\n" +
+	"\x17GenerateTokenKeyRequest\"M\n" +
+	"\x18GenerateTokenKeyResponse\x121\n" +
+	"\x10token_public_key\x18\x01 \x01(\fB\a\xbaH\x04z\x02h R\x0etokenPublicKey\"\xfc\x01\n" +
+	"\bBlindKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\"\n" +
+	"\fdenomination\x18\x02 \x01(\x03R\fdenomination\x12&\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\tpublicKey\x12H\n" +
+	"\x0eissue_end_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\fissueEndTime\x12C\n" +
+	"\vexpire_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"expireTime\"\xd5\x01\n" +
+	"\x17GenerateBlindKeyRequest\x12+\n" +
+	"\fdenomination\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\fdenomination\x12H\n" +
+	"\x0eissue_end_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\fissueEndTime\x12C\n" +
+	"\vexpire_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"expireTime\"L\n" +
+	"\x18GenerateBlindKeyResponse\x120\n" +
+	"\tblind_key\x18\x01 \x01(\v2\x13.signer.v1.BlindKeyR\bblindKey\"\x16\n" +
+	"\x14ListBlindKeysRequest\"K\n" +
+	"\x15ListBlindKeysResponse\x122\n" +
+	"\n" +
+	"blind_keys\x18\x01 \x03(\v2\x13.signer.v1.BlindKeyR\tblindKeys\"k\n" +
+	"\x12SignBlindedRequest\x12\x1e\n" +
+	"\x06key_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05keyId\x125\n" +
+	"\x10blinded_messages\x18\x02 \x03(\fB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10dR\x0fblindedMessages\"@\n" +
+	"\x13SignBlindedResponse\x12)\n" +
+	"\x10blind_signatures\x18\x01 \x03(\fR\x0fblindSignatures2\xb2\x05\n" +
+	"\rSignerService\x12R\n" +
+	"\rGetPublicKeys\x12\x1f.signer.v1.GetPublicKeysRequest\x1a .signer.v1.GetPublicKeysResponse\x12X\n" +
+	"\x0fSignTransaction\x12!.signer.v1.SignTransactionRequest\x1a\".signer.v1.SignTransactionResponse\x12F\n" +
+	"\tSignToken\x12\x1b.signer.v1.SignTokenRequest\x1a\x1c.signer.v1.SignTokenResponse\x12O\n" +
+	"\fSignTreeHead\x12\x1e.signer.v1.SignTreeHeadRequest\x1a\x1f.signer.v1.SignTreeHeadResponse\x12[\n" +
+	"\x10GenerateTokenKey\x12\".signer.v1.GenerateTokenKeyRequest\x1a#.signer.v1.GenerateTokenKeyResponse\x12[\n" +
+	"\x10GenerateBlindKey\x12\".signer.v1.GenerateBlindKeyRequest\x1a#.signer.v1.GenerateBlindKeyResponse\x12R\n" +
+	"\rListBlindKeys\x12\x1f.signer.v1.ListBlindKeysRequest\x1a .signer.v1.ListBlindKeysResponse\x12L\n" +
+	"\vSignBlinded\x12\x1d.signer.v1.SignBlindedRequest\x1a\x1e.signer.v1.SignBlindedResponseBBZ@github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1;signerb\x06proto3"
+
+var (
+	file_signer_v1_signer_proto_rawDescOnce sync.Once
+	file_signer_v1_signer_proto_rawDescData []byte
+)
+
+func file_signer_v1_signer_proto_rawDescGZIP() []byte {
+	file_signer_v1_signer_proto_rawDescOnce.Do(func() {
+		file_signer_v1_signer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_signer_v1_signer_proto_rawDesc), len(file_signer_v1_signer_proto_rawDesc)))
+	})
+	return file_signer_v1_signer_proto_rawDescData
+}
+
+var file_signer_v1_signer_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_signer_v1_signer_proto_goTypes = []any{
+	(*GetPublicKeysRequest)(nil),     // 0: signer.v1.GetPublicKeysRequest
+	(*GetPublicKeysResponse)(nil),    // 1: signer.v1.GetPublicKeysResponse
+	(*SignTransactionRequest)(nil),   // 2: signer.v1.SignTransactionRequest
+	(*SignTransactionResponse)(nil),  // 3: signer.v1.SignTransactionResponse
+	(*SignTokenRequest)(nil),         // 4: signer.v1.SignTokenRequest
+	(*SignTokenResponse)(nil),        // 5: signer.v1.SignTokenResponse
+	(*SignTreeHeadRequest)(nil),      // 6: signer.v1.SignTreeHeadRequest
+	(*SignTreeHeadResponse)(nil),     // 7: signer.v1.SignTreeHeadResponse
+	(*GenerateTokenKeyRequest)(nil),  // 8: signer.v1.GenerateTokenKeyRequest
+	(*GenerateTokenKeyResponse)(nil), // 9: signer.v1.GenerateTokenKeyResponse
+	(*BlindKey)(nil),                 // 10: signer.v1.BlindKey
+	(*GenerateBlindKeyRequest)(nil),  // 11: signer.v1.GenerateBlindKeyRequest
+	(*GenerateBlindKeyResponse)(nil), // 12: signer.v1.GenerateBlindKeyResponse
+	(*ListBlindKeysRequest)(nil),     // 13: signer.v1.ListBlindKeysRequest
+	(*ListBlindKeysResponse)(nil),    // 14: signer.v1.ListBlindKeysResponse
+	(*SignBlindedRequest)(nil),       // 15: signer.v1.SignBlindedRequest
+	(*SignBlindedResponse)(nil),      // 16: signer.v1.SignBlindedResponse
+	(*timestamppb.Timestamp)(nil),    // 17: google.protobuf.Timestamp
+}
+var file_signer_v1_signer_proto_depIdxs = []int32{
+	17, // 0: signer.v1.SignTreeHeadRequest.timestamp:type_name -> google.protobuf.Timestamp
+	17, // 1: signer.v1.BlindKey.issue_end_time:type_name -> google.protobuf.Timestamp
+	17, // 2: signer.v1.BlindKey.expire_time:type_name -> google.protobuf.Timestamp
+	17, // 3: signer.v1.GenerateBlindKeyRequest.issue_end_time:type_name -> google.protobuf.Timestamp
+	17, // 4: signer.v1.GenerateBlindKeyRequest.expire_time:type_name -> google.protobuf.Timestamp
+	10, // 5: signer.v1.GenerateBlindKeyResponse.blind_key:type_name -> signer.v1.BlindKey
+	10, // 6: signer.v1.ListBlindKeysResponse.blind_keys:type_name -> signer.v1.BlindKey
+	0,  // 7: signer.v1.SignerService.GetPublicKeys:input_type -> signer.v1.GetPublicKeysRequest
+	2,  // 8: signer.v1.SignerService.SignTransaction:input_type -> signer.v1.SignTransactionRequest
+	4,  // 9: signer.v1.SignerService.SignToken:input_type -> signer.v1.SignTokenRequest
+	6,  // 10: signer.v1.SignerService.SignTreeHead:input_type -> signer.v1.SignTreeHeadRequest
+	8,  // 11: signer.v1.SignerService.GenerateTokenKey:input_type -> signer.v1.GenerateTokenKeyRequest
+	11, // 12: signer.v1.SignerService.GenerateBlindKey:input_type -> signer.v1.GenerateBlindKeyRequest
+	13, // 13: signer.v1.SignerService.ListBlindKeys:input_type -> signer.v1.ListBlindKeysRequest
+	15, // 14: signer.v1.SignerService.SignBlinded:input_type -> signer.v1.SignBlindedRequest
+	1,  // 15: signer.v1.SignerService.GetPublicKeys:output_type -> signer.v1.GetPublicKeysResponse
+	3,  // 16: signer.v1.SignerService.SignTransaction:output_type -> signer.v1.SignTransactionResponse
+	5,  // 17: signer.v1.SignerService.SignToken:output_type -> signer.v1.SignTokenResponse
+	7,  // 18: signer.v1.SignerService.SignTreeHead:output_type -> signer.v1.SignTreeHeadResponse
+	9,  // 19: signer.v1.SignerService.GenerateTokenKey:output_type -> signer.v1.GenerateTokenKeyResponse
+	12, // 20: signer.v1.SignerService.GenerateBlindKey:output_type -> signer.v1.GenerateBlindKeyResponse
+	14, // 21: signer.v1.SignerService.ListBlindKeys:output_type -> signer.v1.ListBlindKeysResponse
+	16, // 22: signer.v1.SignerService.SignBlinded:output_type -> signer.v1.SignBlindedResponse
+	15, // [15:23] is the sub-list for method output_type
+	7,  // [7:15] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_signer_v1_signer_proto_init() }
+func file_signer_v1_signer_proto_init() {
+	if File_signer_v1_signer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_signer_v1_signer_proto_rawDesc), len(file_signer_v1_signer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_signer_v1_signer_proto_goTypes,
+		DependencyIndexes: file_signer_v1_signer_proto_depIdxs,
+		MessageInfos:      file_signer_v1_signer_proto_msgTypes,
+	}.Build()
+	File_signer_v1_signer_proto = out.File
+	file_signer_v1_signer_proto_goTypes = nil
+	file_signer_v1_signer_proto_depIdxs = nil
+}
@@ -0,0 +1,329 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: signer/v1/signer.proto
+
+package signerconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/atticplaygroup/prex/pkg/proto/gen/go/signer/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SignerServiceName is the fully-qualified name of the SignerService service.
+	SignerServiceName = "signer.v1.SignerService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SignerServiceGetPublicKeysProcedure is the fully-qualified name of the SignerService's
+	// GetPublicKeys RPC.
+	SignerServiceGetPublicKeysProcedure = "/signer.v1.SignerService/GetPublicKeys"
+	// SignerServiceSignTransactionProcedure is the fully-qualified name of the SignerService's
+	// SignTransaction RPC.
+	SignerServiceSignTransactionProcedure = "/signer.v1.SignerService/SignTransaction"
+	// SignerServiceSignTokenProcedure is the fully-qualified name of the SignerService's SignToken RPC.
+	SignerServiceSignTokenProcedure = "/signer.v1.SignerService/SignToken"
+	// SignerServiceSignTreeHeadProcedure is the fully-qualified name of the SignerService's
+	// SignTreeHead RPC.
+	SignerServiceSignTreeHeadProcedure = "/signer.v1.SignerService/SignTreeHead"
+	// SignerServiceGenerateTokenKeyProcedure is the fully-qualified name of the SignerService's
+	// GenerateTokenKey RPC.
+	SignerServiceGenerateTokenKeyProcedure = "/signer.v1.SignerService/GenerateTokenKey"
+	// SignerServiceGenerateBlindKeyProcedure is the fully-qualified name of the SignerService's
+	// GenerateBlindKey RPC.
+	SignerServiceGenerateBlindKeyProcedure = "/signer.v1.SignerService/GenerateBlindKey"
+	// SignerServiceListBlindKeysProcedure is the fully-qualified name of the SignerService's
+	// ListBlindKeys RPC.
+	SignerServiceListBlindKeysProcedure = "/signer.v1.SignerService/ListBlindKeys"
+	// SignerServiceSignBlindedProcedure is the fully-qualified name of the SignerService's SignBlinded
+	// RPC.
+	SignerServiceSignBlindedProcedure = "/signer.v1.SignerService/SignBlinded"
+)
+
+// SignerServiceClient is a client for the signer.v1.SignerService service.
+type SignerServiceClient interface {
+	GetPublicKeys(context.Context, *connect.Request[v1.GetPublicKeysRequest]) (*connect.Response[v1.GetPublicKeysResponse], error)
+	SignTransaction(context.Context, *connect.Request[v1.SignTransactionRequest]) (*connect.Response[v1.SignTransactionResponse], error)
+	SignToken(context.Context, *connect.Request[v1.SignTokenRequest]) (*connect.Response[v1.SignTokenResponse], error)
+	// SignTreeHead signs a head of the transparency log with a token key. The
+	// signer builds the signing input itself, so a tree head can never pass
+	// for a token or the other way around.
+	SignTreeHead(context.Context, *connect.Request[v1.SignTreeHeadRequest]) (*connect.Response[v1.SignTreeHeadResponse], error)
+	// GenerateTokenKey creates a token key for rotation. The signer keeps the
+	// private key, the caller stages it by its kid.
+	GenerateTokenKey(context.Context, *connect.Request[v1.GenerateTokenKeyRequest]) (*connect.Response[v1.GenerateTokenKeyResponse], error)
+	// GenerateBlindKey creates an RSA key signing blind tokens of one
+	// denomination. The signer keeps the private key.
+	GenerateBlindKey(context.Context, *connect.Request[v1.GenerateBlindKeyRequest]) (*connect.Response[v1.GenerateBlindKeyResponse], error)
+	ListBlindKeys(context.Context, *connect.Request[v1.ListBlindKeysRequest]) (*connect.Response[v1.ListBlindKeysResponse], error)
+	// SignBlinded signs blinded blind token messages with a key that still
+	// issues tokens.
+	SignBlinded(context.Context, *connect.Request[v1.SignBlindedRequest]) (*connect.Response[v1.SignBlindedResponse], error)
+}
+
+// NewSignerServiceClient constructs a client for the signer.v1.SignerService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSignerServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SignerServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	signerServiceMethods := v1.File_signer_v1_signer_proto.Services().ByName("SignerService").Methods()
+	return &signerServiceClient{
+		getPublicKeys: connect.NewClient[v1.GetPublicKeysRequest, v1.GetPublicKeysResponse](
+			httpClient,
+			baseURL+SignerServiceGetPublicKeysProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("GetPublicKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		signTransaction: connect.NewClient[v1.SignTransactionRequest, v1.SignTransactionResponse](
+			httpClient,
+			baseURL+SignerServiceSignTransactionProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignTransaction")),
+			connect.WithClientOptions(opts...),
+		),
+		signToken: connect.NewClient[v1.SignTokenRequest, v1.SignTokenResponse](
+			httpClient,
+			baseURL+SignerServiceSignTokenProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignToken")),
+			connect.WithClientOptions(opts...),
+		),
+		signTreeHead: connect.NewClient[v1.SignTreeHeadRequest, v1.SignTreeHeadResponse](
+			httpClient,
+			baseURL+SignerServiceSignTreeHeadProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignTreeHead")),
+			connect.WithClientOptions(opts...),
+		),
+		generateTokenKey: connect.NewClient[v1.GenerateTokenKeyRequest, v1.GenerateTokenKeyResponse](
+			httpClient,
+			baseURL+SignerServiceGenerateTokenKeyProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("GenerateTokenKey")),
+			connect.WithClientOptions(opts...),
+		),
+		generateBlindKey: connect.NewClient[v1.GenerateBlindKeyRequest, v1.GenerateBlindKeyResponse](
+			httpClient,
+			baseURL+SignerServiceGenerateBlindKeyProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("GenerateBlindKey")),
+			connect.WithClientOptions(opts...),
+		),
+		listBlindKeys: connect.NewClient[v1.ListBlindKeysRequest, v1.ListBlindKeysResponse](
+			httpClient,
+			baseURL+SignerServiceListBlindKeysProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("ListBlindKeys")),
+			connect.WithClientOptions(opts...),
+		),
+		signBlinded: connect.NewClient[v1.SignBlindedRequest, v1.SignBlindedResponse](
+			httpClient,
+			baseURL+SignerServiceSignBlindedProcedure,
+			connect.WithSchema(signerServiceMethods.ByName("SignBlinded")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// signerServiceClient implements SignerServiceClient.
+type signerServiceClient struct {
+	getPublicKeys    *connect.Client[v1.GetPublicKeysRequest, v1.GetPublicKeysResponse]
+	signTransaction  *connect.Client[v1.SignTransactionRequest, v1.SignTransactionResponse]
+	signToken        *connect.Client[v1.SignTokenRequest, v1.SignTokenResponse]
+	signTreeHead     *connect.Client[v1.SignTreeHeadRequest, v1.SignTreeHeadResponse]
+	generateTokenKey *connect.Client[v1.GenerateTokenKeyRequest, v1.GenerateTokenKeyResponse]
+	generateBlindKey *connect.Client[v1.GenerateBlindKeyRequest, v1.GenerateBlindKeyResponse]
+	listBlindKeys    *connect.Client[v1.ListBlindKeysRequest, v1.ListBlindKeysResponse]
+	signBlinded      *connect.Client[v1.SignBlindedRequest, v1.SignBlindedResponse]
+}
+
+// GetPublicKeys calls signer.v1.SignerService.GetPublicKeys.
+func (c *signerServiceClient) GetPublicKeys(ctx context.Context, req *connect.Request[v1.GetPublicKeysRequest]) (*connect.Response[v1.GetPublicKeysResponse], error) {
+	return c.getPublicKeys.CallUnary(ctx, req)
+}
+
+// SignTransaction calls signer.v1.SignerService.SignTransaction.
+func (c *signerServiceClient) SignTransaction(ctx context.Context, req *connect.Request[v1.SignTransactionRequest]) (*connect.Response[v1.SignTransactionResponse], error) {
+	return c.signTransaction.CallUnary(ctx, req)
+}
+
+// SignToken calls signer.v1.SignerService.SignToken.
+func (c *signerServiceClient) SignToken(ctx context.Context, req *connect.Request[v1.SignTokenRequest]) (*connect.Response[v1.SignTokenResponse], error) {
+	return c.signToken.CallUnary(ctx, req)
+}
+
+// SignTreeHead calls signer.v1.SignerService.SignTreeHead.
+func (c *signerServiceClient) SignTreeHead(ctx context.Context, req *connect.Request[v1.SignTreeHeadRequest]) (*connect.Response[v1.SignTreeHeadResponse], error) {
+	return c.signTreeHead.CallUnary(ctx, req)
+}
+
+// GenerateTokenKey calls signer.v1.SignerService.GenerateTokenKey.
+func (c *signerServiceClient) GenerateTokenKey(ctx context.Context, req *connect.Request[v1.GenerateTokenKeyRequest]) (*connect.Response[v1.GenerateTokenKeyResponse], error) {
+	return c.generateTokenKey.CallUnary(ctx, req)
+}
+
+// GenerateBlindKey calls signer.v1.SignerService.GenerateBlindKey.
+func (c *signerServiceClient) GenerateBlindKey(ctx context.Context, req *connect.Request[v1.GenerateBlindKeyRequest]) (*connect.Response[v1.GenerateBlindKeyResponse], error) {
+	return c.generateBlindKey.CallUnary(ctx, req)
+}
+
+// ListBlindKeys calls signer.v1.SignerService.ListBlindKeys.
+func (c *signerServiceClient) ListBlindKeys(ctx context.Context, req *connect.Request[v1.ListBlindKeysRequest]) (*connect.Response[v1.ListBlindKeysResponse], error) {
+	return c.listBlindKeys.CallUnary(ctx, req)
+}
+
+// SignBlinded calls signer.v1.SignerService.SignBlinded.
+func (c *signerServiceClient) SignBlinded(ctx context.Context, req *connect.Request[v1.SignBlindedRequest]) (*connect.Response[v1.SignBlindedResponse], error) {
+	return c.signBlinded.CallUnary(ctx, req)
+}
+
+// SignerServiceHandler is an implementation of the signer.v1.SignerService service.
+type SignerServiceHandler interface {
+	GetPublicKeys(context.Context, *connect.Request[v1.GetPublicKeysRequest]) (*connect.Response[v1.GetPublicKeysResponse], error)
+	SignTransaction(context.Context, *connect.Request[v1.SignTransactionRequest]) (*connect.Response[v1.SignTransactionResponse], error)
+	SignToken(context.Context, *connect.Request[v1.SignTokenRequest]) (*connect.Response[v1.SignTokenResponse], error)
+	// SignTreeHead signs a head of the transparency log with a token key. The
+	// signer builds the signing input itself, so a tree head can never pass
+	// for a token or the other way around.
+	SignTreeHead(context.Context, *connect.Request[v1.SignTreeHeadRequest]) (*connect.Response[v1.SignTreeHeadResponse], error)
+	// GenerateTokenKey creates a token key for rotation. The signer keeps the
+	// private key, the caller stages it by its kid.
+	GenerateTokenKey(context.Context, *connect.Request[v1.GenerateTokenKeyRequest]) (*connect.Response[v1.GenerateTokenKeyResponse], error)
+	// GenerateBlindKey creates an RSA key signing blind tokens of one
+	// denomination. The signer keeps the private key.
+	GenerateBlindKey(context.Context, *connect.Request[v1.GenerateBlindKeyRequest]) (*connect.Response[v1.GenerateBlindKeyResponse], error)
+	ListBlindKeys(context.Context, *connect.Request[v1.ListBlindKeysRequest]) (*connect.Response[v1.ListBlindKeysResponse], error)
+	// SignBlinded signs blinded blind token messages with a key that still
+	// issues tokens.
+	SignBlinded(context.Context, *connect.Request[v1.SignBlindedRequest]) (*connect.Response[v1.SignBlindedResponse], error)
+}
+
+// NewSignerServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSignerServiceHandler(svc SignerServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	signerServiceMethods := v1.File_signer_v1_signer_proto.Services().ByName("SignerService").Methods()
+	signerServiceGetPublicKeysHandler := connect.NewUnaryHandler(
+		SignerServiceGetPublicKeysProcedure,
+		svc.GetPublicKeys,
+		connect.WithSchema(signerServiceMethods.ByName("GetPublicKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSignTransactionHandler := connect.NewUnaryHandler(
+		SignerServiceSignTransactionProcedure,
+		svc.SignTransaction,
+		connect.WithSchema(signerServiceMethods.ByName("SignTransaction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSignTokenHandler := connect.NewUnaryHandler(
+		SignerServiceSignTokenProcedure,
+		svc.SignToken,
+		connect.WithSchema(signerServiceMethods.ByName("SignToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSignTreeHeadHandler := connect.NewUnaryHandler(
+		SignerServiceSignTreeHeadProcedure,
+		svc.SignTreeHead,
+		connect.WithSchema(signerServiceMethods.ByName("SignTreeHead")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceGenerateTokenKeyHandler := connect.NewUnaryHandler(
+		SignerServiceGenerateTokenKeyProcedure,
+		svc.GenerateTokenKey,
+		connect.WithSchema(signerServiceMethods.ByName("GenerateTokenKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceGenerateBlindKeyHandler := connect.NewUnaryHandler(
+		SignerServiceGenerateBlindKeyProcedure,
+		svc.GenerateBlindKey,
+		connect.WithSchema(signerServiceMethods.ByName("GenerateBlindKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceListBlindKeysHandler := connect.NewUnaryHandler(
+		SignerServiceListBlindKeysProcedure,
+		svc.ListBlindKeys,
+		connect.WithSchema(signerServiceMethods.ByName("ListBlindKeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	signerServiceSignBlindedHandler := connect.NewUnaryHandler(
+		SignerServiceSignBlindedProcedure,
+		svc.SignBlinded,
+		connect.WithSchema(signerServiceMethods.ByName("SignBlinded")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/signer.v1.SignerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SignerServiceGetPublicKeysProcedure:
+			signerServiceGetPublicKeysHandler.ServeHTTP(w, r)
+		case SignerServiceSignTransactionProcedure:
+			signerServiceSignTransactionHandler.ServeHTTP(w, r)
+		case SignerServiceSignTokenProcedure:
+			signerServiceSignTokenHandler.ServeHTTP(w, r)
+		case SignerServiceSignTreeHeadProcedure:
+			signerServiceSignTreeHeadHandler.ServeHTTP(w, r)
+		case SignerServiceGenerateTokenKeyProcedure:
+			signerServiceGenerateTokenKeyHandler.ServeHTTP(w, r)
+		case SignerServiceGenerateBlindKeyProcedure:
+			signerServiceGenerateBlindKeyHandler.ServeHTTP(w, r)
+		case SignerServiceListBlindKeysProcedure:
+			signerServiceListBlindKeysHandler.ServeHTTP(w, r)
+		case SignerServiceSignBlindedProcedure:
+			signerServiceSignBlindedHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSignerServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSignerServiceHandler struct{}
+
+func (UnimplementedSignerServiceHandler) GetPublicKeys(context.Context, *connect.Request[v1.GetPublicKeysRequest]) (*connect.Response[v1.GetPublicKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.GetPublicKeys is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SignTransaction(context.Context, *connect.Request[v1.SignTransactionRequest]) (*connect.Response[v1.SignTransactionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.SignTransaction is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SignToken(context.Context, *connect.Request[v1.SignTokenRequest]) (*connect.Response[v1.SignTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.SignToken is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SignTreeHead(context.Context, *connect.Request[v1.SignTreeHeadRequest]) (*connect.Response[v1.SignTreeHeadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.SignTreeHead is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) GenerateTokenKey(context.Context, *connect.Request[v1.GenerateTokenKeyRequest]) (*connect.Response[v1.GenerateTokenKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.GenerateTokenKey is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) GenerateBlindKey(context.Context, *connect.Request[v1.GenerateBlindKeyRequest]) (*connect.Response[v1.GenerateBlindKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.GenerateBlindKey is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) ListBlindKeys(context.Context, *connect.Request[v1.ListBlindKeysRequest]) (*connect.Response[v1.ListBlindKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.ListBlindKeys is not implemented"))
+}
+
+func (UnimplementedSignerServiceHandler) SignBlinded(context.Context, *connect.Request[v1.SignBlindedRequest]) (*connect.Response[v1.SignBlindedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signer.v1.SignerService.SignBlinded is not implemented"))
+}
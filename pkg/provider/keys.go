@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/didkey"
+)
+
+const PATH_JWKS = "/.well-known/jwks.json"
+
+// IKeySource looks up the token signing keys of Prex by kid.
+type IKeySource interface {
+	Key(ctx context.Context, kid string) (ed25519.PublicKey, error)
+}
+
+// StaticKeySource trusts a fixed set of did:key ids.
+type StaticKeySource map[string]ed25519.PublicKey
+
+func NewStaticKeySource(kids ...string) (StaticKeySource, error) {
+	ret := make(StaticKeySource)
+	for _, kid := range kids {
+		publicKey, err := didkey.ParseKeyId(kid)
+		if err != nil {
+			return nil, err
+		}
+		ret[kid] = publicKey
+	}
+	return ret, nil
+}
+
+func (s StaticKeySource) Key(ctx context.Context, kid string) (ed25519.PublicKey, error) {
+	publicKey, ok := s[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %s", kid)
+	}
+	return publicKey, nil
+}
+
+// JwksKeySource fetches the keys Prex publishes at PATH_JWKS and caches them
+// for ttl. Unknown kids trigger a refetch at most every minRefresh so that a
+// key rotation is noticed without letting callers hammer Prex.
+type JwksKeySource struct {
+	url        string
+	client     *http.Client
+	ttl        time.Duration
+	minRefresh time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]ed25519.PublicKey
+	fetchTime time.Time
+}
+
+// NewJwksKeySource fetches keys from the Prex server at baseUrl, e.g.
+// https://prex.example.com.
+func NewJwksKeySource(baseUrl string, ttl time.Duration) *JwksKeySource {
+	return &JwksKeySource{
+		url:        strings.TrimSuffix(baseUrl, "/") + PATH_JWKS,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		ttl:        ttl,
+		minRefresh: 10 * time.Second,
+		keys:       make(map[string]ed25519.PublicKey),
+	}
+}
+
+func (s *JwksKeySource) Key(ctx context.Context, kid string) (ed25519.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	publicKey, ok := s.keys[kid]
+	since := time.Since(s.fetchTime)
+	if (ok && since < s.ttl) || (!ok && since < s.minRefresh) {
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %s", kid)
+		}
+		return publicKey, nil
+	}
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		// Keep serving cached keys while Prex is unreachable
+		if ok {
+			return publicKey, nil
+		}
+		return nil, err
+	}
+	s.keys = keys
+	s.fetchTime = time.Now()
+	if publicKey, ok = s.keys[kid]; !ok {
+		return nil, fmt.Errorf("unknown kid %s", kid)
+	}
+	return publicKey, nil
+}
+
+type jsonWebKeySet struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Kid string `json:"kid"`
+	} `json:"keys"`
+}
+
+func (s *JwksKeySource) fetch(ctx context.Context) (map[string]ed25519.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", s.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", s.url, resp.Status)
+	}
+	var jwks jsonWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", s.url, err)
+	}
+	ret := make(map[string]ed25519.PublicKey)
+	for _, key := range jwks.Keys {
+		if key.Kty != "OKP" || key.Crv != "Ed25519" {
+			continue
+		}
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			continue
+		}
+		// The kid of Prex keys is their did:key, so a mismatch is a bad key
+		if didkey.KeyId(x) != key.Kid {
+			continue
+		}
+		ret[key.Kid] = ed25519.PublicKey(x)
+	}
+	return ret, nil
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type grantKey struct{}
+
+// GrantFromContext returns the grant of a request verified by a middleware.
+func GrantFromContext(ctx context.Context) (*Grant, bool) {
+	grant, ok := ctx.Value(grantKey{}).(*Grant)
+	return grant, ok
+}
+
+func bearerToken(authorization string) string {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, ErrInvalidToken):
+		return codes.Unauthenticated
+	case errors.Is(err, ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, ErrQuotaExhausted):
+		return codes.ResourceExhausted
+	}
+	return codes.Unavailable
+}
+
+func (v *Verifier) verifyRequest(ctx context.Context, authorization string, endpoint string) (context.Context, error) {
+	token := bearerToken(authorization)
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+	grant, err := v.Verify(ctx, token, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, grantKey{}, grant), nil
+}
+
+// HttpHandler requires a token in the Authorization header of requests to
+// next. The endpoint of a request is its URL path.
+func (v *Verifier) HttpHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := v.verifyRequest(r.Context(), r.Header.Get("Authorization"), r.URL.Path)
+		if err != nil {
+			httpStatus := http.StatusServiceUnavailable
+			switch errorCode(err) {
+			case codes.Unauthenticated:
+				httpStatus = http.StatusUnauthorized
+			case codes.PermissionDenied:
+				httpStatus = http.StatusForbidden
+			case codes.ResourceExhausted:
+				httpStatus = http.StatusPaymentRequired
+			}
+			http.Error(w, err.Error(), httpStatus)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// UnaryServerInterceptor requires a token in the authorization metadata of
+// gRPC calls. The endpoint of a call is its full method name.
+func (v *Verifier) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		authorization := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				authorization = values[0]
+			}
+		}
+		ctx, err := v.verifyRequest(ctx, authorization, info.FullMethod)
+		if err != nil {
+			return nil, status.Error(errorCode(err), err.Error())
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ConnectInterceptor requires a token in the Authorization header of unary
+// Connect calls. The endpoint of a call is its procedure.
+func (v *Verifier) ConnectInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			ctx, err := v.verifyRequest(ctx, req.Header().Get("Authorization"), req.Spec().Procedure)
+			if err != nil {
+				return nil, connect.NewError(connect.Code(errorCode(err)), err)
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package provider_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/provider"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// keySigner signs caveat tokens the way Prex does, without importing its
+// internal packages like a service would
+type keySigner ed25519.PrivateKey
+
+func (s keySigner) GetPublicKey() ed25519.PublicKey {
+	return ed25519.PrivateKey(s).Public().(ed25519.PublicKey)
+}
+
+func (s keySigner) SignToken(ctx context.Context, signingInput string) ([]byte, error) {
+	return ed25519.Sign(ed25519.PrivateKey(s), []byte(signingInput)), nil
+}
+
+var _ = Describe("Provider", Label("provider"), func() {
+	ctx := context.Background()
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	kid := didkey.KeyId(publicKey)
+	audience := "did:key:z6MkSeller"
+	now := time.Now()
+
+	var prex *httptest.Server
+	var verifier *provider.Verifier
+	var handler http.Handler
+
+	BeforeEach(func() {
+		prex = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal(provider.PATH_JWKS))
+			json.NewEncoder(w).Encode(map[string]any{
+				"keys": []map[string]string{{
+					"kty": "OKP",
+					"crv": "Ed25519",
+					"x":   base64.RawURLEncoding.EncodeToString(publicKey),
+					"kid": kid,
+				}},
+			})
+		}))
+		var err error
+		verifier, err = provider.NewVerifier(provider.Options{
+			Audience: audience,
+			Keys:     provider.NewJwksKeySource(prex.URL, time.Hour),
+			Quota:    provider.NewMemoryQuotaStore(),
+		})
+		Expect(err).To(BeNil())
+		handler = verifier.HttpHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			grant, ok := provider.GrantFromContext(r.Context())
+			Expect(ok).To(BeTrue())
+			Expect(grant.Audience).To(Equal(audience))
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+
+	AfterEach(func() {
+		prex.Close()
+	})
+
+	signJwt := func(aud string, quantity int64) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, provider.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "5e6f7081-92a3-4b4c-9d5e-6f708192a3b4",
+				Audience:  jwt.ClaimStrings{aud},
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+			Quantity: quantity,
+			Usage:    pb.JwtUsage_JWT_USAGE_CREATE_SESSION,
+		})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(privateKey)
+		Expect(err).To(BeNil())
+		return signed
+	}
+
+	call := func(path string, token string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	It("should meter a JWT until its quantity is used", func() {
+		token := signJwt(audience, 2)
+		Expect(call("/v1/chat", token)).To(Equal(http.StatusOK))
+		Expect(call("/v1/chat", token)).To(Equal(http.StatusOK))
+		Expect(call("/v1/chat", token)).To(Equal(http.StatusPaymentRequired))
+	})
+
+	It("should reject tokens of other audiences", func() {
+		Expect(call("/v1/chat", signJwt("did:key:z6MkOther", 2))).To(Equal(http.StatusUnauthorized))
+		Expect(call("/v1/chat", "")).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should enforce caveats", func() {
+		token, err := caveat.Issue(ctx, keySigner(privateKey), caveat.Authority{
+			Jti:        "7081920a-3b4c-4d5e-8f70-8192a3b4c5d6",
+			Audience:   audience,
+			Quantity:   100,
+			IssuedAt:   now.Unix(),
+			ExpireTime: now.Add(time.Hour).Unix(),
+		})
+		Expect(err).To(BeNil())
+		token, err = token.Attenuate(caveat.Caveat{MaxQuantity: 1, Endpoints: []string{"/v1/chat"}})
+		Expect(err).To(BeNil())
+		Expect(call("/v1/image", token.String())).To(Equal(http.StatusForbidden))
+		Expect(call("/v1/chat", token.String())).To(Equal(http.StatusOK))
+		Expect(call("/v1/chat", token.String())).To(Equal(http.StatusPaymentRequired))
+	})
+})
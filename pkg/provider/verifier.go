@@ -0,0 +1,250 @@
+// Package provider verifies quota tokens bought on Prex for services selling
+// there. Wrap handlers with Verifier.HttpHandler, Verifier.UnaryServerInterceptor
+// or Verifier.ConnectInterceptor to require a token for the audience of the
+// service on every request.
+package provider
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1/exchangeconnect"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidToken   = errors.New("invalid quota token")
+	ErrForbidden      = errors.New("quota token not valid for this request")
+	ErrQuotaExhausted = errors.New("not enough quota left on token")
+)
+
+// Claims of the JWTs issued by BuyToken.
+type Claims struct {
+	jwt.RegisteredClaims
+	Quantity int64       `json:"quantity"`
+	Usage    pb.JwtUsage `json:"usage"`
+}
+
+// QuotaEntry is a quantity cap tracked under Id. A token has one for itself
+// and one for every cap added by caveats.
+type QuotaEntry struct {
+	Id       string
+	Quantity int64
+}
+
+// IQuotaStore meters consumed quota. Consume must add amount to every entry
+// or to none if any would exceed its quantity.
+type IQuotaStore interface {
+	Consume(ctx context.Context, entries []QuotaEntry, amount int64, expireTime time.Time) error
+}
+
+// IRedeemer redeems quota with Prex, which is authoritative across instances
+// of a service.
+type IRedeemer interface {
+	Redeem(ctx context.Context, token string, endpoint string, quantity int64) error
+}
+
+// Grant is what a verified request may spend.
+type Grant struct {
+	TokenId   string
+	Audience  string
+	Quantity  int64
+	Cost      int64
+	Endpoint  string
+	ExpiresAt time.Time
+}
+
+type Options struct {
+	// Audience is the did the service sells under
+	Audience string
+	Keys     IKeySource
+	// Quota meters tokens locally if set
+	Quota IQuotaStore
+	// Redeemer redeems every request with Prex if set
+	Redeemer IRedeemer
+	// Cost of a request to endpoint, 1 if unset
+	Cost func(endpoint string) int64
+}
+
+type Verifier struct {
+	options Options
+}
+
+func NewVerifier(options Options) (*Verifier, error) {
+	if options.Audience == "" || options.Keys == nil {
+		return nil, fmt.Errorf("audience and keys are required")
+	}
+	if options.Cost == nil {
+		options.Cost = func(string) int64 { return 1 }
+	}
+	return &Verifier{options: options}, nil
+}
+
+// Verify checks token allows a request to endpoint and meters its cost.
+func (v *Verifier) Verify(ctx context.Context, token string, endpoint string) (*Grant, error) {
+	cost := v.options.Cost(endpoint)
+	var grant *Grant
+	var entries []QuotaEntry
+	var err error
+	if caveat.IsToken(token) {
+		grant, entries, err = v.verifyCaveatToken(ctx, token, endpoint, cost)
+	} else {
+		grant, entries, err = v.verifyJwt(ctx, token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	grant.Cost = cost
+	grant.Endpoint = endpoint
+	if cost > grant.Quantity {
+		return nil, fmt.Errorf("%w: request costs %d of %d", ErrQuotaExhausted, cost, grant.Quantity)
+	}
+	if v.options.Quota != nil {
+		if err := v.options.Quota.Consume(ctx, entries, cost, grant.ExpiresAt); err != nil {
+			return nil, err
+		}
+	}
+	if v.options.Redeemer != nil {
+		if err := v.options.Redeemer.Redeem(ctx, token, endpoint, cost); err != nil {
+			return nil, err
+		}
+	}
+	return grant, nil
+}
+
+func (v *Verifier) verifyJwt(ctx context.Context, token string) (*Grant, []QuotaEntry, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(
+		token,
+		claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return v.options.Keys.Key(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithAudience(v.options.Audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Usage != pb.JwtUsage_JWT_USAGE_CREATE_SESSION || claims.ID == "" {
+		return nil, nil, fmt.Errorf("%w: not a quota token", ErrInvalidToken)
+	}
+	return &Grant{
+		TokenId:   claims.ID,
+		Audience:  v.options.Audience,
+		Quantity:  claims.Quantity,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, []QuotaEntry{{Id: claims.ID, Quantity: claims.Quantity}}, nil
+}
+
+func (v *Verifier) verifyCaveatToken(
+	ctx context.Context, token string, endpoint string, cost int64,
+) (*Grant, []QuotaEntry, error) {
+	parsed, err := caveat.Parse(token, func(kid string) (ed25519.PublicKey, error) {
+		return v.options.Keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	claims := parsed.Claims()
+	if claims.Audience != v.options.Audience {
+		return nil, nil, fmt.Errorf("%w: token is for audience %s", ErrInvalidToken, claims.Audience)
+	}
+	if err := claims.Authorize(endpoint, cost, time.Now()); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrForbidden, err)
+	}
+	entries := []QuotaEntry{{Id: claims.Jti, Quantity: claims.Quantity}}
+	for _, limit := range claims.Limits {
+		entries = append(entries, QuotaEntry{Id: limit.Id, Quantity: limit.Quantity})
+	}
+	return &Grant{
+		TokenId:   claims.Jti,
+		Audience:  claims.Audience,
+		Quantity:  claims.Quantity,
+		ExpiresAt: claims.ExpiresAt,
+	}, entries, nil
+}
+
+type memoryQuota struct {
+	consumed   int64
+	expireTime time.Time
+}
+
+// MemoryQuotaStore meters quota within one process.
+type MemoryQuotaStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryQuota
+}
+
+func NewMemoryQuotaStore() *MemoryQuotaStore {
+	return &MemoryQuotaStore{entries: make(map[string]*memoryQuota)}
+}
+
+func (s *MemoryQuotaStore) Consume(
+	ctx context.Context, entries []QuotaEntry, amount int64, expireTime time.Time,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for id, entry := range s.entries {
+		if !entry.expireTime.After(now) {
+			delete(s.entries, id)
+		}
+	}
+	for _, entry := range entries {
+		if quota, ok := s.entries[entry.Id]; ok && quota.consumed+amount > entry.Quantity {
+			return fmt.Errorf("%w: %d of %d consumed", ErrQuotaExhausted, quota.consumed, entry.Quantity)
+		}
+	}
+	for _, entry := range entries {
+		quota, ok := s.entries[entry.Id]
+		if !ok {
+			quota = &memoryQuota{expireTime: expireTime}
+			s.entries[entry.Id] = quota
+		}
+		quota.consumed += amount
+	}
+	return nil
+}
+
+// PrexRedeemer redeems tokens with RedeemToken, authenticated by an API key
+// with the redeem-token scope of the seller account.
+type PrexRedeemer struct {
+	client exchangeconnect.ExchangeServiceClient
+	apiKey string
+}
+
+func NewPrexRedeemer(client exchangeconnect.ExchangeServiceClient, apiKey string) *PrexRedeemer {
+	return &PrexRedeemer{client: client, apiKey: apiKey}
+}
+
+func (r *PrexRedeemer) Redeem(ctx context.Context, token string, endpoint string, quantity int64) error {
+	req := connect.NewRequest(&pb.RedeemTokenRequest{
+		Token:    token,
+		Quantity: &quantity,
+		Endpoint: endpoint,
+	})
+	req.Header().Set("Authorization", "Bearer "+r.apiKey)
+	_, err := r.client.RedeemToken(ctx, req)
+	switch connect.CodeOf(err) {
+	case connect.CodeFailedPrecondition:
+		return fmt.Errorf("%w: %v", ErrQuotaExhausted, err)
+	case connect.CodePermissionDenied:
+		return fmt.Errorf("%w: %v", ErrForbidden, err)
+	case connect.CodeInvalidArgument:
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to redeem token: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package receipt
+
+import (
+	"crypto/ed25519"
+
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// CONSUMPTION_RECEIPT_SIGNING_PREFIX separates consumption receipts from the
+// challenges and caveat tokens the did:key of an audience also signs.
+const CONSUMPTION_RECEIPT_SIGNING_PREFIX = "prex-consumption-receipt:v1\n"
+
+// ConsumptionSigningInput is what the audience signs of a serialized
+// ConsumptionReceipt.
+func ConsumptionSigningInput(receipt []byte) []byte {
+	return append([]byte(CONSUMPTION_RECEIPT_SIGNING_PREFIX), receipt...)
+}
+
+// SignConsumption signs a consumption receipt with the private key of the
+// did:key of the audience, ready for SubmitConsumptionReceipts.
+func SignConsumption(
+	privateKey ed25519.PrivateKey, receipt *pb.ConsumptionReceipt,
+) (*pb.SignedConsumptionReceipt, error) {
+	serialized, err := proto.Marshal(receipt)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignedConsumptionReceipt{
+		Receipt:   serialized,
+		Signature: ed25519.Sign(privateKey, ConsumptionSigningInput(serialized)),
+	}, nil
+}
+
+// VerifyConsumption reports whether a consumption receipt is signed by
+// publicKey.
+func VerifyConsumption(publicKey ed25519.PublicKey, signed *pb.SignedConsumptionReceipt) bool {
+	return ed25519.Verify(publicKey, ConsumptionSigningInput(signed.GetReceipt()), signed.GetSignature())
+}
@@ -0,0 +1,67 @@
+// Package receipt verifies the purchase receipts Prex returns with every
+// token sold. Receipts are EdDSA JWTs signed by the same keys as tokens, so
+// they can be checked offline against the keys published at
+// /.well-known/jwks.json, and prove what the buyer paid and to whom even if
+// the token is lost. It also signs the consumption receipts services submit
+// to claim escrowed payments.
+package receipt
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidReceipt = errors.New("invalid purchase receipt")
+
+// Claims of a purchase receipt. Subject is the buyer and Audience the
+// audience of the token. Receipts have no jti of their own, so they cannot be
+// mistaken for tokens.
+type Claims struct {
+	jwt.RegisteredClaims
+	// TokenJti is the jti of the token bought
+	TokenJti string `json:"token_jti"`
+	// Seller is the account paid for the token
+	Seller string `json:"seller"`
+	// Amount is the quantity of the token
+	Amount int64 `json:"amount"`
+	// Price is what the buyer paid for the token. Prex sells quota at a unit
+	// price of 1, so it equals Amount.
+	Price int64 `json:"price"`
+	// Escrow is set if the price is held until the seller claims it
+	Escrow bool        `json:"escrow,omitempty"`
+	Usage  pb.JwtUsage `json:"usage"`
+}
+
+// KeyResolver returns the public key of the issuer kid if it is trusted.
+type KeyResolver func(kid string) (ed25519.PublicKey, error)
+
+// Parse verifies a receipt signed by a key resolve trusts and returns its
+// claims. Receipts do not expire.
+func Parse(s string, resolve KeyResolver) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(
+		s,
+		claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			if kid != claims.Issuer {
+				return nil, fmt.Errorf("kid %s is not the issuer %s", kid, claims.Issuer)
+			}
+			return resolve(kid)
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReceipt, err)
+	}
+	if claims.Usage != pb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT ||
+		claims.TokenJti == "" || len(claims.Audience) != 1 || claims.IssuedAt == nil {
+		return nil, fmt.Errorf("%w: not a purchase receipt", ErrInvalidReceipt)
+	}
+	return claims, nil
+}
@@ -0,0 +1,14 @@
+package receipt_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReceipt(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Receipt Suite")
+}
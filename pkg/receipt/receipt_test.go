@@ -0,0 +1,83 @@
+package receipt_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/pkg/caveat"
+	"github.com/atticplaygroup/prex/pkg/didkey"
+	pb "github.com/atticplaygroup/prex/pkg/proto/gen/go/exchange/v1"
+	"github.com/atticplaygroup/prex/pkg/receipt"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Purchase receipts", Label("receipt"), func() {
+	ctx := context.Background()
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	signer := signing.NewMemoryTokenSigner(privateKey)
+	kid := didkey.KeyId(signer.GetPublicKey())
+	resolve := receipt.KeyResolver(caveat.TrustedKeys(kid))
+
+	sign := func(usage pb.JwtUsage) string {
+		claims := &receipt.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:   kid,
+				Subject:  "did:key:z6MkBuyer",
+				Audience: jwt.ClaimStrings{"did:key:z6MkSeller"},
+				IssuedAt: jwt.NewNumericDate(time.Now()),
+			},
+			TokenJti: "6f708192-a3b4-4c5d-9e6f-708192a3b4c5",
+			Seller:   "did:key:z6MkSeller",
+			Amount:   100,
+			Price:    100,
+			Usage:    usage,
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+		token.Header["kid"] = kid
+		signed, err := signing.SignJwt(ctx, signer, token)
+		Expect(err).To(BeNil())
+		return signed
+	}
+
+	It("should verify receipts offline", func() {
+		claims, err := receipt.Parse(sign(pb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT), resolve)
+		Expect(err).To(BeNil())
+		Expect(claims.Subject).To(Equal("did:key:z6MkBuyer"))
+		Expect(claims.Seller).To(Equal("did:key:z6MkSeller"))
+		Expect(claims.Price).To(Equal(int64(100)))
+	})
+
+	It("should reject tokens and untrusted or tampered receipts", func() {
+		_, err := receipt.Parse(sign(pb.JwtUsage_JWT_USAGE_CREATE_SESSION), resolve)
+		Expect(err).To(MatchError(receipt.ErrInvalidReceipt))
+
+		signed := sign(pb.JwtUsage_JWT_USAGE_PURCHASE_RECEIPT)
+		_, err = receipt.Parse(signed, receipt.KeyResolver(caveat.TrustedKeys()))
+		Expect(err).To(MatchError(receipt.ErrInvalidReceipt))
+
+		tampered := []byte(signed)
+		tampered[len(tampered)-10] ^= 1
+		_, err = receipt.Parse(string(tampered), resolve)
+		Expect(err).To(MatchError(receipt.ErrInvalidReceipt))
+	})
+})
+
+var _ = Describe("Consumption receipts", Label("receipt"), func() {
+	It("should only verify signatures over the receipt prefix", func() {
+		privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+		publicKey := privateKey.Public().(ed25519.PublicKey)
+		signed, err := receipt.SignConsumption(privateKey, &pb.ConsumptionReceipt{
+			Jti:      "6f708192-a3b4-4c5d-9e6f-708192a3b4c5",
+			Consumed: 40,
+		})
+		Expect(err).To(BeNil())
+		Expect(receipt.VerifyConsumption(publicKey, signed)).To(BeTrue())
+
+		signed.Signature = ed25519.Sign(privateKey, signed.GetReceipt())
+		Expect(receipt.VerifyConsumption(publicKey, signed)).To(BeFalse())
+	})
+})
@@ -0,0 +1,259 @@
+package transparency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+var ErrInvalidProof = errors.New("invalid merkle proof")
+
+// INodeReader returns the hash of the perfect subtree at level covering
+// leaves [index << level, (index + 1) << level). Leaves are level 0.
+type INodeReader interface {
+	Node(ctx context.Context, level int32, index int64) ([]byte, error)
+}
+
+// Node is the hash of a perfect subtree to be stored.
+type Node struct {
+	Level int32
+	Index int64
+	Hash  []byte
+}
+
+// LeafHash hashes leaf data as in RFC 9162.
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// HashChildren hashes an interior node as in RFC 9162.
+func HashChildren(left []byte, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// AppendNodes returns the nodes to store when appending the leaf at index:
+// the leaf and every perfect subtree it completes. Nodes of earlier leaves
+// must already be readable.
+func AppendNodes(ctx context.Context, reader INodeReader, index int64, leafHash []byte) ([]Node, error) {
+	nodes := []Node{{Level: 0, Index: index, Hash: leafHash}}
+	hash, level := leafHash, int32(0)
+	for ; index%2 == 1; index /= 2 {
+		left, err := reader.Node(ctx, level, index-1)
+		if err != nil {
+			return nil, err
+		}
+		hash = HashChildren(left, hash)
+		level++
+		nodes = append(nodes, Node{Level: level, Index: index / 2, Hash: hash})
+	}
+	return nodes, nil
+}
+
+// splitPoint is the largest power of two less than size.
+func splitPoint(size int64) int64 {
+	return 1 << (bits.Len64(uint64(size-1)) - 1)
+}
+
+// subtreeHash is the hash of leaves [start, end), where start is aligned as
+// in the recursive split of RFC 9162.
+func subtreeHash(ctx context.Context, reader INodeReader, start int64, end int64) ([]byte, error) {
+	size := end - start
+	if size&(size-1) == 0 {
+		level := int32(bits.TrailingZeros64(uint64(size)))
+		return reader.Node(ctx, level, start>>level)
+	}
+	k := splitPoint(size)
+	left, err := subtreeHash(ctx, reader, start, start+k)
+	if err != nil {
+		return nil, err
+	}
+	right, err := subtreeHash(ctx, reader, start+k, end)
+	if err != nil {
+		return nil, err
+	}
+	return HashChildren(left, right), nil
+}
+
+// RootHash returns the root of the tree of the first size leaves.
+func RootHash(ctx context.Context, reader INodeReader, size int64) ([]byte, error) {
+	if size == 0 {
+		empty := sha256.Sum256(nil)
+		return empty[:], nil
+	}
+	return subtreeHash(ctx, reader, 0, size)
+}
+
+// InclusionProof returns the audit path of the leaf at index in the tree of
+// the first size leaves.
+func InclusionProof(ctx context.Context, reader INodeReader, index int64, size int64) ([][]byte, error) {
+	if index < 0 || index >= size {
+		return nil, fmt.Errorf("leaf %d is not in a tree of size %d", index, size)
+	}
+	return inclusionPath(ctx, reader, index, 0, size)
+}
+
+func inclusionPath(ctx context.Context, reader INodeReader, index int64, start int64, end int64) ([][]byte, error) {
+	if end-start == 1 {
+		return [][]byte{}, nil
+	}
+	k := splitPoint(end - start)
+	var path [][]byte
+	var sibling []byte
+	var err error
+	if index < start+k {
+		if path, err = inclusionPath(ctx, reader, index, start, start+k); err != nil {
+			return nil, err
+		}
+		sibling, err = subtreeHash(ctx, reader, start+k, end)
+	} else {
+		if path, err = inclusionPath(ctx, reader, index, start+k, end); err != nil {
+			return nil, err
+		}
+		sibling, err = subtreeHash(ctx, reader, start, start+k)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(path, sibling), nil
+}
+
+// ConsistencyProof proves the tree of the first oldSize leaves is a prefix of
+// the tree of the first newSize leaves.
+func ConsistencyProof(ctx context.Context, reader INodeReader, oldSize int64, newSize int64) ([][]byte, error) {
+	if oldSize < 0 || oldSize > newSize {
+		return nil, fmt.Errorf("cannot prove size %d against size %d", oldSize, newSize)
+	}
+	if oldSize == 0 || oldSize == newSize {
+		return [][]byte{}, nil
+	}
+	return consistencyPath(ctx, reader, oldSize, 0, newSize, true)
+}
+
+func consistencyPath(
+	ctx context.Context, reader INodeReader, m int64, start int64, end int64, complete bool,
+) ([][]byte, error) {
+	if m == end-start {
+		if complete {
+			return [][]byte{}, nil
+		}
+		hash, err := subtreeHash(ctx, reader, start, end)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{hash}, nil
+	}
+	k := splitPoint(end - start)
+	var path [][]byte
+	var sibling []byte
+	var err error
+	if m <= k {
+		if path, err = consistencyPath(ctx, reader, m, start, start+k, complete); err != nil {
+			return nil, err
+		}
+		sibling, err = subtreeHash(ctx, reader, start+k, end)
+	} else {
+		if path, err = consistencyPath(ctx, reader, m-k, start+k, end, false); err != nil {
+			return nil, err
+		}
+		sibling, err = subtreeHash(ctx, reader, start, start+k)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(path, sibling), nil
+}
+
+// VerifyInclusion checks proof shows the leaf at index is in the tree of size
+// with root.
+func VerifyInclusion(leafHash []byte, index int64, size int64, proof [][]byte, root []byte) error {
+	if index < 0 || index >= size {
+		return fmt.Errorf("%w: leaf %d is not in a tree of size %d", ErrInvalidProof, index, size)
+	}
+	fn, sn := index, size-1
+	hash := leafHash
+	for _, p := range proof {
+		if sn == 0 {
+			return fmt.Errorf("%w: proof too long", ErrInvalidProof)
+		}
+		if fn%2 == 1 || fn == sn {
+			hash = HashChildren(p, hash)
+			for fn%2 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			hash = HashChildren(hash, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 || !bytes.Equal(hash, root) {
+		return fmt.Errorf("%w: root mismatch", ErrInvalidProof)
+	}
+	return nil
+}
+
+// VerifyConsistency checks proof shows the tree of oldSize with oldRoot is a
+// prefix of the tree of newSize with newRoot.
+func VerifyConsistency(
+	oldSize int64, newSize int64, oldRoot []byte, newRoot []byte, proof [][]byte,
+) error {
+	if oldSize < 0 || oldSize > newSize {
+		return fmt.Errorf("%w: size %d is larger than %d", ErrInvalidProof, oldSize, newSize)
+	}
+	if oldSize == newSize {
+		if len(proof) != 0 || !bytes.Equal(oldRoot, newRoot) {
+			return fmt.Errorf("%w: root mismatch", ErrInvalidProof)
+		}
+		return nil
+	}
+	if oldSize == 0 {
+		if len(proof) != 0 {
+			return fmt.Errorf("%w: proof too long", ErrInvalidProof)
+		}
+		return nil
+	}
+	if len(proof) == 0 {
+		return fmt.Errorf("%w: empty proof", ErrInvalidProof)
+	}
+	if oldSize&(oldSize-1) == 0 {
+		proof = append([][]byte{oldRoot}, proof...)
+	}
+	fn, sn := oldSize-1, newSize-1
+	for fn%2 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+	fr, sr := proof[0], proof[0]
+	for _, c := range proof[1:] {
+		if sn == 0 {
+			return fmt.Errorf("%w: proof too long", ErrInvalidProof)
+		}
+		if fn%2 == 1 || fn == sn {
+			fr = HashChildren(c, fr)
+			sr = HashChildren(c, sr)
+			for fn%2 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr = HashChildren(sr, c)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 || !bytes.Equal(fr, oldRoot) || !bytes.Equal(sr, newRoot) {
+		return fmt.Errorf("%w: root mismatch", ErrInvalidProof)
+	}
+	return nil
+}
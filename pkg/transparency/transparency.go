@@ -0,0 +1,94 @@
+// Package transparency verifies the append-only log of tokens issued by Prex.
+// Every token is a leaf of a Merkle tree hashed as in RFC 9162. Prex signs
+// tree heads with its token key and returns an inclusion proof with every
+// token it sells, so buyers can check their token is logged and sellers can
+// sum the quantity logged for their did and compare it with what they were
+// paid. Consistency proofs between tree heads show entries are never removed
+// or rewritten.
+package transparency
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Entry is a leaf of the log. Tokens themselves are not published, only
+// their hash.
+type Entry struct {
+	TokenHash []byte
+	Audience  string
+	Quantity  int64
+	IssueTime time.Time
+}
+
+// HashToken hashes a token string for its entry.
+func HashToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+// Marshal encodes the entry as the hashed leaf data.
+func (e *Entry) Marshal() []byte {
+	buf := make([]byte, 0, len(e.TokenHash)+len(e.Audience)+20)
+	buf = append(buf, e.TokenHash...)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(e.Quantity))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(e.IssueTime.UnixMilli()))
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(e.Audience)))
+	return append(buf, e.Audience...)
+}
+
+func (e *Entry) LeafHash() []byte {
+	return LeafHash(e.Marshal())
+}
+
+// TREE_HEAD_SIGNING_PREFIX separates signed tree heads from signed tokens.
+const TREE_HEAD_SIGNING_PREFIX = "prex-tree-head:v1\n"
+
+// ISigner signs tree heads. The token signers of Prex implement it.
+type ISigner interface {
+	SignToken(ctx context.Context, signingInput string) ([]byte, error)
+}
+
+// ITreeHeadSigner is a signer with a path for tree heads apart from tokens,
+// like the remote signer, which only signs tokens its policy allows.
+type ITreeHeadSigner interface {
+	SignTreeHead(ctx context.Context, head *TreeHead) ([]byte, error)
+}
+
+// TreeHead commits to the first Size entries of the log.
+type TreeHead struct {
+	Size      int64
+	RootHash  []byte
+	Timestamp time.Time
+}
+
+// SigningInput is what the signature of a tree head covers.
+func (h *TreeHead) SigningInput() string {
+	return fmt.Sprintf(
+		"%s%d\n%s\n%d",
+		TREE_HEAD_SIGNING_PREFIX,
+		h.Size,
+		hex.EncodeToString(h.RootHash),
+		h.Timestamp.UnixMilli(),
+	)
+}
+
+func (h *TreeHead) Sign(ctx context.Context, signer ISigner) ([]byte, error) {
+	if treeHeadSigner, ok := signer.(ITreeHeadSigner); ok {
+		return treeHeadSigner.SignTreeHead(ctx, h)
+	}
+	return signer.SignToken(ctx, h.SigningInput())
+}
+
+// Verify checks signature of the tree head by the token key publicKey.
+func (h *TreeHead) Verify(publicKey ed25519.PublicKey, signature []byte) error {
+	if !ed25519.Verify(publicKey, []byte(h.SigningInput()), signature) {
+		return fmt.Errorf("invalid tree head signature")
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package transparency_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTransparency(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "Transparency Suite")
+}
@@ -0,0 +1,110 @@
+package transparency_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/atticplaygroup/prex/internal/signing"
+	"github.com/atticplaygroup/prex/pkg/transparency"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type memoryNodes map[[2]int64][]byte
+
+func (m memoryNodes) Node(ctx context.Context, level int32, index int64) ([]byte, error) {
+	hash, ok := m[[2]int64{int64(level), index}]
+	if !ok {
+		return nil, fmt.Errorf("node %d/%d not found", level, index)
+	}
+	return hash, nil
+}
+
+// naiveRoot is the Merkle tree hash of RFC 9162 computed from all leaves.
+func naiveRoot(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := 1
+	for k*2 < len(leaves) {
+		k *= 2
+	}
+	return transparency.HashChildren(naiveRoot(leaves[:k]), naiveRoot(leaves[k:]))
+}
+
+var _ = Describe("Transparency log", Label("transparency"), func() {
+	ctx := context.Background()
+	const size = 21
+	nodes := make(memoryNodes)
+	leaves := make([][]byte, 0, size)
+	roots := make([][]byte, size+1)
+
+	BeforeEach(func() {
+		if len(leaves) > 0 {
+			return
+		}
+		for i := int64(0); i < size; i++ {
+			entry := transparency.Entry{
+				TokenHash: transparency.HashToken(fmt.Sprintf("token-%d", i)),
+				Audience:  "did:key:z6MkSeller",
+				Quantity:  i + 1,
+				IssueTime: time.UnixMilli(1_700_000_000_000 + i),
+			}
+			appended, err := transparency.AppendNodes(ctx, nodes, i, entry.LeafHash())
+			Expect(err).To(BeNil())
+			for _, node := range appended {
+				nodes[[2]int64{int64(node.Level), node.Index}] = node.Hash
+			}
+			leaves = append(leaves, entry.LeafHash())
+			roots[i+1], err = transparency.RootHash(ctx, nodes, i+1)
+			Expect(err).To(BeNil())
+		}
+	})
+
+	It("should compute the roots of RFC 9162", func() {
+		for n := 1; n <= size; n++ {
+			Expect(roots[n]).To(Equal(naiveRoot(leaves[:n])))
+		}
+	})
+
+	It("should prove inclusion of every leaf", func() {
+		for n := int64(1); n <= size; n++ {
+			for m := int64(0); m < n; m++ {
+				proof, err := transparency.InclusionProof(ctx, nodes, m, n)
+				Expect(err).To(BeNil())
+				Expect(transparency.VerifyInclusion(leaves[m], m, n, proof, roots[n])).To(Succeed())
+				if n > 1 {
+					Expect(transparency.VerifyInclusion(leaves[(m+1)%n], m, n, proof, roots[n])).
+						NotTo(Succeed())
+				}
+			}
+		}
+	})
+
+	It("should prove consistency of every pair of tree heads", func() {
+		for n := int64(1); n <= size; n++ {
+			for m := int64(1); m <= n; m++ {
+				proof, err := transparency.ConsistencyProof(ctx, nodes, m, n)
+				Expect(err).To(BeNil())
+				Expect(transparency.VerifyConsistency(m, n, roots[m], roots[n], proof)).To(Succeed())
+				if m < n {
+					Expect(transparency.VerifyConsistency(m, n, roots[m-1], roots[n], proof)).
+						NotTo(Succeed())
+				}
+			}
+		}
+	})
+
+	It("should sign tree heads", func() {
+		privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+		signer := signing.NewMemoryTokenSigner(privateKey)
+		head := transparency.TreeHead{Size: size, RootHash: roots[size], Timestamp: time.Now()}
+		signature, err := head.Sign(ctx, signer)
+		Expect(err).To(BeNil())
+		Expect(head.Verify(signer.GetPublicKey(), signature)).To(Succeed())
+		head.Size--
+		Expect(head.Verify(signer.GetPublicKey(), signature)).NotTo(Succeed())
+	})
+})